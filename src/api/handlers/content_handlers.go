@@ -1,13 +1,19 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"log"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/Ceesaxp/autonomous-content-service/src/domain/entities"
 	"github.com/Ceesaxp/autonomous-content-service/src/domain/repositories"
 	"github.com/Ceesaxp/autonomous-content-service/src/services/content_creation"
+	"github.com/Ceesaxp/autonomous-content-service/src/services/jobs"
 	"github.com/google/uuid"
 	"github.com/gorilla/mux"
 )
@@ -18,15 +24,17 @@ type ContentHandler struct {
 	ProjectRepository  repositories.ProjectRepository
 	FeedbackRepository repositories.FeedbackRepository
 	ContentPipeline    *content_creation.ContentPipeline
+	Jobs               *jobs.Manager
 }
 
 // NewContentHandler creates a new content handler
-func NewContentHandler(contentRepo repositories.ContentRepository, projectRepo repositories.ProjectRepository, feedbackRepo repositories.FeedbackRepository, contentPipeline *content_creation.ContentPipeline) *ContentHandler {
+func NewContentHandler(contentRepo repositories.ContentRepository, projectRepo repositories.ProjectRepository, feedbackRepo repositories.FeedbackRepository, contentPipeline *content_creation.ContentPipeline, jobManager *jobs.Manager) *ContentHandler {
 	return &ContentHandler{
 		ContentRepository:  contentRepo,
 		ProjectRepository:  projectRepo,
 		FeedbackRepository: feedbackRepo,
 		ContentPipeline:    contentPipeline,
+		Jobs:               jobManager,
 	}
 }
 
@@ -107,12 +115,12 @@ func (h *ContentHandler) CreateContent(w http.ResponseWriter, r *http.Request) {
 	// Check if project exists
 	_, err = h.ProjectRepository.FindByID(r.Context(), projectID) // project
 	if err != nil {
-		http.Error(w, "Project not found", http.StatusNotFound)
+		writeLookupError(w, err, "Project not found")
 		return
 	}
 
-	// Create content through the pipeline
-	content, err := h.ContentPipeline.CreateContent(r.Context(), projectID, req.Title, req.Type)
+	// Create the content; the pipeline stages run in a background job
+	content, err := h.ContentPipeline.StartContent(r.Context(), projectID, req.Title, req.Type)
 	if err != nil {
 		http.Error(w, "Failed to create content: "+err.Error(), http.StatusInternalServerError)
 		return
@@ -126,35 +134,207 @@ func (h *ContentHandler) CreateContent(w http.ResponseWriter, r *http.Request) {
 		h.ContentRepository.Update(r.Context(), content)
 	}
 
-	// Prepare response
-	res := ContentResponse{
-		ContentID: content.ContentID.String(),
-		ProjectID: content.ProjectID.String(),
-		Title:     content.Title,
-		Type:      content.Type,
-		Status:    content.Status,
-		Data:      content.Data,
-		Metadata:  content.Metadata,
-		Version:   content.Version,
-		WordCount: content.WordCount,
-		CreatedAt: content.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
-		UpdatedAt: content.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	job, err := h.Jobs.Submit(r.Context(), content)
+	if err != nil {
+		http.Error(w, "Failed to queue content generation: "+err.Error(), http.StatusInternalServerError)
+		return
 	}
 
-	// Add statistics if available
-	if content.Statistics != nil {
-		res.Statistics = &StatisticsResponse{
-			ReadabilityScore: content.Statistics.ReadabilityScore,
-			SEOScore:         content.Statistics.SEOScore,
-			EngagementScore:  content.Statistics.EngagementScore,
-			PlagiarismScore:  content.Statistics.PlagiarismScore,
-		}
+	// Return response
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(newJobResponse(job))
+}
+
+// RetryContent handles requests to resume a failed piece of content from its first incomplete stage
+func (h *ContentHandler) RetryContent(w http.ResponseWriter, r *http.Request) {
+	// Extract content ID from URL
+	vars := mux.Vars(r)
+	contentID, err := uuid.Parse(vars["contentId"])
+	if err != nil {
+		http.Error(w, "Invalid content ID", http.StatusBadRequest)
+		return
+	}
+
+	// Retrieve content
+	content, err := h.ContentRepository.FindByID(r.Context(), contentID)
+	if err != nil {
+		writeLookupError(w, err, "Content not found")
+		return
+	}
+
+	// Check there is work left to do
+	_, err = h.ContentPipeline.FirstIncompleteStage(r.Context(), contentID)
+	if errors.Is(err, content_creation.ErrPipelineComplete) {
+		http.Error(w, "All pipeline stages have already completed", http.StatusConflict)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Failed to load pipeline progress: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	job, err := h.Jobs.Submit(r.Context(), content)
+	if errors.Is(err, jobs.ErrJobActive) {
+		http.Error(w, "Content generation is already in progress", http.StatusConflict)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Failed to queue content generation: "+err.Error(), http.StatusInternalServerError)
+		return
 	}
 
 	// Return response
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusCreated)
-	json.NewEncoder(w).Encode(res)
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(newJobResponse(job))
+}
+
+// progressKeepAlive is how often an idle progress stream sends a comment to keep the connection open
+const progressKeepAlive = 15 * time.Second
+
+// GetContentProgress streams pipeline progress for a piece of content as Server-Sent Events.
+// Earlier events are replayed first, starting after the Last-Event-ID header when it is set,
+// and the stream ends with the final event of a pipeline run; for a run that had already
+// failed, that is a "failed" event built from the stored job and content. While drafting and editing,
+// "streaming" events carry the text written since the previous one in their partial field,
+// starting partialOffset characters into the stage's text.
+func (h *ContentHandler) GetContentProgress(w http.ResponseWriter, r *http.Request) {
+	// Extract content ID from URL
+	vars := mux.Vars(r)
+	contentID, err := uuid.Parse(vars["contentId"])
+	if err != nil {
+		http.Error(w, "Invalid content ID", http.StatusBadRequest)
+		return
+	}
+
+	var lastEventID int64
+	if header := r.Header.Get("Last-Event-ID"); header != "" {
+		lastEventID, err = strconv.ParseInt(header, 10, 64)
+		if err != nil {
+			http.Error(w, "Invalid Last-Event-ID", http.StatusBadRequest)
+			return
+		}
+	}
+
+	// Check if content exists
+	content, err := h.ContentRepository.FindByID(r.Context(), contentID)
+	if err != nil {
+		writeLookupError(w, err, "Content not found")
+		return
+	}
+
+	// The stream outlives the server's write timeout
+	controller := http.NewResponseController(w)
+	controller.SetWriteDeadline(time.Time{})
+
+	replay, updates, unsubscribe := h.ContentPipeline.SubscribeProgress(contentID, lastEventID)
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	for _, event := range replay {
+		if err := writeProgressEvent(w, event); err != nil || event.Final {
+			controller.Flush()
+			return
+		}
+	}
+	if err := controller.Flush(); err != nil {
+		return
+	}
+
+	// Nothing more will be published for content that has already left the pipeline,
+	// or whose run failed without a retry pending
+	if len(replay) == 0 && lastEventID == 0 && (content.Status == entities.ContentStatusReview || content.Status == entities.ContentStatusHumanReview || content.IsComplete()) {
+		return
+	}
+	if len(replay) == 0 {
+		if event, failed := h.failedRun(r.Context(), content); failed {
+			writeProgressEvent(w, event)
+			controller.Flush()
+			return
+		}
+	}
+
+	keepAlive := time.NewTicker(progressKeepAlive)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+
+		case event, ok := <-updates:
+			if !ok {
+				// Fell behind; the client reconnects with Last-Event-ID
+				return
+			}
+			if err := writeProgressEvent(w, event); err != nil {
+				return
+			}
+			controller.Flush()
+			if event.Final {
+				return
+			}
+
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+			controller.Flush()
+		}
+	}
+}
+
+// failedRun returns the final event of a pipeline run that failed before the stream started.
+// The content's latest job decides when it has one, so a failed attempt awaiting its retry
+// does not end the stream; otherwise the error the pipeline left in the metadata does.
+func (h *ContentHandler) failedRun(ctx context.Context, content *entities.Content) (content_creation.PipelineProgressEvent, bool) {
+	event := content_creation.PipelineProgressEvent{
+		ContentID: content.ContentID,
+		ProjectID: content.ProjectID,
+		Status:    "failed",
+		Timestamp: content.UpdatedAt,
+		Final:     true,
+	}
+
+	job, err := h.Jobs.Latest(ctx, content.ContentID)
+	if err != nil {
+		log.Printf("Failed to look up the job of content %s: %v", content.ContentID, err)
+	}
+	switch {
+	case job != nil && job.Status == entities.ContentJobStatusFailed:
+		event.Stage = content_creation.PipelineStage(job.Stage)
+		event.Details = "Pipeline failed: " + job.LastError()
+		if job.CompletedAt != nil {
+			event.Timestamp = *job.CompletedAt
+		}
+		return event, true
+
+	case job != nil && (job.Status == entities.ContentJobStatusQueued || job.Status == entities.ContentJobStatusRunning):
+		return event, false
+	}
+
+	message, _ := content.Metadata["error"].(string)
+	if message == "" {
+		return event, false
+	}
+	event.Details = "Pipeline failed: " + message
+	return event, true
+}
+
+// writeProgressEvent writes a progress event in Server-Sent Events format
+func writeProgressEvent(w http.ResponseWriter, event content_creation.PipelineProgressEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %d\ndata: %s\n\n", event.EventID, data)
+	return err
 }
 
 // GetContent handles requests to retrieve content details
@@ -170,7 +350,7 @@ func (h *ContentHandler) GetContent(w http.ResponseWriter, r *http.Request) {
 	// Retrieve content
 	content, err := h.ContentRepository.FindByID(r.Context(), contentID)
 	if err != nil {
-		http.Error(w, "Content not found", http.StatusNotFound)
+		writeLookupError(w, err, "Content not found")
 		return
 	}
 
@@ -225,7 +405,7 @@ func (h *ContentHandler) UpdateContent(w http.ResponseWriter, r *http.Request) {
 	// Retrieve content
 	content, err := h.ContentRepository.FindByID(r.Context(), contentID)
 	if err != nil {
-		http.Error(w, "Content not found", http.StatusNotFound)
+		writeLookupError(w, err, "Content not found")
 		return
 	}
 
@@ -301,7 +481,7 @@ func (h *ContentHandler) GetContentVersions(w http.ResponseWriter, r *http.Reque
 	// Check if content exists
 	_, err = h.ContentRepository.FindByID(r.Context(), contentID)
 	if err != nil {
-		http.Error(w, "Content not found", http.StatusNotFound)
+		writeLookupError(w, err, "Content not found")
 		return
 	}
 
@@ -344,13 +524,13 @@ func (h *ContentHandler) ApproveContent(w http.ResponseWriter, r *http.Request)
 	// Retrieve content
 	content, err := h.ContentRepository.FindByID(r.Context(), contentID)
 	if err != nil {
-		http.Error(w, "Content not found", http.StatusNotFound)
+		writeLookupError(w, err, "Content not found")
 		return
 	}
 
-	// Check if content can be approved
-	if content.Status != entities.ContentStatusReview {
-		http.Error(w, "Content must be in Review status to be approved", http.StatusConflict)
+	// Check if content can be approved; content escalated for human review is approved by an editor
+	if content.Status != entities.ContentStatusReview && content.Status != entities.ContentStatusHumanReview {
+		http.Error(w, "Content must be in Review or HumanReview status to be approved", http.StatusConflict)
 		return
 	}
 
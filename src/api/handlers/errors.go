@@ -0,0 +1,19 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/Ceesaxp/autonomous-content-service/src/domain/repositories"
+)
+
+// writeLookupError responds with 404 when the entity does not exist and 500 for any other repository failure
+func writeLookupError(w http.ResponseWriter, err error, notFoundMessage string) {
+	if repositories.IsNotFound(err) {
+		http.Error(w, notFoundMessage, http.StatusNotFound)
+		return
+	}
+
+	log.Printf("Repository lookup failed: %v", err)
+	http.Error(w, "Internal server error", http.StatusInternalServerError)
+}
@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/Ceesaxp/autonomous-content-service/src/domain/entities"
+	"github.com/Ceesaxp/autonomous-content-service/src/services/jobs"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+// JobHandler handles requests about background content generation jobs
+type JobHandler struct {
+	Jobs *jobs.Manager
+}
+
+// NewJobHandler creates a new job handler
+func NewJobHandler(jobManager *jobs.Manager) *JobHandler {
+	return &JobHandler{Jobs: jobManager}
+}
+
+// JobResponse represents a content generation job in API responses
+type JobResponse struct {
+	JobID              string             `json:"jobId"`
+	ContentID          string             `json:"contentId"`
+	ProjectID          string             `json:"projectId"`
+	Status             string             `json:"status"`
+	Stage              string             `json:"stage,omitempty"`
+	LastCompletedStage string             `json:"lastCompletedStage,omitempty"`
+	Attempts           int                `json:"attempts"`
+	LastError          string             `json:"lastError,omitempty"`
+	Errors             []JobErrorResponse `json:"errors"`
+	CreatedAt          string             `json:"createdAt"`
+	UpdatedAt          string             `json:"updatedAt"`
+	CompletedAt        string             `json:"completedAt,omitempty"`
+}
+
+// JobErrorResponse represents a failed stage attempt in API responses
+type JobErrorResponse struct {
+	Stage      string `json:"stage"`
+	Attempt    int    `json:"attempt"`
+	Message    string `json:"message"`
+	OccurredAt string `json:"occurredAt"`
+}
+
+func newJobResponse(job *entities.ContentJob) JobResponse {
+	res := JobResponse{
+		JobID:              job.JobID.String(),
+		ContentID:          job.ContentID.String(),
+		ProjectID:          job.ProjectID.String(),
+		Status:             string(job.Status),
+		Stage:              job.Stage,
+		LastCompletedStage: job.LastCompletedStage,
+		Attempts:           job.Attempts,
+		LastError:          job.LastError(),
+		Errors:             []JobErrorResponse{},
+		CreatedAt:          job.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		UpdatedAt:          job.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+
+	for _, jobErr := range job.Errors {
+		res.Errors = append(res.Errors, JobErrorResponse{
+			Stage:      jobErr.Stage,
+			Attempt:    jobErr.Attempt,
+			Message:    jobErr.Message,
+			OccurredAt: jobErr.OccurredAt.Format("2006-01-02T15:04:05Z07:00"),
+		})
+	}
+
+	if job.CompletedAt != nil {
+		res.CompletedAt = job.CompletedAt.Format("2006-01-02T15:04:05Z07:00")
+	}
+
+	return res
+}
+
+// GetJob handles requests for the progress of a content generation job
+func (h *JobHandler) GetJob(w http.ResponseWriter, r *http.Request) {
+	// Extract job ID from URL
+	vars := mux.Vars(r)
+	jobID, err := uuid.Parse(vars["jobId"])
+	if err != nil {
+		http.Error(w, "Invalid job ID", http.StatusBadRequest)
+		return
+	}
+
+	job, err := h.Jobs.Get(r.Context(), jobID)
+	if err != nil {
+		writeLookupError(w, err, "Job not found")
+		return
+	}
+
+	// Return response
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(newJobResponse(job))
+}
@@ -131,7 +131,7 @@ func (h *ProjectHandler) CreateProject(w http.ResponseWriter, r *http.Request) {
 	// Check if client exists
 	_, err = h.ClientRepository.FindByID(r.Context(), clientID)
 	if err != nil {
-		http.Error(w, "Client not found", http.StatusNotFound)
+		writeLookupError(w, err, "Client not found")
 		return
 	}
 
@@ -233,7 +233,7 @@ func (h *ProjectHandler) GetProject(w http.ResponseWriter, r *http.Request) {
 	// Retrieve project
 	project, err := h.ProjectRepository.FindByID(r.Context(), projectID)
 	if err != nil {
-		http.Error(w, "Project not found", http.StatusNotFound)
+		writeLookupError(w, err, "Project not found")
 		return
 	}
 
@@ -303,7 +303,7 @@ func (h *ProjectHandler) UpdateProject(w http.ResponseWriter, r *http.Request) {
 	// Retrieve project
 	project, err := h.ProjectRepository.FindByID(r.Context(), projectID)
 	if err != nil {
-		http.Error(w, "Project not found", http.StatusNotFound)
+		writeLookupError(w, err, "Project not found")
 		return
 	}
 
@@ -493,7 +493,7 @@ func (h *ProjectHandler) CancelProject(w http.ResponseWriter, r *http.Request) {
 	// Retrieve project
 	project, err := h.ProjectRepository.FindByID(r.Context(), projectID)
 	if err != nil {
-		http.Error(w, "Project not found", http.StatusNotFound)
+		writeLookupError(w, err, "Project not found")
 		return
 	}
 
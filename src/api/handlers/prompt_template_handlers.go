@@ -0,0 +1,310 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/Ceesaxp/autonomous-content-service/src/domain/entities"
+	"github.com/Ceesaxp/autonomous-content-service/src/domain/repositories"
+	"github.com/Ceesaxp/autonomous-content-service/src/services/content_creation"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+// PromptTemplateHandler handles requests to manage the stored prompt templates
+type PromptTemplateHandler struct {
+	PromptTemplateRepository repositories.PromptTemplateRepository
+	TemplateManager          *content_creation.PromptTemplateManager
+}
+
+// NewPromptTemplateHandler creates a new prompt template handler
+func NewPromptTemplateHandler(templateRepo repositories.PromptTemplateRepository, templateManager *content_creation.PromptTemplateManager) *PromptTemplateHandler {
+	return &PromptTemplateHandler{
+		PromptTemplateRepository: templateRepo,
+		TemplateManager:          templateManager,
+	}
+}
+
+// PromptTemplateRequest represents a request to create a prompt template
+type PromptTemplateRequest struct {
+	Name         string                      `json:"name"`
+	ContentType  entities.ContentType        `json:"contentType"`
+	Industry     string                      `json:"industry,omitempty"`
+	ClientID     *uuid.UUID                  `json:"clientId,omitempty"`
+	Body         string                      `json:"body"`
+	OutputFormat entities.PromptOutputFormat `json:"outputFormat,omitempty"` // markdown (the default) or html
+	Author       string                      `json:"author"`
+}
+
+// PromptTemplateVersionRequest represents a request to store a new version of a prompt
+// template. Without an output format the version keeps the template's current one.
+type PromptTemplateVersionRequest struct {
+	Body         string                      `json:"body"`
+	OutputFormat entities.PromptOutputFormat `json:"outputFormat,omitempty"`
+	Author       string                      `json:"author"`
+}
+
+// RenderPromptRequest represents a dry-run render of a prompt template. It renders an
+// unsaved body when given, otherwise a stored template (optionally a past version) when a
+// template ID is given, and otherwise the template the pipeline would pick for the scope.
+// Without data, the sample data that stored templates are validated with is used.
+type RenderPromptRequest struct {
+	TemplateID  *uuid.UUID                   `json:"templateId,omitempty"`
+	Version     int                          `json:"version,omitempty"`
+	Body        string                       `json:"body,omitempty"`
+	Name        string                       `json:"name,omitempty"`
+	ContentType entities.ContentType         `json:"contentType,omitempty"`
+	Industry    string                       `json:"industry,omitempty"`
+	ClientID    *uuid.UUID                   `json:"clientId,omitempty"`
+	Data        *content_creation.PromptData `json:"data,omitempty"`
+}
+
+// RenderPromptResponse represents a rendered prompt and the template it came from
+type RenderPromptResponse struct {
+	Prompt   string                              `json:"prompt"`
+	Template *content_creation.PromptTemplateRef `json:"template,omitempty"`
+}
+
+// CreatePromptTemplate handles requests to store a new prompt template
+func (h *PromptTemplateHandler) CreatePromptTemplate(w http.ResponseWriter, r *http.Request) {
+	var req PromptTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+
+	template, err := entities.NewPromptTemplate(req.Name, req.ContentType, req.Industry, req.ClientID, req.Body, req.Author)
+	if err != nil {
+		http.Error(w, "Invalid prompt template: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if !req.OutputFormat.Valid() {
+		http.Error(w, "Invalid output format: "+string(req.OutputFormat), http.StatusBadRequest)
+		return
+	}
+	template.OutputFormat = req.OutputFormat
+	if err := h.TemplateManager.ValidateTemplate(template.ContentType, template.Name, template.Body); err != nil {
+		http.Error(w, "Invalid template body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	err = h.PromptTemplateRepository.Create(r.Context(), template)
+	if errors.Is(err, repositories.ErrPromptTemplateExists) {
+		http.Error(w, "A prompt template already exists for this scope; add a version instead", http.StatusConflict)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Failed to save prompt template: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Return response
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(template)
+}
+
+// ListPromptTemplates handles requests to list the stored prompt templates, filtered by
+// the name, contentType, industry and clientId query parameters
+func (h *PromptTemplateHandler) ListPromptTemplates(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := repositories.PromptTemplateFilter{
+		Name:        query.Get("name"),
+		ContentType: entities.ContentType(query.Get("contentType")),
+		Industry:    query.Get("industry"),
+	}
+	if clientIDStr := query.Get("clientId"); clientIDStr != "" {
+		clientID, err := uuid.Parse(clientIDStr)
+		if err != nil {
+			http.Error(w, "Invalid client ID", http.StatusBadRequest)
+			return
+		}
+		filter.ClientID = &clientID
+	}
+
+	templates, err := h.PromptTemplateRepository.List(r.Context(), filter)
+	if err != nil {
+		http.Error(w, "Failed to retrieve prompt templates: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Return response
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(templates)
+}
+
+// GetPromptTemplate handles requests for a prompt template and its current version
+func (h *PromptTemplateHandler) GetPromptTemplate(w http.ResponseWriter, r *http.Request) {
+	templateID, ok := parseTemplateID(w, r)
+	if !ok {
+		return
+	}
+
+	template, err := h.PromptTemplateRepository.FindByID(r.Context(), templateID)
+	if err != nil {
+		writeLookupError(w, err, "Prompt template not found")
+		return
+	}
+
+	// Return response
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(template)
+}
+
+// UpdatePromptTemplate handles requests to store a new version of a prompt template
+func (h *PromptTemplateHandler) UpdatePromptTemplate(w http.ResponseWriter, r *http.Request) {
+	templateID, ok := parseTemplateID(w, r)
+	if !ok {
+		return
+	}
+
+	var req PromptTemplateVersionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+	if req.Body == "" {
+		http.Error(w, "Template body is required", http.StatusBadRequest)
+		return
+	}
+	if req.Author == "" {
+		http.Error(w, "Template author is required", http.StatusBadRequest)
+		return
+	}
+	if !req.OutputFormat.Valid() {
+		http.Error(w, "Invalid output format: "+string(req.OutputFormat), http.StatusBadRequest)
+		return
+	}
+
+	stored, err := h.PromptTemplateRepository.FindByID(r.Context(), templateID)
+	if err != nil {
+		writeLookupError(w, err, "Prompt template not found")
+		return
+	}
+	if err := h.TemplateManager.ValidateTemplate(stored.ContentType, stored.Name, req.Body); err != nil {
+		http.Error(w, "Invalid template body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	outputFormat := req.OutputFormat
+	if outputFormat == "" {
+		outputFormat = stored.OutputFormat
+	}
+
+	template, err := h.PromptTemplateRepository.AddVersion(r.Context(), templateID, req.Body, outputFormat, req.Author)
+	if err != nil {
+		writeLookupError(w, err, "Prompt template not found")
+		return
+	}
+
+	// Return response
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(template)
+}
+
+// DeletePromptTemplate handles requests to delete a prompt template; the prompts it overrode
+// fall back to the next template in the lookup order. The template and its versions stay
+// readable by ID, for the content rendered from them.
+func (h *PromptTemplateHandler) DeletePromptTemplate(w http.ResponseWriter, r *http.Request) {
+	templateID, ok := parseTemplateID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.PromptTemplateRepository.Delete(r.Context(), templateID); err != nil {
+		writeLookupError(w, err, "Prompt template not found")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetPromptTemplateVersions handles requests for every version of a prompt template
+func (h *PromptTemplateHandler) GetPromptTemplateVersions(w http.ResponseWriter, r *http.Request) {
+	templateID, ok := parseTemplateID(w, r)
+	if !ok {
+		return
+	}
+
+	versions, err := h.PromptTemplateRepository.ListVersions(r.Context(), templateID)
+	if err != nil {
+		writeLookupError(w, err, "Prompt template not found")
+		return
+	}
+
+	// Return response
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(versions)
+}
+
+// RenderPrompt handles dry-run renders of a prompt template against sample prompt data
+func (h *PromptTemplateHandler) RenderPrompt(w http.ResponseWriter, r *http.Request) {
+	var req RenderPromptRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+	data := content_creation.SamplePromptData(req.ContentType, req.Name)
+	if req.Data != nil {
+		data = *req.Data
+		if data.ContentType == "" {
+			data.ContentType = req.ContentType
+		}
+	}
+
+	var res RenderPromptResponse
+	switch {
+	case req.Body != "":
+		prompt, err := h.TemplateManager.RenderText("preview", req.Body, data)
+		if err != nil {
+			http.Error(w, "Failed to render template: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		res.Prompt = prompt
+
+	case req.TemplateID != nil:
+		prompt, ref, err := h.TemplateManager.RenderVersion(r.Context(), *req.TemplateID, req.Version, data)
+		if repositories.IsNotFound(err) {
+			writeLookupError(w, err, "Prompt template version not found")
+			return
+		}
+		if err != nil {
+			http.Error(w, "Failed to render template: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		res.Prompt, res.Template = prompt, &ref
+
+	default:
+		if req.Name == "" || req.ContentType == "" {
+			http.Error(w, "Template name and content type are required", http.StatusBadRequest)
+			return
+		}
+		scope := content_creation.PromptScope{Industry: entities.NormalizeIndustry(req.Industry)}
+		if req.ClientID != nil {
+			scope = h.TemplateManager.ScopeForClient(r.Context(), *req.ClientID)
+			if req.Industry != "" {
+				scope.Industry = entities.NormalizeIndustry(req.Industry)
+			}
+		}
+		prompt, ref, err := h.TemplateManager.Render(r.Context(), req.ContentType, req.Name, scope, data)
+		if err != nil {
+			http.Error(w, "Failed to render template: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		res.Prompt, res.Template = prompt, &ref
+	}
+
+	// Return response
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
+
+// parseTemplateID reads the template ID path variable, responding with 400 when it is invalid
+func parseTemplateID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	templateID, err := uuid.Parse(mux.Vars(r)["templateId"])
+	if err != nil {
+		http.Error(w, "Invalid template ID", http.StatusBadRequest)
+		return uuid.Nil, false
+	}
+	return templateID, true
+}
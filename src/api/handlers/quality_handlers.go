@@ -0,0 +1,40 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/Ceesaxp/autonomous-content-service/src/domain/repositories"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+// QualityHandler handles requests for the quality reports of content
+type QualityHandler struct {
+	QualityAssessmentRepository repositories.QualityAssessmentRepository
+}
+
+// NewQualityHandler creates a new quality handler
+func NewQualityHandler(qualityRepo repositories.QualityAssessmentRepository) *QualityHandler {
+	return &QualityHandler{QualityAssessmentRepository: qualityRepo}
+}
+
+// GetContentQuality handles requests for the latest quality assessment of a content, with
+// its per-criterion scores, findings, fact-check claims and grade
+func (h *QualityHandler) GetContentQuality(w http.ResponseWriter, r *http.Request) {
+	contentID, err := uuid.Parse(mux.Vars(r)["contentId"])
+	if err != nil {
+		http.Error(w, "Invalid content ID", http.StatusBadRequest)
+		return
+	}
+
+	assessment, err := h.QualityAssessmentRepository.FindLatestByContentID(r.Context(), contentID)
+	if err != nil {
+		writeLookupError(w, err, "No quality assessment for this content")
+		return
+	}
+
+	// Return response
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(assessment)
+}
@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/Ceesaxp/autonomous-content-service/src/domain/entities"
+	"github.com/Ceesaxp/autonomous-content-service/src/domain/repositories"
+	"github.com/Ceesaxp/autonomous-content-service/src/services/content_creation"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+// UsageHandler handles requests for LLM token usage and cost, and the margin it leaves on price quotes
+type UsageHandler struct {
+	Usage  *content_creation.LLMUsageTracker
+	Cache  *content_creation.CachingLLMClient
+	Quotes repositories.PriceQuoteRepository
+}
+
+// NewUsageHandler creates a new usage handler. cache is nil when the LLM cache is disabled.
+func NewUsageHandler(tracker *content_creation.LLMUsageTracker, cache *content_creation.CachingLLMClient, quotes repositories.PriceQuoteRepository) *UsageHandler {
+	return &UsageHandler{Usage: tracker, Cache: cache, Quotes: quotes}
+}
+
+// GetContentUsage handles requests for the LLM usage of a content
+func (h *UsageHandler) GetContentUsage(w http.ResponseWriter, r *http.Request) {
+	h.writeUsage(w, r, "contentId", "Invalid content ID", h.Usage.ContentUsage)
+}
+
+// GetProjectUsage handles requests for the LLM usage of a project
+func (h *UsageHandler) GetProjectUsage(w http.ResponseWriter, r *http.Request) {
+	h.writeUsage(w, r, "projectId", "Invalid project ID", h.Usage.ProjectUsage)
+}
+
+// GetClientUsage handles requests for the LLM usage of a client
+func (h *UsageHandler) GetClientUsage(w http.ResponseWriter, r *http.Request) {
+	h.writeUsage(w, r, "clientId", "Invalid client ID", h.Usage.ClientUsage)
+}
+
+// GetQuoteMargin handles requests for the margin left on a price quote after the LLM cost of its project
+func (h *UsageHandler) GetQuoteMargin(w http.ResponseWriter, r *http.Request) {
+	quote, err := h.Quotes.GetPriceQuote(r.Context(), mux.Vars(r)["quoteId"])
+	if err != nil {
+		writeLookupError(w, err, "Price quote not found")
+		return
+	}
+
+	margin, err := h.Usage.QuoteMargin(r.Context(), quote)
+	if err != nil {
+		http.Error(w, "Failed to calculate quote margin: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Return response
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(margin)
+}
+
+// GetProjectMargins handles requests for the margins left on the price quotes of a project
+func (h *UsageHandler) GetProjectMargins(w http.ResponseWriter, r *http.Request) {
+	projectID, err := uuid.Parse(mux.Vars(r)["projectId"])
+	if err != nil {
+		http.Error(w, "Invalid project ID", http.StatusBadRequest)
+		return
+	}
+
+	quotes, err := h.Quotes.GetPriceQuotesByProject(r.Context(), projectID.String())
+	if err != nil {
+		http.Error(w, "Failed to retrieve price quotes: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	margins := make([]*content_creation.QuoteMargin, 0, len(quotes))
+	for _, quote := range quotes {
+		margin, err := h.Usage.QuoteMargin(r.Context(), quote)
+		if err != nil {
+			http.Error(w, "Failed to calculate quote margin: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		margins = append(margins, margin)
+	}
+
+	// Return response
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(margins)
+}
+
+// GetLLMCacheStats handles requests for the hit and miss counts of the LLM cache
+func (h *UsageHandler) GetLLMCacheStats(w http.ResponseWriter, r *http.Request) {
+	if h.Cache == nil {
+		http.Error(w, "LLM cache is disabled", http.StatusNotFound)
+		return
+	}
+
+	// Return response
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(h.Cache.Stats())
+}
+
+// writeUsage parses the ID in the named path variable and writes the summary returned for it
+func (h *UsageHandler) writeUsage(w http.ResponseWriter, r *http.Request, param, invalidMsg string,
+	summarize func(context.Context, uuid.UUID) (*entities.LLMUsageSummary, error)) {
+	id, err := uuid.Parse(mux.Vars(r)[param])
+	if err != nil {
+		http.Error(w, invalidMsg, http.StatusBadRequest)
+		return
+	}
+
+	summary, err := summarize(r.Context(), id)
+	if err != nil {
+		http.Error(w, "Failed to get LLM usage: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Return response
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(summary)
+}
@@ -8,7 +8,7 @@ import (
 )
 
 // SetupRoutes configures all API routes for the service
-func SetupRoutes(router *mux.Router, contentHandler *handlers.ContentHandler, projectHandler *handlers.ProjectHandler, onboardingHandler *handlers.OnboardingHandler, dashboardHandler *handlers.DashboardHandlers) {
+func SetupRoutes(router *mux.Router, contentHandler *handlers.ContentHandler, jobHandler *handlers.JobHandler, usageHandler *handlers.UsageHandler, qualityHandler *handlers.QualityHandler, promptTemplateHandler *handlers.PromptTemplateHandler, projectHandler *handlers.ProjectHandler, onboardingHandler *handlers.OnboardingHandler, dashboardHandler *handlers.DashboardHandlers) {
 	// Create web handler
 	webHandler := handlers.NewWebHandler(projectHandler, contentHandler)
 
@@ -28,6 +28,29 @@ func SetupRoutes(router *mux.Router, contentHandler *handlers.ContentHandler, pr
 	apiV1.HandleFunc("/content/{contentId}", contentHandler.UpdateContent).Methods("PUT")
 	apiV1.HandleFunc("/content/{contentId}/versions", contentHandler.GetContentVersions).Methods("GET")
 	apiV1.HandleFunc("/content/{contentId}/approve", contentHandler.ApproveContent).Methods("POST")
+	apiV1.HandleFunc("/content/{contentId}/retry", contentHandler.RetryContent).Methods("POST")
+	apiV1.HandleFunc("/content/{contentId}/progress", contentHandler.GetContentProgress).Methods("GET")
+	apiV1.HandleFunc("/content/{contentId}/quality", qualityHandler.GetContentQuality).Methods("GET")
+
+	// Content generation job endpoints
+	apiV1.HandleFunc("/jobs/{jobId}", jobHandler.GetJob).Methods("GET")
+
+	// LLM usage and cost endpoints
+	apiV1.HandleFunc("/content/{contentId}/usage", usageHandler.GetContentUsage).Methods("GET")
+	apiV1.HandleFunc("/projects/{projectId}/usage", usageHandler.GetProjectUsage).Methods("GET")
+	apiV1.HandleFunc("/clients/{clientId}/usage", usageHandler.GetClientUsage).Methods("GET")
+	apiV1.HandleFunc("/projects/{projectId}/margins", usageHandler.GetProjectMargins).Methods("GET")
+	apiV1.HandleFunc("/quotes/{quoteId}/margin", usageHandler.GetQuoteMargin).Methods("GET")
+	apiV1.HandleFunc("/llm/cache/stats", usageHandler.GetLLMCacheStats).Methods("GET")
+
+	// Prompt template endpoints
+	apiV1.HandleFunc("/prompt-templates", promptTemplateHandler.CreatePromptTemplate).Methods("POST")
+	apiV1.HandleFunc("/prompt-templates", promptTemplateHandler.ListPromptTemplates).Methods("GET")
+	apiV1.HandleFunc("/prompt-templates/render", promptTemplateHandler.RenderPrompt).Methods("POST")
+	apiV1.HandleFunc("/prompt-templates/{templateId}", promptTemplateHandler.GetPromptTemplate).Methods("GET")
+	apiV1.HandleFunc("/prompt-templates/{templateId}", promptTemplateHandler.UpdatePromptTemplate).Methods("PUT")
+	apiV1.HandleFunc("/prompt-templates/{templateId}", promptTemplateHandler.DeletePromptTemplate).Methods("DELETE")
+	apiV1.HandleFunc("/prompt-templates/{templateId}/versions", promptTemplateHandler.GetPromptTemplateVersions).Methods("GET")
 
 	// Web interface endpoints
 	apiV1.HandleFunc("/quote", webHandler.RequestQuote).Methods("POST")
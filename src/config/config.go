@@ -3,18 +3,35 @@ package config
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 
 	"github.com/joho/godotenv"
 )
 
+// Storage backends selectable with STORAGE_BACKEND
+const (
+	StorageBackendPostgres = "postgres"
+	StorageBackendMemory   = "memory"
+)
+
+// LLM cache backends selectable with LLM_CACHE_BACKEND
+const (
+	LLMCacheBackendMemory   = "memory"
+	LLMCacheBackendPostgres = "postgres"
+	LLMCacheBackendNone     = "none"
+)
+
 // Config holds all configuration for the application
 type Config struct {
 	// Server configuration
 	Port int
 	Host string
 
+	// StorageBackend selects where repositories keep their data
+	StorageBackend string
+
 	// Database configuration
 	DBHost     string
 	DBPort     int
@@ -28,15 +45,45 @@ type Config struct {
 	JWTExpiry int
 
 	// LLM configuration
+	LLMProvider       string
+	LLMBaseURL        string
 	LLMAPIKey         string
 	LLMModel          string
 	LLMMaxTokens      int
 	LLMTemperature    float64
 	ContextWindowSize int
 
+	// Project context cache configuration; windows are stored in the database
+	ContextCacheSize   int
+	ContextIdleMinutes int
+
+	// Tokenizer vocabularies, in the tiktoken format
+	TokenizerVocabDir string
+	TokenizerVocabURL string // downloads missing vocabularies when set
+	// TokenizerStrict refuses to start unless the vocabularies of the configured models load;
+	// otherwise token counts fall back to estimates
+	TokenizerStrict bool
+
+	// PipelineConfigFile is an optional JSON pipeline configuration, including the LLM configs
+	PipelineConfigFile string
+
+	// LLM response cache configuration
+	LLMCacheBackend    string
+	LLMCacheTTLMinutes int
+	LLMCacheMaxEntries int
+
 	// Search configuration
-	SearchAPIKey string
-	SearchURL    string
+	SearchProvider  string
+	SearchAPIKey    string
+	SearchURL       string
+	SearchCorpusDir string // documents searched by the local provider
+
+	// Page fetching configuration
+	FetchUserAgent       string
+	FetchMaxBodyBytes    int64
+	FetchHostDelayMillis int
+	FetchCacheDir        string // empty disables the on-disk response cache
+	FetchCacheTTLMinutes int
 
 	// Plagiarism API configuration
 	PlagiarismAPIKey  string
@@ -44,6 +91,10 @@ type Config struct {
 	EnablePlagiarism  bool
 	EnableFactChecking bool
 	EnableSEO         bool
+
+	// Background job configuration
+	JobWorkers     int
+	JobMaxAttempts int
 }
 
 // LoadConfig loads configuration from environment variables and .env file
@@ -55,16 +106,28 @@ func LoadConfig() (*Config, error) {
 	config := &Config{
 		Port:              8080,
 		Host:              "0.0.0.0",
+		StorageBackend:    StorageBackendPostgres,
 		DBPort:            5432,
 		DBSSLMode:         "disable",
 		JWTExpiry:         24 * 60, // 24 hours in minutes
+		LLMProvider:       "openai",
 		LLMModel:          "gpt-4",
 		LLMMaxTokens:      2048,
 		LLMTemperature:    0.7,
 		ContextWindowSize: 8192,
+		ContextCacheSize:   1000,
+		ContextIdleMinutes: 30,
+		LLMCacheBackend:   LLMCacheBackendMemory,
+		LLMCacheTTLMinutes: 24 * 60,
+		LLMCacheMaxEntries: 10000,
+		FetchMaxBodyBytes: 5 * 1024 * 1024,
+		FetchHostDelayMillis: 1000,
+		FetchCacheTTLMinutes: 24 * 60,
 		EnablePlagiarism:  true,
 		EnableFactChecking: true,
 		EnableSEO:         true,
+		JobWorkers:        4,
+		JobMaxAttempts:    3,
 	}
 
 	// Server config
@@ -73,15 +136,16 @@ func LoadConfig() (*Config, error) {
 	}
 	config.Host = getEnv("HOST", "0.0.0.0")
 
-	// Database config
-	config.DBHost = getEnv("DB_HOST", "localhost")
-	if port, err := strconv.Atoi(getEnv("DB_PORT", "5432")); err == nil {
-		config.DBPort = port
+	// Storage config
+	config.StorageBackend = strings.ToLower(getEnv("STORAGE_BACKEND", StorageBackendPostgres))
+	switch config.StorageBackend {
+	case StorageBackendPostgres, StorageBackendMemory:
+	default:
+		return nil, fmt.Errorf("unsupported STORAGE_BACKEND %q", config.StorageBackend)
 	}
-	config.DBUser = getEnv("DB_USER", "postgres")
-	config.DBPassword = getEnv("DB_PASSWORD", "")
-	config.DBName = getEnv("DB_NAME", "contentservice")
-	config.DBSSLMode = getEnv("DB_SSLMODE", "disable")
+
+	// Database config
+	loadDatabaseConfig(config)
 
 	// Authentication config
 	config.JWTSecret = getEnv("JWT_SECRET", "")
@@ -93,9 +157,11 @@ func LoadConfig() (*Config, error) {
 	}
 
 	// LLM config
+	config.LLMProvider = strings.ToLower(getEnv("LLM_PROVIDER", "openai"))
+	config.LLMBaseURL = getEnv("LLM_BASE_URL", "")
 	config.LLMAPIKey = getEnv("LLM_API_KEY", "")
-	if config.LLMAPIKey == "" {
-		return nil, fmt.Errorf("LLM_API_KEY is required")
+	if config.LLMAPIKey == "" && (config.LLMProvider == "openai" || config.LLMProvider == "anthropic") {
+		return nil, fmt.Errorf("LLM_API_KEY is required for LLM_PROVIDER %s", config.LLMProvider)
 	}
 	config.LLMModel = getEnv("LLM_MODEL", "gpt-4")
 	if tokens, err := strconv.Atoi(getEnv("LLM_MAX_TOKENS", "2048")); err == nil {
@@ -107,10 +173,59 @@ func LoadConfig() (*Config, error) {
 	if windowSize, err := strconv.Atoi(getEnv("CONTEXT_WINDOW_SIZE", "8192")); err == nil {
 		config.ContextWindowSize = windowSize
 	}
+	if size, err := strconv.Atoi(getEnv("CONTEXT_CACHE_SIZE", "1000")); err == nil && size >= 0 {
+		config.ContextCacheSize = size
+	}
+	if idle, err := strconv.Atoi(getEnv("CONTEXT_IDLE_MINUTES", "30")); err == nil && idle >= 0 {
+		config.ContextIdleMinutes = idle
+	}
+	config.TokenizerVocabDir = getEnv("TOKENIZER_VOCAB_DIR", filepath.Join(os.TempDir(), "content-service-tokenizers"))
+	config.TokenizerVocabURL = getEnv("TOKENIZER_VOCAB_URL", "")
+	if config.TokenizerVocabURL == "off" {
+		config.TokenizerVocabURL = ""
+	}
+	config.TokenizerStrict = getBoolEnv("TOKENIZER_STRICT", false)
+	config.PipelineConfigFile = getEnv("PIPELINE_CONFIG_FILE", "")
+
+	// LLM cache config
+	config.LLMCacheBackend = strings.ToLower(getEnv("LLM_CACHE_BACKEND", LLMCacheBackendMemory))
+	switch config.LLMCacheBackend {
+	case LLMCacheBackendMemory, LLMCacheBackendNone:
+	case LLMCacheBackendPostgres:
+		if config.StorageBackend != StorageBackendPostgres {
+			return nil, fmt.Errorf("LLM_CACHE_BACKEND postgres requires STORAGE_BACKEND postgres")
+		}
+	default:
+		return nil, fmt.Errorf("unsupported LLM_CACHE_BACKEND %q", config.LLMCacheBackend)
+	}
+	if ttl, err := strconv.Atoi(getEnv("LLM_CACHE_TTL_MINUTES", "1440")); err == nil && ttl > 0 {
+		config.LLMCacheTTLMinutes = ttl
+	}
+	if entries, err := strconv.Atoi(getEnv("LLM_CACHE_MAX_ENTRIES", "10000")); err == nil && entries > 0 {
+		config.LLMCacheMaxEntries = entries
+	}
 
 	// Search config
+	config.SearchProvider = strings.ToLower(getEnv("SEARCH_PROVIDER", "generic"))
 	config.SearchAPIKey = getEnv("SEARCH_API_KEY", "")
 	config.SearchURL = getEnv("SEARCH_URL", "")
+	config.SearchCorpusDir = getEnv("SEARCH_CORPUS_DIR", "")
+
+	// Page fetching config
+	config.FetchUserAgent = getEnv("FETCH_USER_AGENT", "")
+	if size, err := strconv.ParseInt(getEnv("FETCH_MAX_BODY_BYTES", "5242880"), 10, 64); err == nil && size > 0 {
+		config.FetchMaxBodyBytes = size
+	}
+	if delay, err := strconv.Atoi(getEnv("FETCH_HOST_DELAY_MS", "1000")); err == nil && delay >= 0 {
+		config.FetchHostDelayMillis = delay
+	}
+	config.FetchCacheDir = getEnv("FETCH_CACHE_DIR", filepath.Join(os.TempDir(), "content-service-fetch-cache"))
+	if config.FetchCacheDir == "off" {
+		config.FetchCacheDir = ""
+	}
+	if ttl, err := strconv.Atoi(getEnv("FETCH_CACHE_TTL_MINUTES", "1440")); err == nil && ttl > 0 {
+		config.FetchCacheTTLMinutes = ttl
+	}
 
 	// Plagiarism API config
 	config.PlagiarismAPIKey = getEnv("PLAGIARISM_API_KEY", "")
@@ -119,9 +234,40 @@ func LoadConfig() (*Config, error) {
 	config.EnableFactChecking = getBoolEnv("ENABLE_FACT_CHECKING", true)
 	config.EnableSEO = getBoolEnv("ENABLE_SEO", true)
 
+	// Background job config
+	if workers, err := strconv.Atoi(getEnv("JOB_WORKERS", "4")); err == nil && workers > 0 {
+		config.JobWorkers = workers
+	}
+	if attempts, err := strconv.Atoi(getEnv("JOB_MAX_ATTEMPTS", "3")); err == nil && attempts > 0 {
+		config.JobMaxAttempts = attempts
+	}
+
 	return config, nil
 }
 
+// LoadDatabaseConfig loads only the database settings, for tools such as the
+// migrate command that do not need the API or LLM credentials
+func LoadDatabaseConfig() *Config {
+	_ = godotenv.Load()
+
+	config := &Config{}
+	loadDatabaseConfig(config)
+	return config
+}
+
+// loadDatabaseConfig populates the database fields from the environment
+func loadDatabaseConfig(config *Config) {
+	config.DBHost = getEnv("DB_HOST", "localhost")
+	config.DBPort = 5432
+	if port, err := strconv.Atoi(getEnv("DB_PORT", "5432")); err == nil {
+		config.DBPort = port
+	}
+	config.DBUser = getEnv("DB_USER", "postgres")
+	config.DBPassword = getEnv("DB_PASSWORD", "")
+	config.DBName = getEnv("DB_NAME", "contentservice")
+	config.DBSSLMode = getEnv("DB_SSLMODE", "disable")
+}
+
 // GetDSN returns the database connection string
 func (c *Config) GetDSN() string {
 	return fmt.Sprintf(
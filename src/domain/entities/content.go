@@ -20,6 +20,19 @@ const (
 	ContentTypePressRelease       ContentType = "PressRelease"
 )
 
+// AllContentTypes returns every content type the service produces
+func AllContentTypes() []ContentType {
+	return []ContentType{
+		ContentTypeBlogPost,
+		ContentTypeSocialPost,
+		ContentTypeEmailNewsletter,
+		ContentTypeWebsiteCopy,
+		ContentTypeTechnicalArticle,
+		ContentTypeProductDescription,
+		ContentTypePressRelease,
+	}
+}
+
 // ContentStatus represents the status of content
 type ContentStatus string
 
@@ -29,6 +42,7 @@ const (
 	ContentStatusDrafting    ContentStatus = "Drafting"
 	ContentStatusEditing     ContentStatus = "Editing"
 	ContentStatusReview      ContentStatus = "Review"
+	ContentStatusHumanReview ContentStatus = "HumanReview" // below quality thresholds after automatic revision
 	ContentStatusApproved    ContentStatus = "Approved"
 	ContentStatusPublished   ContentStatus = "Published"
 	ContentStatusArchived    ContentStatus = "Archived"
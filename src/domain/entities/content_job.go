@@ -0,0 +1,129 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ContentJobStatus represents the status of a background content generation job
+type ContentJobStatus string
+
+const (
+	ContentJobStatusQueued    ContentJobStatus = "Queued"
+	ContentJobStatusRunning   ContentJobStatus = "Running"
+	ContentJobStatusCompleted ContentJobStatus = "Completed"
+	ContentJobStatusFailed    ContentJobStatus = "Failed"
+)
+
+// ContentJobError records a failed attempt of a pipeline stage
+type ContentJobError struct {
+	Stage      string    `json:"stage"`
+	Attempt    int       `json:"attempt"`
+	Message    string    `json:"message"`
+	OccurredAt time.Time `json:"occurredAt"`
+}
+
+// ContentJob tracks the generation of a piece of content by the background workers
+type ContentJob struct {
+	JobID              uuid.UUID          `json:"jobId"`
+	ContentID          uuid.UUID          `json:"contentId"`
+	ProjectID          uuid.UUID          `json:"projectId"`
+	Status             ContentJobStatus   `json:"status"`
+	Stage              string             `json:"stage,omitempty"`
+	LastCompletedStage string             `json:"lastCompletedStage,omitempty"`
+	Attempts           int                `json:"attempts"`
+	Errors             []*ContentJobError `json:"errors"`
+	Worker             string             `json:"worker,omitempty"`     // the worker running the latest attempt
+	LeaseUntil         *time.Time         `json:"leaseUntil,omitempty"` // a running job past its lease is requeued
+	CreatedAt          time.Time          `json:"createdAt"`
+	UpdatedAt          time.Time          `json:"updatedAt"`
+	CompletedAt        *time.Time         `json:"completedAt,omitempty"`
+}
+
+// NewContentJob creates a queued job for generating the given content
+func NewContentJob(contentID, projectID uuid.UUID) *ContentJob {
+	now := time.Now()
+	return &ContentJob{
+		JobID:     uuid.New(),
+		ContentID: contentID,
+		ProjectID: projectID,
+		Status:    ContentJobStatusQueued,
+		Errors:    []*ContentJobError{},
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// Start marks the job as running a new attempt
+func (j *ContentJob) Start() {
+	j.Status = ContentJobStatusRunning
+	j.Attempts++
+	j.UpdatedAt = time.Now()
+}
+
+// Claim starts a new attempt of the job on a worker, leased until leaseUntil
+func (j *ContentJob) Claim(worker string, leaseUntil time.Time) {
+	j.Start()
+	j.Worker = worker
+	j.LeaseUntil = &leaseUntil
+}
+
+// LeaseExpired reports whether the job is running on a lease that ended before now
+func (j *ContentJob) LeaseExpired(now time.Time) bool {
+	return j.Status == ContentJobStatusRunning && (j.LeaseUntil == nil || j.LeaseUntil.Before(now))
+}
+
+// EnterStage records the stage the job is currently running
+func (j *ContentJob) EnterStage(stage string) {
+	j.Stage = stage
+	j.UpdatedAt = time.Now()
+}
+
+// CompleteStage records that a stage finished and its result is stored on the content
+func (j *ContentJob) CompleteStage(stage string) {
+	j.LastCompletedStage = stage
+	j.UpdatedAt = time.Now()
+}
+
+// RecordError appends a failed stage attempt to the job's error history
+func (j *ContentJob) RecordError(stage string, attempt int, message string) {
+	j.Errors = append(j.Errors, &ContentJobError{
+		Stage:      stage,
+		Attempt:    attempt,
+		Message:    message,
+		OccurredAt: time.Now(),
+	})
+	j.UpdatedAt = time.Now()
+}
+
+// Requeue puts the job back in the queue after a failed attempt
+func (j *ContentJob) Requeue() {
+	j.Status = ContentJobStatusQueued
+	j.UpdatedAt = time.Now()
+}
+
+// Complete marks the job as finished successfully
+func (j *ContentJob) Complete() {
+	now := time.Now()
+	j.Status = ContentJobStatusCompleted
+	j.Stage = ""
+	j.UpdatedAt = now
+	j.CompletedAt = &now
+}
+
+// Fail marks the job as permanently failed
+func (j *ContentJob) Fail() {
+	now := time.Now()
+	j.Status = ContentJobStatusFailed
+	j.UpdatedAt = now
+	j.CompletedAt = &now
+}
+
+// LastError returns the message of the most recent failure, if any
+func (j *ContentJob) LastError() string {
+	if len(j.Errors) == 0 {
+		return ""
+	}
+	return j.Errors[len(j.Errors)-1].Message
+}
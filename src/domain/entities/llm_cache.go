@@ -0,0 +1,31 @@
+package entities
+
+import (
+	"time"
+)
+
+// LLMCacheEntry is a cached LLM response, keyed by a hash of the model, parameters and prompt
+type LLMCacheEntry struct {
+	Key       string    `json:"key"`
+	Model     string    `json:"model"`
+	Response  []byte    `json:"response"` // JSON-encoded response
+	CreatedAt time.Time `json:"createdAt"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// NewLLMCacheEntry creates a cache entry that expires after ttl
+func NewLLMCacheEntry(key, model string, response []byte, ttl time.Duration) *LLMCacheEntry {
+	now := time.Now()
+	return &LLMCacheEntry{
+		Key:       key,
+		Model:     model,
+		Response:  response,
+		CreatedAt: now,
+		ExpiresAt: now.Add(ttl),
+	}
+}
+
+// IsExpired reports whether the entry has expired at now
+func (e *LLMCacheEntry) IsExpired(now time.Time) bool {
+	return !now.Before(e.ExpiresAt)
+}
@@ -0,0 +1,96 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// LLMUsageRecord records the tokens used and cost incurred by a single LLM call
+type LLMUsageRecord struct {
+	UsageID          uuid.UUID `json:"usageId"`
+	ContentID        uuid.UUID `json:"contentId"`
+	ProjectID        uuid.UUID `json:"projectId"`
+	ClientID         uuid.UUID `json:"clientId"`
+	Model            string    `json:"model"`
+	Stage            string    `json:"stage"`
+	PromptTokens     int       `json:"promptTokens"`
+	CompletionTokens int       `json:"completionTokens"`
+	Cost             Money     `json:"cost"`
+	RecordedAt       time.Time `json:"recordedAt"`
+}
+
+// NewLLMUsageRecord creates a usage record for a call made while working on a content
+func NewLLMUsageRecord(contentID, projectID, clientID uuid.UUID, model, stage string, promptTokens, completionTokens int, cost Money) *LLMUsageRecord {
+	return &LLMUsageRecord{
+		UsageID:          uuid.New(),
+		ContentID:        contentID,
+		ProjectID:        projectID,
+		ClientID:         clientID,
+		Model:            model,
+		Stage:            stage,
+		PromptTokens:     promptTokens,
+		CompletionTokens: completionTokens,
+		Cost:             cost,
+		RecordedAt:       time.Now(),
+	}
+}
+
+// TotalTokens returns the prompt and completion tokens of the call
+func (r *LLMUsageRecord) TotalTokens() int {
+	return r.PromptTokens + r.CompletionTokens
+}
+
+// LLMUsageTotals sums the calls, tokens and cost of a set of usage records
+type LLMUsageTotals struct {
+	Calls            int     `json:"calls"`
+	PromptTokens     int     `json:"promptTokens"`
+	CompletionTokens int     `json:"completionTokens"`
+	TotalTokens      int     `json:"totalTokens"`
+	Cost             float64 `json:"cost"`
+}
+
+func (t *LLMUsageTotals) add(record *LLMUsageRecord) {
+	t.Calls++
+	t.PromptTokens += record.PromptTokens
+	t.CompletionTokens += record.CompletionTokens
+	t.TotalTokens += record.TotalTokens()
+	t.Cost += record.Cost.Amount
+}
+
+// LLMUsageSummary rolls up usage records, in total and broken down by model and stage
+type LLMUsageSummary struct {
+	LLMUsageTotals
+	Currency string                     `json:"currency"`
+	ByModel  map[string]*LLMUsageTotals `json:"byModel"`
+	ByStage  map[string]*LLMUsageTotals `json:"byStage"`
+}
+
+// SummarizeLLMUsage rolls up usage records. Costs are assumed to share one currency;
+// currency is used when there are no records.
+func SummarizeLLMUsage(records []*LLMUsageRecord, currency string) *LLMUsageSummary {
+	summary := &LLMUsageSummary{
+		Currency: currency,
+		ByModel:  make(map[string]*LLMUsageTotals),
+		ByStage:  make(map[string]*LLMUsageTotals),
+	}
+
+	for _, record := range records {
+		if record.Cost.Currency != "" {
+			summary.Currency = record.Cost.Currency
+		}
+		summary.add(record)
+
+		if _, ok := summary.ByModel[record.Model]; !ok {
+			summary.ByModel[record.Model] = &LLMUsageTotals{}
+		}
+		summary.ByModel[record.Model].add(record)
+
+		if _, ok := summary.ByStage[record.Stage]; !ok {
+			summary.ByStage[record.Stage] = &LLMUsageTotals{}
+		}
+		summary.ByStage[record.Stage].add(record)
+	}
+
+	return summary
+}
@@ -0,0 +1,82 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ProjectContext is the stored LLM conversation context of a project: its token budget and
+// the domain knowledge injected for it. Version increases with every change, so processes
+// caching the context can tell when another process has changed it.
+type ProjectContext struct {
+	ProjectID       uuid.UUID              `json:"projectId"`
+	ClientID        uuid.UUID              `json:"clientId"`
+	ContentType     ContentType            `json:"contentType"`
+	MaxTokens       int                    `json:"maxTokens"`
+	TokenCount      int                    `json:"tokenCount"`
+	DomainKnowledge map[string]interface{} `json:"domainKnowledge"`
+	Version         int64                  `json:"version"`
+	CompactionCount int                    `json:"compactionCount"`
+	Compactions     []ContextCompaction    `json:"compactions,omitempty"` // most recent last
+	LastAccessed    time.Time              `json:"lastAccessed"`
+	CreatedAt       time.Time              `json:"createdAt"`
+	UpdatedAt       time.Time              `json:"updatedAt"`
+}
+
+// NewProjectContext creates an empty context for a project
+func NewProjectContext(projectID uuid.UUID, maxTokens int) *ProjectContext {
+	now := time.Now()
+	return &ProjectContext{
+		ProjectID:       projectID,
+		MaxTokens:       maxTokens,
+		DomainKnowledge: make(map[string]interface{}),
+		Version:         1,
+		LastAccessed:    now,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+}
+
+// ProjectContextEntry is a message kept in a project's context
+type ProjectContextEntry struct {
+	EntryID    uuid.UUID              `json:"entryId"`
+	ProjectID  uuid.UUID              `json:"projectId"`
+	Role       string                 `json:"role"`
+	Content    string                 `json:"content"`
+	Priority   int                    `json:"priority"`
+	MustKeep   bool                   `json:"mustKeep"`
+	Metadata   map[string]interface{} `json:"metadata,omitempty"`
+	TokenCount int                    `json:"tokenCount"`
+	CreatedAt  time.Time              `json:"createdAt"`
+}
+
+// ProjectContextChange lists the entries a context update adds and removes
+type ProjectContextChange struct {
+	Add    []*ProjectContextEntry
+	Remove []uuid.UUID
+}
+
+// MaxRecordedCompactions is the number of recent compactions kept with a project context
+const MaxRecordedCompactions = 20
+
+// ContextCompaction records a compaction of a project's context, in which entries were
+// evicted to stay within the token budget and, when Summarized is set, folded into the
+// project memory entry
+type ContextCompaction struct {
+	At             time.Time `json:"at"`
+	EntriesEvicted int       `json:"entriesEvicted"`
+	TokensBefore   int       `json:"tokensBefore"`
+	TokensAfter    int       `json:"tokensAfter"`
+	Summarized     bool      `json:"summarized"`
+	Error          string    `json:"error,omitempty"` // why the entries were dropped unsummarized
+}
+
+// RecordCompaction adds a compaction to the context's history, keeping the most recent ones
+func (c *ProjectContext) RecordCompaction(compaction ContextCompaction) {
+	c.CompactionCount++
+	c.Compactions = append(c.Compactions, compaction)
+	if len(c.Compactions) > MaxRecordedCompactions {
+		c.Compactions = c.Compactions[len(c.Compactions)-MaxRecordedCompactions:]
+	}
+}
@@ -0,0 +1,135 @@
+package entities
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PromptTemplateScope is the level at which a stored prompt template applies
+type PromptTemplateScope string
+
+const (
+	// PromptTemplateScopeClient templates override the prompts of a single client
+	PromptTemplateScopeClient PromptTemplateScope = "client"
+	// PromptTemplateScopeIndustry templates apply to the clients of an industry
+	PromptTemplateScopeIndustry PromptTemplateScope = "industry"
+	// PromptTemplateScopeContentType templates apply to every client
+	PromptTemplateScopeContentType PromptTemplateScope = "contentType"
+	// PromptTemplateScopeBuiltin marks the templates compiled into the service
+	PromptTemplateScopeBuiltin PromptTemplateScope = "builtin"
+)
+
+// PromptOutputFormat is the markup a prompt template asks the model to write its output in
+type PromptOutputFormat string
+
+const (
+	// PromptOutputFormatMarkdown is the format of templates that declare none
+	PromptOutputFormatMarkdown PromptOutputFormat = "markdown"
+	PromptOutputFormatHTML     PromptOutputFormat = "html"
+)
+
+// Valid reports whether the format is known; empty means Markdown
+func (f PromptOutputFormat) Valid() bool {
+	switch f {
+	case "", PromptOutputFormatMarkdown, PromptOutputFormatHTML:
+		return true
+	default:
+		return false
+	}
+}
+
+// PromptTemplate is a stored prompt template for a pipeline stage of a content type,
+// optionally limited to an industry or a client. Every change adds a version; the
+// template carries the body, output format and author of its current version. Deleted templates no
+// longer apply but are kept, with their versions, for the content rendered from them.
+type PromptTemplate struct {
+	TemplateID   uuid.UUID          `json:"templateId"`
+	Name         string             `json:"name"` // the stage template name, e.g. "draft"
+	ContentType  ContentType        `json:"contentType"`
+	Industry     string             `json:"industry,omitempty"`
+	ClientID     *uuid.UUID         `json:"clientId,omitempty"`
+	Version      int                `json:"version"`
+	Body         string             `json:"body"`
+	OutputFormat PromptOutputFormat `json:"outputFormat,omitempty"` // the markup the current version asks for
+	Author       string             `json:"author"`
+	CreatedAt    time.Time          `json:"createdAt"`
+	UpdatedAt    time.Time          `json:"updatedAt"`
+	DeletedAt    *time.Time         `json:"deletedAt,omitempty"`
+}
+
+// PromptTemplateVersion is a version of a stored prompt template
+type PromptTemplateVersion struct {
+	TemplateID   uuid.UUID          `json:"templateId"`
+	Version      int                `json:"version"`
+	Body         string             `json:"body"`
+	OutputFormat PromptOutputFormat `json:"outputFormat,omitempty"`
+	Author       string             `json:"author"`
+	CreatedAt    time.Time          `json:"createdAt"`
+}
+
+// NewPromptTemplate creates the first version of a prompt template. A template applies
+// to a client or to an industry, not both; with neither it applies to the content type.
+func NewPromptTemplate(name string, contentType ContentType, industry string, clientID *uuid.UUID, body, author string) (*PromptTemplate, error) {
+	industry = NormalizeIndustry(industry)
+	if strings.TrimSpace(name) == "" {
+		return nil, errors.New("template name is required")
+	}
+	if contentType == "" {
+		return nil, errors.New("content type is required")
+	}
+	if industry != "" && clientID != nil {
+		return nil, errors.New("a template applies to a client or an industry, not both")
+	}
+	if strings.TrimSpace(body) == "" {
+		return nil, errors.New("template body is required")
+	}
+	if strings.TrimSpace(author) == "" {
+		return nil, errors.New("template author is required")
+	}
+
+	now := time.Now()
+	return &PromptTemplate{
+		TemplateID:  uuid.New(),
+		Name:        strings.TrimSpace(name),
+		ContentType: contentType,
+		Industry:    industry,
+		ClientID:    clientID,
+		Version:     1,
+		Body:        body,
+		Author:      author,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}, nil
+}
+
+// Scope returns the level at which the template applies
+func (t *PromptTemplate) Scope() PromptTemplateScope {
+	switch {
+	case t.ClientID != nil:
+		return PromptTemplateScopeClient
+	case t.Industry != "":
+		return PromptTemplateScopeIndustry
+	default:
+		return PromptTemplateScopeContentType
+	}
+}
+
+// CurrentVersion returns the current version of the template
+func (t *PromptTemplate) CurrentVersion() *PromptTemplateVersion {
+	return &PromptTemplateVersion{
+		TemplateID:   t.TemplateID,
+		Version:      t.Version,
+		Body:         t.Body,
+		OutputFormat: t.OutputFormat,
+		Author:       t.Author,
+		CreatedAt:    t.UpdatedAt,
+	}
+}
+
+// NormalizeIndustry returns the form industries are stored and matched in
+func NormalizeIndustry(industry string) string {
+	return strings.ToLower(strings.TrimSpace(industry))
+}
@@ -0,0 +1,34 @@
+package entities
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// QualityAssessment is a stored quality report for a version of a piece of content
+type QualityAssessment struct {
+	AssessmentID    uuid.UUID       `json:"assessmentId"`
+	ContentID       uuid.UUID       `json:"contentId"`
+	ContentVersion  int             `json:"contentVersion"`
+	OverallScore    float64         `json:"overallScore"`
+	Grade           string          `json:"grade"`
+	PassedThreshold bool            `json:"passedThreshold"`
+	Report          json.RawMessage `json:"report"` // the full JSON-encoded assessment
+	AssessedAt      time.Time       `json:"assessedAt"`
+}
+
+// NewQualityAssessment creates a quality assessment of the current version of the content
+func NewQualityAssessment(content *Content, overallScore float64, grade string, passedThreshold bool, report []byte) *QualityAssessment {
+	return &QualityAssessment{
+		AssessmentID:    uuid.New(),
+		ContentID:       content.ContentID,
+		ContentVersion:  content.Version,
+		OverallScore:    overallScore,
+		Grade:           grade,
+		PassedThreshold: passedThreshold,
+		Report:          report,
+		AssessedAt:      time.Now(),
+	}
+}
@@ -0,0 +1,65 @@
+package entities
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SimilarityDocumentKind distinguishes the texts held in the similarity index
+type SimilarityDocumentKind string
+
+const (
+	SimilarityDocumentContent SimilarityDocumentKind = "content" // a version of a piece of content
+	SimilarityDocumentSource  SimilarityDocumentKind = "source"  // a page fetched during research
+)
+
+// SimilarityDocument is a text in the near-duplicate index that new content is checked against
+type SimilarityDocument struct {
+	DocumentID    uuid.UUID              `json:"documentId"`
+	Key           string                 `json:"key"` // identifies the text, e.g. a content version or a source URL
+	Kind          SimilarityDocumentKind `json:"kind"`
+	ContentID     uuid.UUID              `json:"contentId,omitempty"` // uuid.Nil for sources
+	ProjectID     uuid.UUID              `json:"projectId,omitempty"`
+	ClientID      uuid.UUID              `json:"clientId,omitempty"`
+	VersionNumber int                    `json:"versionNumber,omitempty"`
+	URL           string                 `json:"url,omitempty"`
+	Title         string                 `json:"title"`
+	Text          string                 `json:"text"`
+	IndexedAt     time.Time              `json:"indexedAt"`
+}
+
+// NewContentSimilarityDocument creates an index entry for a version of a piece of content
+func NewContentSimilarityDocument(content *Content, clientID uuid.UUID, versionNumber int, text string) *SimilarityDocument {
+	return &SimilarityDocument{
+		DocumentID:    uuid.New(),
+		Key:           ContentSimilarityKey(content.ContentID, versionNumber),
+		Kind:          SimilarityDocumentContent,
+		ContentID:     content.ContentID,
+		ProjectID:     content.ProjectID,
+		ClientID:      clientID,
+		VersionNumber: versionNumber,
+		Title:         content.Title,
+		Text:          text,
+		IndexedAt:     time.Now(),
+	}
+}
+
+// NewSourceSimilarityDocument creates an index entry for a research source page
+func NewSourceSimilarityDocument(url, title, text string) *SimilarityDocument {
+	return &SimilarityDocument{
+		DocumentID: uuid.New(),
+		Key:        "source:" + url,
+		Kind:       SimilarityDocumentSource,
+		URL:        url,
+		Title:      title,
+		Text:       text,
+		IndexedAt:  time.Now(),
+	}
+}
+
+// ContentSimilarityKey returns the index key of a version of a piece of content
+func ContentSimilarityKey(contentID uuid.UUID, versionNumber int) string {
+	return fmt.Sprintf("content:%s:%d", contentID, versionNumber)
+}
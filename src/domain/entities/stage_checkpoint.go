@@ -0,0 +1,39 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// StageCheckpointStatus represents the outcome of a pipeline stage
+type StageCheckpointStatus string
+
+const (
+	StageCheckpointCompleted StageCheckpointStatus = "Completed"
+	StageCheckpointFailed    StageCheckpointStatus = "Failed"
+	StageCheckpointSkipped   StageCheckpointStatus = "Skipped"
+)
+
+// StageCheckpoint records the result of a pipeline stage for a piece of content,
+// so a failed run can be resumed without repeating the stages that succeeded
+type StageCheckpoint struct {
+	ContentID   uuid.UUID              `json:"contentId"`
+	Stage       string                 `json:"stage"`
+	Status      StageCheckpointStatus  `json:"status"`
+	Output      string                 `json:"output,omitempty"`
+	Metadata    map[string]interface{} `json:"metadata,omitempty"`
+	Error       string                 `json:"error,omitempty"`
+	ElapsedTime time.Duration          `json:"elapsedTime"`
+	RecordedAt  time.Time              `json:"recordedAt"`
+}
+
+// IsCompleted reports whether the stage finished successfully
+func (c *StageCheckpoint) IsCompleted() bool {
+	return c.Status == StageCheckpointCompleted
+}
+
+// IsSkipped reports whether the stage was skipped because it does not apply to the content
+func (c *StageCheckpoint) IsSkipped() bool {
+	return c.Status == StageCheckpointSkipped
+}
@@ -0,0 +1,108 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// StoredEvent is a domain event loaded back from the event store.
+// The original event payload is exposed through Data.
+type StoredEvent struct {
+	BaseEvent
+	AggregateID uuid.UUID `json:"aggregateId"`
+}
+
+// NewStoredEvent creates a stored event from its persisted representation
+func NewStoredEvent(eventID uuid.UUID, eventType string, aggregateID uuid.UUID, occurredAt time.Time, payload map[string]interface{}) *StoredEvent {
+	return &StoredEvent{
+		BaseEvent: BaseEvent{
+			EventID:   eventID,
+			EventType: eventType,
+			Timestamp: occurredAt,
+			Data:      payload,
+		},
+		AggregateID: aggregateID,
+	}
+}
+
+// aggregateIDKeys lists the payload keys checked, in order, to find an event's aggregate
+var aggregateIDKeys = []string{"aggregateId", "contentId", "projectId", "clientId", "transactionId", "paymentId", "feedbackId"}
+
+// ToStoredEvent converts a domain event into its stored representation. Domain events
+// embed BaseEvent, so the identifier, type and timestamp are read from its JSON fields
+// and the aggregate is taken from the first entity ID found in the payload.
+func ToStoredEvent(event interface{}) (*StoredEvent, error) {
+	if event == nil {
+		return nil, fmt.Errorf("event is nil")
+	}
+	if stored, ok := event.(*StoredEvent); ok {
+		return stored, nil
+	}
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode event: %w", err)
+	}
+
+	var payload map[string]interface{}
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return nil, fmt.Errorf("event must serialise to a JSON object: %w", err)
+	}
+
+	stored := &StoredEvent{}
+	stored.Data = payload
+
+	if e, ok := event.(Event); ok {
+		stored.EventID = e.GetID()
+		stored.EventType = e.GetType()
+		stored.Timestamp = e.GetTimestamp()
+	} else {
+		stored.EventID = uuidField(payload, "eventId")
+		stored.EventType, _ = payload["eventType"].(string)
+		if ts, ok := payload["timestamp"].(string); ok {
+			stored.Timestamp, _ = time.Parse(time.RFC3339Nano, ts)
+		}
+	}
+
+	if stored.EventID == uuid.Nil {
+		stored.EventID = uuid.New()
+	}
+	if stored.EventType == "" {
+		stored.EventType = fmt.Sprintf("%T", event)
+	}
+	if stored.Timestamp.IsZero() {
+		stored.Timestamp = time.Now()
+	}
+
+	for _, key := range aggregateIDKeys {
+		if id := uuidField(payload, key); id != uuid.Nil {
+			stored.AggregateID = id
+			break
+		}
+	}
+	if stored.AggregateID == uuid.Nil {
+		if data, ok := payload["data"].(map[string]interface{}); ok {
+			stored.AggregateID = uuidField(data, "entityId")
+		}
+	}
+	if stored.AggregateID == uuid.Nil {
+		return nil, fmt.Errorf("event %s has no aggregate ID", stored.EventType)
+	}
+
+	return stored, nil
+}
+
+func uuidField(fields map[string]interface{}, key string) uuid.UUID {
+	value, ok := fields[key].(string)
+	if !ok {
+		return uuid.Nil
+	}
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return uuid.Nil
+	}
+	return id
+}
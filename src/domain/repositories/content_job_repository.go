@@ -0,0 +1,46 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Ceesaxp/autonomous-content-service/src/domain/entities"
+	"github.com/google/uuid"
+)
+
+// ErrContentJobActive is returned when creating a job for content that already has a queued
+// or running job
+var ErrContentJobActive = errors.New("content already has an active job")
+
+// ContentJobRepository defines the interface for content job persistence operations
+type ContentJobRepository interface {
+	// FindByID retrieves a job by ID
+	FindByID(ctx context.Context, id uuid.UUID) (*entities.ContentJob, error)
+
+	// FindByContentID retrieves the jobs for a specific content, oldest first
+	FindByContentID(ctx context.Context, contentID uuid.UUID) ([]*entities.ContentJob, error)
+
+	// FindByStatus retrieves jobs with any of the given statuses, oldest first
+	FindByStatus(ctx context.Context, statuses ...entities.ContentJobStatus) ([]*entities.ContentJob, error)
+
+	// Create adds a new job to the repository, or returns ErrContentJobActive
+	Create(ctx context.Context, job *entities.ContentJob) error
+
+	// Update updates an existing job in the repository. The worker and lease of the job
+	// are only changed by Claim and RenewLease.
+	Update(ctx context.Context, job *entities.ContentJob) error
+
+	// Claim atomically starts a new attempt of a queued job for the worker, leased until
+	// leaseUntil. A job that is not queued, for instance because another worker claimed
+	// it, gives a NotFoundError.
+	Claim(ctx context.Context, id uuid.UUID, worker string, leaseUntil time.Time) (*entities.ContentJob, error)
+
+	// RenewLease extends the lease of a job the worker is running. A job the worker no
+	// longer holds gives a NotFoundError.
+	RenewLease(ctx context.Context, id uuid.UUID, worker string, leaseUntil time.Time) error
+
+	// RequeueExpired puts running jobs whose lease expired before now back in the queue
+	// and returns them
+	RequeueExpired(ctx context.Context, now time.Time) ([]*entities.ContentJob, error)
+}
@@ -0,0 +1,30 @@
+package repositories
+
+import (
+	"errors"
+	"fmt"
+)
+
+// NotFoundError is returned by repositories when the requested entity does not exist
+type NotFoundError struct {
+	Entity string
+	ID     string
+}
+
+// NewNotFoundError creates a new not-found error for the given entity and identifier
+func NewNotFoundError(entity string, id interface{}) *NotFoundError {
+	return &NotFoundError{
+		Entity: entity,
+		ID:     fmt.Sprintf("%v", id),
+	}
+}
+
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("%s not found: %s", e.Entity, e.ID)
+}
+
+// IsNotFound reports whether err, or any error it wraps, is a NotFoundError
+func IsNotFound(err error) bool {
+	var notFound *NotFoundError
+	return errors.As(err, &notFound)
+}
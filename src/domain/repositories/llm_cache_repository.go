@@ -0,0 +1,20 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/Ceesaxp/autonomous-content-service/src/domain/entities"
+)
+
+// LLMCacheRepository defines the interface for cached LLM response persistence
+type LLMCacheRepository interface {
+	// Get retrieves an unexpired entry by key, or a NotFoundError
+	Get(ctx context.Context, key string) (*entities.LLMCacheEntry, error)
+
+	// Put stores an entry, replacing any entry with the same key
+	Put(ctx context.Context, entry *entities.LLMCacheEntry) error
+
+	// DeleteExpired removes the entries expired at now and returns how many were removed
+	DeleteExpired(ctx context.Context, now time.Time) (int, error)
+}
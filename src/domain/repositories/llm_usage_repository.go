@@ -0,0 +1,23 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/Ceesaxp/autonomous-content-service/src/domain/entities"
+	"github.com/google/uuid"
+)
+
+// LLMUsageRepository defines the interface for LLM usage record persistence
+type LLMUsageRepository interface {
+	// FindByContentID retrieves the usage recorded for a specific content, oldest first
+	FindByContentID(ctx context.Context, contentID uuid.UUID) ([]*entities.LLMUsageRecord, error)
+
+	// FindByProjectID retrieves the usage recorded for a specific project, oldest first
+	FindByProjectID(ctx context.Context, projectID uuid.UUID) ([]*entities.LLMUsageRecord, error)
+
+	// FindByClientID retrieves the usage recorded for a specific client, oldest first
+	FindByClientID(ctx context.Context, clientID uuid.UUID) ([]*entities.LLMUsageRecord, error)
+
+	// Create adds a new usage record to the repository
+	Create(ctx context.Context, record *entities.LLMUsageRecord) error
+}
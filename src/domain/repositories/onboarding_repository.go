@@ -334,6 +334,8 @@ type OnboardingStats struct {
 }
 
 // CreateOnboardingTables creates the database tables for onboarding
+//
+// Deprecated: the onboarding schema is applied by database.RunMigrations.
 func CreateOnboardingTables(db *sql.DB) error {
 	createSessionsTable := `
 		CREATE TABLE IF NOT EXISTS onboarding_sessions (
@@ -0,0 +1,32 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/Ceesaxp/autonomous-content-service/src/domain/entities"
+	"github.com/google/uuid"
+)
+
+// ProjectContextUpdate changes a project context in place and returns the entries to add
+// and remove. It runs while the context is locked against concurrent updates.
+type ProjectContextUpdate func(projectContext *entities.ProjectContext, entries []*entities.ProjectContextEntry) (entities.ProjectContextChange, error)
+
+// ProjectContextRepository defines the interface for project conversation context persistence
+type ProjectContextRepository interface {
+	// Create stores a new project context; an existing context of the project is kept
+	Create(ctx context.Context, projectContext *entities.ProjectContext) error
+
+	// Load retrieves a project context and its entries in the order they were added
+	Load(ctx context.Context, projectID uuid.UUID) (*entities.ProjectContext, []*entities.ProjectContextEntry, error)
+
+	// Version retrieves the version of a project context
+	Version(ctx context.Context, projectID uuid.UUID) (int64, error)
+
+	// Update applies an update to a project context atomically, increments its version and
+	// returns the updated context and entries
+	Update(ctx context.Context, projectID uuid.UUID, update ProjectContextUpdate) (*entities.ProjectContext, []*entities.ProjectContextEntry, error)
+
+	// Touch records when a project context was last used, without changing its version
+	Touch(ctx context.Context, projectID uuid.UUID, at time.Time) error
+}
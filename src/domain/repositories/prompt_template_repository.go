@@ -0,0 +1,51 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Ceesaxp/autonomous-content-service/src/domain/entities"
+	"github.com/google/uuid"
+)
+
+// ErrPromptTemplateExists is returned when creating a template for a scope that already has one
+var ErrPromptTemplateExists = errors.New("a prompt template already exists for this scope")
+
+// PromptTemplateFilter selects stored prompt templates; empty fields match any template
+type PromptTemplateFilter struct {
+	Name        string
+	ContentType entities.ContentType
+	Industry    string
+	ClientID    *uuid.UUID
+}
+
+// PromptTemplateRepository defines the interface for prompt template persistence
+type PromptTemplateRepository interface {
+	// Create stores a new template with its first version, or returns ErrPromptTemplateExists
+	Create(ctx context.Context, template *entities.PromptTemplate) error
+
+	// AddVersion stores a new version of a template, makes it current and returns the
+	// updated template. Deleted templates are not found.
+	AddVersion(ctx context.Context, templateID uuid.UUID, body string, outputFormat entities.PromptOutputFormat, author string) (*entities.PromptTemplate, error)
+
+	// FindByID retrieves a template with its current version, including deleted templates
+	FindByID(ctx context.Context, templateID uuid.UUID) (*entities.PromptTemplate, error)
+
+	// FindByScope retrieves the template of exactly this scope: a client's when clientID is
+	// set, an industry's when industry is set, and otherwise the content type's. Deleted
+	// templates are not found.
+	FindByScope(ctx context.Context, name string, contentType entities.ContentType, industry string, clientID *uuid.UUID) (*entities.PromptTemplate, error)
+
+	// FindVersion retrieves a version of a template
+	FindVersion(ctx context.Context, templateID uuid.UUID, version int) (*entities.PromptTemplateVersion, error)
+
+	// ListVersions retrieves every version of a template, oldest first
+	ListVersions(ctx context.Context, templateID uuid.UUID) ([]*entities.PromptTemplateVersion, error)
+
+	// List retrieves the templates matching the filter, leaving out deleted templates
+	List(ctx context.Context, filter PromptTemplateFilter) ([]*entities.PromptTemplate, error)
+
+	// Delete marks a template as deleted, so it no longer applies. The template and its
+	// versions stay readable by ID for the content stamped with them.
+	Delete(ctx context.Context, templateID uuid.UUID) error
+}
@@ -0,0 +1,17 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/Ceesaxp/autonomous-content-service/src/domain/entities"
+	"github.com/google/uuid"
+)
+
+// QualityAssessmentRepository defines the interface for quality assessment persistence
+type QualityAssessmentRepository interface {
+	// Save stores an assessment
+	Save(ctx context.Context, assessment *entities.QualityAssessment) error
+
+	// FindLatestByContentID retrieves the most recent assessment of a content, or a NotFoundError
+	FindLatestByContentID(ctx context.Context, contentID uuid.UUID) (*entities.QualityAssessment, error)
+}
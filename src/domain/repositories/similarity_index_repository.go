@@ -0,0 +1,20 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/Ceesaxp/autonomous-content-service/src/domain/entities"
+)
+
+// SimilarityIndexRepository defines the interface for the near-duplicate index. Documents are
+// stored with the locality-sensitive hash bands of their text and found by shared bands.
+type SimilarityIndexRepository interface {
+	// Save stores a document and its bands, unless a document with the same key is already indexed
+	Save(ctx context.Context, document *entities.SimilarityDocument, bands []uint64) error
+
+	// Exists reports whether a document with the key is indexed
+	Exists(ctx context.Context, key string) (bool, error)
+
+	// FindByBands retrieves the documents sharing at least one of the bands
+	FindByBands(ctx context.Context, bands []uint64) ([]*entities.SimilarityDocument, error)
+}
@@ -0,0 +1,20 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/Ceesaxp/autonomous-content-service/src/domain/entities"
+	"github.com/google/uuid"
+)
+
+// StageCheckpointRepository defines the interface for pipeline stage checkpoint persistence
+type StageCheckpointRepository interface {
+	// FindByContentID retrieves the checkpoints recorded for a specific content
+	FindByContentID(ctx context.Context, contentID uuid.UUID) ([]*entities.StageCheckpoint, error)
+
+	// Save stores a checkpoint, replacing any earlier one for the same content and stage
+	Save(ctx context.Context, checkpoint *entities.StageCheckpoint) error
+
+	// DeleteStages removes the checkpoints of the given stages; missing checkpoints are ignored
+	DeleteStages(ctx context.Context, contentID uuid.UUID, stages ...string) error
+}
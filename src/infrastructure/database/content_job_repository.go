@@ -0,0 +1,222 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Ceesaxp/autonomous-content-service/src/domain/entities"
+	"github.com/Ceesaxp/autonomous-content-service/src/domain/repositories"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// PostgresContentJobRepository implements the ContentJobRepository interface
+type PostgresContentJobRepository struct {
+	db *sql.DB
+}
+
+// NewContentJobRepository creates a new PostgreSQL content job repository
+func NewContentJobRepository(db *sql.DB) repositories.ContentJobRepository {
+	return &PostgresContentJobRepository{db: db}
+}
+
+const contentJobColumns = `job_id, content_id, project_id, status, stage, last_completed_stage, attempts, errors,
+	created_at, updated_at, completed_at, worker, lease_until`
+
+// activeContentJobIndex is the unique index that allows one queued or running job per content
+const activeContentJobIndex = "idx_content_jobs_active_content_id"
+
+func scanContentJob(row rowScanner) (*entities.ContentJob, error) {
+	job := &entities.ContentJob{}
+	var stage, lastCompleted, worker sql.NullString
+	var jobErrors []byte
+	var completedAt, leaseUntil sql.NullTime
+
+	err := row.Scan(
+		&job.JobID,
+		&job.ContentID,
+		&job.ProjectID,
+		&job.Status,
+		&stage,
+		&lastCompleted,
+		&job.Attempts,
+		&jobErrors,
+		&job.CreatedAt,
+		&job.UpdatedAt,
+		&completedAt,
+		&worker,
+		&leaseUntil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	job.Stage = stage.String
+	job.LastCompletedStage = lastCompleted.String
+	job.Errors = []*entities.ContentJobError{}
+	if err := decodeJSON(jobErrors, &job.Errors); err != nil {
+		return nil, fmt.Errorf("failed to decode content job errors: %w", err)
+	}
+	if completedAt.Valid {
+		job.CompletedAt = &completedAt.Time
+	}
+	job.Worker = worker.String
+	if leaseUntil.Valid {
+		job.LeaseUntil = &leaseUntil.Time
+	}
+
+	return job, nil
+}
+
+func (r *PostgresContentJobRepository) queryContentJobs(ctx context.Context, query string, args ...interface{}) ([]*entities.ContentJob, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query content jobs: %w", err)
+	}
+	defer rows.Close()
+
+	jobs := []*entities.ContentJob{}
+	for rows.Next() {
+		job, err := scanContentJob(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan content job: %w", err)
+		}
+		jobs = append(jobs, job)
+	}
+
+	return jobs, rows.Err()
+}
+
+func (r *PostgresContentJobRepository) FindByID(ctx context.Context, id uuid.UUID) (*entities.ContentJob, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT `+contentJobColumns+` FROM content_jobs WHERE job_id = $1`, id)
+
+	job, err := scanContentJob(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repositories.NewNotFoundError("content job", id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find content job: %w", err)
+	}
+
+	return job, nil
+}
+
+func (r *PostgresContentJobRepository) FindByContentID(ctx context.Context, contentID uuid.UUID) ([]*entities.ContentJob, error) {
+	return r.queryContentJobs(ctx,
+		`SELECT `+contentJobColumns+` FROM content_jobs WHERE content_id = $1 ORDER BY created_at ASC`,
+		contentID)
+}
+
+func (r *PostgresContentJobRepository) FindByStatus(ctx context.Context, statuses ...entities.ContentJobStatus) ([]*entities.ContentJob, error) {
+	names := make([]string, len(statuses))
+	for i, status := range statuses {
+		names[i] = string(status)
+	}
+
+	return r.queryContentJobs(ctx,
+		`SELECT `+contentJobColumns+` FROM content_jobs WHERE status = ANY($1) ORDER BY created_at ASC`,
+		pq.Array(names))
+}
+
+func (r *PostgresContentJobRepository) Create(ctx context.Context, job *entities.ContentJob) error {
+	values, err := contentJobValues(job)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.ExecContext(ctx, `
+		INSERT INTO content_jobs (`+contentJobColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)`,
+		append(values, nullString(job.Worker), nullTime(job.LeaseUntil))...)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation && pqErr.Constraint == activeContentJobIndex {
+		return repositories.ErrContentJobActive
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create content job: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresContentJobRepository) Update(ctx context.Context, job *entities.ContentJob) error {
+	values, err := contentJobValues(job)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE content_jobs SET
+			content_id = $2, project_id = $3, status = $4, stage = $5, last_completed_stage = $6,
+			attempts = $7, errors = $8, created_at = $9, updated_at = $10, completed_at = $11
+		WHERE job_id = $1`,
+		values...)
+	if err != nil {
+		return fmt.Errorf("failed to update content job: %w", err)
+	}
+	return expectAffected(result, "content job", job.JobID)
+}
+
+func (r *PostgresContentJobRepository) Claim(ctx context.Context, id uuid.UUID, worker string, leaseUntil time.Time) (*entities.ContentJob, error) {
+	row := r.db.QueryRowContext(ctx, `
+		UPDATE content_jobs SET
+			status = $2, attempts = attempts + 1, worker = $3, lease_until = $4, updated_at = $5
+		WHERE job_id = $1 AND status = $6
+		RETURNING `+contentJobColumns,
+		id, entities.ContentJobStatusRunning, worker, leaseUntil, time.Now(), entities.ContentJobStatusQueued)
+
+	job, err := scanContentJob(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repositories.NewNotFoundError("queued content job", id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim content job: %w", err)
+	}
+	return job, nil
+}
+
+func (r *PostgresContentJobRepository) RenewLease(ctx context.Context, id uuid.UUID, worker string, leaseUntil time.Time) error {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE content_jobs SET lease_until = $3
+		WHERE job_id = $1 AND worker = $2 AND status = $4`,
+		id, worker, leaseUntil, entities.ContentJobStatusRunning)
+	if err != nil {
+		return fmt.Errorf("failed to renew content job lease: %w", err)
+	}
+	return expectAffected(result, "running content job", id)
+}
+
+func (r *PostgresContentJobRepository) RequeueExpired(ctx context.Context, now time.Time) ([]*entities.ContentJob, error) {
+	return r.queryContentJobs(ctx, `
+		UPDATE content_jobs SET status = $1, updated_at = $2
+		WHERE status = $3 AND (lease_until IS NULL OR lease_until < $2)
+		RETURNING `+contentJobColumns,
+		entities.ContentJobStatusQueued, now, entities.ContentJobStatusRunning)
+}
+
+func contentJobValues(job *entities.ContentJob) ([]interface{}, error) {
+	jobErrors := job.Errors
+	if jobErrors == nil {
+		jobErrors = []*entities.ContentJobError{}
+	}
+	encodedErrors, err := encodeJSON(jobErrors)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode content job errors: %w", err)
+	}
+
+	return []interface{}{
+		job.JobID,
+		job.ContentID,
+		job.ProjectID,
+		job.Status,
+		nullString(job.Stage),
+		nullString(job.LastCompletedStage),
+		job.Attempts,
+		encodedErrors,
+		job.CreatedAt,
+		job.UpdatedAt,
+		nullTime(job.CompletedAt),
+	}, nil
+}
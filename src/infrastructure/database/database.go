@@ -21,15 +21,3 @@ func NewPostgresDB(dsn string) (*sql.DB, error) {
 
 	return db, nil
 }
-
-// RunMigrations runs database migrations
-func RunMigrations(db *sql.DB) error {
-	// For now, this is a placeholder
-	// In a real implementation, this would run SQL migration scripts
-	fmt.Println("Running database migrations...")
-	
-	// Check if tables exist and create them if they don't
-	// This is a simplified approach - in production you'd use a proper migration tool
-	
-	return nil
-}
\ No newline at end of file
@@ -0,0 +1,75 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/Ceesaxp/autonomous-content-service/src/domain/entities"
+	"github.com/Ceesaxp/autonomous-content-service/src/domain/repositories"
+)
+
+// PostgresLLMCacheRepository implements the LLMCacheRepository interface
+type PostgresLLMCacheRepository struct {
+	db *sql.DB
+}
+
+// NewLLMCacheRepository creates a new PostgreSQL LLM cache repository
+func NewLLMCacheRepository(db *sql.DB) repositories.LLMCacheRepository {
+	return &PostgresLLMCacheRepository{db: db}
+}
+
+func (r *PostgresLLMCacheRepository) Get(ctx context.Context, key string) (*entities.LLMCacheEntry, error) {
+	entry := &entities.LLMCacheEntry{}
+	err := r.db.QueryRowContext(ctx, `
+		SELECT cache_key, model, response, created_at, expires_at
+		FROM llm_cache
+		WHERE cache_key = $1 AND expires_at > NOW()`, key).Scan(
+		&entry.Key,
+		&entry.Model,
+		&entry.Response,
+		&entry.CreatedAt,
+		&entry.ExpiresAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, repositories.NewNotFoundError("LLM cache entry", key)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get LLM cache entry: %w", err)
+	}
+	return entry, nil
+}
+
+func (r *PostgresLLMCacheRepository) Put(ctx context.Context, entry *entities.LLMCacheEntry) error {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO llm_cache (cache_key, model, response, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (cache_key) DO UPDATE SET
+			model = EXCLUDED.model,
+			response = EXCLUDED.response,
+			created_at = EXCLUDED.created_at,
+			expires_at = EXCLUDED.expires_at`,
+		entry.Key,
+		entry.Model,
+		entry.Response,
+		entry.CreatedAt,
+		entry.ExpiresAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to store LLM cache entry: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresLLMCacheRepository) DeleteExpired(ctx context.Context, now time.Time) (int, error) {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM llm_cache WHERE expires_at <= $1`, now)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired LLM cache entries: %w", err)
+	}
+	removed, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	return int(removed), nil
+}
@@ -0,0 +1,108 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/Ceesaxp/autonomous-content-service/src/domain/entities"
+	"github.com/Ceesaxp/autonomous-content-service/src/domain/repositories"
+	"github.com/google/uuid"
+)
+
+// PostgresLLMUsageRepository implements the LLMUsageRepository interface
+type PostgresLLMUsageRepository struct {
+	db *sql.DB
+}
+
+// NewLLMUsageRepository creates a new PostgreSQL LLM usage repository
+func NewLLMUsageRepository(db *sql.DB) repositories.LLMUsageRepository {
+	return &PostgresLLMUsageRepository{db: db}
+}
+
+const llmUsageColumns = `usage_id, content_id, project_id, client_id, model, stage, prompt_tokens, completion_tokens,
+	cost_amount, cost_currency, recorded_at`
+
+func scanLLMUsage(row rowScanner) (*entities.LLMUsageRecord, error) {
+	record := &entities.LLMUsageRecord{}
+	var clientID uuid.NullUUID
+
+	err := row.Scan(
+		&record.UsageID,
+		&record.ContentID,
+		&record.ProjectID,
+		&clientID,
+		&record.Model,
+		&record.Stage,
+		&record.PromptTokens,
+		&record.CompletionTokens,
+		&record.Cost.Amount,
+		&record.Cost.Currency,
+		&record.RecordedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	record.ClientID = clientID.UUID
+	return record, nil
+}
+
+func (r *PostgresLLMUsageRepository) queryLLMUsage(ctx context.Context, column string, id uuid.UUID) ([]*entities.LLMUsageRecord, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT `+llmUsageColumns+` FROM llm_usage WHERE `+column+` = $1 ORDER BY recorded_at ASC`, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query LLM usage: %w", err)
+	}
+	defer rows.Close()
+
+	records := []*entities.LLMUsageRecord{}
+	for rows.Next() {
+		record, err := scanLLMUsage(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan LLM usage: %w", err)
+		}
+		records = append(records, record)
+	}
+
+	return records, rows.Err()
+}
+
+func (r *PostgresLLMUsageRepository) FindByContentID(ctx context.Context, contentID uuid.UUID) ([]*entities.LLMUsageRecord, error) {
+	return r.queryLLMUsage(ctx, "content_id", contentID)
+}
+
+func (r *PostgresLLMUsageRepository) FindByProjectID(ctx context.Context, projectID uuid.UUID) ([]*entities.LLMUsageRecord, error) {
+	return r.queryLLMUsage(ctx, "project_id", projectID)
+}
+
+func (r *PostgresLLMUsageRepository) FindByClientID(ctx context.Context, clientID uuid.UUID) ([]*entities.LLMUsageRecord, error) {
+	return r.queryLLMUsage(ctx, "client_id", clientID)
+}
+
+func (r *PostgresLLMUsageRepository) Create(ctx context.Context, record *entities.LLMUsageRecord) error {
+	var clientID *uuid.UUID
+	if record.ClientID != uuid.Nil {
+		clientID = &record.ClientID
+	}
+
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO llm_usage (`+llmUsageColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`,
+		record.UsageID,
+		record.ContentID,
+		record.ProjectID,
+		nullUUID(clientID),
+		record.Model,
+		record.Stage,
+		record.PromptTokens,
+		record.CompletionTokens,
+		record.Cost.Amount,
+		record.Cost.Currency,
+		record.RecordedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create LLM usage record: %w", err)
+	}
+	return nil
+}
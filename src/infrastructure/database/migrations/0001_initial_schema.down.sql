@@ -0,0 +1,24 @@
+-- Revert the initial schema for Autonomous Content Creation Service
+
+DROP TABLE IF EXISTS events;
+DROP TABLE IF EXISTS system_capabilities;
+DROP TABLE IF EXISTS feedback;
+DROP TABLE IF EXISTS transactions;
+DROP TABLE IF EXISTS content_versions;
+DROP TABLE IF EXISTS content;
+DROP TABLE IF EXISTS projects;
+DROP TABLE IF EXISTS client_profiles;
+DROP TABLE IF EXISTS clients;
+
+DROP TYPE IF EXISTS capability_status;
+DROP TYPE IF EXISTS capability_type;
+DROP TYPE IF EXISTS feedback_status;
+DROP TYPE IF EXISTS feedback_type;
+DROP TYPE IF EXISTS feedback_source;
+DROP TYPE IF EXISTS transaction_status;
+DROP TYPE IF EXISTS transaction_type;
+DROP TYPE IF EXISTS content_status;
+DROP TYPE IF EXISTS content_type;
+DROP TYPE IF EXISTS priority;
+DROP TYPE IF EXISTS project_status;
+DROP TYPE IF EXISTS client_status;
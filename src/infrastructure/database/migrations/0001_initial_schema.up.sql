@@ -58,8 +58,7 @@ CREATE TYPE transaction_type AS ENUM (
     'Payment',
     'Refund',
     'Fee',
-    'Subscription',
-    'ApiCost'
+    'Credit'
 );
 
 -- Transaction status enum
@@ -67,37 +66,35 @@ CREATE TYPE transaction_status AS ENUM (
     'Pending',
     'Completed',
     'Failed',
-    'Disputed'
-);
-
--- Payment method enum
-CREATE TYPE payment_method_type AS ENUM (
-    'CreditCard',
-    'BankTransfer',
-    'Cryptocurrency',
-    'PayPal'
+    'Cancelled',
+    'Refunded'
 );
 
 -- Feedback source enum
 CREATE TYPE feedback_source AS ENUM (
     'Client',
     'System',
+    'Automatic',
     'ThirdParty'
 );
 
 -- Feedback type enum
 CREATE TYPE feedback_type AS ENUM (
-    'Revision',
-    'Comment',
-    'Rating'
+    'Positive',
+    'Negative',
+    'Neutral',
+    'Suggestion',
+    'Complaint',
+    'Testimonial'
 );
 
 -- Feedback status enum
 CREATE TYPE feedback_status AS ENUM (
-    'New',
-    'Acknowledged',
-    'Implemented',
-    'Rejected'
+    'Open',
+    'InProgress',
+    'Resolved',
+    'Closed',
+    'Dismissed'
 );
 
 -- Capability type enum
@@ -167,6 +164,8 @@ CREATE TABLE projects (
     budget_currency VARCHAR(3) NOT NULL,
     priority priority NOT NULL DEFAULT 'Medium',
     status project_status NOT NULL DEFAULT 'Draft',
+    requirements JSONB,
+    metadata JSONB,
     created_at TIMESTAMP NOT NULL DEFAULT NOW(),
     updated_at TIMESTAMP NOT NULL DEFAULT NOW()
 );
@@ -229,10 +228,13 @@ CREATE TABLE transactions (
     currency VARCHAR(3) NOT NULL,
     type transaction_type NOT NULL,
     status transaction_status NOT NULL DEFAULT 'Pending',
-    payment_method payment_method_type NOT NULL,
-    external_reference VARCHAR(255),
+    payment_method VARCHAR(50) NOT NULL,
+    payment_reference VARCHAR(255),
     description TEXT NOT NULL,
-    timestamp TIMESTAMP NOT NULL DEFAULT NOW()
+    processed_at TIMESTAMP,
+    metadata JSONB,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
 );
 
 -- Create index on client_id
@@ -243,21 +245,29 @@ CREATE INDEX idx_transactions_project_id ON transactions(project_id);
 CREATE INDEX idx_transactions_status ON transactions(status);
 -- Create index on transaction type
 CREATE INDEX idx_transactions_type ON transactions(type);
--- Create index on timestamp for date range queries
-CREATE INDEX idx_transactions_timestamp ON transactions(timestamp);
+-- Create index on created_at for date range queries
+CREATE INDEX idx_transactions_created_at ON transactions(created_at);
 
 -- Feedback table
 CREATE TABLE feedback (
     feedback_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
     content_id UUID REFERENCES content(content_id) ON DELETE CASCADE,
     project_id UUID REFERENCES projects(project_id) ON DELETE CASCADE,
+    client_id UUID NOT NULL REFERENCES clients(client_id) ON DELETE CASCADE,
     source feedback_source NOT NULL,
     type feedback_type NOT NULL,
-    score DECIMAL(3, 1),
-    comment TEXT,
-    status feedback_status NOT NULL DEFAULT 'New',
+    status feedback_status NOT NULL DEFAULT 'Open',
+    title VARCHAR(255) NOT NULL,
+    message TEXT NOT NULL,
+    rating_score DECIMAL(4, 2),
+    rating_max_score DECIMAL(4, 2),
+    tags TEXT[],
+    is_resolved BOOLEAN NOT NULL DEFAULT FALSE,
+    resolved_at TIMESTAMP,
+    resolved_by UUID,
+    metadata JSONB,
     created_at TIMESTAMP NOT NULL DEFAULT NOW(),
-    CHECK (content_id IS NOT NULL OR project_id IS NOT NULL)
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
 );
 
 -- Create index on content_id
@@ -268,6 +278,8 @@ CREATE INDEX idx_feedback_project_id ON feedback(project_id);
 CREATE INDEX idx_feedback_status ON feedback(status);
 -- Create index on feedback source
 CREATE INDEX idx_feedback_source ON feedback(source);
+-- Create index on feedback type
+CREATE INDEX idx_feedback_type ON feedback(type);
 
 -- System capabilities table
 CREATE TABLE system_capabilities (
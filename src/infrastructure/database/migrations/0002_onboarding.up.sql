@@ -0,0 +1,18 @@
+-- Onboarding sessions for the conversational client onboarding flow
+
+CREATE TABLE onboarding_sessions (
+    session_id UUID PRIMARY KEY,
+    client_id UUID NOT NULL REFERENCES clients(client_id) ON DELETE CASCADE,
+    stage VARCHAR(50) NOT NULL,
+    responses JSONB DEFAULT '{}',
+    conversation_log JSONB DEFAULT '[]',
+    started_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    completed_at TIMESTAMP NULL
+);
+
+CREATE INDEX idx_onboarding_sessions_client_id ON onboarding_sessions(client_id);
+CREATE INDEX idx_onboarding_sessions_stage ON onboarding_sessions(stage);
+CREATE INDEX idx_onboarding_sessions_started_at ON onboarding_sessions(started_at);
+CREATE INDEX idx_onboarding_sessions_completed_at ON onboarding_sessions(completed_at);
+CREATE INDEX idx_onboarding_sessions_updated_at ON onboarding_sessions(updated_at);
@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS billing_history;
+DROP TABLE IF EXISTS project_analytics;
+DROP TABLE IF EXISTS dashboard_messages;
+DROP TABLE IF EXISTS message_threads;
+DROP TABLE IF EXISTS revision_requests;
+DROP TABLE IF EXISTS content_approvals;
+DROP TABLE IF EXISTS project_milestones;
+DROP TABLE IF EXISTS dashboard_notifications;
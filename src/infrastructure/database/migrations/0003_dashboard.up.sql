@@ -0,0 +1,120 @@
+-- Client dashboard: notifications, milestones, approvals, messaging, analytics and billing
+
+CREATE TABLE dashboard_notifications (
+    notification_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    client_id UUID NOT NULL REFERENCES clients(client_id) ON DELETE CASCADE,
+    project_id UUID REFERENCES projects(project_id) ON DELETE CASCADE,
+    type VARCHAR(50) NOT NULL,
+    priority VARCHAR(20) NOT NULL DEFAULT 'Medium',
+    title VARCHAR(255) NOT NULL,
+    message TEXT NOT NULL,
+    action_url TEXT,
+    is_read BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    read_at TIMESTAMP
+);
+
+CREATE INDEX idx_dashboard_notifications_client_id ON dashboard_notifications(client_id, is_read);
+
+CREATE TABLE project_milestones (
+    milestone_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    project_id UUID NOT NULL REFERENCES projects(project_id) ON DELETE CASCADE,
+    title VARCHAR(255) NOT NULL,
+    description TEXT NOT NULL,
+    due_date TIMESTAMP NOT NULL,
+    is_completed BOOLEAN NOT NULL DEFAULT FALSE,
+    completed_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_project_milestones_project_id ON project_milestones(project_id);
+
+CREATE TABLE content_approvals (
+    approval_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    content_id UUID NOT NULL REFERENCES content(content_id) ON DELETE CASCADE,
+    project_id UUID NOT NULL REFERENCES projects(project_id) ON DELETE CASCADE,
+    client_id UUID NOT NULL REFERENCES clients(client_id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL DEFAULT 'Pending',
+    feedback TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    approved_at TIMESTAMP
+);
+
+CREATE INDEX idx_content_approvals_project_id ON content_approvals(project_id);
+CREATE INDEX idx_content_approvals_client_id ON content_approvals(client_id, status);
+
+CREATE TABLE revision_requests (
+    request_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    content_id UUID NOT NULL REFERENCES content(content_id) ON DELETE CASCADE,
+    project_id UUID NOT NULL REFERENCES projects(project_id) ON DELETE CASCADE,
+    client_id UUID NOT NULL REFERENCES clients(client_id) ON DELETE CASCADE,
+    reason VARCHAR(255) NOT NULL,
+    details TEXT NOT NULL,
+    is_completed BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    completed_at TIMESTAMP
+);
+
+CREATE INDEX idx_revision_requests_project_id ON revision_requests(project_id);
+CREATE INDEX idx_revision_requests_client_id ON revision_requests(client_id);
+
+CREATE TABLE message_threads (
+    thread_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    project_id UUID NOT NULL REFERENCES projects(project_id) ON DELETE CASCADE,
+    client_id UUID NOT NULL REFERENCES clients(client_id) ON DELETE CASCADE,
+    subject VARCHAR(255) NOT NULL,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    last_message TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_message_threads_project_id ON message_threads(project_id);
+CREATE INDEX idx_message_threads_client_id ON message_threads(client_id);
+
+CREATE TABLE dashboard_messages (
+    message_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    thread_id UUID NOT NULL REFERENCES message_threads(thread_id) ON DELETE CASCADE,
+    type VARCHAR(20) NOT NULL,
+    content TEXT NOT NULL,
+    is_read BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    read_at TIMESTAMP
+);
+
+CREATE INDEX idx_dashboard_messages_thread_id ON dashboard_messages(thread_id, created_at);
+
+CREATE TABLE project_analytics (
+    project_id UUID PRIMARY KEY REFERENCES projects(project_id) ON DELETE CASCADE,
+    total_tasks INT NOT NULL DEFAULT 0,
+    completed_tasks INT NOT NULL DEFAULT 0,
+    pending_tasks INT NOT NULL DEFAULT 0,
+    progress_percent DECIMAL(5, 2) NOT NULL DEFAULT 0,
+    time_spent BIGINT NOT NULL DEFAULT 0,
+    estimated_time BIGINT NOT NULL DEFAULT 0,
+    days_to_deadline INT NOT NULL DEFAULT 0,
+    content_delivered INT NOT NULL DEFAULT 0,
+    revision_requests INT NOT NULL DEFAULT 0,
+    client_satisfaction DECIMAL(5, 2) NOT NULL DEFAULT 0,
+    custom_metrics JSONB,
+    last_updated TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE billing_history (
+    billing_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    project_id UUID NOT NULL REFERENCES projects(project_id) ON DELETE CASCADE,
+    client_id UUID NOT NULL REFERENCES clients(client_id) ON DELETE CASCADE,
+    invoice_number VARCHAR(100) NOT NULL UNIQUE,
+    amount DECIMAL(10, 2) NOT NULL,
+    currency VARCHAR(3) NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    due_date TIMESTAMP NOT NULL,
+    paid_date TIMESTAMP,
+    description TEXT NOT NULL,
+    line_items JSONB,
+    metadata JSONB,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_billing_history_project_id ON billing_history(project_id);
+CREATE INDEX idx_billing_history_client_id ON billing_history(client_id, status);
@@ -0,0 +1,18 @@
+-- Background content generation jobs, resumed from the last completed stage after a restart
+
+CREATE TABLE content_jobs (
+    job_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    content_id UUID NOT NULL REFERENCES content(content_id) ON DELETE CASCADE,
+    project_id UUID NOT NULL REFERENCES projects(project_id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL DEFAULT 'Queued',
+    stage VARCHAR(50),
+    last_completed_stage VARCHAR(50),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    errors JSONB NOT NULL DEFAULT '[]',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    completed_at TIMESTAMP
+);
+
+CREATE INDEX idx_content_jobs_content_id ON content_jobs(content_id);
+CREATE INDEX idx_content_jobs_status ON content_jobs(status);
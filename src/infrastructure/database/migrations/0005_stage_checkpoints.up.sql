@@ -0,0 +1,13 @@
+-- Per-stage pipeline results, used to resume failed content without repeating completed stages
+
+CREATE TABLE content_stage_checkpoints (
+    content_id UUID NOT NULL REFERENCES content(content_id) ON DELETE CASCADE,
+    stage VARCHAR(50) NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    output TEXT,
+    metadata JSONB,
+    error TEXT,
+    elapsed_ms BIGINT NOT NULL DEFAULT 0,
+    recorded_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (content_id, stage)
+);
@@ -0,0 +1,19 @@
+-- Tokens and cost of each LLM call, rolled up per content, project and client
+
+CREATE TABLE llm_usage (
+    usage_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    content_id UUID NOT NULL REFERENCES content(content_id) ON DELETE CASCADE,
+    project_id UUID NOT NULL REFERENCES projects(project_id) ON DELETE CASCADE,
+    client_id UUID REFERENCES clients(client_id) ON DELETE CASCADE,
+    model VARCHAR(100) NOT NULL,
+    stage VARCHAR(50) NOT NULL,
+    prompt_tokens INTEGER NOT NULL DEFAULT 0,
+    completion_tokens INTEGER NOT NULL DEFAULT 0,
+    cost_amount NUMERIC(12, 6) NOT NULL DEFAULT 0,
+    cost_currency VARCHAR(3) NOT NULL DEFAULT 'USD',
+    recorded_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_llm_usage_content_id ON llm_usage(content_id);
+CREATE INDEX idx_llm_usage_project_id ON llm_usage(project_id);
+CREATE INDEX idx_llm_usage_client_id ON llm_usage(client_id);
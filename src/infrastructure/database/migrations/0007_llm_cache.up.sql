@@ -0,0 +1,11 @@
+-- Cached LLM responses, keyed by a hash of the model, parameters and normalized prompt
+
+CREATE TABLE llm_cache (
+    cache_key VARCHAR(64) PRIMARY KEY,
+    model VARCHAR(100) NOT NULL,
+    response JSONB NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_llm_cache_expires_at ON llm_cache(expires_at);
@@ -0,0 +1,22 @@
+-- PostgreSQL cannot drop an enum value, so the type is recreated without it
+
+UPDATE content SET status = 'Review' WHERE status = 'HumanReview';
+
+ALTER TYPE content_status RENAME TO content_status_old;
+
+CREATE TYPE content_status AS ENUM (
+    'Planning',
+    'Researching',
+    'Drafting',
+    'Editing',
+    'Review',
+    'Approved',
+    'Published',
+    'Archived'
+);
+
+ALTER TABLE content ALTER COLUMN status DROP DEFAULT;
+ALTER TABLE content ALTER COLUMN status TYPE content_status USING status::text::content_status;
+ALTER TABLE content ALTER COLUMN status SET DEFAULT 'Planning';
+
+DROP TYPE content_status_old;
@@ -0,0 +1,3 @@
+-- Content that misses its quality thresholds after automatic revision waits for an editor
+
+ALTER TYPE content_status ADD VALUE IF NOT EXISTS 'HumanReview' AFTER 'Review';
@@ -0,0 +1,14 @@
+-- Quality assessments of content versions, with the full report of every check
+
+CREATE TABLE quality_assessments (
+    assessment_id UUID PRIMARY KEY,
+    content_id UUID NOT NULL REFERENCES content(content_id) ON DELETE CASCADE,
+    content_version INTEGER NOT NULL,
+    overall_score NUMERIC(5,2) NOT NULL,
+    grade VARCHAR(30) NOT NULL,
+    passed_threshold BOOLEAN NOT NULL,
+    report JSONB NOT NULL,
+    assessed_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_quality_assessments_content_id ON quality_assessments(content_id, assessed_at DESC);
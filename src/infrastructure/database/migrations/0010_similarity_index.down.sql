@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS similarity_bands;
+DROP TABLE IF EXISTS similarity_documents;
@@ -0,0 +1,21 @@
+-- Near-duplicate index of content versions and research sources, found by MinHash LSH bands
+
+CREATE TABLE similarity_documents (
+    document_id UUID PRIMARY KEY,
+    document_key VARCHAR(512) NOT NULL UNIQUE,
+    kind VARCHAR(20) NOT NULL,
+    content_id UUID REFERENCES content(content_id) ON DELETE CASCADE,
+    project_id UUID,
+    client_id UUID,
+    version_number INTEGER,
+    url TEXT,
+    title TEXT NOT NULL DEFAULT '',
+    body TEXT NOT NULL,
+    indexed_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE similarity_bands (
+    band BIGINT NOT NULL,
+    document_id UUID NOT NULL REFERENCES similarity_documents(document_id) ON DELETE CASCADE,
+    PRIMARY KEY (band, document_id)
+);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS project_context_entries;
+DROP TABLE IF EXISTS project_contexts;
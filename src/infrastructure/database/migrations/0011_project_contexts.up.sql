@@ -0,0 +1,29 @@
+-- LLM conversation context of each project, shared by every API and worker process.
+-- version increases on every change so that processes can tell when their cached copy is stale.
+
+CREATE TABLE project_contexts (
+    project_id UUID PRIMARY KEY REFERENCES projects(project_id) ON DELETE CASCADE,
+    client_id UUID,
+    content_type VARCHAR(50),
+    max_tokens INTEGER NOT NULL,
+    token_count INTEGER NOT NULL DEFAULT 0,
+    domain_knowledge JSONB,
+    version BIGINT NOT NULL DEFAULT 1,
+    last_accessed TIMESTAMP NOT NULL DEFAULT NOW(),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE project_context_entries (
+    entry_id UUID PRIMARY KEY,
+    project_id UUID NOT NULL REFERENCES project_contexts(project_id) ON DELETE CASCADE,
+    sequence BIGSERIAL NOT NULL,
+    role VARCHAR(20) NOT NULL,
+    content TEXT NOT NULL,
+    priority INTEGER NOT NULL DEFAULT 0,
+    metadata JSONB,
+    token_count INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_project_context_entries_project ON project_context_entries(project_id, sequence);
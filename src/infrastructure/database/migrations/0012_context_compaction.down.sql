@@ -0,0 +1,4 @@
+ALTER TABLE project_contexts DROP COLUMN IF EXISTS compactions;
+ALTER TABLE project_contexts DROP COLUMN IF EXISTS compaction_count;
+
+ALTER TABLE project_context_entries DROP COLUMN IF EXISTS must_keep;
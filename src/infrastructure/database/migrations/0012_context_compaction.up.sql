@@ -0,0 +1,7 @@
+-- Context compaction: entries a client must keep are never evicted, and the recent
+-- compactions of each project context are kept for metrics.
+
+ALTER TABLE project_context_entries ADD COLUMN must_keep BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE project_contexts ADD COLUMN compaction_count INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE project_contexts ADD COLUMN compactions JSONB;
@@ -0,0 +1,27 @@
+-- Stored prompt templates. A template applies to a client, an industry or, with neither,
+-- to every client of its content type, and overrides the built-in template of its stage.
+-- Every change adds a version; current_version points at the one in use.
+
+CREATE TABLE prompt_templates (
+    template_id UUID PRIMARY KEY,
+    name VARCHAR(50) NOT NULL,
+    content_type VARCHAR(50) NOT NULL,
+    industry VARCHAR(100) NOT NULL DEFAULT '',
+    client_id UUID REFERENCES clients(client_id) ON DELETE CASCADE,
+    current_version INTEGER NOT NULL DEFAULT 1,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX idx_prompt_templates_scope ON prompt_templates(
+    name, content_type, industry, COALESCE(client_id, '00000000-0000-0000-0000-000000000000'::uuid)
+);
+
+CREATE TABLE prompt_template_versions (
+    template_id UUID NOT NULL REFERENCES prompt_templates(template_id) ON DELETE CASCADE,
+    version INTEGER NOT NULL,
+    body TEXT NOT NULL,
+    author VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (template_id, version)
+);
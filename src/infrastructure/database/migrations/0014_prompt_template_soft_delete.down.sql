@@ -0,0 +1,8 @@
+DELETE FROM prompt_templates WHERE deleted_at IS NOT NULL;
+
+DROP INDEX IF EXISTS idx_prompt_templates_scope;
+CREATE UNIQUE INDEX idx_prompt_templates_scope ON prompt_templates(
+    name, content_type, industry, COALESCE(client_id, '00000000-0000-0000-0000-000000000000'::uuid)
+);
+
+ALTER TABLE prompt_templates DROP COLUMN IF EXISTS deleted_at;
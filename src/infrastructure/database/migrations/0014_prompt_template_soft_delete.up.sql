@@ -0,0 +1,10 @@
+-- Deleted prompt templates are kept, with their versions, so content metadata that
+-- records the template and version a prompt came from still resolves. Only templates
+-- in use are unique per scope.
+
+ALTER TABLE prompt_templates ADD COLUMN deleted_at TIMESTAMP;
+
+DROP INDEX idx_prompt_templates_scope;
+CREATE UNIQUE INDEX idx_prompt_templates_scope ON prompt_templates(
+    name, content_type, industry, COALESCE(client_id, '00000000-0000-0000-0000-000000000000'::uuid)
+) WHERE deleted_at IS NULL;
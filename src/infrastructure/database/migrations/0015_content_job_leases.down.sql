@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_content_jobs_active_content_id;
+ALTER TABLE content_jobs DROP COLUMN IF EXISTS lease_until;
+ALTER TABLE content_jobs DROP COLUMN IF EXISTS worker;
@@ -0,0 +1,8 @@
+-- Workers claim jobs on a lease; a running job whose lease expired is requeued
+
+ALTER TABLE content_jobs ADD COLUMN worker VARCHAR(255);
+ALTER TABLE content_jobs ADD COLUMN lease_until TIMESTAMP;
+
+-- One queued or running job per content
+CREATE UNIQUE INDEX idx_content_jobs_active_content_id ON content_jobs(content_id)
+    WHERE status IN ('Queued', 'Running');
@@ -0,0 +1 @@
+ALTER TABLE prompt_template_versions DROP COLUMN IF EXISTS output_format;
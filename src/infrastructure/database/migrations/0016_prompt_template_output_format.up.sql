@@ -0,0 +1,4 @@
+-- The markup a template version asks the model to write, so citations added to its
+-- output match it. Empty means Markdown.
+
+ALTER TABLE prompt_template_versions ADD COLUMN output_format VARCHAR(20) NOT NULL DEFAULT '';
@@ -0,0 +1,102 @@
+package database
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func migrationFS(names ...string) fstest.MapFS {
+	fsys := fstest.MapFS{}
+	for _, name := range names {
+		fsys["migrations/"+name] = &fstest.MapFile{Data: []byte("-- " + name)}
+	}
+	return fsys
+}
+
+func TestLoadMigrations_PairsScriptsInVersionOrder(t *testing.T) {
+	fsys := migrationFS(
+		"0002_add_jobs.down.sql",
+		"0002_add_jobs.up.sql",
+		"0001_initial_schema.up.sql",
+		"0001_initial_schema.down.sql",
+		"README.md",
+	)
+
+	migrations, err := loadMigrations(fsys, "migrations")
+	require.NoError(t, err)
+	assert.Equal(t, []Migration{
+		{Version: 1, Name: "initial_schema", Up: "-- 0001_initial_schema.up.sql", Down: "-- 0001_initial_schema.down.sql"},
+		{Version: 2, Name: "add_jobs", Up: "-- 0002_add_jobs.up.sql", Down: "-- 0002_add_jobs.down.sql"},
+	}, migrations)
+}
+
+func TestLoadMigrations_EmbeddedSetIsValid(t *testing.T) {
+	migrations, err := loadMigrations(migrationFiles, "migrations")
+	require.NoError(t, err)
+	assert.NotEmpty(t, migrations)
+}
+
+func TestLoadMigrations_RejectsInvalidSets(t *testing.T) {
+	tests := []struct {
+		name  string
+		files []string
+		err   string
+	}{
+		{
+			name:  "missing down script",
+			files: []string{"0001_initial.up.sql"},
+			err:   "migration 1_initial has no down script",
+		},
+		{
+			name:  "missing up script",
+			files: []string{"0001_initial.down.sql"},
+			err:   "migration 1_initial has no up script",
+		},
+		{
+			name:  "version used by two migrations",
+			files: []string{"0001_initial.up.sql", "0001_initial.down.sql", "0001_other.up.sql", "0001_other.down.sql"},
+			err:   "migration version 1 is used by both",
+		},
+		{
+			name:  "version written two ways",
+			files: []string{"0001_initial.up.sql", "1_initial.up.sql", "0001_initial.down.sql"},
+			err:   "migration version 1 has more than one up script",
+		},
+		{
+			name:  "gap between versions",
+			files: []string{"0001_initial.up.sql", "0001_initial.down.sql", "0003_jobs.up.sql", "0003_jobs.down.sql"},
+			err:   "migration version 2 is missing before 3_jobs",
+		},
+		{
+			name:  "no direction",
+			files: []string{"0001_initial.sql"},
+			err:   "must end in .up.sql or .down.sql",
+		},
+		{
+			name:  "no name",
+			files: []string{"0001.up.sql"},
+			err:   "must be named <version>_<name>",
+		},
+		{
+			name:  "non-numeric version",
+			files: []string{"first_initial.up.sql"},
+			err:   "has an invalid version",
+		},
+		{
+			name:  "zero version",
+			files: []string{"0000_initial.up.sql"},
+			err:   "has an invalid version",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := loadMigrations(migrationFS(tt.files...), "migrations")
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.err)
+		})
+	}
+}
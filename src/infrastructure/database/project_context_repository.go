@@ -0,0 +1,284 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Ceesaxp/autonomous-content-service/src/domain/entities"
+	"github.com/Ceesaxp/autonomous-content-service/src/domain/repositories"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// PostgresProjectContextRepository implements the ProjectContextRepository interface.
+// Updates lock the project's context row, so concurrent processes apply them one at a time.
+type PostgresProjectContextRepository struct {
+	db *sql.DB
+}
+
+// NewProjectContextRepository creates a new PostgreSQL project context repository
+func NewProjectContextRepository(db *sql.DB) repositories.ProjectContextRepository {
+	return &PostgresProjectContextRepository{db: db}
+}
+
+const projectContextColumns = `project_id, client_id, content_type, max_tokens, token_count, domain_knowledge, version, compaction_count, compactions, last_accessed, created_at, updated_at`
+
+const projectContextEntryColumns = `entry_id, project_id, role, content, priority, must_keep, metadata, token_count, created_at`
+
+// contextQueryer is satisfied by both *sql.DB and *sql.Tx
+type contextQueryer interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+func scanProjectContext(row rowScanner) (*entities.ProjectContext, error) {
+	projectContext := &entities.ProjectContext{}
+	var clientID uuid.NullUUID
+	var contentType sql.NullString
+	var knowledge, compactions []byte
+
+	err := row.Scan(
+		&projectContext.ProjectID,
+		&clientID,
+		&contentType,
+		&projectContext.MaxTokens,
+		&projectContext.TokenCount,
+		&knowledge,
+		&projectContext.Version,
+		&projectContext.CompactionCount,
+		&compactions,
+		&projectContext.LastAccessed,
+		&projectContext.CreatedAt,
+		&projectContext.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	projectContext.ClientID = clientID.UUID
+	projectContext.ContentType = entities.ContentType(contentType.String)
+	if projectContext.DomainKnowledge, err = decodeMetadata(knowledge); err != nil {
+		return nil, fmt.Errorf("failed to decode domain knowledge: %w", err)
+	}
+	if len(compactions) > 0 {
+		if err := json.Unmarshal(compactions, &projectContext.Compactions); err != nil {
+			return nil, fmt.Errorf("failed to decode context compactions: %w", err)
+		}
+	}
+	return projectContext, nil
+}
+
+func scanProjectContextEntry(row rowScanner) (*entities.ProjectContextEntry, error) {
+	entry := &entities.ProjectContextEntry{}
+	var metadata []byte
+
+	err := row.Scan(
+		&entry.EntryID,
+		&entry.ProjectID,
+		&entry.Role,
+		&entry.Content,
+		&entry.Priority,
+		&entry.MustKeep,
+		&metadata,
+		&entry.TokenCount,
+		&entry.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if entry.Metadata, err = decodeMetadata(metadata); err != nil {
+		return nil, fmt.Errorf("failed to decode context entry metadata: %w", err)
+	}
+	return entry, nil
+}
+
+func (r *PostgresProjectContextRepository) Create(ctx context.Context, projectContext *entities.ProjectContext) error {
+	knowledge, err := encodeJSON(projectContext.DomainKnowledge)
+	if err != nil {
+		return fmt.Errorf("failed to encode domain knowledge: %w", err)
+	}
+	compactions, err := encodeJSON(projectContext.Compactions)
+	if err != nil {
+		return fmt.Errorf("failed to encode context compactions: %w", err)
+	}
+
+	_, err = r.db.ExecContext(ctx, `
+		INSERT INTO project_contexts (`+projectContextColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		ON CONFLICT (project_id) DO NOTHING`,
+		projectContext.ProjectID,
+		optionalUUID(projectContext.ClientID),
+		nullString(string(projectContext.ContentType)),
+		projectContext.MaxTokens,
+		projectContext.TokenCount,
+		knowledge,
+		projectContext.Version,
+		projectContext.CompactionCount,
+		compactions,
+		projectContext.LastAccessed,
+		projectContext.CreatedAt,
+		projectContext.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create project context: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresProjectContextRepository) Load(ctx context.Context, projectID uuid.UUID) (*entities.ProjectContext, []*entities.ProjectContextEntry, error) {
+	return loadProjectContext(ctx, r.db, projectID, false)
+}
+
+// loadProjectContext reads a project context and its entries, locking the context row when
+// forUpdate is set
+func loadProjectContext(ctx context.Context, q contextQueryer, projectID uuid.UUID, forUpdate bool) (*entities.ProjectContext, []*entities.ProjectContextEntry, error) {
+	query := `SELECT ` + projectContextColumns + ` FROM project_contexts WHERE project_id = $1`
+	if forUpdate {
+		query += ` FOR UPDATE`
+	}
+
+	projectContext, err := scanProjectContext(q.QueryRowContext(ctx, query, projectID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil, repositories.NewNotFoundError("project context", projectID)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load project context: %w", err)
+	}
+
+	rows, err := q.QueryContext(ctx,
+		`SELECT `+projectContextEntryColumns+` FROM project_context_entries WHERE project_id = $1 ORDER BY sequence ASC`,
+		projectID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to query project context entries: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []*entities.ProjectContextEntry{}
+	for rows.Next() {
+		entry, err := scanProjectContextEntry(rows)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to scan project context entry: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+	return projectContext, entries, rows.Err()
+}
+
+func (r *PostgresProjectContextRepository) Version(ctx context.Context, projectID uuid.UUID) (int64, error) {
+	var version int64
+	err := r.db.QueryRowContext(ctx, `SELECT version FROM project_contexts WHERE project_id = $1`, projectID).Scan(&version)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, repositories.NewNotFoundError("project context", projectID)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to read project context version: %w", err)
+	}
+	return version, nil
+}
+
+func (r *PostgresProjectContextRepository) Update(ctx context.Context, projectID uuid.UUID, update repositories.ProjectContextUpdate) (*entities.ProjectContext, []*entities.ProjectContextEntry, error) {
+	var updated *entities.ProjectContext
+	var updatedEntries []*entities.ProjectContextEntry
+
+	err := withTx(ctx, r.db, func(tx *sql.Tx) error {
+		projectContext, entries, err := loadProjectContext(ctx, tx, projectID, true)
+		if err != nil {
+			return err
+		}
+
+		change, err := update(projectContext, entries)
+		if err != nil {
+			return err
+		}
+
+		if len(change.Remove) > 0 {
+			ids := make([]string, len(change.Remove))
+			for i, id := range change.Remove {
+				ids[i] = id.String()
+			}
+			if _, err := tx.ExecContext(ctx,
+				`DELETE FROM project_context_entries WHERE project_id = $1 AND entry_id = ANY($2::uuid[])`,
+				projectID, pq.Array(ids)); err != nil {
+				return fmt.Errorf("failed to remove project context entries: %w", err)
+			}
+		}
+
+		for _, entry := range change.Add {
+			metadata, err := encodeJSON(entry.Metadata)
+			if err != nil {
+				return fmt.Errorf("failed to encode context entry metadata: %w", err)
+			}
+			if _, err := tx.ExecContext(ctx, `
+				INSERT INTO project_context_entries (`+projectContextEntryColumns+`)
+				VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+				entry.EntryID,
+				projectID,
+				entry.Role,
+				entry.Content,
+				entry.Priority,
+				entry.MustKeep,
+				metadata,
+				entry.TokenCount,
+				entry.CreatedAt,
+			); err != nil {
+				return fmt.Errorf("failed to add project context entry: %w", err)
+			}
+		}
+
+		knowledge, err := encodeJSON(projectContext.DomainKnowledge)
+		if err != nil {
+			return fmt.Errorf("failed to encode domain knowledge: %w", err)
+		}
+		compactions, err := encodeJSON(projectContext.Compactions)
+		if err != nil {
+			return fmt.Errorf("failed to encode context compactions: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx, `
+			UPDATE project_contexts SET
+				client_id = $2,
+				content_type = $3,
+				max_tokens = $4,
+				token_count = $5,
+				domain_knowledge = $6,
+				version = version + 1,
+				compaction_count = $7,
+				compactions = $8,
+				last_accessed = $9,
+				updated_at = NOW()
+			WHERE project_id = $1`,
+			projectID,
+			optionalUUID(projectContext.ClientID),
+			nullString(string(projectContext.ContentType)),
+			projectContext.MaxTokens,
+			projectContext.TokenCount,
+			knowledge,
+			projectContext.CompactionCount,
+			compactions,
+			projectContext.LastAccessed,
+		); err != nil {
+			return fmt.Errorf("failed to update project context: %w", err)
+		}
+
+		updated, updatedEntries, err = loadProjectContext(ctx, tx, projectID, false)
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return updated, updatedEntries, nil
+}
+
+func (r *PostgresProjectContextRepository) Touch(ctx context.Context, projectID uuid.UUID, at time.Time) error {
+	result, err := r.db.ExecContext(ctx,
+		`UPDATE project_contexts SET last_accessed = $2 WHERE project_id = $1`,
+		projectID, at)
+	if err != nil {
+		return fmt.Errorf("failed to touch project context: %w", err)
+	}
+	return expectAffected(result, "project context", projectID)
+}
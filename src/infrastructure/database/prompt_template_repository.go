@@ -0,0 +1,279 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Ceesaxp/autonomous-content-service/src/domain/entities"
+	"github.com/Ceesaxp/autonomous-content-service/src/domain/repositories"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// uniqueViolation is the PostgreSQL error code of a unique constraint violation
+const uniqueViolation = "23505"
+
+// PostgresPromptTemplateRepository implements the PromptTemplateRepository interface.
+// Adding a version locks the template row, so concurrent edits get consecutive versions.
+type PostgresPromptTemplateRepository struct {
+	db *sql.DB
+}
+
+// NewPromptTemplateRepository creates a new PostgreSQL prompt template repository
+func NewPromptTemplateRepository(db *sql.DB) repositories.PromptTemplateRepository {
+	return &PostgresPromptTemplateRepository{db: db}
+}
+
+// promptTemplateQuery selects templates joined with their current version
+const promptTemplateQuery = `
+	SELECT t.template_id, t.name, t.content_type, t.industry, t.client_id, t.current_version,
+		v.body, v.output_format, v.author, t.created_at, t.updated_at, t.deleted_at
+	FROM prompt_templates t
+	JOIN prompt_template_versions v ON v.template_id = t.template_id AND v.version = t.current_version`
+
+const promptTemplateVersionColumns = `template_id, version, body, output_format, author, created_at`
+
+func scanPromptTemplate(row rowScanner) (*entities.PromptTemplate, error) {
+	template := &entities.PromptTemplate{}
+	var contentType, outputFormat string
+	var clientID uuid.NullUUID
+	var deletedAt sql.NullTime
+
+	err := row.Scan(
+		&template.TemplateID,
+		&template.Name,
+		&contentType,
+		&template.Industry,
+		&clientID,
+		&template.Version,
+		&template.Body,
+		&outputFormat,
+		&template.Author,
+		&template.CreatedAt,
+		&template.UpdatedAt,
+		&deletedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	template.ContentType = entities.ContentType(contentType)
+	template.OutputFormat = entities.PromptOutputFormat(outputFormat)
+	if clientID.Valid {
+		template.ClientID = &clientID.UUID
+	}
+	if deletedAt.Valid {
+		template.DeletedAt = &deletedAt.Time
+	}
+	return template, nil
+}
+
+func scanPromptTemplateVersion(row rowScanner) (*entities.PromptTemplateVersion, error) {
+	version := &entities.PromptTemplateVersion{}
+	var outputFormat string
+	err := row.Scan(&version.TemplateID, &version.Version, &version.Body, &outputFormat, &version.Author, &version.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	version.OutputFormat = entities.PromptOutputFormat(outputFormat)
+	return version, nil
+}
+
+func (r *PostgresPromptTemplateRepository) Create(ctx context.Context, template *entities.PromptTemplate) error {
+	return withTx(ctx, r.db, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO prompt_templates (
+				template_id, name, content_type, industry, client_id, current_version, created_at, updated_at
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+			template.TemplateID,
+			template.Name,
+			string(template.ContentType),
+			template.Industry,
+			nullUUID(template.ClientID),
+			template.Version,
+			template.CreatedAt,
+			template.UpdatedAt,
+		)
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation {
+			return repositories.ErrPromptTemplateExists
+		}
+		if err != nil {
+			return fmt.Errorf("failed to create prompt template: %w", err)
+		}
+
+		return insertPromptTemplateVersion(ctx, tx, template.CurrentVersion())
+	})
+}
+
+func insertPromptTemplateVersion(ctx context.Context, tx *sql.Tx, version *entities.PromptTemplateVersion) error {
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO prompt_template_versions (`+promptTemplateVersionColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6)`,
+		version.TemplateID,
+		version.Version,
+		version.Body,
+		string(version.OutputFormat),
+		version.Author,
+		version.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to store prompt template version: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresPromptTemplateRepository) AddVersion(ctx context.Context, templateID uuid.UUID, body string, outputFormat entities.PromptOutputFormat, author string) (*entities.PromptTemplate, error) {
+	var updated *entities.PromptTemplate
+
+	err := withTx(ctx, r.db, func(tx *sql.Tx) error {
+		var current int
+		err := tx.QueryRowContext(ctx,
+			`SELECT current_version FROM prompt_templates WHERE template_id = $1 AND deleted_at IS NULL FOR UPDATE`,
+			templateID).Scan(&current)
+		if errors.Is(err, sql.ErrNoRows) {
+			return repositories.NewNotFoundError("prompt template", templateID)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to lock prompt template: %w", err)
+		}
+
+		version := &entities.PromptTemplateVersion{TemplateID: templateID, Version: current + 1, Body: body, OutputFormat: outputFormat, Author: author}
+		err = tx.QueryRowContext(ctx, `
+			UPDATE prompt_templates SET current_version = $2, updated_at = NOW()
+			WHERE template_id = $1
+			RETURNING updated_at`,
+			templateID, version.Version).Scan(&version.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to update prompt template: %w", err)
+		}
+		if err := insertPromptTemplateVersion(ctx, tx, version); err != nil {
+			return err
+		}
+
+		updated, err = scanPromptTemplate(tx.QueryRowContext(ctx, promptTemplateQuery+` WHERE t.template_id = $1`, templateID))
+		if err != nil {
+			return fmt.Errorf("failed to read prompt template: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+func (r *PostgresPromptTemplateRepository) FindByID(ctx context.Context, templateID uuid.UUID) (*entities.PromptTemplate, error) {
+	template, err := scanPromptTemplate(r.db.QueryRowContext(ctx, promptTemplateQuery+` WHERE t.template_id = $1`, templateID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repositories.NewNotFoundError("prompt template", templateID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get prompt template: %w", err)
+	}
+	return template, nil
+}
+
+func (r *PostgresPromptTemplateRepository) FindByScope(ctx context.Context, name string, contentType entities.ContentType, industry string, clientID *uuid.UUID) (*entities.PromptTemplate, error) {
+	template, err := scanPromptTemplate(r.db.QueryRowContext(ctx, promptTemplateQuery+`
+		WHERE t.name = $1 AND t.content_type = $2 AND t.industry = $3 AND t.client_id IS NOT DISTINCT FROM $4
+			AND t.deleted_at IS NULL`,
+		name, string(contentType), entities.NormalizeIndustry(industry), nullUUID(clientID)))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repositories.NewNotFoundError("prompt template", name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get prompt template: %w", err)
+	}
+	return template, nil
+}
+
+func (r *PostgresPromptTemplateRepository) FindVersion(ctx context.Context, templateID uuid.UUID, version int) (*entities.PromptTemplateVersion, error) {
+	stored, err := scanPromptTemplateVersion(r.db.QueryRowContext(ctx,
+		`SELECT `+promptTemplateVersionColumns+` FROM prompt_template_versions WHERE template_id = $1 AND version = $2`,
+		templateID, version))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repositories.NewNotFoundError("prompt template version", version)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get prompt template version: %w", err)
+	}
+	return stored, nil
+}
+
+func (r *PostgresPromptTemplateRepository) ListVersions(ctx context.Context, templateID uuid.UUID) ([]*entities.PromptTemplateVersion, error) {
+	if _, err := r.FindByID(ctx, templateID); err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT `+promptTemplateVersionColumns+` FROM prompt_template_versions WHERE template_id = $1 ORDER BY version ASC`,
+		templateID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query prompt template versions: %w", err)
+	}
+	defer rows.Close()
+
+	versions := []*entities.PromptTemplateVersion{}
+	for rows.Next() {
+		version, err := scanPromptTemplateVersion(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan prompt template version: %w", err)
+		}
+		versions = append(versions, version)
+	}
+	return versions, rows.Err()
+}
+
+func (r *PostgresPromptTemplateRepository) List(ctx context.Context, filter repositories.PromptTemplateFilter) ([]*entities.PromptTemplate, error) {
+	conditions := []string{"t.deleted_at IS NULL"}
+	var args []interface{}
+	addCondition := func(condition string, value interface{}) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+	if filter.Name != "" {
+		addCondition("t.name = $%d", filter.Name)
+	}
+	if filter.ContentType != "" {
+		addCondition("t.content_type = $%d", string(filter.ContentType))
+	}
+	if industry := entities.NormalizeIndustry(filter.Industry); industry != "" {
+		addCondition("t.industry = $%d", industry)
+	}
+	if filter.ClientID != nil {
+		addCondition("t.client_id = $%d", *filter.ClientID)
+	}
+
+	query := promptTemplateQuery + " WHERE " + strings.Join(conditions, " AND ") + " ORDER BY t.created_at ASC"
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query prompt templates: %w", err)
+	}
+	defer rows.Close()
+
+	templates := []*entities.PromptTemplate{}
+	for rows.Next() {
+		template, err := scanPromptTemplate(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan prompt template: %w", err)
+		}
+		templates = append(templates, template)
+	}
+	return templates, rows.Err()
+}
+
+func (r *PostgresPromptTemplateRepository) Delete(ctx context.Context, templateID uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE prompt_templates SET deleted_at = NOW(), updated_at = NOW()
+		WHERE template_id = $1 AND deleted_at IS NULL`,
+		templateID)
+	if err != nil {
+		return fmt.Errorf("failed to delete prompt template: %w", err)
+	}
+	return expectAffected(result, "prompt template", templateID)
+}
@@ -0,0 +1,69 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/Ceesaxp/autonomous-content-service/src/domain/entities"
+	"github.com/Ceesaxp/autonomous-content-service/src/domain/repositories"
+	"github.com/google/uuid"
+)
+
+// PostgresQualityAssessmentRepository implements the QualityAssessmentRepository interface
+type PostgresQualityAssessmentRepository struct {
+	db *sql.DB
+}
+
+// NewQualityAssessmentRepository creates a new PostgreSQL quality assessment repository
+func NewQualityAssessmentRepository(db *sql.DB) repositories.QualityAssessmentRepository {
+	return &PostgresQualityAssessmentRepository{db: db}
+}
+
+func (r *PostgresQualityAssessmentRepository) Save(ctx context.Context, assessment *entities.QualityAssessment) error {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO quality_assessments (
+			assessment_id, content_id, content_version, overall_score, grade, passed_threshold, report, assessed_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+		assessment.AssessmentID,
+		assessment.ContentID,
+		assessment.ContentVersion,
+		assessment.OverallScore,
+		assessment.Grade,
+		assessment.PassedThreshold,
+		[]byte(assessment.Report),
+		assessment.AssessedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to store quality assessment: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresQualityAssessmentRepository) FindLatestByContentID(ctx context.Context, contentID uuid.UUID) (*entities.QualityAssessment, error) {
+	assessment := &entities.QualityAssessment{}
+	var report []byte
+	err := r.db.QueryRowContext(ctx, `
+		SELECT assessment_id, content_id, content_version, overall_score, grade, passed_threshold, report, assessed_at
+		FROM quality_assessments
+		WHERE content_id = $1
+		ORDER BY assessed_at DESC
+		LIMIT 1`, contentID).Scan(
+		&assessment.AssessmentID,
+		&assessment.ContentID,
+		&assessment.ContentVersion,
+		&assessment.OverallScore,
+		&assessment.Grade,
+		&assessment.PassedThreshold,
+		&report,
+		&assessment.AssessedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, repositories.NewNotFoundError("quality assessment", contentID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get quality assessment: %w", err)
+	}
+	assessment.Report = report
+	return assessment, nil
+}
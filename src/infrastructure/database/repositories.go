@@ -3,12 +3,16 @@ package database
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/Ceesaxp/autonomous-content-service/src/domain/entities"
 	"github.com/Ceesaxp/autonomous-content-service/src/domain/events"
 	"github.com/Ceesaxp/autonomous-content-service/src/domain/repositories"
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
 // PostgresClientRepository implements the ClientRepository interface
@@ -21,44 +25,188 @@ func NewClientRepository(db *sql.DB) repositories.ClientRepository {
 	return &PostgresClientRepository{db: db}
 }
 
+const clientColumns = `client_id, name, contact_email, contact_phone, timezone, status, created_at, updated_at,
+	billing_street, billing_city, billing_state, billing_postal_code, billing_country`
+
+func scanClient(row rowScanner) (*entities.Client, error) {
+	client := &entities.Client{}
+	var state, postalCode sql.NullString
+
+	err := row.Scan(
+		&client.ClientID,
+		&client.Name,
+		&client.ContactEmail,
+		&client.ContactPhone,
+		&client.Timezone,
+		&client.Status,
+		&client.CreatedAt,
+		&client.UpdatedAt,
+		&client.BillingAddress.Street,
+		&client.BillingAddress.City,
+		&state,
+		&postalCode,
+		&client.BillingAddress.Country,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	client.BillingAddress.State = state.String
+	client.BillingAddress.PostalCode = postalCode.String
+	return client, nil
+}
+
+func (r *PostgresClientRepository) queryClients(ctx context.Context, query string, args ...interface{}) ([]*entities.Client, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query clients: %w", err)
+	}
+	defer rows.Close()
+
+	clients := []*entities.Client{}
+	for rows.Next() {
+		client, err := scanClient(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan client: %w", err)
+		}
+		clients = append(clients, client)
+	}
+
+	return clients, rows.Err()
+}
+
 func (r *PostgresClientRepository) FindByStatus(ctx context.Context, status entities.ClientStatus, offset, limit int) ([]*entities.Client, int, error) {
-	// Placeholder implementation
-	return nil, 0, nil
+	total, err := countRows(ctx, r.db, "clients", "status = $1", status)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	clients, err := r.queryClients(ctx,
+		`SELECT `+clientColumns+` FROM clients WHERE status = $1 ORDER BY created_at DESC OFFSET $2 LIMIT $3`,
+		status, offset, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return clients, total, nil
 }
 
 func (r *PostgresClientRepository) FindByID(ctx context.Context, id uuid.UUID) (*entities.Client, error) {
-	// Placeholder implementation
-	return nil, nil
+	row := r.db.QueryRowContext(ctx, `SELECT `+clientColumns+` FROM clients WHERE client_id = $1`, id)
+
+	client, err := scanClient(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repositories.NewNotFoundError("client", id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find client: %w", err)
+	}
+
+	return client, nil
 }
 
 func (r *PostgresClientRepository) FindByEmail(ctx context.Context, email string) (*entities.Client, error) {
-	// Placeholder implementation
-	return nil, nil
+	row := r.db.QueryRowContext(ctx, `SELECT `+clientColumns+` FROM clients WHERE contact_email = $1`, email)
+
+	client, err := scanClient(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repositories.NewNotFoundError("client", email)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find client by email: %w", err)
+	}
+
+	return client, nil
 }
 
 func (r *PostgresClientRepository) FindAll(ctx context.Context, offset, limit int) ([]*entities.Client, int, error) {
-	// Placeholder implementation
-	return nil, 0, nil
+	total, err := countRows(ctx, r.db, "clients", "TRUE")
+	if err != nil {
+		return nil, 0, err
+	}
+
+	clients, err := r.queryClients(ctx,
+		`SELECT `+clientColumns+` FROM clients ORDER BY created_at DESC OFFSET $1 LIMIT $2`,
+		offset, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return clients, total, nil
 }
 
 func (r *PostgresClientRepository) Save(ctx context.Context, client *entities.Client) error {
-	// Placeholder implementation
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO clients (`+clientColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		ON CONFLICT (client_id) DO UPDATE SET
+			name = EXCLUDED.name,
+			contact_email = EXCLUDED.contact_email,
+			contact_phone = EXCLUDED.contact_phone,
+			timezone = EXCLUDED.timezone,
+			status = EXCLUDED.status,
+			updated_at = EXCLUDED.updated_at,
+			billing_street = EXCLUDED.billing_street,
+			billing_city = EXCLUDED.billing_city,
+			billing_state = EXCLUDED.billing_state,
+			billing_postal_code = EXCLUDED.billing_postal_code,
+			billing_country = EXCLUDED.billing_country`,
+		clientValues(client)...)
+	if err != nil {
+		return fmt.Errorf("failed to save client: %w", err)
+	}
 	return nil
 }
 
 func (r *PostgresClientRepository) Create(ctx context.Context, client *entities.Client) error {
-	// Placeholder implementation
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO clients (`+clientColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)`,
+		clientValues(client)...)
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
 	return nil
 }
 
 func (r *PostgresClientRepository) Update(ctx context.Context, client *entities.Client) error {
-	// Placeholder implementation
-	return nil
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE clients SET
+			name = $2, contact_email = $3, contact_phone = $4, timezone = $5, status = $6,
+			created_at = $7, updated_at = $8, billing_street = $9, billing_city = $10,
+			billing_state = $11, billing_postal_code = $12, billing_country = $13
+		WHERE client_id = $1`,
+		clientValues(client)...)
+	if err != nil {
+		return fmt.Errorf("failed to update client: %w", err)
+	}
+	return expectAffected(result, "client", client.ClientID)
 }
 
 func (r *PostgresClientRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	// Placeholder implementation
-	return nil
+	result, err := r.db.ExecContext(ctx, `DELETE FROM clients WHERE client_id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete client: %w", err)
+	}
+	return expectAffected(result, "client", id)
+}
+
+func clientValues(client *entities.Client) []interface{} {
+	return []interface{}{
+		client.ClientID,
+		client.Name,
+		client.ContactEmail,
+		client.ContactPhone,
+		client.Timezone,
+		client.Status,
+		client.CreatedAt,
+		client.UpdatedAt,
+		client.BillingAddress.Street,
+		client.BillingAddress.City,
+		nullString(client.BillingAddress.State),
+		nullString(client.BillingAddress.PostalCode),
+		client.BillingAddress.Country,
+	}
 }
 
 // PostgresClientProfileRepository implements the ClientProfileRepository interface
@@ -111,54 +259,185 @@ func NewProjectRepository(db *sql.DB) repositories.ProjectRepository {
 	return &PostgresProjectRepository{db: db}
 }
 
+const projectColumns = `project_id, client_id, title, description, content_type, deadline, budget_amount, budget_currency,
+	priority, status, requirements, metadata, created_at, updated_at`
+
+func scanProject(row rowScanner) (*entities.Project, error) {
+	project := &entities.Project{}
+	var requirements, metadata []byte
+
+	err := row.Scan(
+		&project.ProjectID,
+		&project.ClientID,
+		&project.Title,
+		&project.Description,
+		&project.ContentType,
+		&project.Deadline,
+		&project.Budget.Amount,
+		&project.Budget.Currency,
+		&project.Priority,
+		&project.Status,
+		&requirements,
+		&metadata,
+		&project.CreatedAt,
+		&project.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	project.Requirements = []string{}
+	if err := decodeJSON(requirements, &project.Requirements); err != nil {
+		return nil, fmt.Errorf("failed to decode project requirements: %w", err)
+	}
+	if project.Metadata, err = decodeMetadata(metadata); err != nil {
+		return nil, fmt.Errorf("failed to decode project metadata: %w", err)
+	}
+	project.Contents = []*entities.Content{}
+
+	return project, nil
+}
+
+func (r *PostgresProjectRepository) queryProjects(ctx context.Context, query string, args ...interface{}) ([]*entities.Project, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query projects: %w", err)
+	}
+	defer rows.Close()
+
+	projects := []*entities.Project{}
+	for rows.Next() {
+		project, err := scanProject(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan project: %w", err)
+		}
+		projects = append(projects, project)
+	}
+
+	return projects, rows.Err()
+}
+
+func (r *PostgresProjectRepository) findPage(ctx context.Context, where, orderBy string, offset, limit int, args ...interface{}) ([]*entities.Project, int, error) {
+	total, err := countRows(ctx, r.db, "projects", where, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	query := fmt.Sprintf(`SELECT %s FROM projects WHERE %s ORDER BY %s OFFSET $%d LIMIT $%d`,
+		projectColumns, where, orderBy, len(args)+1, len(args)+2)
+	projects, err := r.queryProjects(ctx, query, append(args, offset, limit)...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return projects, total, nil
+}
+
 func (r *PostgresProjectRepository) FindByID(ctx context.Context, id uuid.UUID) (*entities.Project, error) {
-	// Placeholder implementation
-	return nil, nil
+	row := r.db.QueryRowContext(ctx, `SELECT `+projectColumns+` FROM projects WHERE project_id = $1`, id)
+
+	project, err := scanProject(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repositories.NewNotFoundError("project", id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find project: %w", err)
+	}
+
+	return project, nil
 }
 
 func (r *PostgresProjectRepository) FindByStatus(ctx context.Context, status entities.ProjectStatus, offset, limit int) ([]*entities.Project, int, error) {
-	// Placeholder implementation
-	return nil, 0, nil
+	return r.findPage(ctx, "status = $1", "created_at DESC", offset, limit, status)
 }
 
 func (r *PostgresProjectRepository) FindActive(ctx context.Context, offset, limit int) ([]*entities.Project, int, error) {
-	// Placeholder implementation
-	return nil, 0, nil
+	return r.findPage(ctx, "status NOT IN ($1, $2)", "deadline ASC", offset, limit,
+		entities.ProjectStatusCompleted, entities.ProjectStatusCancelled)
 }
 
 func (r *PostgresProjectRepository) FindAll(ctx context.Context, offset, limit int) ([]*entities.Project, int, error) {
-	// Placeholder implementation
-	return nil, 0, nil
+	return r.findPage(ctx, "TRUE", "created_at DESC", offset, limit)
 }
 
 func (r *PostgresProjectRepository) FindByClientID(ctx context.Context, clientID uuid.UUID, offset, limit int) ([]*entities.Project, int, error) {
-	// Placeholder implementation
-	return nil, 0, nil
+	return r.findPage(ctx, "client_id = $1", "created_at DESC", offset, limit, clientID)
 }
 
 func (r *PostgresProjectRepository) FindByDeadlineRange(ctx context.Context, start, end time.Time, offset, limit int) ([]*entities.Project, int, error) {
-	// Placeholder implementation
-	return nil, 0, nil
+	return r.findPage(ctx, "deadline BETWEEN $1 AND $2", "deadline ASC", offset, limit, start, end)
 }
 
 func (r *PostgresProjectRepository) Save(ctx context.Context, project *entities.Project) error {
-	// Placeholder implementation
+	values, err := projectValues(project)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.ExecContext(ctx, `
+		INSERT INTO projects (`+projectColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+		ON CONFLICT (project_id) DO UPDATE SET
+			client_id = EXCLUDED.client_id,
+			title = EXCLUDED.title,
+			description = EXCLUDED.description,
+			content_type = EXCLUDED.content_type,
+			deadline = EXCLUDED.deadline,
+			budget_amount = EXCLUDED.budget_amount,
+			budget_currency = EXCLUDED.budget_currency,
+			priority = EXCLUDED.priority,
+			status = EXCLUDED.status,
+			requirements = EXCLUDED.requirements,
+			metadata = EXCLUDED.metadata,
+			updated_at = EXCLUDED.updated_at`,
+		values...)
+	if err != nil {
+		return fmt.Errorf("failed to save project: %w", err)
+	}
 	return nil
 }
 
 func (r *PostgresProjectRepository) Create(ctx context.Context, project *entities.Project) error {
-	// Placeholder implementation
+	values, err := projectValues(project)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.ExecContext(ctx, `
+		INSERT INTO projects (`+projectColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)`,
+		values...)
+	if err != nil {
+		return fmt.Errorf("failed to create project: %w", err)
+	}
 	return nil
 }
 
 func (r *PostgresProjectRepository) Update(ctx context.Context, project *entities.Project) error {
-	// Placeholder implementation
-	return nil
+	values, err := projectValues(project)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE projects SET
+			client_id = $2, title = $3, description = $4, content_type = $5, deadline = $6,
+			budget_amount = $7, budget_currency = $8, priority = $9, status = $10,
+			requirements = $11, metadata = $12, created_at = $13, updated_at = $14
+		WHERE project_id = $1`,
+		values...)
+	if err != nil {
+		return fmt.Errorf("failed to update project: %w", err)
+	}
+	return expectAffected(result, "project", project.ProjectID)
 }
 
 func (r *PostgresProjectRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	// Placeholder implementation
-	return nil
+	result, err := r.db.ExecContext(ctx, `DELETE FROM projects WHERE project_id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete project: %w", err)
+	}
+	return expectAffected(result, "project", id)
 }
 
 func (r *PostgresProjectRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.Project, error) {
@@ -172,6 +451,34 @@ func (r *PostgresProjectRepository) GetByClientID(ctx context.Context, clientID
 	return projects, err
 }
 
+func projectValues(project *entities.Project) ([]interface{}, error) {
+	requirements, err := encodeJSON(project.Requirements)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode project requirements: %w", err)
+	}
+	metadata, err := encodeJSON(project.Metadata)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode project metadata: %w", err)
+	}
+
+	return []interface{}{
+		project.ProjectID,
+		project.ClientID,
+		project.Title,
+		project.Description,
+		project.ContentType,
+		project.Deadline,
+		project.Budget.Amount,
+		project.Budget.Currency,
+		project.Priority,
+		project.Status,
+		requirements,
+		metadata,
+		project.CreatedAt,
+		project.UpdatedAt,
+	}, nil
+}
+
 // PostgresContentRepository implements the ContentRepository interface
 type PostgresContentRepository struct {
 	db *sql.DB
@@ -182,43 +489,259 @@ func NewContentRepository(db *sql.DB) repositories.ContentRepository {
 	return &PostgresContentRepository{db: db}
 }
 
+const contentColumns = `content_id, project_id, title, type, status, data, metadata, version, word_count, created_at, updated_at,
+	readability_score, seo_score, engagement_score, plagiarism_score`
+
+func scanContent(row rowScanner) (*entities.Content, error) {
+	content := &entities.Content{}
+	var data sql.NullString
+	var metadata []byte
+	var readability, seo, engagement, plagiarism sql.NullFloat64
+
+	err := row.Scan(
+		&content.ContentID,
+		&content.ProjectID,
+		&content.Title,
+		&content.Type,
+		&content.Status,
+		&data,
+		&metadata,
+		&content.Version,
+		&content.WordCount,
+		&content.CreatedAt,
+		&content.UpdatedAt,
+		&readability,
+		&seo,
+		&engagement,
+		&plagiarism,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	content.Data = data.String
+	if content.Metadata, err = decodeMetadata(metadata); err != nil {
+		return nil, fmt.Errorf("failed to decode content metadata: %w", err)
+	}
+	content.Versions = []*entities.ContentVersion{}
+	content.Statistics = &entities.ContentStatistics{
+		ReadabilityScore: readability.Float64,
+		SEOScore:         seo.Float64,
+		EngagementScore:  engagement.Float64,
+		PlagiarismScore:  plagiarism.Float64,
+	}
+
+	return content, nil
+}
+
+func (r *PostgresContentRepository) queryContents(ctx context.Context, query string, args ...interface{}) ([]*entities.Content, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query content: %w", err)
+	}
+	defer rows.Close()
+
+	contents := []*entities.Content{}
+	for rows.Next() {
+		content, err := scanContent(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan content: %w", err)
+		}
+		contents = append(contents, content)
+	}
+
+	return contents, rows.Err()
+}
+
 func (r *PostgresContentRepository) FindByID(ctx context.Context, id uuid.UUID) (*entities.Content, error) {
-	// Placeholder implementation
-	return nil, nil
+	row := r.db.QueryRowContext(ctx, `SELECT `+contentColumns+` FROM content WHERE content_id = $1`, id)
+
+	content, err := scanContent(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repositories.NewNotFoundError("content", id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find content: %w", err)
+	}
+
+	return content, nil
 }
 
 func (r *PostgresContentRepository) FindByProjectID(ctx context.Context, projectID uuid.UUID) ([]*entities.Content, error) {
-	// Placeholder implementation
-	return nil, nil
+	return r.queryContents(ctx,
+		`SELECT `+contentColumns+` FROM content WHERE project_id = $1 ORDER BY created_at ASC`,
+		projectID)
 }
 
 func (r *PostgresContentRepository) FindByStatus(ctx context.Context, status entities.ContentStatus, offset, limit int) ([]*entities.Content, int, error) {
-	// Placeholder implementation
-	return nil, 0, nil
+	total, err := countRows(ctx, r.db, "content", "status = $1", status)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	contents, err := r.queryContents(ctx,
+		`SELECT `+contentColumns+` FROM content WHERE status = $1 ORDER BY updated_at DESC OFFSET $2 LIMIT $3`,
+		status, offset, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return contents, total, nil
 }
 
 func (r *PostgresContentRepository) FindByType(ctx context.Context, contentType entities.ContentType, offset, limit int) ([]*entities.Content, int, error) {
-	// Placeholder implementation
-	return nil, 0, nil
+	total, err := countRows(ctx, r.db, "content", "type = $1", contentType)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	contents, err := r.queryContents(ctx,
+		`SELECT `+contentColumns+` FROM content WHERE type = $1 ORDER BY updated_at DESC OFFSET $2 LIMIT $3`,
+		contentType, offset, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return contents, total, nil
 }
 
 func (r *PostgresContentRepository) Save(ctx context.Context, content *entities.Content) error {
-	// Placeholder implementation
-	return nil
+	values, err := contentValues(content)
+	if err != nil {
+		return err
+	}
+
+	return withTx(ctx, r.db, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO content (`+contentColumns+`)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+			ON CONFLICT (content_id) DO UPDATE SET
+				project_id = EXCLUDED.project_id,
+				title = EXCLUDED.title,
+				type = EXCLUDED.type,
+				status = EXCLUDED.status,
+				data = EXCLUDED.data,
+				metadata = EXCLUDED.metadata,
+				version = EXCLUDED.version,
+				word_count = EXCLUDED.word_count,
+				updated_at = EXCLUDED.updated_at,
+				readability_score = EXCLUDED.readability_score,
+				seo_score = EXCLUDED.seo_score,
+				engagement_score = EXCLUDED.engagement_score,
+				plagiarism_score = EXCLUDED.plagiarism_score`,
+			values...)
+		if err != nil {
+			return fmt.Errorf("failed to save content: %w", err)
+		}
+		return insertContentVersions(ctx, tx, content.Versions)
+	})
 }
 
 func (r *PostgresContentRepository) Create(ctx context.Context, content *entities.Content) error {
-	// Placeholder implementation
-	return nil
-}
-
+	values, err := contentValues(content)
+	if err != nil {
+		return err
+	}
+
+	return withTx(ctx, r.db, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO content (`+contentColumns+`)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)`,
+			values...)
+		if err != nil {
+			return fmt.Errorf("failed to create content: %w", err)
+		}
+		return insertContentVersions(ctx, tx, content.Versions)
+	})
+}
+
+// Update persists the content and any versions accumulated through Content.UpdateContent
 func (r *PostgresContentRepository) Update(ctx context.Context, content *entities.Content) error {
-	// Placeholder implementation
-	return nil
+	values, err := contentValues(content)
+	if err != nil {
+		return err
+	}
+
+	return withTx(ctx, r.db, func(tx *sql.Tx) error {
+		result, err := tx.ExecContext(ctx, `
+			UPDATE content SET
+				project_id = $2, title = $3, type = $4, status = $5, data = $6, metadata = $7,
+				version = $8, word_count = $9, created_at = $10, updated_at = $11,
+				readability_score = $12, seo_score = $13, engagement_score = $14, plagiarism_score = $15
+			WHERE content_id = $1`,
+			values...)
+		if err != nil {
+			return fmt.Errorf("failed to update content: %w", err)
+		}
+		if err := expectAffected(result, "content", content.ContentID); err != nil {
+			return err
+		}
+		return insertContentVersions(ctx, tx, content.Versions)
+	})
 }
 
 func (r *PostgresContentRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	// Placeholder implementation
+	result, err := r.db.ExecContext(ctx, `DELETE FROM content WHERE content_id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete content: %w", err)
+	}
+	return expectAffected(result, "content", id)
+}
+
+func contentValues(content *entities.Content) ([]interface{}, error) {
+	metadata, err := encodeJSON(content.Metadata)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode content metadata: %w", err)
+	}
+
+	stats := content.Statistics
+	if stats == nil {
+		stats = &entities.ContentStatistics{}
+	}
+
+	return []interface{}{
+		content.ContentID,
+		content.ProjectID,
+		content.Title,
+		content.Type,
+		content.Status,
+		content.Data,
+		metadata,
+		content.Version,
+		content.WordCount,
+		content.CreatedAt,
+		content.UpdatedAt,
+		stats.ReadabilityScore,
+		stats.SEOScore,
+		stats.EngagementScore,
+		stats.PlagiarismScore,
+	}, nil
+}
+
+// insertContentVersions stores content versions, skipping those that were persisted earlier
+func insertContentVersions(ctx context.Context, tx *sql.Tx, versions []*entities.ContentVersion) error {
+	for _, version := range versions {
+		metadata, err := encodeJSON(version.Metadata)
+		if err != nil {
+			return fmt.Errorf("failed to encode content version metadata: %w", err)
+		}
+
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO content_versions (`+contentVersionColumns+`)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)
+			ON CONFLICT (content_id, version_number) DO NOTHING`,
+			version.VersionID,
+			version.ContentID,
+			version.VersionNumber,
+			version.Data,
+			metadata,
+			version.CreatedAt,
+			version.CreatedBy,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to store content version %d: %w", version.VersionNumber, err)
+		}
+	}
 	return nil
 }
 
@@ -232,28 +755,116 @@ func NewContentVersionRepository(db *sql.DB) repositories.ContentVersionReposito
 	return &PostgresContentVersionRepository{db: db}
 }
 
+const contentVersionColumns = `version_id, content_id, version_number, data, metadata, created_at, created_by`
+
+func scanContentVersion(row rowScanner) (*entities.ContentVersion, error) {
+	version := &entities.ContentVersion{}
+	var metadata []byte
+
+	err := row.Scan(
+		&version.VersionID,
+		&version.ContentID,
+		&version.VersionNumber,
+		&version.Data,
+		&metadata,
+		&version.CreatedAt,
+		&version.CreatedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if version.Metadata, err = decodeMetadata(metadata); err != nil {
+		return nil, fmt.Errorf("failed to decode content version metadata: %w", err)
+	}
+
+	return version, nil
+}
+
 func (r *PostgresContentVersionRepository) FindByID(ctx context.Context, id uuid.UUID) (*entities.ContentVersion, error) {
-	// Placeholder implementation
-	return nil, nil
+	row := r.db.QueryRowContext(ctx, `SELECT `+contentVersionColumns+` FROM content_versions WHERE version_id = $1`, id)
+
+	version, err := scanContentVersion(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repositories.NewNotFoundError("content version", id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find content version: %w", err)
+	}
+
+	return version, nil
 }
 
 func (r *PostgresContentVersionRepository) FindByContentID(ctx context.Context, contentID uuid.UUID) ([]*entities.ContentVersion, error) {
-	// Placeholder implementation
-	return nil, nil
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT `+contentVersionColumns+` FROM content_versions WHERE content_id = $1 ORDER BY version_number ASC`,
+		contentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query content versions: %w", err)
+	}
+	defer rows.Close()
+
+	versions := []*entities.ContentVersion{}
+	for rows.Next() {
+		version, err := scanContentVersion(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan content version: %w", err)
+		}
+		versions = append(versions, version)
+	}
+
+	return versions, rows.Err()
 }
 
 func (r *PostgresContentVersionRepository) FindByContentIDAndVersion(ctx context.Context, contentID uuid.UUID, version int) (*entities.ContentVersion, error) {
-	// Placeholder implementation
-	return nil, nil
+	row := r.db.QueryRowContext(ctx,
+		`SELECT `+contentVersionColumns+` FROM content_versions WHERE content_id = $1 AND version_number = $2`,
+		contentID, version)
+
+	contentVersion, err := scanContentVersion(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repositories.NewNotFoundError("content version", fmt.Sprintf("%s@%d", contentID, version))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find content version: %w", err)
+	}
+
+	return contentVersion, nil
 }
 
 func (r *PostgresContentVersionRepository) Save(ctx context.Context, version *entities.ContentVersion) error {
-	// Placeholder implementation
+	metadata, err := encodeJSON(version.Metadata)
+	if err != nil {
+		return fmt.Errorf("failed to encode content version metadata: %w", err)
+	}
+
+	_, err = r.db.ExecContext(ctx, `
+		INSERT INTO content_versions (`+contentVersionColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (version_id) DO UPDATE SET
+			data = EXCLUDED.data,
+			metadata = EXCLUDED.metadata,
+			created_by = EXCLUDED.created_by`,
+		version.VersionID, version.ContentID, version.VersionNumber, version.Data, metadata, version.CreatedAt, version.CreatedBy)
+	if err != nil {
+		return fmt.Errorf("failed to save content version: %w", err)
+	}
 	return nil
 }
 
 func (r *PostgresContentVersionRepository) Create(ctx context.Context, version *entities.ContentVersion) error {
-	// Placeholder implementation
+	metadata, err := encodeJSON(version.Metadata)
+	if err != nil {
+		return fmt.Errorf("failed to encode content version metadata: %w", err)
+	}
+
+	_, err = r.db.ExecContext(ctx, `
+		INSERT INTO content_versions (`+contentVersionColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		version.VersionID, version.ContentID, version.VersionNumber, version.Data, metadata, version.CreatedAt, version.CreatedBy)
+	if err != nil {
+		return fmt.Errorf("failed to create content version: %w", err)
+	}
 	return nil
 }
 
@@ -267,59 +878,258 @@ func NewFeedbackRepository(db *sql.DB) repositories.FeedbackRepository {
 	return &PostgresFeedbackRepository{db: db}
 }
 
+const feedbackColumns = `feedback_id, content_id, project_id, client_id, source, type, status, title, message,
+	rating_score, rating_max_score, tags, is_resolved, resolved_at, resolved_by, metadata, created_at, updated_at`
+
+func scanFeedback(row rowScanner) (*entities.Feedback, error) {
+	feedback := &entities.Feedback{}
+	var contentID, projectID, resolvedBy uuid.NullUUID
+	var ratingScore, ratingMaxScore sql.NullFloat64
+	var resolvedAt sql.NullTime
+	var tags pq.StringArray
+	var metadata []byte
+
+	err := row.Scan(
+		&feedback.FeedbackID,
+		&contentID,
+		&projectID,
+		&feedback.ClientID,
+		&feedback.Source,
+		&feedback.Type,
+		&feedback.Status,
+		&feedback.Title,
+		&feedback.Message,
+		&ratingScore,
+		&ratingMaxScore,
+		&tags,
+		&feedback.IsResolved,
+		&resolvedAt,
+		&resolvedBy,
+		&metadata,
+		&feedback.CreatedAt,
+		&feedback.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if contentID.Valid {
+		feedback.ContentID = &contentID.UUID
+	}
+	if projectID.Valid {
+		feedback.ProjectID = &projectID.UUID
+	}
+	if resolvedBy.Valid {
+		feedback.ResolvedBy = &resolvedBy.UUID
+	}
+	if resolvedAt.Valid {
+		feedback.ResolvedAt = &resolvedAt.Time
+	}
+	if ratingScore.Valid {
+		feedback.Rating = &entities.FeedbackRating{
+			Score:    ratingScore.Float64,
+			MaxScore: ratingMaxScore.Float64,
+		}
+	}
+	feedback.Tags = []string(tags)
+	if feedback.Tags == nil {
+		feedback.Tags = []string{}
+	}
+	if feedback.Metadata, err = decodeMetadata(metadata); err != nil {
+		return nil, fmt.Errorf("failed to decode feedback metadata: %w", err)
+	}
+
+	return feedback, nil
+}
+
+func (r *PostgresFeedbackRepository) queryFeedback(ctx context.Context, query string, args ...interface{}) ([]*entities.Feedback, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query feedback: %w", err)
+	}
+	defer rows.Close()
+
+	feedbackList := []*entities.Feedback{}
+	for rows.Next() {
+		feedback, err := scanFeedback(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan feedback: %w", err)
+		}
+		feedbackList = append(feedbackList, feedback)
+	}
+
+	return feedbackList, rows.Err()
+}
+
+func (r *PostgresFeedbackRepository) findPage(ctx context.Context, where string, offset, limit int, args ...interface{}) ([]*entities.Feedback, int, error) {
+	total, err := countRows(ctx, r.db, "feedback", where, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	query := fmt.Sprintf(`SELECT %s FROM feedback WHERE %s ORDER BY created_at DESC OFFSET $%d LIMIT $%d`,
+		feedbackColumns, where, len(args)+1, len(args)+2)
+	feedbackList, err := r.queryFeedback(ctx, query, append(args, offset, limit)...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return feedbackList, total, nil
+}
+
 func (r *PostgresFeedbackRepository) FindByID(ctx context.Context, id uuid.UUID) (*entities.Feedback, error) {
-	// Placeholder implementation
-	return nil, nil
+	row := r.db.QueryRowContext(ctx, `SELECT `+feedbackColumns+` FROM feedback WHERE feedback_id = $1`, id)
+
+	feedback, err := scanFeedback(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repositories.NewNotFoundError("feedback", id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find feedback: %w", err)
+	}
+
+	return feedback, nil
 }
 
 func (r *PostgresFeedbackRepository) FindByContentID(ctx context.Context, contentID uuid.UUID) ([]*entities.Feedback, error) {
-	// Placeholder implementation
-	return nil, nil
+	return r.queryFeedback(ctx,
+		`SELECT `+feedbackColumns+` FROM feedback WHERE content_id = $1 ORDER BY created_at DESC`,
+		contentID)
 }
 
 func (r *PostgresFeedbackRepository) FindByProjectID(ctx context.Context, projectID uuid.UUID) ([]*entities.Feedback, error) {
-	// Placeholder implementation
-	return nil, nil
+	return r.queryFeedback(ctx,
+		`SELECT `+feedbackColumns+` FROM feedback WHERE project_id = $1 ORDER BY created_at DESC`,
+		projectID)
 }
 
 func (r *PostgresFeedbackRepository) FindByClientID(ctx context.Context, clientID uuid.UUID) ([]*entities.Feedback, error) {
-	// Placeholder implementation
-	return nil, nil
+	return r.queryFeedback(ctx,
+		`SELECT `+feedbackColumns+` FROM feedback WHERE client_id = $1 ORDER BY created_at DESC`,
+		clientID)
 }
 
 func (r *PostgresFeedbackRepository) FindByType(ctx context.Context, feedbackType entities.FeedbackType, offset, limit int) ([]*entities.Feedback, int, error) {
-	// Placeholder implementation
-	return nil, 0, nil
+	return r.findPage(ctx, "type = $1", offset, limit, feedbackType)
 }
 
 func (r *PostgresFeedbackRepository) FindBySource(ctx context.Context, source entities.FeedbackSource, offset, limit int) ([]*entities.Feedback, int, error) {
-	// Placeholder implementation
-	return nil, 0, nil
+	return r.findPage(ctx, "source = $1", offset, limit, source)
 }
 
 func (r *PostgresFeedbackRepository) FindByStatus(ctx context.Context, status entities.FeedbackStatus, offset, limit int) ([]*entities.Feedback, int, error) {
-	// Placeholder implementation
-	return nil, 0, nil
+	return r.findPage(ctx, "status = $1", offset, limit, status)
 }
 
 func (r *PostgresFeedbackRepository) Save(ctx context.Context, feedback *entities.Feedback) error {
-	// Placeholder implementation
+	values, err := feedbackValues(feedback)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.ExecContext(ctx, `
+		INSERT INTO feedback (`+feedbackColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)
+		ON CONFLICT (feedback_id) DO UPDATE SET
+			content_id = EXCLUDED.content_id,
+			project_id = EXCLUDED.project_id,
+			client_id = EXCLUDED.client_id,
+			source = EXCLUDED.source,
+			type = EXCLUDED.type,
+			status = EXCLUDED.status,
+			title = EXCLUDED.title,
+			message = EXCLUDED.message,
+			rating_score = EXCLUDED.rating_score,
+			rating_max_score = EXCLUDED.rating_max_score,
+			tags = EXCLUDED.tags,
+			is_resolved = EXCLUDED.is_resolved,
+			resolved_at = EXCLUDED.resolved_at,
+			resolved_by = EXCLUDED.resolved_by,
+			metadata = EXCLUDED.metadata,
+			updated_at = EXCLUDED.updated_at`,
+		values...)
+	if err != nil {
+		return fmt.Errorf("failed to save feedback: %w", err)
+	}
 	return nil
 }
 
 func (r *PostgresFeedbackRepository) Create(ctx context.Context, feedback *entities.Feedback) error {
-	// Placeholder implementation
+	values, err := feedbackValues(feedback)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.ExecContext(ctx, `
+		INSERT INTO feedback (`+feedbackColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)`,
+		values...)
+	if err != nil {
+		return fmt.Errorf("failed to create feedback: %w", err)
+	}
 	return nil
 }
 
 func (r *PostgresFeedbackRepository) Update(ctx context.Context, feedback *entities.Feedback) error {
-	// Placeholder implementation
-	return nil
+	values, err := feedbackValues(feedback)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE feedback SET
+			content_id = $2, project_id = $3, client_id = $4, source = $5, type = $6, status = $7,
+			title = $8, message = $9, rating_score = $10, rating_max_score = $11, tags = $12,
+			is_resolved = $13, resolved_at = $14, resolved_by = $15, metadata = $16,
+			created_at = $17, updated_at = $18
+		WHERE feedback_id = $1`,
+		values...)
+	if err != nil {
+		return fmt.Errorf("failed to update feedback: %w", err)
+	}
+	return expectAffected(result, "feedback", feedback.FeedbackID)
 }
 
 func (r *PostgresFeedbackRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	// Placeholder implementation
-	return nil
+	result, err := r.db.ExecContext(ctx, `DELETE FROM feedback WHERE feedback_id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete feedback: %w", err)
+	}
+	return expectAffected(result, "feedback", id)
+}
+
+func feedbackValues(feedback *entities.Feedback) ([]interface{}, error) {
+	metadata, err := encodeJSON(feedback.Metadata)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode feedback metadata: %w", err)
+	}
+
+	var ratingScore, ratingMaxScore sql.NullFloat64
+	if feedback.Rating != nil {
+		ratingScore = sql.NullFloat64{Float64: feedback.Rating.Score, Valid: true}
+		ratingMaxScore = sql.NullFloat64{Float64: feedback.Rating.MaxScore, Valid: true}
+	}
+
+	return []interface{}{
+		feedback.FeedbackID,
+		nullUUID(feedback.ContentID),
+		nullUUID(feedback.ProjectID),
+		feedback.ClientID,
+		feedback.Source,
+		feedback.Type,
+		feedback.Status,
+		feedback.Title,
+		feedback.Message,
+		ratingScore,
+		ratingMaxScore,
+		pq.Array(feedback.Tags),
+		feedback.IsResolved,
+		nullTime(feedback.ResolvedAt),
+		nullUUID(feedback.ResolvedBy),
+		metadata,
+		feedback.CreatedAt,
+		feedback.UpdatedAt,
+	}, nil
 }
 
 // PostgresSystemCapabilityRepository implements the SystemCapabilityRepository interface
@@ -387,37 +1197,146 @@ func NewEventRepository(db *sql.DB) repositories.EventRepository {
 	return &PostgresEventRepository{db: db}
 }
 
+const eventColumns = `event_id, event_type, aggregate_id, occurred_at, event_data`
+
+func scanEvent(row rowScanner) (*events.StoredEvent, error) {
+	var eventID, aggregateID uuid.UUID
+	var eventType string
+	var occurredAt time.Time
+	var data []byte
+
+	if err := row.Scan(&eventID, &eventType, &aggregateID, &occurredAt, &data); err != nil {
+		return nil, err
+	}
+
+	payload, err := decodeMetadata(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode event data: %w", err)
+	}
+
+	return events.NewStoredEvent(eventID, eventType, aggregateID, occurredAt, payload), nil
+}
+
+func (r *PostgresEventRepository) queryEvents(ctx context.Context, query string, args ...interface{}) ([]*events.StoredEvent, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query events: %w", err)
+	}
+	defer rows.Close()
+
+	storedEvents := []*events.StoredEvent{}
+	for rows.Next() {
+		event, err := scanEvent(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan event: %w", err)
+		}
+		storedEvents = append(storedEvents, event)
+	}
+
+	return storedEvents, rows.Err()
+}
+
+func (r *PostgresEventRepository) findPage(ctx context.Context, where string, offset, limit int, args ...interface{}) ([]interface{}, int, error) {
+	total, err := countRows(ctx, r.db, "events", where, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	query := fmt.Sprintf(`SELECT %s FROM events WHERE %s ORDER BY occurred_at ASC OFFSET $%d LIMIT $%d`,
+		eventColumns, where, len(args)+1, len(args)+2)
+	storedEvents, err := r.queryEvents(ctx, query, append(args, offset, limit)...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return toInterfaces(storedEvents), total, nil
+}
+
 func (r *PostgresEventRepository) FindByID(ctx context.Context, id uuid.UUID) (interface{}, error) {
-	// Placeholder implementation
-	return nil, nil
+	row := r.db.QueryRowContext(ctx, `SELECT `+eventColumns+` FROM events WHERE event_id = $1`, id)
+
+	event, err := scanEvent(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repositories.NewNotFoundError("event", id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find event: %w", err)
+	}
+
+	return event, nil
 }
 
 func (r *PostgresEventRepository) FindByAggregateID(ctx context.Context, aggregateID uuid.UUID, offset, limit int) ([]interface{}, int, error) {
-	// Placeholder implementation
-	return nil, 0, nil
+	return r.findPage(ctx, "aggregate_id = $1", offset, limit, aggregateID)
 }
 
 func (r *PostgresEventRepository) FindByEntityID(ctx context.Context, entityID uuid.UUID, eventType string, offset, limit int) ([]*events.Event, int, error) {
-	// Placeholder implementation
-	return nil, 0, nil
+	where := "aggregate_id = $1"
+	args := []interface{}{entityID}
+	if eventType != "" {
+		where += " AND event_type = $2"
+		args = append(args, eventType)
+	}
+
+	total, err := countRows(ctx, r.db, "events", where, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	query := fmt.Sprintf(`SELECT %s FROM events WHERE %s ORDER BY occurred_at ASC OFFSET $%d LIMIT $%d`,
+		eventColumns, where, len(args)+1, len(args)+2)
+	storedEvents, err := r.queryEvents(ctx, query, append(args, offset, limit)...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	result := make([]*events.Event, len(storedEvents))
+	for i, storedEvent := range storedEvents {
+		var event events.Event = storedEvent
+		result[i] = &event
+	}
+
+	return result, total, nil
 }
 
 func (r *PostgresEventRepository) FindByTimeRange(ctx context.Context, start, end time.Time, offset, limit int) ([]interface{}, int, error) {
-	// Placeholder implementation
-	return nil, 0, nil
+	return r.findPage(ctx, "occurred_at BETWEEN $1 AND $2", offset, limit, start, end)
 }
 
 func (r *PostgresEventRepository) FindByType(ctx context.Context, eventType string, offset, limit int) ([]interface{}, int, error) {
-	// Placeholder implementation
-	return nil, 0, nil
+	return r.findPage(ctx, "event_type = $1", offset, limit, eventType)
 }
 
 func (r *PostgresEventRepository) FindLatest(ctx context.Context, limit int) ([]interface{}, error) {
-	return nil, nil
+	storedEvents, err := r.queryEvents(ctx,
+		`SELECT `+eventColumns+` FROM events ORDER BY occurred_at DESC LIMIT $1`,
+		limit)
+	if err != nil {
+		return nil, err
+	}
+	return toInterfaces(storedEvents), nil
 }
 
+// Save stores any domain event using its events.StoredEvent representation
 func (r *PostgresEventRepository) Save(ctx context.Context, event interface{}) error {
-	// Placeholder implementation
+	stored, err := events.ToStoredEvent(event)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(stored.Data)
+	if err != nil {
+		return fmt.Errorf("failed to encode event data: %w", err)
+	}
+
+	_, err = r.db.ExecContext(ctx, `
+		INSERT INTO events (`+eventColumns+`)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (event_id) DO NOTHING`,
+		stored.EventID, stored.EventType, stored.AggregateID, stored.Timestamp, data)
+	if err != nil {
+		return fmt.Errorf("failed to save event: %w", err)
+	}
 	return nil
 }
 
@@ -431,47 +1350,197 @@ func NewTransactionRepository(db *sql.DB) repositories.TransactionRepository {
 	return &PostgresTransactionRepository{db: db}
 }
 
+const transactionColumns = `transaction_id, client_id, project_id, type, status, amount, currency, payment_method,
+	payment_reference, description, processed_at, metadata, created_at, updated_at`
+
+func scanTransaction(row rowScanner) (*entities.Transaction, error) {
+	transaction := &entities.Transaction{}
+	var projectID uuid.NullUUID
+	var paymentReference sql.NullString
+	var processedAt sql.NullTime
+	var metadata []byte
+
+	err := row.Scan(
+		&transaction.TransactionID,
+		&transaction.ClientID,
+		&projectID,
+		&transaction.Type,
+		&transaction.Status,
+		&transaction.Amount.Amount,
+		&transaction.Amount.Currency,
+		&transaction.PaymentMethod,
+		&paymentReference,
+		&transaction.Description,
+		&processedAt,
+		&metadata,
+		&transaction.CreatedAt,
+		&transaction.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if projectID.Valid {
+		transaction.ProjectID = &projectID.UUID
+	}
+	if processedAt.Valid {
+		transaction.ProcessedAt = &processedAt.Time
+	}
+	transaction.PaymentReference = paymentReference.String
+	if transaction.Metadata, err = decodeMetadata(metadata); err != nil {
+		return nil, fmt.Errorf("failed to decode transaction metadata: %w", err)
+	}
+
+	return transaction, nil
+}
+
+func (r *PostgresTransactionRepository) findPage(ctx context.Context, where string, offset, limit int, args ...interface{}) ([]*entities.Transaction, int, error) {
+	total, err := countRows(ctx, r.db, "transactions", where, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	query := fmt.Sprintf(`SELECT %s FROM transactions WHERE %s ORDER BY created_at DESC OFFSET $%d LIMIT $%d`,
+		transactionColumns, where, len(args)+1, len(args)+2)
+	rows, err := r.db.QueryContext(ctx, query, append(args, offset, limit)...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query transactions: %w", err)
+	}
+	defer rows.Close()
+
+	transactions := []*entities.Transaction{}
+	for rows.Next() {
+		transaction, err := scanTransaction(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan transaction: %w", err)
+		}
+		transactions = append(transactions, transaction)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	return transactions, total, nil
+}
+
 func (r *PostgresTransactionRepository) FindByID(ctx context.Context, id uuid.UUID) (*entities.Transaction, error) {
-	// Placeholder implementation
-	return nil, nil
+	row := r.db.QueryRowContext(ctx, `SELECT `+transactionColumns+` FROM transactions WHERE transaction_id = $1`, id)
+
+	transaction, err := scanTransaction(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repositories.NewNotFoundError("transaction", id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find transaction: %w", err)
+	}
+
+	return transaction, nil
 }
 
 func (r *PostgresTransactionRepository) FindByClientID(ctx context.Context, clientID uuid.UUID, offset, limit int) ([]*entities.Transaction, int, error) {
-	// Placeholder implementation
-	return nil, 0, nil
+	return r.findPage(ctx, "client_id = $1", offset, limit, clientID)
 }
 
 func (r *PostgresTransactionRepository) FindByProjectID(ctx context.Context, projectID uuid.UUID, offset, limit int) ([]*entities.Transaction, int, error) {
-	// Placeholder implementation
-	return nil, 0, nil
+	return r.findPage(ctx, "project_id = $1", offset, limit, projectID)
 }
 
 func (r *PostgresTransactionRepository) FindByStatus(ctx context.Context, status entities.TransactionStatus, offset, limit int) ([]*entities.Transaction, int, error) {
-	// Placeholder implementation
-	return nil, 0, nil
+	return r.findPage(ctx, "status = $1", offset, limit, status)
 }
 
 func (r *PostgresTransactionRepository) FindByType(ctx context.Context, transactionType entities.TransactionType, offset, limit int) ([]*entities.Transaction, int, error) {
-	// Placeholder implementation
-	return nil, 0, nil
+	return r.findPage(ctx, "type = $1", offset, limit, transactionType)
 }
 
 func (r *PostgresTransactionRepository) FindByDateRange(ctx context.Context, start, end time.Time, offset, limit int) ([]*entities.Transaction, int, error) {
-	// Placeholder implementation
-	return nil, 0, nil
+	return r.findPage(ctx, "created_at BETWEEN $1 AND $2", offset, limit, start, end)
 }
 
 func (r *PostgresTransactionRepository) Save(ctx context.Context, transaction *entities.Transaction) error {
-	// Placeholder implementation
+	values, err := transactionValues(transaction)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.ExecContext(ctx, `
+		INSERT INTO transactions (`+transactionColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+		ON CONFLICT (transaction_id) DO UPDATE SET
+			client_id = EXCLUDED.client_id,
+			project_id = EXCLUDED.project_id,
+			type = EXCLUDED.type,
+			status = EXCLUDED.status,
+			amount = EXCLUDED.amount,
+			currency = EXCLUDED.currency,
+			payment_method = EXCLUDED.payment_method,
+			payment_reference = EXCLUDED.payment_reference,
+			description = EXCLUDED.description,
+			processed_at = EXCLUDED.processed_at,
+			metadata = EXCLUDED.metadata,
+			updated_at = EXCLUDED.updated_at`,
+		values...)
+	if err != nil {
+		return fmt.Errorf("failed to save transaction: %w", err)
+	}
 	return nil
 }
 
 func (r *PostgresTransactionRepository) Create(ctx context.Context, transaction *entities.Transaction) error {
-	// Placeholder implementation
+	values, err := transactionValues(transaction)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.ExecContext(ctx, `
+		INSERT INTO transactions (`+transactionColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)`,
+		values...)
+	if err != nil {
+		return fmt.Errorf("failed to create transaction: %w", err)
+	}
 	return nil
 }
 
 func (r *PostgresTransactionRepository) Update(ctx context.Context, transaction *entities.Transaction) error {
-	// Placeholder implementation
-	return nil
+	values, err := transactionValues(transaction)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE transactions SET
+			client_id = $2, project_id = $3, type = $4, status = $5, amount = $6, currency = $7,
+			payment_method = $8, payment_reference = $9, description = $10, processed_at = $11,
+			metadata = $12, created_at = $13, updated_at = $14
+		WHERE transaction_id = $1`,
+		values...)
+	if err != nil {
+		return fmt.Errorf("failed to update transaction: %w", err)
+	}
+	return expectAffected(result, "transaction", transaction.TransactionID)
+}
+
+func transactionValues(transaction *entities.Transaction) ([]interface{}, error) {
+	metadata, err := encodeJSON(transaction.Metadata)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode transaction metadata: %w", err)
+	}
+
+	return []interface{}{
+		transaction.TransactionID,
+		transaction.ClientID,
+		nullUUID(transaction.ProjectID),
+		transaction.Type,
+		transaction.Status,
+		transaction.Amount.Amount,
+		transaction.Amount.Currency,
+		transaction.PaymentMethod,
+		nullString(transaction.PaymentReference),
+		transaction.Description,
+		nullTime(transaction.ProcessedAt),
+		metadata,
+		transaction.CreatedAt,
+		transaction.UpdatedAt,
+	}, nil
 }
@@ -0,0 +1,143 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/Ceesaxp/autonomous-content-service/src/domain/entities"
+	"github.com/Ceesaxp/autonomous-content-service/src/domain/repositories"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// PostgresSimilarityIndexRepository implements the SimilarityIndexRepository interface
+type PostgresSimilarityIndexRepository struct {
+	db *sql.DB
+}
+
+// NewSimilarityIndexRepository creates a new PostgreSQL similarity index repository
+func NewSimilarityIndexRepository(db *sql.DB) repositories.SimilarityIndexRepository {
+	return &PostgresSimilarityIndexRepository{db: db}
+}
+
+const similarityDocumentColumns = `document_id, document_key, kind, content_id, project_id, client_id, version_number,
+	url, title, body, indexed_at`
+
+func scanSimilarityDocument(row rowScanner) (*entities.SimilarityDocument, error) {
+	document := &entities.SimilarityDocument{}
+	var contentID, projectID, clientID uuid.NullUUID
+	var versionNumber sql.NullInt64
+	var url sql.NullString
+
+	err := row.Scan(
+		&document.DocumentID,
+		&document.Key,
+		&document.Kind,
+		&contentID,
+		&projectID,
+		&clientID,
+		&versionNumber,
+		&url,
+		&document.Title,
+		&document.Text,
+		&document.IndexedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	document.ContentID = contentID.UUID
+	document.ProjectID = projectID.UUID
+	document.ClientID = clientID.UUID
+	document.VersionNumber = int(versionNumber.Int64)
+	document.URL = url.String
+	return document, nil
+}
+
+func (r *PostgresSimilarityIndexRepository) Save(ctx context.Context, document *entities.SimilarityDocument, bands []uint64) error {
+	return withTx(ctx, r.db, func(tx *sql.Tx) error {
+		var documentID uuid.UUID
+		err := tx.QueryRowContext(ctx, `
+			INSERT INTO similarity_documents (`+similarityDocumentColumns+`)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+			ON CONFLICT (document_key) DO NOTHING
+			RETURNING document_id`,
+			document.DocumentID,
+			document.Key,
+			document.Kind,
+			optionalUUID(document.ContentID),
+			optionalUUID(document.ProjectID),
+			optionalUUID(document.ClientID),
+			sql.NullInt64{Int64: int64(document.VersionNumber), Valid: document.VersionNumber > 0},
+			nullString(document.URL),
+			document.Title,
+			document.Text,
+			document.IndexedAt,
+		).Scan(&documentID)
+		if err == sql.ErrNoRows {
+			return nil // already indexed
+		}
+		if err != nil {
+			return fmt.Errorf("failed to store similarity document: %w", err)
+		}
+
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO similarity_bands (band, document_id)
+			SELECT DISTINCT unnest($1::BIGINT[]), $2
+			ON CONFLICT DO NOTHING`,
+			pq.Array(bandValues(bands)), documentID)
+		if err != nil {
+			return fmt.Errorf("failed to store similarity bands: %w", err)
+		}
+		return nil
+	})
+}
+
+func (r *PostgresSimilarityIndexRepository) Exists(ctx context.Context, key string) (bool, error) {
+	var exists bool
+	err := r.db.QueryRowContext(ctx,
+		`SELECT EXISTS (SELECT 1 FROM similarity_documents WHERE document_key = $1)`, key).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to look up similarity document: %w", err)
+	}
+	return exists, nil
+}
+
+func (r *PostgresSimilarityIndexRepository) FindByBands(ctx context.Context, bands []uint64) ([]*entities.SimilarityDocument, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+similarityDocumentColumns+`
+		FROM similarity_documents
+		WHERE document_id IN (SELECT document_id FROM similarity_bands WHERE band = ANY($1))
+		ORDER BY indexed_at ASC`,
+		pq.Array(bandValues(bands)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query similarity index: %w", err)
+	}
+	defer rows.Close()
+
+	documents := []*entities.SimilarityDocument{}
+	for rows.Next() {
+		document, err := scanSimilarityDocument(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan similarity document: %w", err)
+		}
+		documents = append(documents, document)
+	}
+
+	return documents, rows.Err()
+}
+
+// bandValues converts band hashes to the signed integers of a BIGINT column
+func bandValues(bands []uint64) []int64 {
+	values := make([]int64, len(bands))
+	for i, band := range bands {
+		values[i] = int64(band)
+	}
+	return values
+}
+
+// optionalUUID stores uuid.Nil as NULL
+func optionalUUID(id uuid.UUID) uuid.NullUUID {
+	return uuid.NullUUID{UUID: id, Valid: id != uuid.Nil}
+}
@@ -0,0 +1,119 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Ceesaxp/autonomous-content-service/src/domain/events"
+	"github.com/Ceesaxp/autonomous-content-service/src/domain/repositories"
+	"github.com/google/uuid"
+)
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// countRows returns the number of rows in table matching the where clause
+func countRows(ctx context.Context, db *sql.DB, table, where string, args ...interface{}) (int, error) {
+	var total int
+	query := fmt.Sprintf(`SELECT COUNT(*) FROM %s WHERE %s`, table, where)
+	if err := db.QueryRowContext(ctx, query, args...).Scan(&total); err != nil {
+		return 0, fmt.Errorf("failed to count %s: %w", table, err)
+	}
+	return total, nil
+}
+
+// expectAffected turns an update or delete that touched no rows into a NotFoundError
+func expectAffected(result sql.Result, entity string, id interface{}) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to read affected rows: %w", err)
+	}
+	if affected == 0 {
+		return repositories.NewNotFoundError(entity, id)
+	}
+	return nil
+}
+
+// withTx runs fn inside a transaction, committing on success and rolling back on error
+func withTx(ctx context.Context, db *sql.DB, fn func(tx *sql.Tx) error) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// encodeJSON marshals a value for a JSONB column, storing nil values as NULL
+func encodeJSON(value interface{}) (interface{}, error) {
+	if value == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	if string(data) == "null" {
+		return nil, nil
+	}
+	return data, nil
+}
+
+// decodeJSON unmarshals a JSONB column into target, leaving it untouched for NULL
+func decodeJSON(data []byte, target interface{}) error {
+	if len(data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(data, target)
+}
+
+// decodeMetadata unmarshals a JSONB metadata column, returning an empty map for NULL
+func decodeMetadata(data []byte) (map[string]interface{}, error) {
+	metadata := make(map[string]interface{})
+	if err := decodeJSON(data, &metadata); err != nil {
+		return nil, err
+	}
+	if metadata == nil {
+		metadata = make(map[string]interface{})
+	}
+	return metadata, nil
+}
+
+func nullString(value string) sql.NullString {
+	return sql.NullString{String: value, Valid: value != ""}
+}
+
+func nullUUID(value *uuid.UUID) uuid.NullUUID {
+	if value == nil {
+		return uuid.NullUUID{}
+	}
+	return uuid.NullUUID{UUID: *value, Valid: true}
+}
+
+func nullTime(value *time.Time) sql.NullTime {
+	if value == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: *value, Valid: true}
+}
+
+func toInterfaces(storedEvents []*events.StoredEvent) []interface{} {
+	result := make([]interface{}, len(storedEvents))
+	for i, event := range storedEvents {
+		result[i] = event
+	}
+	return result
+}
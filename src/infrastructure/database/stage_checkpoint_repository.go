@@ -0,0 +1,121 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/Ceesaxp/autonomous-content-service/src/domain/entities"
+	"github.com/Ceesaxp/autonomous-content-service/src/domain/repositories"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// PostgresStageCheckpointRepository implements the StageCheckpointRepository interface
+type PostgresStageCheckpointRepository struct {
+	db *sql.DB
+}
+
+// NewStageCheckpointRepository creates a new PostgreSQL stage checkpoint repository
+func NewStageCheckpointRepository(db *sql.DB) repositories.StageCheckpointRepository {
+	return &PostgresStageCheckpointRepository{db: db}
+}
+
+const stageCheckpointColumns = `content_id, stage, status, output, metadata, error, elapsed_ms, recorded_at`
+
+func scanStageCheckpoint(row rowScanner) (*entities.StageCheckpoint, error) {
+	checkpoint := &entities.StageCheckpoint{}
+	var output, stageError sql.NullString
+	var metadata []byte
+	var elapsedMs int64
+
+	err := row.Scan(
+		&checkpoint.ContentID,
+		&checkpoint.Stage,
+		&checkpoint.Status,
+		&output,
+		&metadata,
+		&stageError,
+		&elapsedMs,
+		&checkpoint.RecordedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	checkpoint.Output = output.String
+	checkpoint.Error = stageError.String
+	checkpoint.ElapsedTime = time.Duration(elapsedMs) * time.Millisecond
+	if checkpoint.Metadata, err = decodeMetadata(metadata); err != nil {
+		return nil, fmt.Errorf("failed to decode checkpoint metadata: %w", err)
+	}
+
+	return checkpoint, nil
+}
+
+func (r *PostgresStageCheckpointRepository) FindByContentID(ctx context.Context, contentID uuid.UUID) ([]*entities.StageCheckpoint, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT `+stageCheckpointColumns+` FROM content_stage_checkpoints WHERE content_id = $1 ORDER BY recorded_at ASC`,
+		contentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query stage checkpoints: %w", err)
+	}
+	defer rows.Close()
+
+	checkpoints := []*entities.StageCheckpoint{}
+	for rows.Next() {
+		checkpoint, err := scanStageCheckpoint(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan stage checkpoint: %w", err)
+		}
+		checkpoints = append(checkpoints, checkpoint)
+	}
+
+	return checkpoints, rows.Err()
+}
+
+func (r *PostgresStageCheckpointRepository) Save(ctx context.Context, checkpoint *entities.StageCheckpoint) error {
+	metadata, err := encodeJSON(checkpoint.Metadata)
+	if err != nil {
+		return fmt.Errorf("failed to encode checkpoint metadata: %w", err)
+	}
+
+	_, err = r.db.ExecContext(ctx, `
+		INSERT INTO content_stage_checkpoints (`+stageCheckpointColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (content_id, stage) DO UPDATE SET
+			status = EXCLUDED.status,
+			output = EXCLUDED.output,
+			metadata = EXCLUDED.metadata,
+			error = EXCLUDED.error,
+			elapsed_ms = EXCLUDED.elapsed_ms,
+			recorded_at = EXCLUDED.recorded_at`,
+		checkpoint.ContentID,
+		checkpoint.Stage,
+		checkpoint.Status,
+		nullString(checkpoint.Output),
+		metadata,
+		nullString(checkpoint.Error),
+		checkpoint.ElapsedTime.Milliseconds(),
+		checkpoint.RecordedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save stage checkpoint: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresStageCheckpointRepository) DeleteStages(ctx context.Context, contentID uuid.UUID, stages ...string) error {
+	if len(stages) == 0 {
+		return nil
+	}
+
+	_, err := r.db.ExecContext(ctx,
+		`DELETE FROM content_stage_checkpoints WHERE content_id = $1 AND stage = ANY($2)`,
+		contentID, pq.Array(stages))
+	if err != nil {
+		return fmt.Errorf("failed to delete stage checkpoints: %w", err)
+	}
+	return nil
+}
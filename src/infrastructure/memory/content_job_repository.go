@@ -0,0 +1,149 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Ceesaxp/autonomous-content-service/src/domain/entities"
+	"github.com/Ceesaxp/autonomous-content-service/src/domain/repositories"
+	"github.com/google/uuid"
+)
+
+// ContentJobRepository implements the ContentJobRepository interface in memory
+type ContentJobRepository struct {
+	mu   sync.RWMutex
+	jobs map[uuid.UUID]*entities.ContentJob
+}
+
+// NewContentJobRepository creates a new in-memory content job repository
+func NewContentJobRepository() repositories.ContentJobRepository {
+	return &ContentJobRepository{jobs: make(map[uuid.UUID]*entities.ContentJob)}
+}
+
+func copyContentJob(job *entities.ContentJob) *entities.ContentJob {
+	copied := *job
+	copied.Errors = copyAll(job.Errors)
+	if job.LeaseUntil != nil {
+		leaseUntil := *job.LeaseUntil
+		copied.LeaseUntil = &leaseUntil
+	}
+	return &copied
+}
+
+func isActiveJob(job *entities.ContentJob) bool {
+	return job.Status == entities.ContentJobStatusQueued || job.Status == entities.ContentJobStatusRunning
+}
+
+func (r *ContentJobRepository) find(keep func(*entities.ContentJob) bool) []*entities.ContentJob {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	matches := filter(r.jobs, keep)
+	sortByTime(matches, func(j *entities.ContentJob) time.Time { return j.CreatedAt }, true)
+
+	result := make([]*entities.ContentJob, len(matches))
+	for i, job := range matches {
+		result[i] = copyContentJob(job)
+	}
+	return result
+}
+
+func (r *ContentJobRepository) FindByID(ctx context.Context, id uuid.UUID) (*entities.ContentJob, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	job, ok := r.jobs[id]
+	if !ok {
+		return nil, repositories.NewNotFoundError("content job", id)
+	}
+	return copyContentJob(job), nil
+}
+
+func (r *ContentJobRepository) FindByContentID(ctx context.Context, contentID uuid.UUID) ([]*entities.ContentJob, error) {
+	return r.find(func(j *entities.ContentJob) bool { return j.ContentID == contentID }), nil
+}
+
+func (r *ContentJobRepository) FindByStatus(ctx context.Context, statuses ...entities.ContentJobStatus) ([]*entities.ContentJob, error) {
+	return r.find(func(j *entities.ContentJob) bool {
+		for _, status := range statuses {
+			if j.Status == status {
+				return true
+			}
+		}
+		return false
+	}), nil
+}
+
+func (r *ContentJobRepository) Create(ctx context.Context, job *entities.ContentJob) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.jobs[job.JobID]; exists {
+		return fmt.Errorf("content job already exists: %s", job.JobID)
+	}
+	if isActiveJob(job) {
+		for _, existing := range r.jobs {
+			if existing.ContentID == job.ContentID && isActiveJob(existing) {
+				return repositories.ErrContentJobActive
+			}
+		}
+	}
+	r.jobs[job.JobID] = copyContentJob(job)
+	return nil
+}
+
+func (r *ContentJobRepository) Update(ctx context.Context, job *entities.ContentJob) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, exists := r.jobs[job.JobID]
+	if !exists {
+		return repositories.NewNotFoundError("content job", job.JobID)
+	}
+	updated := copyContentJob(job)
+	updated.Worker = existing.Worker
+	updated.LeaseUntil = existing.LeaseUntil
+	r.jobs[job.JobID] = updated
+	return nil
+}
+
+func (r *ContentJobRepository) Claim(ctx context.Context, id uuid.UUID, worker string, leaseUntil time.Time) (*entities.ContentJob, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	job, ok := r.jobs[id]
+	if !ok || job.Status != entities.ContentJobStatusQueued {
+		return nil, repositories.NewNotFoundError("queued content job", id)
+	}
+	job.Claim(worker, leaseUntil)
+	return copyContentJob(job), nil
+}
+
+func (r *ContentJobRepository) RenewLease(ctx context.Context, id uuid.UUID, worker string, leaseUntil time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	job, ok := r.jobs[id]
+	if !ok || job.Status != entities.ContentJobStatusRunning || job.Worker != worker {
+		return repositories.NewNotFoundError("running content job", id)
+	}
+	job.LeaseUntil = &leaseUntil
+	return nil
+}
+
+func (r *ContentJobRepository) RequeueExpired(ctx context.Context, now time.Time) ([]*entities.ContentJob, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	expired := filter(r.jobs, func(j *entities.ContentJob) bool { return j.LeaseExpired(now) })
+	sortByTime(expired, func(j *entities.ContentJob) time.Time { return j.CreatedAt }, true)
+
+	result := make([]*entities.ContentJob, len(expired))
+	for i, job := range expired {
+		job.Requeue()
+		result[i] = copyContentJob(job)
+	}
+	return result, nil
+}
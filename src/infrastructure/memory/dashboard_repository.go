@@ -0,0 +1,611 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Ceesaxp/autonomous-content-service/src/domain/entities"
+	"github.com/Ceesaxp/autonomous-content-service/src/domain/repositories"
+	"github.com/google/uuid"
+)
+
+// DashboardRepository implements the DashboardRepository interface in memory.
+// Project data for summaries is read from the project repository it is given.
+type DashboardRepository struct {
+	mu            sync.RWMutex
+	projectRepo   repositories.ProjectRepository
+	notifications map[uuid.UUID]*entities.DashboardNotification
+	milestones    map[uuid.UUID]*entities.ProjectMilestone
+	approvals     map[uuid.UUID]*entities.ContentApproval
+	revisions     map[uuid.UUID]*entities.RevisionRequest
+	threads       map[uuid.UUID]*entities.MessageThread
+	messages      map[uuid.UUID]*entities.DashboardMessage
+	analytics     map[uuid.UUID]*entities.ProjectAnalytics
+	billing       map[uuid.UUID]*entities.BillingHistory
+}
+
+// NewDashboardRepository creates a new in-memory dashboard repository
+func NewDashboardRepository(projectRepo repositories.ProjectRepository) repositories.DashboardRepository {
+	return &DashboardRepository{
+		projectRepo:   projectRepo,
+		notifications: make(map[uuid.UUID]*entities.DashboardNotification),
+		milestones:    make(map[uuid.UUID]*entities.ProjectMilestone),
+		approvals:     make(map[uuid.UUID]*entities.ContentApproval),
+		revisions:     make(map[uuid.UUID]*entities.RevisionRequest),
+		threads:       make(map[uuid.UUID]*entities.MessageThread),
+		messages:      make(map[uuid.UUID]*entities.DashboardMessage),
+		analytics:     make(map[uuid.UUID]*entities.ProjectAnalytics),
+		billing:       make(map[uuid.UUID]*entities.BillingHistory),
+	}
+}
+
+// Notifications
+
+func (r *DashboardRepository) CreateNotification(ctx context.Context, notification *entities.DashboardNotification) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	copied := *notification
+	r.notifications[notification.NotificationID] = &copied
+	return nil
+}
+
+func (r *DashboardRepository) GetNotificationsByClientID(ctx context.Context, clientID uuid.UUID, limit, offset int) ([]*entities.DashboardNotification, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	matches := filter(r.notifications, func(n *entities.DashboardNotification) bool { return n.ClientID == clientID })
+	sortByTime(matches, func(n *entities.DashboardNotification) time.Time { return n.CreatedAt }, false)
+	return copyAll(paginate(matches, offset, limit)), nil
+}
+
+func (r *DashboardRepository) GetUnreadNotificationCount(ctx context.Context, clientID uuid.UUID) (int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return len(filter(r.notifications, func(n *entities.DashboardNotification) bool {
+		return n.ClientID == clientID && !n.IsRead
+	})), nil
+}
+
+func (r *DashboardRepository) MarkNotificationAsRead(ctx context.Context, notificationID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	notification, ok := r.notifications[notificationID]
+	if !ok {
+		return repositories.NewNotFoundError("notification", notificationID)
+	}
+	notification.MarkAsRead()
+	return nil
+}
+
+func (r *DashboardRepository) DeleteNotification(ctx context.Context, notificationID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.notifications[notificationID]; !ok {
+		return repositories.NewNotFoundError("notification", notificationID)
+	}
+	delete(r.notifications, notificationID)
+	return nil
+}
+
+// Milestones
+
+func (r *DashboardRepository) CreateMilestone(ctx context.Context, milestone *entities.ProjectMilestone) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	copied := *milestone
+	r.milestones[milestone.MilestoneID] = &copied
+	return nil
+}
+
+func (r *DashboardRepository) GetMilestonesByProjectID(ctx context.Context, projectID uuid.UUID) ([]*entities.ProjectMilestone, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	matches := filter(r.milestones, func(m *entities.ProjectMilestone) bool { return m.ProjectID == projectID })
+	sortByTime(matches, func(m *entities.ProjectMilestone) time.Time { return m.DueDate }, true)
+	return copyAll(matches), nil
+}
+
+func (r *DashboardRepository) UpdateMilestone(ctx context.Context, milestone *entities.ProjectMilestone) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.milestones[milestone.MilestoneID]; !ok {
+		return repositories.NewNotFoundError("milestone", milestone.MilestoneID)
+	}
+	copied := *milestone
+	r.milestones[milestone.MilestoneID] = &copied
+	return nil
+}
+
+func (r *DashboardRepository) CompleteMilestone(ctx context.Context, milestoneID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	milestone, ok := r.milestones[milestoneID]
+	if !ok {
+		return repositories.NewNotFoundError("milestone", milestoneID)
+	}
+	milestone.Complete()
+	return nil
+}
+
+func (r *DashboardRepository) DeleteMilestone(ctx context.Context, milestoneID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.milestones[milestoneID]; !ok {
+		return repositories.NewNotFoundError("milestone", milestoneID)
+	}
+	delete(r.milestones, milestoneID)
+	return nil
+}
+
+// Content Approvals
+
+func (r *DashboardRepository) CreateContentApproval(ctx context.Context, approval *entities.ContentApproval) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	copied := *approval
+	r.approvals[approval.ApprovalID] = &copied
+	return nil
+}
+
+func (r *DashboardRepository) GetContentApprovalsByProjectID(ctx context.Context, projectID uuid.UUID) ([]*entities.ContentApproval, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	matches := filter(r.approvals, func(a *entities.ContentApproval) bool { return a.ProjectID == projectID })
+	sortByTime(matches, func(a *entities.ContentApproval) time.Time { return a.CreatedAt }, false)
+	return copyAll(matches), nil
+}
+
+func (r *DashboardRepository) GetContentApprovalsByClientID(ctx context.Context, clientID uuid.UUID, limit, offset int) ([]*entities.ContentApproval, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	matches := filter(r.approvals, func(a *entities.ContentApproval) bool { return a.ClientID == clientID })
+	sortByTime(matches, func(a *entities.ContentApproval) time.Time { return a.CreatedAt }, false)
+	return copyAll(paginate(matches, offset, limit)), nil
+}
+
+func (r *DashboardRepository) UpdateContentApproval(ctx context.Context, approval *entities.ContentApproval) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.approvals[approval.ApprovalID]; !ok {
+		return repositories.NewNotFoundError("content approval", approval.ApprovalID)
+	}
+	copied := *approval
+	r.approvals[approval.ApprovalID] = &copied
+	return nil
+}
+
+func (r *DashboardRepository) GetContentApprovalByID(ctx context.Context, approvalID uuid.UUID) (*entities.ContentApproval, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	approval, ok := r.approvals[approvalID]
+	if !ok {
+		return nil, repositories.NewNotFoundError("content approval", approvalID)
+	}
+	copied := *approval
+	return &copied, nil
+}
+
+// Revision Requests
+
+func (r *DashboardRepository) CreateRevisionRequest(ctx context.Context, request *entities.RevisionRequest) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	copied := *request
+	r.revisions[request.RequestID] = &copied
+	return nil
+}
+
+func (r *DashboardRepository) GetRevisionRequestsByProjectID(ctx context.Context, projectID uuid.UUID) ([]*entities.RevisionRequest, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	matches := filter(r.revisions, func(rr *entities.RevisionRequest) bool { return rr.ProjectID == projectID })
+	sortByTime(matches, func(rr *entities.RevisionRequest) time.Time { return rr.CreatedAt }, false)
+	return copyAll(matches), nil
+}
+
+func (r *DashboardRepository) GetRevisionRequestsByClientID(ctx context.Context, clientID uuid.UUID, limit, offset int) ([]*entities.RevisionRequest, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	matches := filter(r.revisions, func(rr *entities.RevisionRequest) bool { return rr.ClientID == clientID })
+	sortByTime(matches, func(rr *entities.RevisionRequest) time.Time { return rr.CreatedAt }, false)
+	return copyAll(paginate(matches, offset, limit)), nil
+}
+
+func (r *DashboardRepository) UpdateRevisionRequest(ctx context.Context, request *entities.RevisionRequest) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.revisions[request.RequestID]; !ok {
+		return repositories.NewNotFoundError("revision request", request.RequestID)
+	}
+	copied := *request
+	r.revisions[request.RequestID] = &copied
+	return nil
+}
+
+func (r *DashboardRepository) CompleteRevisionRequest(ctx context.Context, requestID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	request, ok := r.revisions[requestID]
+	if !ok {
+		return repositories.NewNotFoundError("revision request", requestID)
+	}
+	request.Complete()
+	return nil
+}
+
+// Message Threads
+
+func (r *DashboardRepository) CreateMessageThread(ctx context.Context, thread *entities.MessageThread) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	copied := *thread
+	r.threads[thread.ThreadID] = &copied
+	return nil
+}
+
+func (r *DashboardRepository) GetMessageThreadsByProjectID(ctx context.Context, projectID uuid.UUID) ([]*entities.MessageThread, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	matches := filter(r.threads, func(t *entities.MessageThread) bool { return t.ProjectID == projectID })
+	sortByTime(matches, threadActivity, false)
+	return copyAll(matches), nil
+}
+
+func (r *DashboardRepository) GetMessageThreadsByClientID(ctx context.Context, clientID uuid.UUID, limit, offset int) ([]*entities.MessageThread, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	matches := filter(r.threads, func(t *entities.MessageThread) bool { return t.ClientID == clientID })
+	sortByTime(matches, threadActivity, false)
+	return copyAll(paginate(matches, offset, limit)), nil
+}
+
+func (r *DashboardRepository) UpdateMessageThread(ctx context.Context, thread *entities.MessageThread) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.threads[thread.ThreadID]; !ok {
+		return repositories.NewNotFoundError("message thread", thread.ThreadID)
+	}
+	copied := *thread
+	r.threads[thread.ThreadID] = &copied
+	return nil
+}
+
+func (r *DashboardRepository) GetMessageThreadByID(ctx context.Context, threadID uuid.UUID) (*entities.MessageThread, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	thread, ok := r.threads[threadID]
+	if !ok {
+		return nil, repositories.NewNotFoundError("message thread", threadID)
+	}
+	copied := *thread
+	return &copied, nil
+}
+
+// threadActivity orders threads by their latest message, falling back to creation time
+func threadActivity(thread *entities.MessageThread) time.Time {
+	if thread.LastMessage != nil {
+		return *thread.LastMessage
+	}
+	return thread.CreatedAt
+}
+
+// Messages
+
+func (r *DashboardRepository) CreateMessage(ctx context.Context, message *entities.DashboardMessage) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.threads[message.ThreadID]; !ok {
+		return repositories.NewNotFoundError("message thread", message.ThreadID)
+	}
+	copied := *message
+	r.messages[message.MessageID] = &copied
+	return nil
+}
+
+func (r *DashboardRepository) GetMessagesByThreadID(ctx context.Context, threadID uuid.UUID, limit, offset int) ([]*entities.DashboardMessage, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	matches := filter(r.messages, func(m *entities.DashboardMessage) bool { return m.ThreadID == threadID })
+	sortByTime(matches, func(m *entities.DashboardMessage) time.Time { return m.CreatedAt }, true)
+	return copyAll(paginate(matches, offset, limit)), nil
+}
+
+func (r *DashboardRepository) MarkMessageAsRead(ctx context.Context, messageID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	message, ok := r.messages[messageID]
+	if !ok {
+		return repositories.NewNotFoundError("message", messageID)
+	}
+	message.MarkAsRead()
+	return nil
+}
+
+func (r *DashboardRepository) GetUnreadMessageCount(ctx context.Context, clientID uuid.UUID) (int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.unreadMessageCount(clientID), nil
+}
+
+// unreadMessageCount counts unread system messages in the client's threads; the caller holds the lock
+func (r *DashboardRepository) unreadMessageCount(clientID uuid.UUID) int {
+	count := 0
+	for _, message := range r.messages {
+		thread, ok := r.threads[message.ThreadID]
+		if ok && thread.ClientID == clientID && !message.IsRead && message.Type != entities.MessageTypeClient {
+			count++
+		}
+	}
+	return count
+}
+
+// Analytics
+
+func (r *DashboardRepository) CreateProjectAnalytics(ctx context.Context, analytics *entities.ProjectAnalytics) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	copied := *analytics
+	copied.CustomMetrics = copyMetadata(analytics.CustomMetrics)
+	r.analytics[analytics.ProjectID] = &copied
+	return nil
+}
+
+func (r *DashboardRepository) GetProjectAnalytics(ctx context.Context, projectID uuid.UUID) (*entities.ProjectAnalytics, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	analytics, ok := r.analytics[projectID]
+	if !ok {
+		return nil, repositories.NewNotFoundError("project analytics", projectID)
+	}
+	copied := *analytics
+	copied.CustomMetrics = copyMetadata(analytics.CustomMetrics)
+	return &copied, nil
+}
+
+func (r *DashboardRepository) UpdateProjectAnalytics(ctx context.Context, analytics *entities.ProjectAnalytics) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.analytics[analytics.ProjectID]; !ok {
+		return repositories.NewNotFoundError("project analytics", analytics.ProjectID)
+	}
+	copied := *analytics
+	copied.CustomMetrics = copyMetadata(analytics.CustomMetrics)
+	r.analytics[analytics.ProjectID] = &copied
+	return nil
+}
+
+func (r *DashboardRepository) GetClientAnalyticsSummary(ctx context.Context, clientID uuid.UUID, fromDate, toDate time.Time) (map[string]interface{}, error) {
+	projects, err := r.projectRepo.GetByClientID(ctx, clientID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get client projects: %w", err)
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	totalProjects, completedProjects, contentDelivered, revisionRequests := 0, 0, 0, 0
+	satisfactionTotal, satisfactionCount := 0.0, 0
+	for _, project := range projects {
+		if project.CreatedAt.Before(fromDate) || project.CreatedAt.After(toDate) {
+			continue
+		}
+		totalProjects++
+		if project.Status == entities.ProjectStatusCompleted {
+			completedProjects++
+		}
+		if analytics, ok := r.analytics[project.ProjectID]; ok {
+			contentDelivered += analytics.ContentDelivered
+			revisionRequests += analytics.RevisionRequests
+			if analytics.ClientSatisfaction > 0 {
+				satisfactionTotal += analytics.ClientSatisfaction
+				satisfactionCount++
+			}
+		}
+	}
+
+	averageSatisfaction := 0.0
+	if satisfactionCount > 0 {
+		averageSatisfaction = satisfactionTotal / float64(satisfactionCount)
+	}
+
+	return map[string]interface{}{
+		"totalProjects":       totalProjects,
+		"completedProjects":   completedProjects,
+		"contentDelivered":    contentDelivered,
+		"revisionRequests":    revisionRequests,
+		"averageSatisfaction": averageSatisfaction,
+		"fromDate":            fromDate,
+		"toDate":              toDate,
+	}, nil
+}
+
+// Billing History
+
+func copyBillingHistory(billing *entities.BillingHistory) *entities.BillingHistory {
+	copied := *billing
+	copied.LineItems = append([]entities.BillingLineItem(nil), billing.LineItems...)
+	copied.Metadata = copyMetadata(billing.Metadata)
+	return &copied
+}
+
+func (r *DashboardRepository) findBilling(keep func(*entities.BillingHistory) bool, limit, offset int) []*entities.BillingHistory {
+	matches := filter(r.billing, keep)
+	sortByTime(matches, func(b *entities.BillingHistory) time.Time { return b.CreatedAt }, false)
+
+	page := paginate(matches, offset, limit)
+	result := make([]*entities.BillingHistory, len(page))
+	for i, billing := range page {
+		result[i] = copyBillingHistory(billing)
+	}
+	return result
+}
+
+func (r *DashboardRepository) CreateBillingHistory(ctx context.Context, billing *entities.BillingHistory) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.billing[billing.BillingID] = copyBillingHistory(billing)
+	return nil
+}
+
+func (r *DashboardRepository) GetBillingHistoryByProjectID(ctx context.Context, projectID uuid.UUID) ([]*entities.BillingHistory, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.findBilling(func(b *entities.BillingHistory) bool { return b.ProjectID == projectID }, 0, 0), nil
+}
+
+// GetBillingHistoryByClientID returns the client's billing history; uuid.Nil returns every client's records
+func (r *DashboardRepository) GetBillingHistoryByClientID(ctx context.Context, clientID uuid.UUID, limit, offset int) ([]*entities.BillingHistory, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.findBilling(func(b *entities.BillingHistory) bool {
+		return clientID == uuid.Nil || b.ClientID == clientID
+	}, limit, offset), nil
+}
+
+func (r *DashboardRepository) UpdateBillingHistory(ctx context.Context, billing *entities.BillingHistory) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.billing[billing.BillingID]; !ok {
+		return repositories.NewNotFoundError("billing history", billing.BillingID)
+	}
+	r.billing[billing.BillingID] = copyBillingHistory(billing)
+	return nil
+}
+
+func (r *DashboardRepository) GetOutstandingInvoices(ctx context.Context, clientID uuid.UUID) ([]*entities.BillingHistory, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.findBilling(func(b *entities.BillingHistory) bool {
+		return b.ClientID == clientID && isOutstanding(b)
+	}, 0, 0), nil
+}
+
+func isOutstanding(billing *entities.BillingHistory) bool {
+	switch billing.Status {
+	case entities.PaymentStatusCompleted, entities.PaymentStatusCancelled, entities.PaymentStatusRefunded:
+		return false
+	}
+	return true
+}
+
+// Dashboard Summary
+
+func (r *DashboardRepository) GetDashboardSummary(ctx context.Context, clientID uuid.UUID) (*repositories.DashboardSummary, error) {
+	projects, err := r.projectRepo.GetByClientID(ctx, clientID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get client projects: %w", err)
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	now := time.Now()
+	summary := &repositories.DashboardSummary{
+		ClientID:               clientID,
+		ProjectStatusBreakdown: make(map[entities.ProjectStatus]int),
+		RecentActivity:         []repositories.DashboardActivity{},
+		UpcomingDeadlines:      []repositories.DashboardDeadline{},
+		LastUpdated:            now,
+	}
+
+	for _, project := range projects {
+		summary.ProjectStatusBreakdown[project.Status]++
+		switch project.Status {
+		case entities.ProjectStatusCompleted:
+			summary.CompletedProjects++
+			continue
+		case entities.ProjectStatusCancelled:
+			continue
+		}
+
+		summary.ActiveProjects++
+		daysLeft := int(project.Deadline.Sub(now).Hours() / 24)
+		if daysLeft <= 14 {
+			summary.UpcomingDeadlines = append(summary.UpcomingDeadlines, repositories.DashboardDeadline{
+				ProjectID:   project.ProjectID,
+				ProjectName: project.Title,
+				Deadline:    project.Deadline,
+				DaysLeft:    daysLeft,
+				IsOverdue:   project.Deadline.Before(now),
+			})
+		}
+		summary.RecentActivity = append(summary.RecentActivity, repositories.DashboardActivity{
+			Type:        "project_updated",
+			Description: fmt.Sprintf("%s is %s", project.Title, project.Status),
+			ProjectID:   project.ProjectID,
+			Timestamp:   project.UpdatedAt,
+		})
+	}
+
+	for _, approval := range r.approvals {
+		if approval.ClientID == clientID && approval.Status == entities.ContentApprovalPending {
+			summary.PendingApprovals++
+		}
+	}
+	for _, notification := range r.notifications {
+		if notification.ClientID == clientID && !notification.IsRead {
+			summary.UnreadNotifications++
+		}
+	}
+	summary.UnreadMessages = r.unreadMessageCount(clientID)
+
+	for _, billing := range r.billing {
+		if billing.ClientID == clientID && isOutstanding(billing) {
+			summary.OutstandingBalance.Amount += billing.Amount.Amount
+			summary.OutstandingBalance.Currency = billing.Amount.Currency
+		}
+	}
+
+	return summary, nil
+}
+
+func (r *DashboardRepository) GetProjectStatusSummary(ctx context.Context, clientID uuid.UUID) (map[entities.ProjectStatus]int, error) {
+	projects, err := r.projectRepo.GetByClientID(ctx, clientID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get client projects: %w", err)
+	}
+
+	breakdown := make(map[entities.ProjectStatus]int)
+	for _, project := range projects {
+		breakdown[project.Status]++
+	}
+	return breakdown, nil
+}
@@ -0,0 +1,96 @@
+package memory
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/Ceesaxp/autonomous-content-service/src/domain/entities"
+	"github.com/Ceesaxp/autonomous-content-service/src/domain/repositories"
+)
+
+// defaultLLMCacheEntries is the capacity of an LLM cache created without one
+const defaultLLMCacheEntries = 10000
+
+// LLMCacheRepository implements the LLMCacheRepository interface as an in-memory LRU.
+// When full, the least recently used entry is evicted.
+type LLMCacheRepository struct {
+	mu         sync.Mutex
+	maxEntries int
+	order      *list.List // front is most recently used
+	entries    map[string]*list.Element
+}
+
+// NewLLMCacheRepository creates an in-memory LLM cache holding at most maxEntries
+// entries. A non-positive maxEntries selects the default.
+func NewLLMCacheRepository(maxEntries int) repositories.LLMCacheRepository {
+	if maxEntries <= 0 {
+		maxEntries = defaultLLMCacheEntries
+	}
+	return &LLMCacheRepository{
+		maxEntries: maxEntries,
+		order:      list.New(),
+		entries:    make(map[string]*list.Element),
+	}
+}
+
+func (r *LLMCacheRepository) Get(ctx context.Context, key string) (*entities.LLMCacheEntry, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	element, ok := r.entries[key]
+	if !ok {
+		return nil, repositories.NewNotFoundError("LLM cache entry", key)
+	}
+
+	entry := element.Value.(*entities.LLMCacheEntry)
+	if entry.IsExpired(time.Now()) {
+		r.remove(element)
+		return nil, repositories.NewNotFoundError("LLM cache entry", key)
+	}
+
+	r.order.MoveToFront(element)
+	copied := *entry
+	return &copied, nil
+}
+
+func (r *LLMCacheRepository) Put(ctx context.Context, entry *entities.LLMCacheEntry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	copied := *entry
+	if element, ok := r.entries[entry.Key]; ok {
+		element.Value = &copied
+		r.order.MoveToFront(element)
+		return nil
+	}
+
+	r.entries[entry.Key] = r.order.PushFront(&copied)
+	for r.order.Len() > r.maxEntries {
+		r.remove(r.order.Back())
+	}
+	return nil
+}
+
+func (r *LLMCacheRepository) DeleteExpired(ctx context.Context, now time.Time) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	removed := 0
+	for element := r.order.Front(); element != nil; {
+		next := element.Next()
+		if element.Value.(*entities.LLMCacheEntry).IsExpired(now) {
+			r.remove(element)
+			removed++
+		}
+		element = next
+	}
+	return removed, nil
+}
+
+// remove drops an element from both the LRU list and the index
+func (r *LLMCacheRepository) remove(element *list.Element) {
+	r.order.Remove(element)
+	delete(r.entries, element.Value.(*entities.LLMCacheEntry).Key)
+}
@@ -0,0 +1,56 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Ceesaxp/autonomous-content-service/src/domain/entities"
+	"github.com/Ceesaxp/autonomous-content-service/src/domain/repositories"
+	"github.com/google/uuid"
+)
+
+// LLMUsageRepository implements the LLMUsageRepository interface in memory
+type LLMUsageRepository struct {
+	mu      sync.RWMutex
+	records map[uuid.UUID]*entities.LLMUsageRecord
+}
+
+// NewLLMUsageRepository creates a new in-memory LLM usage repository
+func NewLLMUsageRepository() repositories.LLMUsageRepository {
+	return &LLMUsageRepository{records: make(map[uuid.UUID]*entities.LLMUsageRecord)}
+}
+
+func (r *LLMUsageRepository) find(keep func(*entities.LLMUsageRecord) bool) []*entities.LLMUsageRecord {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	matches := filter(r.records, keep)
+	sortByTime(matches, func(u *entities.LLMUsageRecord) time.Time { return u.RecordedAt }, true)
+	return copyAll(matches)
+}
+
+func (r *LLMUsageRepository) FindByContentID(ctx context.Context, contentID uuid.UUID) ([]*entities.LLMUsageRecord, error) {
+	return r.find(func(u *entities.LLMUsageRecord) bool { return u.ContentID == contentID }), nil
+}
+
+func (r *LLMUsageRepository) FindByProjectID(ctx context.Context, projectID uuid.UUID) ([]*entities.LLMUsageRecord, error) {
+	return r.find(func(u *entities.LLMUsageRecord) bool { return u.ProjectID == projectID }), nil
+}
+
+func (r *LLMUsageRepository) FindByClientID(ctx context.Context, clientID uuid.UUID) ([]*entities.LLMUsageRecord, error) {
+	return r.find(func(u *entities.LLMUsageRecord) bool { return u.ClientID == clientID }), nil
+}
+
+func (r *LLMUsageRepository) Create(ctx context.Context, record *entities.LLMUsageRecord) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.records[record.UsageID]; exists {
+		return fmt.Errorf("LLM usage record already exists: %s", record.UsageID)
+	}
+	copied := *record
+	r.records[record.UsageID] = &copied
+	return nil
+}
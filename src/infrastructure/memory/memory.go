@@ -0,0 +1,150 @@
+// Package memory provides thread-safe in-memory implementations of the domain
+// repositories, used for local development and tests without PostgreSQL.
+package memory
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/Ceesaxp/autonomous-content-service/src/domain/repositories"
+)
+
+// paginate returns the page of items starting at offset, with at most limit entries.
+// A non-positive limit returns everything after offset.
+func paginate[T any](items []T, offset, limit int) []T {
+	if offset < 0 {
+		offset = 0
+	}
+	if offset >= len(items) {
+		return []T{}
+	}
+	end := len(items)
+	if limit > 0 && offset+limit < end {
+		end = offset + limit
+	}
+	return items[offset:end]
+}
+
+// filter returns the values of items for which keep reports true
+func filter[K comparable, T any](items map[K]*T, keep func(*T) bool) []*T {
+	result := []*T{}
+	for _, item := range items {
+		if keep(item) {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// sortByTime orders items by the timestamp returned from key, newest first unless ascending is set
+func sortByTime[T any](items []*T, key func(*T) time.Time, ascending bool) {
+	sort.SliceStable(items, func(i, j int) bool {
+		if ascending {
+			return key(items[i]).Before(key(items[j]))
+		}
+		return key(items[i]).After(key(items[j]))
+	})
+}
+
+// copyMetadata returns a shallow copy of a metadata map so stored entities
+// are not mutated by callers holding the original
+func copyMetadata(metadata map[string]interface{}) map[string]interface{} {
+	if metadata == nil {
+		return nil
+	}
+	copied := make(map[string]interface{}, len(metadata))
+	for key, value := range metadata {
+		copied[key] = value
+	}
+	return copied
+}
+
+func all[T any](*T) bool {
+	return true
+}
+
+// table stores shallow copies of records keyed by string ID. It is not
+// synchronised; the owning repository guards it with its own mutex.
+type table[T any] struct {
+	entity string
+	rows   map[string]*T
+}
+
+func newTable[T any](entity string) *table[T] {
+	return &table[T]{entity: entity, rows: make(map[string]*T)}
+}
+
+// insert stores a copy of item, failing if the ID is already taken
+func (t *table[T]) insert(id string, item *T) error {
+	if _, ok := t.rows[id]; ok {
+		return fmt.Errorf("%s %s already exists", t.entity, id)
+	}
+	copied := *item
+	t.rows[id] = &copied
+	return nil
+}
+
+// get returns a copy of the record with the given ID
+func (t *table[T]) get(id string) (*T, error) {
+	item, ok := t.rows[id]
+	if !ok {
+		return nil, repositories.NewNotFoundError(t.entity, id)
+	}
+	copied := *item
+	return &copied, nil
+}
+
+// replace overwrites an existing record with a copy of item
+func (t *table[T]) replace(id string, item *T) error {
+	if _, ok := t.rows[id]; !ok {
+		return repositories.NewNotFoundError(t.entity, id)
+	}
+	copied := *item
+	t.rows[id] = &copied
+	return nil
+}
+
+// modify applies fn to the stored record in place
+func (t *table[T]) modify(id string, fn func(*T)) error {
+	item, ok := t.rows[id]
+	if !ok {
+		return repositories.NewNotFoundError(t.entity, id)
+	}
+	fn(item)
+	return nil
+}
+
+func (t *table[T]) remove(id string) error {
+	if _, ok := t.rows[id]; !ok {
+		return repositories.NewNotFoundError(t.entity, id)
+	}
+	delete(t.rows, id)
+	return nil
+}
+
+// find returns copies of the records for which keep reports true
+func (t *table[T]) find(keep func(*T) bool) []*T {
+	return copyAll(filter(t.rows, keep))
+}
+
+// first returns a copy of the first record for which keep reports true
+func (t *table[T]) first(keep func(*T) bool, key interface{}) (*T, error) {
+	for _, item := range t.rows {
+		if keep(item) {
+			copied := *item
+			return &copied, nil
+		}
+	}
+	return nil, repositories.NewNotFoundError(t.entity, key)
+}
+
+// copyAll returns value copies of items so callers cannot mutate stored records
+func copyAll[T any](items []*T) []*T {
+	result := make([]*T, len(items))
+	for i, item := range items {
+		copied := *item
+		result[i] = &copied
+	}
+	return result
+}
@@ -0,0 +1,584 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Ceesaxp/autonomous-content-service/src/domain/entities"
+	"github.com/Ceesaxp/autonomous-content-service/src/domain/repositories"
+)
+
+// PaymentRepository implements the PaymentRepository interface in memory
+type PaymentRepository struct {
+	mu            sync.RWMutex
+	payments      *table[entities.Payment]
+	invoices      *table[entities.Invoice]
+	processors    *table[entities.PaymentProcessor]
+	notifications *table[entities.PaymentNotification]
+	receipts      *table[entities.PaymentReceipt]
+	webhooks      *table[entities.PaymentWebhook]
+	refunds       *table[entities.Refund]
+}
+
+// NewPaymentRepository creates a new in-memory payment repository
+func NewPaymentRepository() repositories.PaymentRepository {
+	return &PaymentRepository{
+		payments:      newTable[entities.Payment]("payment"),
+		invoices:      newTable[entities.Invoice]("invoice"),
+		processors:    newTable[entities.PaymentProcessor]("payment processor"),
+		notifications: newTable[entities.PaymentNotification]("payment notification"),
+		receipts:      newTable[entities.PaymentReceipt]("payment receipt"),
+		webhooks:      newTable[entities.PaymentWebhook]("payment webhook"),
+		refunds:       newTable[entities.Refund]("refund"),
+	}
+}
+
+func paymentCreatedAt(p *entities.Payment) time.Time { return p.CreatedAt }
+func invoiceCreatedAt(i *entities.Invoice) time.Time { return i.CreatedAt }
+
+// Payment operations
+
+func (r *PaymentRepository) CreatePayment(ctx context.Context, payment *entities.Payment) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.payments.insert(payment.ID, payment)
+}
+
+func (r *PaymentRepository) GetPayment(ctx context.Context, id string) (*entities.Payment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.payments.get(id)
+}
+
+func (r *PaymentRepository) GetPaymentByExternalID(ctx context.Context, externalID string) (*entities.Payment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.payments.first(func(p *entities.Payment) bool {
+		return p.ExternalID != nil && *p.ExternalID == externalID
+	}, externalID)
+}
+
+func (r *PaymentRepository) GetPaymentsByInvoice(ctx context.Context, invoiceID string) ([]*entities.Payment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	payments := r.payments.find(func(p *entities.Payment) bool {
+		return p.InvoiceID != nil && *p.InvoiceID == invoiceID
+	})
+	sortByTime(payments, paymentCreatedAt, false)
+	return payments, nil
+}
+
+func (r *PaymentRepository) GetPaymentsByClient(ctx context.Context, clientID string, limit, offset int) ([]*entities.Payment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	payments := r.payments.find(func(p *entities.Payment) bool { return p.ClientID == clientID })
+	sortByTime(payments, paymentCreatedAt, false)
+	return paginate(payments, offset, limit), nil
+}
+
+func (r *PaymentRepository) GetPaymentsByStatus(ctx context.Context, status entities.PaymentStatus, limit, offset int) ([]*entities.Payment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	payments := r.payments.find(func(p *entities.Payment) bool { return p.Status == status })
+	sortByTime(payments, paymentCreatedAt, false)
+	return paginate(payments, offset, limit), nil
+}
+
+// GetPendingRetries returns failed payments that may be retried and whose retry time has passed
+func (r *PaymentRepository) GetPendingRetries(ctx context.Context) ([]*entities.Payment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	now := time.Now()
+	payments := r.payments.find(func(p *entities.Payment) bool {
+		return p.CanRetry() && (p.NextRetryAt == nil || !p.NextRetryAt.After(now))
+	})
+	sortByTime(payments, paymentCreatedAt, true)
+	return payments, nil
+}
+
+func (r *PaymentRepository) UpdatePayment(ctx context.Context, payment *entities.Payment) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.payments.replace(payment.ID, payment)
+}
+
+func (r *PaymentRepository) UpdatePaymentStatus(ctx context.Context, id string, status entities.PaymentStatus) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.payments.modify(id, func(p *entities.Payment) {
+		p.Status = status
+		p.UpdatedAt = time.Now()
+	})
+}
+
+func (r *PaymentRepository) DeletePayment(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.payments.remove(id)
+}
+
+// Invoice operations
+
+func (r *PaymentRepository) CreateInvoice(ctx context.Context, invoice *entities.Invoice) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.invoices.insert(invoice.ID, invoice)
+}
+
+func (r *PaymentRepository) GetInvoice(ctx context.Context, id string) (*entities.Invoice, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.invoices.get(id)
+}
+
+func (r *PaymentRepository) GetInvoiceByNumber(ctx context.Context, invoiceNumber string) (*entities.Invoice, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.invoices.first(func(i *entities.Invoice) bool { return i.InvoiceNumber == invoiceNumber }, invoiceNumber)
+}
+
+func (r *PaymentRepository) GetInvoicesByClient(ctx context.Context, clientID string, limit, offset int) ([]*entities.Invoice, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	invoices := r.invoices.find(func(i *entities.Invoice) bool { return i.ClientID == clientID })
+	sortByTime(invoices, invoiceCreatedAt, false)
+	return paginate(invoices, offset, limit), nil
+}
+
+func (r *PaymentRepository) GetInvoicesByStatus(ctx context.Context, status entities.InvoiceStatus, limit, offset int) ([]*entities.Invoice, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	invoices := r.invoices.find(func(i *entities.Invoice) bool { return i.Status == status })
+	sortByTime(invoices, invoiceCreatedAt, false)
+	return paginate(invoices, offset, limit), nil
+}
+
+// GetOverdueInvoices returns unpaid invoices whose due date has passed
+func (r *PaymentRepository) GetOverdueInvoices(ctx context.Context) ([]*entities.Invoice, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	now := time.Now()
+	invoices := r.invoices.find(func(i *entities.Invoice) bool {
+		switch i.Status {
+		case entities.InvoiceStatusSent, entities.InvoiceStatusPartial, entities.InvoiceStatusOverdue:
+			return i.DueDate.Before(now)
+		}
+		return false
+	})
+	sortByTime(invoices, func(i *entities.Invoice) time.Time { return i.DueDate }, true)
+	return invoices, nil
+}
+
+func (r *PaymentRepository) UpdateInvoice(ctx context.Context, invoice *entities.Invoice) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.invoices.replace(invoice.ID, invoice)
+}
+
+func (r *PaymentRepository) UpdateInvoiceStatus(ctx context.Context, id string, status entities.InvoiceStatus) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.invoices.modify(id, func(i *entities.Invoice) {
+		i.Status = status
+		i.UpdatedAt = time.Now()
+	})
+}
+
+func (r *PaymentRepository) UpdateInvoicePaidAmount(ctx context.Context, id string, paidAmount int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.invoices.modify(id, func(i *entities.Invoice) {
+		i.PaidAmount = paidAmount
+		i.RemainingAmount = i.TotalAmount - paidAmount
+		i.UpdatedAt = time.Now()
+	})
+}
+
+func (r *PaymentRepository) DeleteInvoice(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.invoices.remove(id)
+}
+
+// Payment processor operations
+
+func (r *PaymentRepository) CreatePaymentProcessor(ctx context.Context, processor *entities.PaymentProcessor) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.processors.insert(processor.ID, processor)
+}
+
+func (r *PaymentRepository) GetPaymentProcessor(ctx context.Context, id string) (*entities.PaymentProcessor, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.processors.get(id)
+}
+
+// GetPaymentProcessorByType returns the highest-priority processor of the given type
+func (r *PaymentRepository) GetPaymentProcessorByType(ctx context.Context, processorType entities.PaymentMethod) (*entities.PaymentProcessor, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	processors := r.processors.find(func(p *entities.PaymentProcessor) bool { return p.Type == processorType })
+	if len(processors) == 0 {
+		return nil, repositories.NewNotFoundError("payment processor", processorType)
+	}
+	sortByPriority(processors)
+	return processors[0], nil
+}
+
+func (r *PaymentRepository) GetEnabledPaymentProcessors(ctx context.Context) ([]*entities.PaymentProcessor, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	processors := r.processors.find(func(p *entities.PaymentProcessor) bool { return p.Enabled })
+	sortByPriority(processors)
+	return processors, nil
+}
+
+func sortByPriority(processors []*entities.PaymentProcessor) {
+	sort.SliceStable(processors, func(i, j int) bool {
+		return processors[i].Priority > processors[j].Priority
+	})
+}
+
+func (r *PaymentRepository) UpdatePaymentProcessor(ctx context.Context, processor *entities.PaymentProcessor) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.processors.replace(processor.ID, processor)
+}
+
+func (r *PaymentRepository) DeletePaymentProcessor(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.processors.remove(id)
+}
+
+// Notification operations
+
+func (r *PaymentRepository) CreateNotification(ctx context.Context, notification *entities.PaymentNotification) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.notifications.insert(notification.ID, notification)
+}
+
+func (r *PaymentRepository) GetNotification(ctx context.Context, id string) (*entities.PaymentNotification, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.notifications.get(id)
+}
+
+func (r *PaymentRepository) GetNotificationsByPayment(ctx context.Context, paymentID string) ([]*entities.PaymentNotification, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	notifications := r.notifications.find(func(n *entities.PaymentNotification) bool { return n.PaymentID == paymentID })
+	sortByTime(notifications, func(n *entities.PaymentNotification) time.Time { return n.CreatedAt }, false)
+	return notifications, nil
+}
+
+func (r *PaymentRepository) GetPendingNotifications(ctx context.Context) ([]*entities.PaymentNotification, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	notifications := r.notifications.find(func(n *entities.PaymentNotification) bool {
+		return n.Status == entities.NotificationStatusPending
+	})
+	sortByTime(notifications, func(n *entities.PaymentNotification) time.Time { return n.CreatedAt }, true)
+	return notifications, nil
+}
+
+func (r *PaymentRepository) UpdateNotification(ctx context.Context, notification *entities.PaymentNotification) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.notifications.replace(notification.ID, notification)
+}
+
+func (r *PaymentRepository) DeleteNotification(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.notifications.remove(id)
+}
+
+// Receipt operations
+
+func (r *PaymentRepository) CreateReceipt(ctx context.Context, receipt *entities.PaymentReceipt) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.receipts.insert(receipt.ID, receipt)
+}
+
+func (r *PaymentRepository) GetReceipt(ctx context.Context, id string) (*entities.PaymentReceipt, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.receipts.get(id)
+}
+
+func (r *PaymentRepository) GetReceiptByPayment(ctx context.Context, paymentID string) (*entities.PaymentReceipt, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.receipts.first(func(rc *entities.PaymentReceipt) bool { return rc.PaymentID == paymentID }, paymentID)
+}
+
+// GetReceiptsByClient returns receipts for payments made by the client
+func (r *PaymentRepository) GetReceiptsByClient(ctx context.Context, clientID string, limit, offset int) ([]*entities.PaymentReceipt, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	receipts := r.receipts.find(func(rc *entities.PaymentReceipt) bool {
+		payment, ok := r.payments.rows[rc.PaymentID]
+		return ok && payment.ClientID == clientID
+	})
+	sortByTime(receipts, func(rc *entities.PaymentReceipt) time.Time { return rc.CreatedAt }, false)
+	return paginate(receipts, offset, limit), nil
+}
+
+func (r *PaymentRepository) UpdateReceipt(ctx context.Context, receipt *entities.PaymentReceipt) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.receipts.replace(receipt.ID, receipt)
+}
+
+func (r *PaymentRepository) DeleteReceipt(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.receipts.remove(id)
+}
+
+// Webhook operations
+
+func (r *PaymentRepository) CreateWebhook(ctx context.Context, webhook *entities.PaymentWebhook) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.webhooks.insert(webhook.ID, webhook)
+}
+
+func (r *PaymentRepository) GetWebhook(ctx context.Context, id string) (*entities.PaymentWebhook, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.webhooks.get(id)
+}
+
+func (r *PaymentRepository) GetUnprocessedWebhooks(ctx context.Context) ([]*entities.PaymentWebhook, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	webhooks := r.webhooks.find(func(w *entities.PaymentWebhook) bool { return !w.Processed })
+	sortByTime(webhooks, func(w *entities.PaymentWebhook) time.Time { return w.CreatedAt }, true)
+	return webhooks, nil
+}
+
+func (r *PaymentRepository) GetWebhooksBySource(ctx context.Context, source string, limit, offset int) ([]*entities.PaymentWebhook, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	webhooks := r.webhooks.find(func(w *entities.PaymentWebhook) bool { return w.Source == source })
+	sortByTime(webhooks, func(w *entities.PaymentWebhook) time.Time { return w.CreatedAt }, false)
+	return paginate(webhooks, offset, limit), nil
+}
+
+func (r *PaymentRepository) UpdateWebhook(ctx context.Context, webhook *entities.PaymentWebhook) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.webhooks.replace(webhook.ID, webhook)
+}
+
+func (r *PaymentRepository) DeleteWebhook(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.webhooks.remove(id)
+}
+
+// Refund operations
+
+func (r *PaymentRepository) CreateRefund(ctx context.Context, refund *entities.Refund) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.refunds.insert(refund.ID, refund)
+}
+
+func (r *PaymentRepository) GetRefund(ctx context.Context, id string) (*entities.Refund, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.refunds.get(id)
+}
+
+func (r *PaymentRepository) GetRefundsByPayment(ctx context.Context, paymentID string) ([]*entities.Refund, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	refunds := r.refunds.find(func(rf *entities.Refund) bool { return rf.PaymentID == paymentID })
+	sortByTime(refunds, func(rf *entities.Refund) time.Time { return rf.CreatedAt }, false)
+	return refunds, nil
+}
+
+func (r *PaymentRepository) GetRefundsByStatus(ctx context.Context, status entities.RefundStatus, limit, offset int) ([]*entities.Refund, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	refunds := r.refunds.find(func(rf *entities.Refund) bool { return rf.Status == status })
+	sortByTime(refunds, func(rf *entities.Refund) time.Time { return rf.CreatedAt }, false)
+	return paginate(refunds, offset, limit), nil
+}
+
+func (r *PaymentRepository) UpdateRefund(ctx context.Context, refund *entities.Refund) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.refunds.replace(refund.ID, refund)
+}
+
+func (r *PaymentRepository) DeleteRefund(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.refunds.remove(id)
+}
+
+// Analytics and reporting
+
+// paymentsBetween returns payments created in [startDate, endDate]; the caller holds the lock
+func (r *PaymentRepository) paymentsBetween(startDate, endDate time.Time) []*entities.Payment {
+	return filter(r.payments.rows, func(p *entities.Payment) bool {
+		return !p.CreatedAt.Before(startDate) && !p.CreatedAt.After(endDate)
+	})
+}
+
+func (r *PaymentRepository) GetPaymentStats(ctx context.Context, startDate, endDate time.Time) (*repositories.PaymentStats, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	stats := &repositories.PaymentStats{}
+	for _, payment := range r.paymentsBetween(startDate, endDate) {
+		stats.TotalPayments++
+		stats.TotalAmount += payment.Amount
+		stats.TotalFees += payment.ProcessorFee
+		stats.RefundedAmount += payment.RefundedAmount
+		switch payment.Status {
+		case entities.PaymentStatusCompleted:
+			stats.SuccessfulPayments++
+		case entities.PaymentStatusFailed:
+			stats.FailedPayments++
+		case entities.PaymentStatusRefunded:
+			stats.RefundedPayments++
+		}
+	}
+
+	if stats.TotalPayments > 0 {
+		stats.AverageAmount = float64(stats.TotalAmount) / float64(stats.TotalPayments)
+		stats.SuccessRate = float64(stats.SuccessfulPayments) / float64(stats.TotalPayments)
+	}
+	return stats, nil
+}
+
+// GetRevenueByPeriod sums completed payments into day, week, month or year buckets
+func (r *PaymentRepository) GetRevenueByPeriod(ctx context.Context, startDate, endDate time.Time, groupBy string) ([]*repositories.RevenueData, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	buckets := make(map[time.Time]*repositories.RevenueData)
+	for _, payment := range r.paymentsBetween(startDate, endDate) {
+		if payment.Status != entities.PaymentStatusCompleted {
+			continue
+		}
+		date := truncatePeriod(payment.CreatedAt, groupBy)
+		bucket, ok := buckets[date]
+		if !ok {
+			bucket = &repositories.RevenueData{Period: groupBy, Date: date}
+			buckets[date] = bucket
+		}
+		bucket.Amount += payment.Amount
+		bucket.Count++
+	}
+
+	result := make([]*repositories.RevenueData, 0, len(buckets))
+	for _, bucket := range buckets {
+		result = append(result, bucket)
+	}
+	sortByTime(result, func(d *repositories.RevenueData) time.Time { return d.Date }, true)
+	return result, nil
+}
+
+func truncatePeriod(t time.Time, groupBy string) time.Time {
+	year, month, day := t.Date()
+	switch groupBy {
+	case "year":
+		return time.Date(year, 1, 1, 0, 0, 0, 0, t.Location())
+	case "month":
+		return time.Date(year, month, 1, 0, 0, 0, 0, t.Location())
+	case "week":
+		start := time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+		return start.AddDate(0, 0, -int(start.Weekday()))
+	default:
+		return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+	}
+}
+
+func (r *PaymentRepository) GetPaymentMethodStats(ctx context.Context, startDate, endDate time.Time) ([]*repositories.PaymentMethodStats, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	byMethod := make(map[entities.PaymentMethod]*repositories.PaymentMethodStats)
+	successes := make(map[entities.PaymentMethod]int64)
+	for _, payment := range r.paymentsBetween(startDate, endDate) {
+		stats, ok := byMethod[payment.PaymentMethod]
+		if !ok {
+			stats = &repositories.PaymentMethodStats{PaymentMethod: payment.PaymentMethod}
+			byMethod[payment.PaymentMethod] = stats
+		}
+		stats.Count++
+		stats.Amount += payment.Amount
+		if payment.IsCompleted() {
+			successes[payment.PaymentMethod]++
+		}
+	}
+
+	result := make([]*repositories.PaymentMethodStats, 0, len(byMethod))
+	for method, stats := range byMethod {
+		stats.SuccessRate = float64(successes[method]) / float64(stats.Count)
+		stats.AverageAmount = float64(stats.Amount) / float64(stats.Count)
+		result = append(result, stats)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Count > result[j].Count })
+	return result, nil
+}
+
+func (r *PaymentRepository) GetFraudStats(ctx context.Context, startDate, endDate time.Time) (*repositories.FraudStats, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	stats := &repositories.FraudStats{TopFraudFlags: []string{}}
+	flagCounts := make(map[string]int)
+	scoreTotal, scored := 0.0, 0
+	for _, payment := range r.paymentsBetween(startDate, endDate) {
+		stats.TotalPayments++
+		if payment.FraudScore != nil {
+			scoreTotal += *payment.FraudScore
+			scored++
+		}
+		if len(payment.FraudFlags) > 0 {
+			stats.FlaggedPayments++
+			if payment.Status == entities.PaymentStatusCancelled || payment.Status == entities.PaymentStatusFailed {
+				stats.BlockedPayments++
+			} else if payment.IsCompleted() {
+				stats.FalsePositives++
+			}
+		}
+		for _, flag := range payment.FraudFlags {
+			flagCounts[flag]++
+		}
+	}
+
+	if stats.TotalPayments > 0 {
+		stats.FraudRate = float64(stats.FlaggedPayments) / float64(stats.TotalPayments)
+	}
+	if scored > 0 {
+		stats.AverageFraudScore = scoreTotal / float64(scored)
+	}
+
+	for flag := range flagCounts {
+		stats.TopFraudFlags = append(stats.TopFraudFlags, flag)
+	}
+	sort.Slice(stats.TopFraudFlags, func(i, j int) bool {
+		a, b := stats.TopFraudFlags[i], stats.TopFraudFlags[j]
+		if flagCounts[a] != flagCounts[b] {
+			return flagCounts[a] > flagCounts[b]
+		}
+		return a < b
+	})
+	if len(stats.TopFraudFlags) > 5 {
+		stats.TopFraudFlags = stats.TopFraudFlags[:5]
+	}
+	return stats, nil
+}
@@ -0,0 +1,1171 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Ceesaxp/autonomous-content-service/src/domain/entities"
+	"github.com/Ceesaxp/autonomous-content-service/src/domain/repositories"
+	"github.com/google/uuid"
+)
+
+// inTimeRange reports whether t falls within timeRange; a nil range matches everything
+func inTimeRange(t time.Time, timeRange *repositories.TimeRange) bool {
+	if timeRange == nil {
+		return true
+	}
+	return !t.Before(timeRange.Start) && !t.After(timeRange.End)
+}
+
+// PricingModelRepository implements the PricingModelRepository interface in memory
+type PricingModelRepository struct {
+	mu     sync.RWMutex
+	models *table[entities.PricingModel]
+	rules  *table[entities.ComplexityRule]
+}
+
+// NewPricingModelRepository creates a new in-memory pricing model repository
+func NewPricingModelRepository() repositories.PricingModelRepository {
+	return &PricingModelRepository{
+		models: newTable[entities.PricingModel]("pricing model"),
+		rules:  newTable[entities.ComplexityRule]("complexity rule"),
+	}
+}
+
+func (r *PricingModelRepository) CreatePricingModel(ctx context.Context, model *entities.PricingModel) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.models.insert(model.ID, model)
+}
+
+func (r *PricingModelRepository) GetPricingModel(ctx context.Context, id string) (*entities.PricingModel, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.models.get(id)
+}
+
+// GetPricingModelByContentType returns the most recently updated active model for the content type
+func (r *PricingModelRepository) GetPricingModelByContentType(ctx context.Context, contentType entities.ContentType) (*entities.PricingModel, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	models := r.models.find(func(m *entities.PricingModel) bool { return m.ContentType == contentType && m.IsActive })
+	if len(models) == 0 {
+		return nil, repositories.NewNotFoundError("pricing model", contentType)
+	}
+	sortByTime(models, func(m *entities.PricingModel) time.Time { return m.UpdatedAt }, false)
+	return models[0], nil
+}
+
+func (r *PricingModelRepository) UpdatePricingModel(ctx context.Context, model *entities.PricingModel) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.models.replace(model.ID, model)
+}
+
+func (r *PricingModelRepository) DeletePricingModel(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.models.remove(id)
+}
+
+func (r *PricingModelRepository) ListPricingModels(ctx context.Context, filter repositories.PricingModelFilter) ([]*entities.PricingModel, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	models := r.models.find(func(m *entities.PricingModel) bool {
+		return (filter.ContentType == nil || m.ContentType == *filter.ContentType) &&
+			(filter.IsActive == nil || m.IsActive == *filter.IsActive) &&
+			(filter.CreatedBy == nil || m.CreatedBy == *filter.CreatedBy) &&
+			inTimeRange(m.CreatedAt, filter.TimeRange)
+	})
+	sortByTime(models, func(m *entities.PricingModel) time.Time { return m.CreatedAt }, false)
+	return paginate(models, filter.Offset, filter.Limit), nil
+}
+
+func (r *PricingModelRepository) CreateComplexityRule(ctx context.Context, rule *entities.ComplexityRule) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rules.insert(rule.ID, rule)
+}
+
+func (r *PricingModelRepository) GetComplexityRule(ctx context.Context, id string) (*entities.ComplexityRule, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.rules.get(id)
+}
+
+func (r *PricingModelRepository) UpdateComplexityRule(ctx context.Context, rule *entities.ComplexityRule) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rules.replace(rule.ID, rule)
+}
+
+func (r *PricingModelRepository) DeleteComplexityRule(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rules.remove(id)
+}
+
+// ListComplexityRules filters rules by metric and active flag; rules carry no timestamps, so TimeRange is ignored
+func (r *PricingModelRepository) ListComplexityRules(ctx context.Context, filter repositories.ComplexityRuleFilter) ([]*entities.ComplexityRule, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	rules := r.rules.find(func(rule *entities.ComplexityRule) bool {
+		return (filter.Metric == nil || rule.Metric == *filter.Metric) &&
+			(filter.IsActive == nil || rule.IsActive == *filter.IsActive)
+	})
+	sort.Slice(rules, func(i, j int) bool { return rules[i].Name < rules[j].Name })
+	return paginate(rules, filter.Offset, filter.Limit), nil
+}
+
+// PriceQuoteRepository implements the PriceQuoteRepository interface in memory
+type PriceQuoteRepository struct {
+	mu     sync.RWMutex
+	quotes *table[entities.PriceQuote]
+}
+
+// NewPriceQuoteRepository creates a new in-memory price quote repository
+func NewPriceQuoteRepository() repositories.PriceQuoteRepository {
+	return &PriceQuoteRepository{quotes: newTable[entities.PriceQuote]("price quote")}
+}
+
+func quoteCreatedAt(q *entities.PriceQuote) time.Time { return q.CreatedAt }
+
+func (r *PriceQuoteRepository) CreatePriceQuote(ctx context.Context, quote *entities.PriceQuote) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.quotes.insert(quote.ID, quote)
+}
+
+func (r *PriceQuoteRepository) GetPriceQuote(ctx context.Context, id string) (*entities.PriceQuote, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.quotes.get(id)
+}
+
+func (r *PriceQuoteRepository) GetPriceQuotesByProject(ctx context.Context, projectID string) ([]*entities.PriceQuote, error) {
+	return r.ListPriceQuotes(ctx, repositories.PriceQuoteFilter{ProjectID: &projectID})
+}
+
+func (r *PriceQuoteRepository) GetPriceQuotesByClient(ctx context.Context, clientID string, filter repositories.PriceQuoteFilter) ([]*entities.PriceQuote, error) {
+	filter.ClientID = &clientID
+	return r.ListPriceQuotes(ctx, filter)
+}
+
+func (r *PriceQuoteRepository) UpdatePriceQuote(ctx context.Context, quote *entities.PriceQuote) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.quotes.replace(quote.ID, quote)
+}
+
+func (r *PriceQuoteRepository) DeletePriceQuote(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.quotes.remove(id)
+}
+
+func (r *PriceQuoteRepository) ListPriceQuotes(ctx context.Context, filter repositories.PriceQuoteFilter) ([]*entities.PriceQuote, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	quotes := r.quotes.find(func(q *entities.PriceQuote) bool {
+		return (filter.ProjectID == nil || q.ProjectID == *filter.ProjectID) &&
+			(filter.ClientID == nil || q.ClientID == *filter.ClientID) &&
+			(filter.ContentType == nil || q.ContentType == *filter.ContentType) &&
+			(filter.Status == nil || q.Status == *filter.Status) &&
+			(filter.PriceRange == nil || (q.FinalPrice >= filter.PriceRange.Min && q.FinalPrice <= filter.PriceRange.Max)) &&
+			inTimeRange(q.CreatedAt, filter.TimeRange)
+	})
+	sortByTime(quotes, quoteCreatedAt, false)
+	return paginate(quotes, filter.Offset, filter.Limit), nil
+}
+
+// GetQuoteAcceptanceRate returns accepted quotes as a fraction of quotes that reached a decision.
+// Quotes carry no client tier, so ClientTier is ignored.
+func (r *PriceQuoteRepository) GetQuoteAcceptanceRate(ctx context.Context, filter repositories.QuoteAnalyticsFilter) (float64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	accepted, decided := 0, 0
+	for _, quote := range r.quotes.rows {
+		if filter.ContentType != nil && quote.ContentType != *filter.ContentType {
+			continue
+		}
+		if !inTimeRange(quote.CreatedAt, filter.TimeRange) {
+			continue
+		}
+		switch quote.Status {
+		case entities.QuoteStatusAccepted:
+			accepted++
+			decided++
+		case entities.QuoteStatusRejected, entities.QuoteStatusExpired:
+			decided++
+		}
+	}
+
+	if decided == 0 {
+		return 0, nil
+	}
+	return float64(accepted) / float64(decided), nil
+}
+
+func (r *PriceQuoteRepository) GetAveragePriceByContentType(ctx context.Context, contentType entities.ContentType, timeRange repositories.TimeRange) (float64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	total, count := 0.0, 0
+	for _, quote := range r.quotes.rows {
+		if quote.ContentType == contentType && inTimeRange(quote.CreatedAt, &timeRange) {
+			total += quote.FinalPrice
+			count++
+		}
+	}
+	if count == 0 {
+		return 0, nil
+	}
+	return total / float64(count), nil
+}
+
+// GetPriceDistribution groups final prices into equal-width buckets labelled "min-max"
+func (r *PriceQuoteRepository) GetPriceDistribution(ctx context.Context, filter repositories.PriceDistributionFilter) (map[string]int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var prices []float64
+	for _, quote := range r.quotes.rows {
+		if filter.ContentType != nil && quote.ContentType != *filter.ContentType {
+			continue
+		}
+		if inTimeRange(quote.CreatedAt, filter.TimeRange) {
+			prices = append(prices, quote.FinalPrice)
+		}
+	}
+
+	distribution := make(map[string]int)
+	if len(prices) == 0 {
+		return distribution, nil
+	}
+
+	buckets := filter.Buckets
+	if buckets <= 0 {
+		buckets = 10
+	}
+	sort.Float64s(prices)
+	minPrice, maxPrice := prices[0], prices[len(prices)-1]
+	width := (maxPrice - minPrice) / float64(buckets)
+	if width == 0 {
+		distribution[fmt.Sprintf("%.2f-%.2f", minPrice, maxPrice)] = len(prices)
+		return distribution, nil
+	}
+
+	for _, price := range prices {
+		bucket := int((price - minPrice) / width)
+		if bucket >= buckets {
+			bucket = buckets - 1
+		}
+		lower := minPrice + float64(bucket)*width
+		distribution[fmt.Sprintf("%.2f-%.2f", lower, lower+width)]++
+	}
+	return distribution, nil
+}
+
+// MarketDataRepository implements the MarketDataRepository interface in memory.
+// Competitor analysis compares market data with quotes from the quote repository.
+type MarketDataRepository struct {
+	mu          sync.RWMutex
+	quoteRepo   repositories.PriceQuoteRepository
+	data        *table[entities.MarketData]
+	competitors map[string][]*entities.CompetitorPricing
+}
+
+// NewMarketDataRepository creates a new in-memory market data repository
+func NewMarketDataRepository(quoteRepo repositories.PriceQuoteRepository) repositories.MarketDataRepository {
+	return &MarketDataRepository{
+		quoteRepo:   quoteRepo,
+		data:        newTable[entities.MarketData]("market data"),
+		competitors: make(map[string][]*entities.CompetitorPricing),
+	}
+}
+
+func marketCollectedAt(d *entities.MarketData) time.Time { return d.CollectedAt }
+
+func (r *MarketDataRepository) CreateMarketData(ctx context.Context, data *entities.MarketData) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.data.insert(data.ID, data)
+}
+
+func (r *MarketDataRepository) GetMarketData(ctx context.Context, id string) (*entities.MarketData, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.data.get(id)
+}
+
+func (r *MarketDataRepository) GetLatestMarketData(ctx context.Context, contentType entities.ContentType, segment string) (*entities.MarketData, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	data := r.data.find(func(d *entities.MarketData) bool {
+		return d.ContentType == contentType && d.MarketSegment == segment
+	})
+	if len(data) == 0 {
+		return nil, repositories.NewNotFoundError("market data", fmt.Sprintf("%s/%s", contentType, segment))
+	}
+	sortByTime(data, marketCollectedAt, false)
+	return data[0], nil
+}
+
+func (r *MarketDataRepository) UpdateMarketData(ctx context.Context, data *entities.MarketData) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.data.replace(data.ID, data)
+}
+
+func (r *MarketDataRepository) DeleteMarketData(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.data.remove(id)
+}
+
+func (r *MarketDataRepository) ListMarketData(ctx context.Context, filter repositories.MarketDataFilter) ([]*entities.MarketData, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	data := r.data.find(func(d *entities.MarketData) bool {
+		return (filter.ContentType == nil || d.ContentType == *filter.ContentType) &&
+			(filter.MarketSegment == nil || d.MarketSegment == *filter.MarketSegment) &&
+			(filter.DemandLevel == nil || d.DemandLevel == *filter.DemandLevel) &&
+			(filter.TrendDirection == nil || d.TrendDirection == *filter.TrendDirection) &&
+			(filter.ConfidenceRange == nil || (d.ConfidenceScore >= filter.ConfidenceRange.Min && d.ConfidenceScore <= filter.ConfidenceRange.Max)) &&
+			inTimeRange(d.CollectedAt, filter.TimeRange)
+	})
+	sortByTime(data, marketCollectedAt, false)
+	return paginate(data, filter.Offset, filter.Limit), nil
+}
+
+// CreateCompetitorPricing records a competitor price observation. Observations
+// have no ID of their own, so the collection timestamp identifies them.
+func (r *MarketDataRepository) CreateCompetitorPricing(ctx context.Context, pricing *entities.CompetitorPricing) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	copied := *pricing
+	r.competitors[pricing.CompetitorID] = append(r.competitors[pricing.CompetitorID], &copied)
+	return nil
+}
+
+// GetCompetitorPricing returns a competitor's observations. Observations are not
+// tagged with a content type, so every observation for the competitor is returned.
+func (r *MarketDataRepository) GetCompetitorPricing(ctx context.Context, competitorID string, contentType entities.ContentType) ([]*entities.CompetitorPricing, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	pricing := copyAll(r.competitors[competitorID])
+	sortByTime(pricing, func(p *entities.CompetitorPricing) time.Time { return p.CollectedAt }, false)
+	return pricing, nil
+}
+
+func (r *MarketDataRepository) UpdateCompetitorPricing(ctx context.Context, pricing *entities.CompetitorPricing) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, existing := range r.competitors[pricing.CompetitorID] {
+		if existing.CollectedAt.Equal(pricing.CollectedAt) {
+			copied := *pricing
+			r.competitors[pricing.CompetitorID][i] = &copied
+			return nil
+		}
+	}
+	return repositories.NewNotFoundError("competitor pricing", pricing.CompetitorID)
+}
+
+// DeleteCompetitorPricing removes the observation whose RFC 3339 collection time matches id
+func (r *MarketDataRepository) DeleteCompetitorPricing(ctx context.Context, competitorID string, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	observations := r.competitors[competitorID]
+	for i, existing := range observations {
+		if existing.CollectedAt.Format(time.RFC3339Nano) == id {
+			r.competitors[competitorID] = append(observations[:i], observations[i+1:]...)
+			return nil
+		}
+	}
+	return repositories.NewNotFoundError("competitor pricing", id)
+}
+
+func (r *MarketDataRepository) GetMarketTrends(ctx context.Context, contentType entities.ContentType, timeRange repositories.TimeRange) ([]*entities.MarketData, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	data := r.data.find(func(d *entities.MarketData) bool {
+		return d.ContentType == contentType && inTimeRange(d.CollectedAt, &timeRange)
+	})
+	sortByTime(data, marketCollectedAt, true)
+	return data, nil
+}
+
+func (r *MarketDataRepository) GetCompetitorAnalysis(ctx context.Context, filter repositories.CompetitorAnalysisFilter) (*repositories.CompetitorAnalysisResult, error) {
+	quotes, err := r.quoteRepo.ListPriceQuotes(ctx, repositories.PriceQuoteFilter{
+		ContentType: filter.ContentType,
+		TimeRange:   filter.TimeRange,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list price quotes: %w", err)
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	wanted := make(map[string]bool, len(filter.CompetitorIDs))
+	for _, id := range filter.CompetitorIDs {
+		wanted[id] = true
+	}
+
+	var prices []float64
+	competitors := make(map[string]bool)
+	for _, data := range r.data.rows {
+		if filter.ContentType != nil && data.ContentType != *filter.ContentType {
+			continue
+		}
+		if !inTimeRange(data.CollectedAt, filter.TimeRange) {
+			continue
+		}
+		for _, competitor := range data.CompetitorData {
+			if len(wanted) > 0 && !wanted[competitor.CompetitorID] {
+				continue
+			}
+			prices = append(prices, competitor.Price)
+			competitors[competitor.CompetitorID] = true
+		}
+	}
+
+	result := &repositories.CompetitorAnalysisResult{
+		CompetitorCount: len(competitors),
+		PricePosition:   "market_rate",
+		AnalyzedAt:      time.Now(),
+	}
+	if filter.ContentType != nil {
+		result.ContentType = *filter.ContentType
+	}
+
+	if len(quotes) > 0 {
+		total := 0.0
+		for _, quote := range quotes {
+			total += quote.FinalPrice
+		}
+		result.OurAveragePrice = total / float64(len(quotes))
+	}
+	if len(prices) == 0 {
+		result.Recommendation = "Insufficient competitor data for analysis"
+		return result, nil
+	}
+
+	sort.Float64s(prices)
+	total := 0.0
+	for _, price := range prices {
+		total += price
+	}
+	result.MarketAveragePrice = total / float64(len(prices))
+	result.PriceGap = result.OurAveragePrice - result.MarketAveragePrice
+
+	switch {
+	case result.OurAveragePrice <= prices[0]:
+		result.PricePosition = "lowest"
+		result.Recommendation = "Prices are at the bottom of the market; consider raising them"
+	case result.OurAveragePrice >= prices[len(prices)-1]:
+		result.PricePosition = "highest"
+		result.Recommendation = "Prices are at the top of the market; ensure quality justifies the premium"
+	case result.PriceGap < -0.05*result.MarketAveragePrice:
+		result.PricePosition = "below_market"
+		result.Recommendation = "Prices are below market rate; there is room to increase them"
+	case result.PriceGap > 0.05*result.MarketAveragePrice:
+		result.PricePosition = "above_market"
+		result.Recommendation = "Prices are above market rate; monitor acceptance closely"
+	default:
+		result.Recommendation = "Prices are in line with the market"
+	}
+	return result, nil
+}
+
+// GetPriceElasticity estimates elasticity from the change in sample size relative to
+// the change in average price between the oldest and newest market data points
+func (r *MarketDataRepository) GetPriceElasticity(ctx context.Context, contentType entities.ContentType, timeRange repositories.TimeRange) (*repositories.PriceElasticityResult, error) {
+	trends, err := r.GetMarketTrends(ctx, contentType, timeRange)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &repositories.PriceElasticityResult{
+		ContentType: contentType,
+		DataPoints:  len(trends),
+		AnalyzedAt:  time.Now(),
+	}
+	if len(trends) < 2 {
+		if len(trends) == 1 {
+			result.OptimalPrice = trends[0].AveragePrice
+		}
+		return result, nil
+	}
+
+	first, last := trends[0], trends[len(trends)-1]
+	result.OptimalPrice = last.AveragePrice
+	if first.AveragePrice > 0 && first.SampleSize > 0 {
+		priceChange := (last.AveragePrice - first.AveragePrice) / first.AveragePrice
+		demandChange := float64(last.SampleSize-first.SampleSize) / float64(first.SampleSize)
+		if priceChange != 0 {
+			result.ElasticityScore = demandChange / priceChange
+			result.RevenueImpact = (1+priceChange)*(1+demandChange) - 1
+		}
+	}
+	result.ConfidenceLevel = math.Min(1, float64(len(trends))/10)
+	return result, nil
+}
+
+// ClientPricingRepository implements the ClientPricingRepository interface in memory.
+// Volume discounts and metrics live on the client's pricing profile.
+type ClientPricingRepository struct {
+	mu          sync.RWMutex
+	profiles    *table[entities.ClientPricingProfile]
+	offers      *table[entities.SpecialOffer]
+	offerClient map[string]string
+}
+
+// NewClientPricingRepository creates a new in-memory client pricing repository
+func NewClientPricingRepository() repositories.ClientPricingRepository {
+	return &ClientPricingRepository{
+		profiles:    newTable[entities.ClientPricingProfile]("client pricing profile"),
+		offers:      newTable[entities.SpecialOffer]("special offer"),
+		offerClient: make(map[string]string),
+	}
+}
+
+// profileFor returns the stored profile for clientID; the caller holds the lock
+func (r *ClientPricingRepository) profileFor(clientID string) (*entities.ClientPricingProfile, error) {
+	for _, profile := range r.profiles.rows {
+		if profile.ClientID == clientID {
+			return profile, nil
+		}
+	}
+	return nil, repositories.NewNotFoundError("client pricing profile", clientID)
+}
+
+func (r *ClientPricingRepository) CreateClientPricingProfile(ctx context.Context, profile *entities.ClientPricingProfile) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, err := r.profileFor(profile.ClientID); err == nil {
+		return fmt.Errorf("client %s already has a pricing profile", profile.ClientID)
+	}
+	return r.profiles.insert(profile.ID, profile)
+}
+
+func (r *ClientPricingRepository) GetClientPricingProfile(ctx context.Context, id string) (*entities.ClientPricingProfile, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.profiles.get(id)
+}
+
+func (r *ClientPricingRepository) GetClientPricingProfileByClient(ctx context.Context, clientID string) (*entities.ClientPricingProfile, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	profile, err := r.profileFor(clientID)
+	if err != nil {
+		return nil, err
+	}
+	copied := *profile
+	return &copied, nil
+}
+
+func (r *ClientPricingRepository) UpdateClientPricingProfile(ctx context.Context, profile *entities.ClientPricingProfile) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.profiles.replace(profile.ID, profile)
+}
+
+func (r *ClientPricingRepository) DeleteClientPricingProfile(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.profiles.remove(id)
+}
+
+func (r *ClientPricingRepository) ListClientPricingProfiles(ctx context.Context, filter repositories.ClientPricingFilter) ([]*entities.ClientPricingProfile, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	profiles := r.profiles.find(func(p *entities.ClientPricingProfile) bool {
+		return (filter.ClientID == nil || p.ClientID == *filter.ClientID) &&
+			(filter.Tier == nil || p.Tier == *filter.Tier) &&
+			(filter.RiskLevel == nil || p.RiskLevel == *filter.RiskLevel) &&
+			(filter.IsActive == nil || p.IsActive == *filter.IsActive) &&
+			inTimeRange(p.CreatedAt, filter.TimeRange)
+	})
+	sortByTime(profiles, func(p *entities.ClientPricingProfile) time.Time { return p.CreatedAt }, false)
+	return paginate(profiles, filter.Offset, filter.Limit), nil
+}
+
+func (r *ClientPricingRepository) CreateVolumeDiscount(ctx context.Context, discount *entities.VolumeDiscount, clientID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	profile, err := r.profileFor(clientID)
+	if err != nil {
+		return err
+	}
+	for _, existing := range profile.VolumeDiscounts {
+		if existing.MinVolume == discount.MinVolume {
+			return fmt.Errorf("volume discount for %d units already exists", discount.MinVolume)
+		}
+	}
+	profile.VolumeDiscounts = append(append([]entities.VolumeDiscount(nil), profile.VolumeDiscounts...), *discount)
+	profile.UpdatedAt = time.Now()
+	return nil
+}
+
+func (r *ClientPricingRepository) UpdateVolumeDiscount(ctx context.Context, discount *entities.VolumeDiscount, clientID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	profile, err := r.profileFor(clientID)
+	if err != nil {
+		return err
+	}
+	for i, existing := range profile.VolumeDiscounts {
+		if existing.MinVolume == discount.MinVolume {
+			discounts := append([]entities.VolumeDiscount(nil), profile.VolumeDiscounts...)
+			discounts[i] = *discount
+			profile.VolumeDiscounts = discounts
+			profile.UpdatedAt = time.Now()
+			return nil
+		}
+	}
+	return repositories.NewNotFoundError("volume discount", discount.MinVolume)
+}
+
+func (r *ClientPricingRepository) DeleteVolumeDiscount(ctx context.Context, clientID string, minVolume int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	profile, err := r.profileFor(clientID)
+	if err != nil {
+		return err
+	}
+	for i, existing := range profile.VolumeDiscounts {
+		if existing.MinVolume == minVolume {
+			discounts := append([]entities.VolumeDiscount(nil), profile.VolumeDiscounts[:i]...)
+			profile.VolumeDiscounts = append(discounts, profile.VolumeDiscounts[i+1:]...)
+			profile.UpdatedAt = time.Now()
+			return nil
+		}
+	}
+	return repositories.NewNotFoundError("volume discount", minVolume)
+}
+
+func (r *ClientPricingRepository) CreateSpecialOffer(ctx context.Context, offer *entities.SpecialOffer, clientID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.offers.insert(offer.ID, offer); err != nil {
+		return err
+	}
+	r.offerClient[offer.ID] = clientID
+	return nil
+}
+
+func (r *ClientPricingRepository) GetSpecialOffer(ctx context.Context, id string) (*entities.SpecialOffer, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.offers.get(id)
+}
+
+func (r *ClientPricingRepository) UpdateSpecialOffer(ctx context.Context, offer *entities.SpecialOffer) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.offers.replace(offer.ID, offer)
+}
+
+func (r *ClientPricingRepository) DeleteSpecialOffer(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.offers.remove(id); err != nil {
+		return err
+	}
+	delete(r.offerClient, id)
+	return nil
+}
+
+// GetActiveSpecialOffers returns the client's offers that are active, in their validity window and under their usage limit
+func (r *ClientPricingRepository) GetActiveSpecialOffers(ctx context.Context, clientID string) ([]*entities.SpecialOffer, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	now := time.Now()
+	offers := r.offers.find(func(o *entities.SpecialOffer) bool {
+		return r.offerClient[o.ID] == clientID && o.IsActive &&
+			!now.Before(o.ValidFrom) && !now.After(o.ValidUntil) &&
+			(o.UsageLimit == nil || o.UsageCount < *o.UsageLimit)
+	})
+	sortByTime(offers, func(o *entities.SpecialOffer) time.Time { return o.ValidUntil }, true)
+	return offers, nil
+}
+
+// GetClientPricingMetrics returns the metrics stored on the client's profile; they are not windowed by timeRange
+func (r *ClientPricingRepository) GetClientPricingMetrics(ctx context.Context, clientID string, timeRange repositories.TimeRange) (*entities.ClientPricingMetrics, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	profile, err := r.profileFor(clientID)
+	if err != nil {
+		return nil, err
+	}
+	metrics := profile.Metrics
+	return &metrics, nil
+}
+
+func (r *ClientPricingRepository) UpdateClientPricingMetrics(ctx context.Context, clientID string, metrics *entities.ClientPricingMetrics) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	profile, err := r.profileFor(clientID)
+	if err != nil {
+		return err
+	}
+	profile.Metrics = *metrics
+	profile.UpdatedAt = time.Now()
+	return nil
+}
+
+func (r *ClientPricingRepository) GetClientChurnRisk(ctx context.Context, clientID string) (float64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	profile, err := r.profileFor(clientID)
+	if err != nil {
+		return 0, err
+	}
+	return profile.Metrics.ChurnRisk, nil
+}
+
+func (r *ClientPricingRepository) GetClientLifetimeValue(ctx context.Context, clientID string) (float64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	profile, err := r.profileFor(clientID)
+	if err != nil {
+		return 0, err
+	}
+	return profile.Metrics.LifetimeValue, nil
+}
+
+// CostModelRepository implements the CostModelRepository interface in memory.
+// Profitability reports take revenue from accepted quotes in the quote repository.
+type CostModelRepository struct {
+	mu        sync.RWMutex
+	quoteRepo repositories.PriceQuoteRepository
+	models    *table[entities.CostModel]
+	usage     []*repositories.ResourceUsageRecord
+}
+
+// NewCostModelRepository creates a new in-memory cost model repository
+func NewCostModelRepository(quoteRepo repositories.PriceQuoteRepository) repositories.CostModelRepository {
+	return &CostModelRepository{
+		quoteRepo: quoteRepo,
+		models:    newTable[entities.CostModel]("cost model"),
+	}
+}
+
+func (r *CostModelRepository) CreateCostModel(ctx context.Context, model *entities.CostModel) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.models.insert(model.ID, model)
+}
+
+func (r *CostModelRepository) GetCostModel(ctx context.Context, id string) (*entities.CostModel, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.models.get(id)
+}
+
+// GetCostModelByContentType returns the most recently updated active cost model for the content type
+func (r *CostModelRepository) GetCostModelByContentType(ctx context.Context, contentType entities.ContentType) (*entities.CostModel, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	models := r.models.find(func(m *entities.CostModel) bool { return m.ContentType == contentType && m.IsActive })
+	if len(models) == 0 {
+		return nil, repositories.NewNotFoundError("cost model", contentType)
+	}
+	sortByTime(models, func(m *entities.CostModel) time.Time { return m.UpdatedAt }, false)
+	return models[0], nil
+}
+
+func (r *CostModelRepository) UpdateCostModel(ctx context.Context, model *entities.CostModel) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.models.replace(model.ID, model)
+}
+
+func (r *CostModelRepository) DeleteCostModel(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.models.remove(id)
+}
+
+func (r *CostModelRepository) ListCostModels(ctx context.Context, filter repositories.CostModelFilter) ([]*entities.CostModel, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	models := r.models.find(func(m *entities.CostModel) bool {
+		return (filter.ContentType == nil || m.ContentType == *filter.ContentType) &&
+			(filter.IsActive == nil || m.IsActive == *filter.IsActive) &&
+			inTimeRange(m.CreatedAt, filter.TimeRange)
+	})
+	sortByTime(models, func(m *entities.CostModel) time.Time { return m.CreatedAt }, false)
+	return paginate(models, filter.Offset, filter.Limit), nil
+}
+
+func (r *CostModelRepository) RecordResourceUsage(ctx context.Context, usage *repositories.ResourceUsageRecord) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	copied := *usage
+	if copied.ID == "" {
+		copied.ID = uuid.New().String()
+	}
+	if copied.RecordedAt.IsZero() {
+		copied.RecordedAt = time.Now()
+	}
+	copied.Metadata = copyMetadata(usage.Metadata)
+	r.usage = append(r.usage, &copied)
+	return nil
+}
+
+// usageMatching returns usage records matching the filter fields; the caller holds the lock
+func (r *CostModelRepository) usageMatching(contentType *entities.ContentType, projectID, resourceType *string, timeRange *repositories.TimeRange) []*repositories.ResourceUsageRecord {
+	var records []*repositories.ResourceUsageRecord
+	for _, record := range r.usage {
+		if (contentType == nil || record.ContentType == *contentType) &&
+			(projectID == nil || record.ProjectID == *projectID) &&
+			(resourceType == nil || record.ResourceType == *resourceType) &&
+			inTimeRange(record.RecordedAt, timeRange) {
+			records = append(records, record)
+		}
+	}
+	return records
+}
+
+func (r *CostModelRepository) GetResourceUsage(ctx context.Context, filter repositories.ResourceUsageFilter) ([]*repositories.ResourceUsageRecord, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	records := copyAll(r.usageMatching(filter.ContentType, filter.ProjectID, filter.ResourceType, filter.TimeRange))
+	sortByTime(records, func(u *repositories.ResourceUsageRecord) time.Time { return u.RecordedAt }, false)
+	return paginate(records, filter.Offset, filter.Limit), nil
+}
+
+func (r *CostModelRepository) GetCostAnalysis(ctx context.Context, filter repositories.CostAnalysisFilter) (*repositories.CostAnalysisResult, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	records := r.usageMatching(filter.ContentType, nil, nil, filter.TimeRange)
+	result := &repositories.CostAnalysisResult{
+		CostByType:      make(map[string]float64),
+		CostTrend:       "stable",
+		Recommendations: []string{},
+		AnalyzedAt:      time.Now(),
+	}
+
+	for _, record := range records {
+		result.TotalCost += record.Cost
+		result.CostByType[costGroup(record, filter.GroupBy)] += record.Cost
+	}
+	if len(records) == 0 {
+		return result, nil
+	}
+	result.AverageCost = result.TotalCost / float64(len(records))
+
+	// Compare the average cost of the older and newer halves of the window
+	sorted := append([]*repositories.ResourceUsageRecord(nil), records...)
+	sortByTime(sorted, func(u *repositories.ResourceUsageRecord) time.Time { return u.RecordedAt }, true)
+	result.EfficiencyScore = 1
+	if half := len(sorted) / 2; half > 0 {
+		early, late := averageCost(sorted[:half]), averageCost(sorted[half:])
+		if late > 0 {
+			result.EfficiencyScore = math.Min(1, early/late)
+		}
+		switch {
+		case late > early*1.1:
+			result.CostTrend = "increasing"
+			result.Recommendations = append(result.Recommendations, "Costs per operation are rising; review model selection and caching")
+		case late < early*0.9:
+			result.CostTrend = "decreasing"
+		}
+	}
+	return result, nil
+}
+
+func averageCost(records []*repositories.ResourceUsageRecord) float64 {
+	total := 0.0
+	for _, record := range records {
+		total += record.Cost
+	}
+	return total / float64(len(records))
+}
+
+func costGroup(record *repositories.ResourceUsageRecord, groupBy string) string {
+	switch groupBy {
+	case "content_type":
+		return string(record.ContentType)
+	case "project":
+		return record.ProjectID
+	case "client":
+		if clientID, ok := record.Metadata["client_id"].(string); ok {
+			return clientID
+		}
+		return "unknown"
+	default:
+		return record.ResourceType
+	}
+}
+
+func (r *CostModelRepository) GetProfitabilityReport(ctx context.Context, filter repositories.ProfitabilityFilter) (*repositories.ProfitabilityResult, error) {
+	accepted := entities.QuoteStatusAccepted
+	quotes, err := r.quoteRepo.ListPriceQuotes(ctx, repositories.PriceQuoteFilter{
+		ClientID:    filter.ClientID,
+		ContentType: filter.ContentType,
+		Status:      &accepted,
+		TimeRange:   filter.TimeRange,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list price quotes: %w", err)
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := &repositories.ProfitabilityResult{
+		ProfitBySegment: make(map[string]float64),
+		AnalyzedAt:      time.Now(),
+	}
+
+	projects := make(map[string]*entities.PriceQuote)
+	for _, quote := range quotes {
+		result.TotalRevenue += quote.FinalPrice
+		result.ProfitBySegment[quoteSegment(quote, filter.GroupBy)] += quote.FinalPrice
+		projects[quote.ProjectID] = quote
+	}
+
+	for _, record := range r.usageMatching(filter.ContentType, nil, nil, filter.TimeRange) {
+		quote, ok := projects[record.ProjectID]
+		if filter.ClientID != nil && !ok {
+			continue
+		}
+		result.TotalCost += record.Cost
+		segment := costGroup(record, filter.GroupBy)
+		if ok {
+			segment = quoteSegment(quote, filter.GroupBy)
+		}
+		result.ProfitBySegment[segment] -= record.Cost
+	}
+
+	result.GrossProfit = result.TotalRevenue - result.TotalCost
+	if result.TotalRevenue > 0 {
+		result.ProfitMargin = result.GrossProfit / result.TotalRevenue
+	}
+	if result.TotalCost > 0 {
+		result.ROI = result.GrossProfit / result.TotalCost
+	}
+	if len(quotes) > 0 {
+		averagePrice := result.TotalRevenue / float64(len(quotes))
+		if averagePrice > 0 {
+			result.BreakevenPoint = result.TotalCost / averagePrice
+		}
+	}
+	return result, nil
+}
+
+func quoteSegment(quote *entities.PriceQuote, groupBy string) string {
+	switch groupBy {
+	case "client":
+		return quote.ClientID
+	case "project":
+		return quote.ProjectID
+	default:
+		return string(quote.ContentType)
+	}
+}
+
+// PricingExperimentRepository implements the PricingExperimentRepository interface in memory.
+// Variants are stored on their experiment.
+type PricingExperimentRepository struct {
+	mu          sync.RWMutex
+	experiments *table[entities.PricingExperiment]
+	events      []*repositories.ExperimentEvent
+}
+
+// NewPricingExperimentRepository creates a new in-memory pricing experiment repository
+func NewPricingExperimentRepository() repositories.PricingExperimentRepository {
+	return &PricingExperimentRepository{experiments: newTable[entities.PricingExperiment]("pricing experiment")}
+}
+
+// targetsContentType reports whether the experiment's segment includes contentType;
+// experiments without a content_type segment apply to every content type
+func targetsContentType(experiment *entities.PricingExperiment, contentType entities.ContentType) bool {
+	target, ok := experiment.TargetSegment["content_type"]
+	if !ok {
+		return true
+	}
+	switch value := target.(type) {
+	case string:
+		return value == string(contentType)
+	case entities.ContentType:
+		return value == contentType
+	case []interface{}:
+		for _, item := range value {
+			if fmt.Sprint(item) == string(contentType) {
+				return true
+			}
+		}
+	case []string:
+		for _, item := range value {
+			if item == string(contentType) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (r *PricingExperimentRepository) CreatePricingExperiment(ctx context.Context, experiment *entities.PricingExperiment) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.experiments.insert(experiment.ID, experiment)
+}
+
+func (r *PricingExperimentRepository) GetPricingExperiment(ctx context.Context, id string) (*entities.PricingExperiment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.experiments.get(id)
+}
+
+func (r *PricingExperimentRepository) UpdatePricingExperiment(ctx context.Context, experiment *entities.PricingExperiment) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.experiments.replace(experiment.ID, experiment)
+}
+
+func (r *PricingExperimentRepository) DeletePricingExperiment(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.experiments.remove(id)
+}
+
+func (r *PricingExperimentRepository) ListPricingExperiments(ctx context.Context, filter repositories.ExperimentFilter) ([]*entities.PricingExperiment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	experiments := r.experiments.find(func(e *entities.PricingExperiment) bool {
+		return (filter.ContentType == nil || targetsContentType(e, *filter.ContentType)) &&
+			(filter.Status == nil || e.Status == *filter.Status) &&
+			(filter.CreatedBy == nil || e.CreatedBy == *filter.CreatedBy) &&
+			inTimeRange(e.CreatedAt, filter.TimeRange)
+	})
+	sortByTime(experiments, func(e *entities.PricingExperiment) time.Time { return e.CreatedAt }, false)
+	return paginate(experiments, filter.Offset, filter.Limit), nil
+}
+
+func (r *PricingExperimentRepository) CreatePricingVariant(ctx context.Context, variant *entities.PricingVariant, experimentID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.experiments.modify(experimentID, func(e *entities.PricingExperiment) {
+		e.Variants = append(append([]entities.PricingVariant(nil), e.Variants...), *variant)
+		e.UpdatedAt = time.Now()
+	})
+}
+
+// updateVariant applies fn to the variants of the experiment holding variantID; the caller holds the lock
+func (r *PricingExperimentRepository) updateVariant(variantID string, fn func(variants []entities.PricingVariant, i int) []entities.PricingVariant) error {
+	for _, experiment := range r.experiments.rows {
+		for i, variant := range experiment.Variants {
+			if variant.ID == variantID {
+				experiment.Variants = fn(append([]entities.PricingVariant(nil), experiment.Variants...), i)
+				experiment.UpdatedAt = time.Now()
+				return nil
+			}
+		}
+	}
+	return repositories.NewNotFoundError("pricing variant", variantID)
+}
+
+func (r *PricingExperimentRepository) UpdatePricingVariant(ctx context.Context, variant *entities.PricingVariant) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.updateVariant(variant.ID, func(variants []entities.PricingVariant, i int) []entities.PricingVariant {
+		variants[i] = *variant
+		return variants
+	})
+}
+
+func (r *PricingExperimentRepository) DeletePricingVariant(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.updateVariant(id, func(variants []entities.PricingVariant, i int) []entities.PricingVariant {
+		return append(variants[:i], variants[i+1:]...)
+	})
+}
+
+func (r *PricingExperimentRepository) RecordExperimentEvent(ctx context.Context, event *repositories.ExperimentEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.experiments.rows[event.ExperimentID]; !ok {
+		return repositories.NewNotFoundError("pricing experiment", event.ExperimentID)
+	}
+	copied := *event
+	if copied.ID == "" {
+		copied.ID = uuid.New().String()
+	}
+	if copied.RecordedAt.IsZero() {
+		copied.RecordedAt = time.Now()
+	}
+	copied.Metadata = copyMetadata(event.Metadata)
+	r.events = append(r.events, &copied)
+	return nil
+}
+
+// GetExperimentMetrics derives variant metrics from the recorded quote events
+func (r *PricingExperimentRepository) GetExperimentMetrics(ctx context.Context, experimentID string, variantID string) (*entities.VariantMetrics, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if _, ok := r.experiments.rows[experimentID]; !ok {
+		return nil, repositories.NewNotFoundError("pricing experiment", experimentID)
+	}
+
+	metrics := &entities.VariantMetrics{}
+	priceTotal, sent := 0.0, 0
+	for _, event := range r.events {
+		if event.ExperimentID != experimentID || event.VariantID != variantID {
+			continue
+		}
+		switch event.EventType {
+		case "quote_generated":
+			metrics.QuotesGenerated++
+			priceTotal += event.Price
+		case "quote_sent":
+			sent++
+		case "quote_accepted":
+			metrics.QuotesAccepted++
+			metrics.TotalRevenue += event.Price
+		}
+	}
+
+	if metrics.QuotesGenerated > 0 {
+		metrics.AveragePrice = priceTotal / float64(metrics.QuotesGenerated)
+		metrics.AcceptanceRate = float64(metrics.QuotesAccepted) / float64(metrics.QuotesGenerated)
+	}
+	if sent > 0 {
+		metrics.ConversionRate = float64(metrics.QuotesAccepted) / float64(sent)
+	}
+	return metrics, nil
+}
+
+func (r *PricingExperimentRepository) UpdateExperimentResults(ctx context.Context, experimentID string, results *entities.ExperimentResults) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.experiments.modify(experimentID, func(e *entities.PricingExperiment) {
+		copied := *results
+		e.Results = &copied
+		e.UpdatedAt = time.Now()
+	})
+}
+
+// GetActiveExperiments returns running experiments that target the content type
+func (r *PricingExperimentRepository) GetActiveExperiments(ctx context.Context, contentType entities.ContentType) ([]*entities.PricingExperiment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	now := time.Now()
+	experiments := r.experiments.find(func(e *entities.PricingExperiment) bool {
+		return e.Status == entities.ExperimentStatusActive && targetsContentType(e, contentType) &&
+			!now.Before(e.StartDate) && (e.EndDate.IsZero() || now.Before(e.EndDate))
+	})
+	sortByTime(experiments, func(e *entities.PricingExperiment) time.Time { return e.StartDate }, true)
+	return experiments, nil
+}
@@ -0,0 +1,125 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/Ceesaxp/autonomous-content-service/src/domain/entities"
+	"github.com/Ceesaxp/autonomous-content-service/src/domain/repositories"
+	"github.com/google/uuid"
+)
+
+// storedProjectContext is a project context with its entries in the order they were added
+type storedProjectContext struct {
+	context *entities.ProjectContext
+	entries []*entities.ProjectContextEntry
+}
+
+// ProjectContextRepository implements the ProjectContextRepository interface in memory
+type ProjectContextRepository struct {
+	mu       sync.Mutex
+	contexts map[uuid.UUID]*storedProjectContext
+}
+
+// NewProjectContextRepository creates a new in-memory project context repository
+func NewProjectContextRepository() repositories.ProjectContextRepository {
+	return &ProjectContextRepository{contexts: make(map[uuid.UUID]*storedProjectContext)}
+}
+
+func copyProjectContext(projectContext *entities.ProjectContext) *entities.ProjectContext {
+	copied := *projectContext
+	copied.DomainKnowledge = copyMetadata(projectContext.DomainKnowledge)
+	copied.Compactions = append([]entities.ContextCompaction(nil), projectContext.Compactions...)
+	return &copied
+}
+
+func copyProjectContextEntries(entries []*entities.ProjectContextEntry) []*entities.ProjectContextEntry {
+	copied := make([]*entities.ProjectContextEntry, len(entries))
+	for i, entry := range entries {
+		entryCopy := *entry
+		entryCopy.Metadata = copyMetadata(entry.Metadata)
+		copied[i] = &entryCopy
+	}
+	return copied
+}
+
+func (r *ProjectContextRepository) Create(ctx context.Context, projectContext *entities.ProjectContext) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.contexts[projectContext.ProjectID]; !exists {
+		r.contexts[projectContext.ProjectID] = &storedProjectContext{context: copyProjectContext(projectContext)}
+	}
+	return nil
+}
+
+func (r *ProjectContextRepository) Load(ctx context.Context, projectID uuid.UUID) (*entities.ProjectContext, []*entities.ProjectContextEntry, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, exists := r.contexts[projectID]
+	if !exists {
+		return nil, nil, repositories.NewNotFoundError("project context", projectID)
+	}
+	return copyProjectContext(stored.context), copyProjectContextEntries(stored.entries), nil
+}
+
+func (r *ProjectContextRepository) Version(ctx context.Context, projectID uuid.UUID) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, exists := r.contexts[projectID]
+	if !exists {
+		return 0, repositories.NewNotFoundError("project context", projectID)
+	}
+	return stored.context.Version, nil
+}
+
+func (r *ProjectContextRepository) Update(ctx context.Context, projectID uuid.UUID, update repositories.ProjectContextUpdate) (*entities.ProjectContext, []*entities.ProjectContextEntry, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, exists := r.contexts[projectID]
+	if !exists {
+		return nil, nil, repositories.NewNotFoundError("project context", projectID)
+	}
+
+	projectContext := copyProjectContext(stored.context)
+	change, err := update(projectContext, copyProjectContextEntries(stored.entries))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	removed := make(map[uuid.UUID]bool, len(change.Remove))
+	for _, id := range change.Remove {
+		removed[id] = true
+	}
+	entries := make([]*entities.ProjectContextEntry, 0, len(stored.entries)+len(change.Add))
+	for _, entry := range stored.entries {
+		if !removed[entry.EntryID] {
+			entries = append(entries, entry)
+		}
+	}
+	entries = append(entries, copyProjectContextEntries(change.Add)...)
+
+	projectContext.ProjectID = projectID
+	projectContext.Version = stored.context.Version + 1
+	projectContext.UpdatedAt = time.Now()
+	stored.context = projectContext
+	stored.entries = entries
+
+	return copyProjectContext(stored.context), copyProjectContextEntries(stored.entries), nil
+}
+
+func (r *ProjectContextRepository) Touch(ctx context.Context, projectID uuid.UUID, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, exists := r.contexts[projectID]
+	if !exists {
+		return repositories.NewNotFoundError("project context", projectID)
+	}
+	stored.context.LastAccessed = at
+	return nil
+}
@@ -0,0 +1,176 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/Ceesaxp/autonomous-content-service/src/domain/entities"
+	"github.com/Ceesaxp/autonomous-content-service/src/domain/repositories"
+	"github.com/google/uuid"
+)
+
+// PromptTemplateRepository implements the PromptTemplateRepository interface in memory
+type PromptTemplateRepository struct {
+	mu        sync.RWMutex
+	templates map[uuid.UUID]*entities.PromptTemplate
+	versions  map[uuid.UUID][]*entities.PromptTemplateVersion // by template, oldest first
+}
+
+// NewPromptTemplateRepository creates a new in-memory prompt template repository
+func NewPromptTemplateRepository() repositories.PromptTemplateRepository {
+	return &PromptTemplateRepository{
+		templates: make(map[uuid.UUID]*entities.PromptTemplate),
+		versions:  make(map[uuid.UUID][]*entities.PromptTemplateVersion),
+	}
+}
+
+func copyPromptTemplate(template *entities.PromptTemplate) *entities.PromptTemplate {
+	copied := *template
+	if template.ClientID != nil {
+		clientID := *template.ClientID
+		copied.ClientID = &clientID
+	}
+	if template.DeletedAt != nil {
+		deletedAt := *template.DeletedAt
+		copied.DeletedAt = &deletedAt
+	}
+	return &copied
+}
+
+// sameClient reports whether two optional client IDs are equal
+func sameClient(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+func (r *PromptTemplateRepository) Create(ctx context.Context, template *entities.PromptTemplate) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.templates {
+		if existing.DeletedAt == nil && existing.Name == template.Name && existing.ContentType == template.ContentType &&
+			existing.Industry == template.Industry && sameClient(existing.ClientID, template.ClientID) {
+			return repositories.ErrPromptTemplateExists
+		}
+	}
+
+	r.templates[template.TemplateID] = copyPromptTemplate(template)
+	r.versions[template.TemplateID] = []*entities.PromptTemplateVersion{template.CurrentVersion()}
+	return nil
+}
+
+func (r *PromptTemplateRepository) AddVersion(ctx context.Context, templateID uuid.UUID, body string, outputFormat entities.PromptOutputFormat, author string) (*entities.PromptTemplate, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	template, ok := r.templates[templateID]
+	if !ok || template.DeletedAt != nil {
+		return nil, repositories.NewNotFoundError("prompt template", templateID)
+	}
+
+	template.Version++
+	template.Body = body
+	template.OutputFormat = outputFormat
+	template.Author = author
+	template.UpdatedAt = time.Now()
+	r.versions[templateID] = append(r.versions[templateID], template.CurrentVersion())
+	return copyPromptTemplate(template), nil
+}
+
+func (r *PromptTemplateRepository) FindByID(ctx context.Context, templateID uuid.UUID) (*entities.PromptTemplate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	template, ok := r.templates[templateID]
+	if !ok {
+		return nil, repositories.NewNotFoundError("prompt template", templateID)
+	}
+	return copyPromptTemplate(template), nil
+}
+
+func (r *PromptTemplateRepository) FindByScope(ctx context.Context, name string, contentType entities.ContentType, industry string, clientID *uuid.UUID) (*entities.PromptTemplate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	industry = entities.NormalizeIndustry(industry)
+	for _, template := range r.templates {
+		if template.DeletedAt == nil && template.Name == name && template.ContentType == contentType &&
+			template.Industry == industry && sameClient(template.ClientID, clientID) {
+			return copyPromptTemplate(template), nil
+		}
+	}
+	return nil, repositories.NewNotFoundError("prompt template", name)
+}
+
+func (r *PromptTemplateRepository) FindVersion(ctx context.Context, templateID uuid.UUID, version int) (*entities.PromptTemplateVersion, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, stored := range r.versions[templateID] {
+		if stored.Version == version {
+			copied := *stored
+			return &copied, nil
+		}
+	}
+	return nil, repositories.NewNotFoundError("prompt template version", version)
+}
+
+func (r *PromptTemplateRepository) ListVersions(ctx context.Context, templateID uuid.UUID) ([]*entities.PromptTemplateVersion, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if _, ok := r.templates[templateID]; !ok {
+		return nil, repositories.NewNotFoundError("prompt template", templateID)
+	}
+	versions := make([]*entities.PromptTemplateVersion, len(r.versions[templateID]))
+	for i, stored := range r.versions[templateID] {
+		copied := *stored
+		versions[i] = &copied
+	}
+	return versions, nil
+}
+
+func (r *PromptTemplateRepository) List(ctx context.Context, filter repositories.PromptTemplateFilter) ([]*entities.PromptTemplate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	industry := entities.NormalizeIndustry(filter.Industry)
+	matches := []*entities.PromptTemplate{}
+	for _, template := range r.templates {
+		if template.DeletedAt != nil {
+			continue
+		}
+		if filter.Name != "" && template.Name != filter.Name {
+			continue
+		}
+		if filter.ContentType != "" && template.ContentType != filter.ContentType {
+			continue
+		}
+		if industry != "" && template.Industry != industry {
+			continue
+		}
+		if filter.ClientID != nil && !sameClient(template.ClientID, filter.ClientID) {
+			continue
+		}
+		matches = append(matches, copyPromptTemplate(template))
+	}
+	sortByTime(matches, func(t *entities.PromptTemplate) time.Time { return t.CreatedAt }, true)
+	return matches, nil
+}
+
+func (r *PromptTemplateRepository) Delete(ctx context.Context, templateID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	template, ok := r.templates[templateID]
+	if !ok || template.DeletedAt != nil {
+		return repositories.NewNotFoundError("prompt template", templateID)
+	}
+	now := time.Now()
+	template.DeletedAt = &now
+	template.UpdatedAt = now
+	return nil
+}
@@ -0,0 +1,42 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/Ceesaxp/autonomous-content-service/src/domain/entities"
+	"github.com/Ceesaxp/autonomous-content-service/src/domain/repositories"
+	"github.com/google/uuid"
+)
+
+// QualityAssessmentRepository implements the QualityAssessmentRepository interface in memory
+type QualityAssessmentRepository struct {
+	mu          sync.RWMutex
+	assessments map[uuid.UUID][]*entities.QualityAssessment // by content, oldest first
+}
+
+// NewQualityAssessmentRepository creates a new in-memory quality assessment repository
+func NewQualityAssessmentRepository() repositories.QualityAssessmentRepository {
+	return &QualityAssessmentRepository{assessments: make(map[uuid.UUID][]*entities.QualityAssessment)}
+}
+
+func (r *QualityAssessmentRepository) Save(ctx context.Context, assessment *entities.QualityAssessment) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	copied := *assessment
+	r.assessments[assessment.ContentID] = append(r.assessments[assessment.ContentID], &copied)
+	return nil
+}
+
+func (r *QualityAssessmentRepository) FindLatestByContentID(ctx context.Context, contentID uuid.UUID) (*entities.QualityAssessment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	assessments := r.assessments[contentID]
+	if len(assessments) == 0 {
+		return nil, repositories.NewNotFoundError("quality assessment", contentID)
+	}
+	copied := *assessments[len(assessments)-1]
+	return &copied, nil
+}
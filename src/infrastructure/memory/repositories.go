@@ -0,0 +1,920 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Ceesaxp/autonomous-content-service/src/domain/entities"
+	"github.com/Ceesaxp/autonomous-content-service/src/domain/events"
+	"github.com/Ceesaxp/autonomous-content-service/src/domain/repositories"
+	"github.com/google/uuid"
+)
+
+// ClientRepository implements the ClientRepository interface in memory
+type ClientRepository struct {
+	mu      sync.RWMutex
+	clients map[uuid.UUID]*entities.Client
+}
+
+// NewClientRepository creates a new in-memory client repository
+func NewClientRepository() repositories.ClientRepository {
+	return &ClientRepository{clients: make(map[uuid.UUID]*entities.Client)}
+}
+
+func copyClient(client *entities.Client) *entities.Client {
+	copied := *client
+	return &copied
+}
+
+func (r *ClientRepository) findPage(keep func(*entities.Client) bool, offset, limit int) ([]*entities.Client, int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	matches := filter(r.clients, keep)
+	sortByTime(matches, func(c *entities.Client) time.Time { return c.CreatedAt }, false)
+
+	page := paginate(matches, offset, limit)
+	result := make([]*entities.Client, len(page))
+	for i, client := range page {
+		result[i] = copyClient(client)
+	}
+	return result, len(matches), nil
+}
+
+func (r *ClientRepository) FindByID(ctx context.Context, id uuid.UUID) (*entities.Client, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	client, ok := r.clients[id]
+	if !ok {
+		return nil, repositories.NewNotFoundError("client", id)
+	}
+	return copyClient(client), nil
+}
+
+func (r *ClientRepository) FindByEmail(ctx context.Context, email string) (*entities.Client, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, client := range r.clients {
+		if client.ContactEmail == email {
+			return copyClient(client), nil
+		}
+	}
+	return nil, repositories.NewNotFoundError("client", email)
+}
+
+func (r *ClientRepository) FindAll(ctx context.Context, offset, limit int) ([]*entities.Client, int, error) {
+	return r.findPage(all[entities.Client], offset, limit)
+}
+
+func (r *ClientRepository) FindByStatus(ctx context.Context, status entities.ClientStatus, offset, limit int) ([]*entities.Client, int, error) {
+	return r.findPage(func(c *entities.Client) bool { return c.Status == status }, offset, limit)
+}
+
+func (r *ClientRepository) Save(ctx context.Context, client *entities.Client) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.clients[client.ClientID] = copyClient(client)
+	return nil
+}
+
+func (r *ClientRepository) Create(ctx context.Context, client *entities.Client) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.clients[client.ClientID]; exists {
+		return fmt.Errorf("client already exists: %s", client.ClientID)
+	}
+	for _, existing := range r.clients {
+		if existing.ContactEmail == client.ContactEmail {
+			return fmt.Errorf("client email already registered: %s", client.ContactEmail)
+		}
+	}
+	r.clients[client.ClientID] = copyClient(client)
+	return nil
+}
+
+func (r *ClientRepository) Update(ctx context.Context, client *entities.Client) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.clients[client.ClientID]; !exists {
+		return repositories.NewNotFoundError("client", client.ClientID)
+	}
+	r.clients[client.ClientID] = copyClient(client)
+	return nil
+}
+
+func (r *ClientRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.clients[id]; !exists {
+		return repositories.NewNotFoundError("client", id)
+	}
+	delete(r.clients, id)
+	return nil
+}
+
+// ClientProfileRepository implements the ClientProfileRepository interface in memory
+type ClientProfileRepository struct {
+	mu       sync.RWMutex
+	profiles map[uuid.UUID]*entities.ClientProfile
+}
+
+// NewClientProfileRepository creates a new in-memory client profile repository
+func NewClientProfileRepository() repositories.ClientProfileRepository {
+	return &ClientProfileRepository{profiles: make(map[uuid.UUID]*entities.ClientProfile)}
+}
+
+func copyClientProfile(profile *entities.ClientProfile) *entities.ClientProfile {
+	copied := *profile
+	copied.StylePreferences = copyMetadata(profile.StylePreferences)
+	copied.CompetitorAnalysis = copyMetadata(profile.CompetitorAnalysis)
+	return &copied
+}
+
+func (r *ClientProfileRepository) FindByID(ctx context.Context, id uuid.UUID) (*entities.ClientProfile, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	profile, ok := r.profiles[id]
+	if !ok {
+		return nil, repositories.NewNotFoundError("client profile", id)
+	}
+	return copyClientProfile(profile), nil
+}
+
+func (r *ClientProfileRepository) FindByClientID(ctx context.Context, clientID uuid.UUID) (*entities.ClientProfile, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, profile := range r.profiles {
+		if profile.ClientID == clientID {
+			return copyClientProfile(profile), nil
+		}
+	}
+	return nil, repositories.NewNotFoundError("client profile", clientID)
+}
+
+func (r *ClientProfileRepository) Save(ctx context.Context, profile *entities.ClientProfile) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.profiles[profile.ProfileID] = copyClientProfile(profile)
+	return nil
+}
+
+func (r *ClientProfileRepository) Create(ctx context.Context, profile *entities.ClientProfile) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.profiles[profile.ProfileID]; exists {
+		return fmt.Errorf("client profile already exists: %s", profile.ProfileID)
+	}
+	r.profiles[profile.ProfileID] = copyClientProfile(profile)
+	return nil
+}
+
+func (r *ClientProfileRepository) Update(ctx context.Context, profile *entities.ClientProfile) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.profiles[profile.ProfileID]; !exists {
+		return repositories.NewNotFoundError("client profile", profile.ProfileID)
+	}
+	r.profiles[profile.ProfileID] = copyClientProfile(profile)
+	return nil
+}
+
+func (r *ClientProfileRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.profiles[id]; !exists {
+		return repositories.NewNotFoundError("client profile", id)
+	}
+	delete(r.profiles, id)
+	return nil
+}
+
+// ProjectRepository implements the ProjectRepository interface in memory
+type ProjectRepository struct {
+	mu       sync.RWMutex
+	projects map[uuid.UUID]*entities.Project
+}
+
+// NewProjectRepository creates a new in-memory project repository
+func NewProjectRepository() repositories.ProjectRepository {
+	return &ProjectRepository{projects: make(map[uuid.UUID]*entities.Project)}
+}
+
+func copyProject(project *entities.Project) *entities.Project {
+	copied := *project
+	copied.Requirements = append([]string(nil), project.Requirements...)
+	copied.Metadata = copyMetadata(project.Metadata)
+	copied.Contents = nil
+	return &copied
+}
+
+func (r *ProjectRepository) findPage(keep func(*entities.Project) bool, byDeadline bool, offset, limit int) ([]*entities.Project, int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	matches := filter(r.projects, keep)
+	if byDeadline {
+		sortByTime(matches, func(p *entities.Project) time.Time { return p.Deadline }, true)
+	} else {
+		sortByTime(matches, func(p *entities.Project) time.Time { return p.CreatedAt }, false)
+	}
+
+	page := paginate(matches, offset, limit)
+	result := make([]*entities.Project, len(page))
+	for i, project := range page {
+		result[i] = copyProject(project)
+	}
+	return result, len(matches), nil
+}
+
+func (r *ProjectRepository) FindByID(ctx context.Context, id uuid.UUID) (*entities.Project, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	project, ok := r.projects[id]
+	if !ok {
+		return nil, repositories.NewNotFoundError("project", id)
+	}
+	return copyProject(project), nil
+}
+
+func (r *ProjectRepository) FindByClientID(ctx context.Context, clientID uuid.UUID, offset, limit int) ([]*entities.Project, int, error) {
+	return r.findPage(func(p *entities.Project) bool { return p.ClientID == clientID }, false, offset, limit)
+}
+
+func (r *ProjectRepository) FindByStatus(ctx context.Context, status entities.ProjectStatus, offset, limit int) ([]*entities.Project, int, error) {
+	return r.findPage(func(p *entities.Project) bool { return p.Status == status }, false, offset, limit)
+}
+
+func (r *ProjectRepository) FindActive(ctx context.Context, offset, limit int) ([]*entities.Project, int, error) {
+	return r.findPage(func(p *entities.Project) bool {
+		return p.Status != entities.ProjectStatusCompleted && p.Status != entities.ProjectStatusCancelled
+	}, true, offset, limit)
+}
+
+func (r *ProjectRepository) FindByDeadlineRange(ctx context.Context, start, end time.Time, offset, limit int) ([]*entities.Project, int, error) {
+	return r.findPage(func(p *entities.Project) bool {
+		return !p.Deadline.Before(start) && !p.Deadline.After(end)
+	}, true, offset, limit)
+}
+
+func (r *ProjectRepository) FindAll(ctx context.Context, offset, limit int) ([]*entities.Project, int, error) {
+	return r.findPage(all[entities.Project], false, offset, limit)
+}
+
+func (r *ProjectRepository) Save(ctx context.Context, project *entities.Project) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.projects[project.ProjectID] = copyProject(project)
+	return nil
+}
+
+func (r *ProjectRepository) Create(ctx context.Context, project *entities.Project) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.projects[project.ProjectID]; exists {
+		return fmt.Errorf("project already exists: %s", project.ProjectID)
+	}
+	r.projects[project.ProjectID] = copyProject(project)
+	return nil
+}
+
+func (r *ProjectRepository) Update(ctx context.Context, project *entities.Project) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.projects[project.ProjectID]; !exists {
+		return repositories.NewNotFoundError("project", project.ProjectID)
+	}
+	r.projects[project.ProjectID] = copyProject(project)
+	return nil
+}
+
+func (r *ProjectRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.projects[id]; !exists {
+		return repositories.NewNotFoundError("project", id)
+	}
+	delete(r.projects, id)
+	return nil
+}
+
+func (r *ProjectRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.Project, error) {
+	return r.FindByID(ctx, id)
+}
+
+func (r *ProjectRepository) GetByClientID(ctx context.Context, clientID uuid.UUID) ([]*entities.Project, error) {
+	projects, _, err := r.FindByClientID(ctx, clientID, 0, 0)
+	return projects, err
+}
+
+// ContentRepository implements the ContentRepository interface in memory
+type ContentRepository struct {
+	mu       sync.RWMutex
+	contents map[uuid.UUID]*entities.Content
+}
+
+// NewContentRepository creates a new in-memory content repository
+func NewContentRepository() repositories.ContentRepository {
+	return &ContentRepository{contents: make(map[uuid.UUID]*entities.Content)}
+}
+
+func copyContent(content *entities.Content) *entities.Content {
+	copied := *content
+	copied.Metadata = copyMetadata(content.Metadata)
+	copied.Versions = append([]*entities.ContentVersion(nil), content.Versions...)
+	if content.Statistics != nil {
+		stats := *content.Statistics
+		copied.Statistics = &stats
+	}
+	return &copied
+}
+
+func (r *ContentRepository) findPage(keep func(*entities.Content) bool, offset, limit int) ([]*entities.Content, int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	matches := filter(r.contents, keep)
+	sortByTime(matches, func(c *entities.Content) time.Time { return c.UpdatedAt }, false)
+
+	page := paginate(matches, offset, limit)
+	result := make([]*entities.Content, len(page))
+	for i, content := range page {
+		result[i] = copyContent(content)
+	}
+	return result, len(matches), nil
+}
+
+func (r *ContentRepository) FindByID(ctx context.Context, id uuid.UUID) (*entities.Content, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	content, ok := r.contents[id]
+	if !ok {
+		return nil, repositories.NewNotFoundError("content", id)
+	}
+	return copyContent(content), nil
+}
+
+func (r *ContentRepository) FindByProjectID(ctx context.Context, projectID uuid.UUID) ([]*entities.Content, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	matches := filter(r.contents, func(c *entities.Content) bool { return c.ProjectID == projectID })
+	sortByTime(matches, func(c *entities.Content) time.Time { return c.CreatedAt }, true)
+
+	result := make([]*entities.Content, len(matches))
+	for i, content := range matches {
+		result[i] = copyContent(content)
+	}
+	return result, nil
+}
+
+func (r *ContentRepository) FindByStatus(ctx context.Context, status entities.ContentStatus, offset, limit int) ([]*entities.Content, int, error) {
+	return r.findPage(func(c *entities.Content) bool { return c.Status == status }, offset, limit)
+}
+
+func (r *ContentRepository) FindByType(ctx context.Context, contentType entities.ContentType, offset, limit int) ([]*entities.Content, int, error) {
+	return r.findPage(func(c *entities.Content) bool { return c.Type == contentType }, offset, limit)
+}
+
+func (r *ContentRepository) Save(ctx context.Context, content *entities.Content) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.contents[content.ContentID] = copyContent(content)
+	return nil
+}
+
+func (r *ContentRepository) Create(ctx context.Context, content *entities.Content) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.contents[content.ContentID]; exists {
+		return fmt.Errorf("content already exists: %s", content.ContentID)
+	}
+	r.contents[content.ContentID] = copyContent(content)
+	return nil
+}
+
+func (r *ContentRepository) Update(ctx context.Context, content *entities.Content) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.contents[content.ContentID]; !exists {
+		return repositories.NewNotFoundError("content", content.ContentID)
+	}
+	r.contents[content.ContentID] = copyContent(content)
+	return nil
+}
+
+func (r *ContentRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.contents[id]; !exists {
+		return repositories.NewNotFoundError("content", id)
+	}
+	delete(r.contents, id)
+	return nil
+}
+
+// ContentVersionRepository implements the ContentVersionRepository interface in memory
+type ContentVersionRepository struct {
+	mu       sync.RWMutex
+	versions map[uuid.UUID]*entities.ContentVersion
+}
+
+// NewContentVersionRepository creates a new in-memory content version repository
+func NewContentVersionRepository() repositories.ContentVersionRepository {
+	return &ContentVersionRepository{versions: make(map[uuid.UUID]*entities.ContentVersion)}
+}
+
+func copyContentVersion(version *entities.ContentVersion) *entities.ContentVersion {
+	copied := *version
+	copied.Metadata = copyMetadata(version.Metadata)
+	return &copied
+}
+
+func (r *ContentVersionRepository) FindByID(ctx context.Context, id uuid.UUID) (*entities.ContentVersion, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	version, ok := r.versions[id]
+	if !ok {
+		return nil, repositories.NewNotFoundError("content version", id)
+	}
+	return copyContentVersion(version), nil
+}
+
+func (r *ContentVersionRepository) FindByContentID(ctx context.Context, contentID uuid.UUID) ([]*entities.ContentVersion, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	matches := filter(r.versions, func(v *entities.ContentVersion) bool { return v.ContentID == contentID })
+	sort.Slice(matches, func(i, j int) bool { return matches[i].VersionNumber < matches[j].VersionNumber })
+
+	result := make([]*entities.ContentVersion, len(matches))
+	for i, version := range matches {
+		result[i] = copyContentVersion(version)
+	}
+	return result, nil
+}
+
+func (r *ContentVersionRepository) FindByContentIDAndVersion(ctx context.Context, contentID uuid.UUID, version int) (*entities.ContentVersion, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, v := range r.versions {
+		if v.ContentID == contentID && v.VersionNumber == version {
+			return copyContentVersion(v), nil
+		}
+	}
+	return nil, repositories.NewNotFoundError("content version", fmt.Sprintf("%s@%d", contentID, version))
+}
+
+func (r *ContentVersionRepository) Save(ctx context.Context, version *entities.ContentVersion) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.versions[version.VersionID] = copyContentVersion(version)
+	return nil
+}
+
+func (r *ContentVersionRepository) Create(ctx context.Context, version *entities.ContentVersion) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.versions {
+		if existing.VersionID == version.VersionID ||
+			(existing.ContentID == version.ContentID && existing.VersionNumber == version.VersionNumber) {
+			return fmt.Errorf("content version already exists: %s@%d", version.ContentID, version.VersionNumber)
+		}
+	}
+	r.versions[version.VersionID] = copyContentVersion(version)
+	return nil
+}
+
+// FeedbackRepository implements the FeedbackRepository interface in memory
+type FeedbackRepository struct {
+	mu       sync.RWMutex
+	feedback map[uuid.UUID]*entities.Feedback
+}
+
+// NewFeedbackRepository creates a new in-memory feedback repository
+func NewFeedbackRepository() repositories.FeedbackRepository {
+	return &FeedbackRepository{feedback: make(map[uuid.UUID]*entities.Feedback)}
+}
+
+func copyFeedback(feedback *entities.Feedback) *entities.Feedback {
+	copied := *feedback
+	copied.Tags = append([]string(nil), feedback.Tags...)
+	copied.Metadata = copyMetadata(feedback.Metadata)
+	return &copied
+}
+
+func (r *FeedbackRepository) find(keep func(*entities.Feedback) bool) []*entities.Feedback {
+	matches := filter(r.feedback, keep)
+	sortByTime(matches, func(f *entities.Feedback) time.Time { return f.CreatedAt }, false)
+
+	result := make([]*entities.Feedback, len(matches))
+	for i, feedback := range matches {
+		result[i] = copyFeedback(feedback)
+	}
+	return result
+}
+
+func (r *FeedbackRepository) findPage(keep func(*entities.Feedback) bool, offset, limit int) ([]*entities.Feedback, int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	matches := r.find(keep)
+	return paginate(matches, offset, limit), len(matches), nil
+}
+
+func (r *FeedbackRepository) FindByID(ctx context.Context, id uuid.UUID) (*entities.Feedback, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	feedback, ok := r.feedback[id]
+	if !ok {
+		return nil, repositories.NewNotFoundError("feedback", id)
+	}
+	return copyFeedback(feedback), nil
+}
+
+func (r *FeedbackRepository) FindByContentID(ctx context.Context, contentID uuid.UUID) ([]*entities.Feedback, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.find(func(f *entities.Feedback) bool { return f.ContentID != nil && *f.ContentID == contentID }), nil
+}
+
+func (r *FeedbackRepository) FindByProjectID(ctx context.Context, projectID uuid.UUID) ([]*entities.Feedback, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.find(func(f *entities.Feedback) bool { return f.ProjectID != nil && *f.ProjectID == projectID }), nil
+}
+
+func (r *FeedbackRepository) FindBySource(ctx context.Context, source entities.FeedbackSource, offset, limit int) ([]*entities.Feedback, int, error) {
+	return r.findPage(func(f *entities.Feedback) bool { return f.Source == source }, offset, limit)
+}
+
+func (r *FeedbackRepository) FindByStatus(ctx context.Context, status entities.FeedbackStatus, offset, limit int) ([]*entities.Feedback, int, error) {
+	return r.findPage(func(f *entities.Feedback) bool { return f.Status == status }, offset, limit)
+}
+
+func (r *FeedbackRepository) FindByType(ctx context.Context, feedbackType entities.FeedbackType, offset, limit int) ([]*entities.Feedback, int, error) {
+	return r.findPage(func(f *entities.Feedback) bool { return f.Type == feedbackType }, offset, limit)
+}
+
+func (r *FeedbackRepository) Save(ctx context.Context, feedback *entities.Feedback) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.feedback[feedback.FeedbackID] = copyFeedback(feedback)
+	return nil
+}
+
+func (r *FeedbackRepository) Create(ctx context.Context, feedback *entities.Feedback) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.feedback[feedback.FeedbackID]; exists {
+		return fmt.Errorf("feedback already exists: %s", feedback.FeedbackID)
+	}
+	r.feedback[feedback.FeedbackID] = copyFeedback(feedback)
+	return nil
+}
+
+func (r *FeedbackRepository) Update(ctx context.Context, feedback *entities.Feedback) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.feedback[feedback.FeedbackID]; !exists {
+		return repositories.NewNotFoundError("feedback", feedback.FeedbackID)
+	}
+	r.feedback[feedback.FeedbackID] = copyFeedback(feedback)
+	return nil
+}
+
+func (r *FeedbackRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.feedback[id]; !exists {
+		return repositories.NewNotFoundError("feedback", id)
+	}
+	delete(r.feedback, id)
+	return nil
+}
+
+// SystemCapabilityRepository implements the SystemCapabilityRepository interface in memory
+type SystemCapabilityRepository struct {
+	mu           sync.RWMutex
+	capabilities map[uuid.UUID]*entities.SystemCapability
+}
+
+// NewSystemCapabilityRepository creates a new in-memory system capability repository
+func NewSystemCapabilityRepository() repositories.SystemCapabilityRepository {
+	return &SystemCapabilityRepository{capabilities: make(map[uuid.UUID]*entities.SystemCapability)}
+}
+
+func copySystemCapability(capability *entities.SystemCapability) *entities.SystemCapability {
+	copied := *capability
+	copied.Configuration = copyMetadata(capability.Configuration)
+	copied.Dependencies = append([]uuid.UUID(nil), capability.Dependencies...)
+	return &copied
+}
+
+func (r *SystemCapabilityRepository) find(keep func(*entities.SystemCapability) bool) []*entities.SystemCapability {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	matches := filter(r.capabilities, keep)
+	sort.Slice(matches, func(i, j int) bool { return matches[i].Name < matches[j].Name })
+
+	result := make([]*entities.SystemCapability, len(matches))
+	for i, capability := range matches {
+		result[i] = copySystemCapability(capability)
+	}
+	return result
+}
+
+func (r *SystemCapabilityRepository) FindByID(ctx context.Context, id uuid.UUID) (*entities.SystemCapability, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	capability, ok := r.capabilities[id]
+	if !ok {
+		return nil, repositories.NewNotFoundError("system capability", id)
+	}
+	return copySystemCapability(capability), nil
+}
+
+func (r *SystemCapabilityRepository) FindByName(ctx context.Context, name string) (*entities.SystemCapability, error) {
+	matches := r.find(func(c *entities.SystemCapability) bool { return c.Name == name })
+	if len(matches) == 0 {
+		return nil, repositories.NewNotFoundError("system capability", name)
+	}
+	return matches[0], nil
+}
+
+func (r *SystemCapabilityRepository) FindByType(ctx context.Context, capabilityType entities.CapabilityType) ([]*entities.SystemCapability, error) {
+	return r.find(func(c *entities.SystemCapability) bool { return c.Type == capabilityType }), nil
+}
+
+func (r *SystemCapabilityRepository) FindByStatus(ctx context.Context, status entities.CapabilityStatus) ([]*entities.SystemCapability, error) {
+	return r.find(func(c *entities.SystemCapability) bool { return c.Status == status }), nil
+}
+
+func (r *SystemCapabilityRepository) FindAll(ctx context.Context) ([]*entities.SystemCapability, error) {
+	return r.find(all[entities.SystemCapability]), nil
+}
+
+func (r *SystemCapabilityRepository) Save(ctx context.Context, capability *entities.SystemCapability) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.capabilities[capability.CapabilityID] = copySystemCapability(capability)
+	return nil
+}
+
+func (r *SystemCapabilityRepository) Create(ctx context.Context, capability *entities.SystemCapability) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.capabilities {
+		if existing.CapabilityID == capability.CapabilityID || existing.Name == capability.Name {
+			return fmt.Errorf("system capability already exists: %s", capability.Name)
+		}
+	}
+	r.capabilities[capability.CapabilityID] = copySystemCapability(capability)
+	return nil
+}
+
+func (r *SystemCapabilityRepository) Update(ctx context.Context, capability *entities.SystemCapability) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.capabilities[capability.CapabilityID]; !exists {
+		return repositories.NewNotFoundError("system capability", capability.CapabilityID)
+	}
+	r.capabilities[capability.CapabilityID] = copySystemCapability(capability)
+	return nil
+}
+
+func (r *SystemCapabilityRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.capabilities[id]; !exists {
+		return repositories.NewNotFoundError("system capability", id)
+	}
+	delete(r.capabilities, id)
+	return nil
+}
+
+// EventRepository implements the EventRepository interface in memory.
+// Events are kept in insertion order as events.StoredEvent values.
+type EventRepository struct {
+	mu     sync.RWMutex
+	events []*events.StoredEvent
+	byID   map[uuid.UUID]*events.StoredEvent
+}
+
+// NewEventRepository creates a new in-memory event repository
+func NewEventRepository() repositories.EventRepository {
+	return &EventRepository{byID: make(map[uuid.UUID]*events.StoredEvent)}
+}
+
+func (r *EventRepository) findPage(keep func(*events.StoredEvent) bool, offset, limit int) ([]interface{}, int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	matches := []*events.StoredEvent{}
+	for _, event := range r.events {
+		if keep(event) {
+			matches = append(matches, event)
+		}
+	}
+	sortByTime(matches, func(e *events.StoredEvent) time.Time { return e.Timestamp }, true)
+
+	page := paginate(matches, offset, limit)
+	result := make([]interface{}, len(page))
+	for i, event := range page {
+		result[i] = event
+	}
+	return result, len(matches), nil
+}
+
+func (r *EventRepository) Save(ctx context.Context, event interface{}) error {
+	stored, err := events.ToStoredEvent(event)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.byID[stored.EventID]; exists {
+		return nil
+	}
+	r.events = append(r.events, stored)
+	r.byID[stored.EventID] = stored
+	return nil
+}
+
+func (r *EventRepository) FindByID(ctx context.Context, id uuid.UUID) (interface{}, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	event, ok := r.byID[id]
+	if !ok {
+		return nil, repositories.NewNotFoundError("event", id)
+	}
+	return event, nil
+}
+
+func (r *EventRepository) FindByType(ctx context.Context, eventType string, offset, limit int) ([]interface{}, int, error) {
+	return r.findPage(func(e *events.StoredEvent) bool { return e.EventType == eventType }, offset, limit)
+}
+
+func (r *EventRepository) FindByAggregateID(ctx context.Context, aggregateID uuid.UUID, offset, limit int) ([]interface{}, int, error) {
+	return r.findPage(func(e *events.StoredEvent) bool { return e.AggregateID == aggregateID }, offset, limit)
+}
+
+func (r *EventRepository) FindByTimeRange(ctx context.Context, start, end time.Time, offset, limit int) ([]interface{}, int, error) {
+	return r.findPage(func(e *events.StoredEvent) bool {
+		return !e.Timestamp.Before(start) && !e.Timestamp.After(end)
+	}, offset, limit)
+}
+
+func (r *EventRepository) FindLatest(ctx context.Context, limit int) ([]interface{}, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	latest := append([]*events.StoredEvent(nil), r.events...)
+	sortByTime(latest, func(e *events.StoredEvent) time.Time { return e.Timestamp }, false)
+
+	page := paginate(latest, 0, limit)
+	result := make([]interface{}, len(page))
+	for i, event := range page {
+		result[i] = event
+	}
+	return result, nil
+}
+
+// TransactionRepository implements the TransactionRepository interface in memory
+type TransactionRepository struct {
+	mu           sync.RWMutex
+	transactions map[uuid.UUID]*entities.Transaction
+}
+
+// NewTransactionRepository creates a new in-memory transaction repository
+func NewTransactionRepository() repositories.TransactionRepository {
+	return &TransactionRepository{transactions: make(map[uuid.UUID]*entities.Transaction)}
+}
+
+func copyTransaction(transaction *entities.Transaction) *entities.Transaction {
+	copied := *transaction
+	copied.Metadata = copyMetadata(transaction.Metadata)
+	return &copied
+}
+
+func (r *TransactionRepository) findPage(keep func(*entities.Transaction) bool, offset, limit int) ([]*entities.Transaction, int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	matches := filter(r.transactions, keep)
+	sortByTime(matches, func(t *entities.Transaction) time.Time { return t.CreatedAt }, false)
+
+	page := paginate(matches, offset, limit)
+	result := make([]*entities.Transaction, len(page))
+	for i, transaction := range page {
+		result[i] = copyTransaction(transaction)
+	}
+	return result, len(matches), nil
+}
+
+func (r *TransactionRepository) FindByID(ctx context.Context, id uuid.UUID) (*entities.Transaction, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	transaction, ok := r.transactions[id]
+	if !ok {
+		return nil, repositories.NewNotFoundError("transaction", id)
+	}
+	return copyTransaction(transaction), nil
+}
+
+func (r *TransactionRepository) FindByClientID(ctx context.Context, clientID uuid.UUID, offset, limit int) ([]*entities.Transaction, int, error) {
+	return r.findPage(func(t *entities.Transaction) bool { return t.ClientID == clientID }, offset, limit)
+}
+
+func (r *TransactionRepository) FindByProjectID(ctx context.Context, projectID uuid.UUID, offset, limit int) ([]*entities.Transaction, int, error) {
+	return r.findPage(func(t *entities.Transaction) bool { return t.ProjectID != nil && *t.ProjectID == projectID }, offset, limit)
+}
+
+func (r *TransactionRepository) FindByStatus(ctx context.Context, status entities.TransactionStatus, offset, limit int) ([]*entities.Transaction, int, error) {
+	return r.findPage(func(t *entities.Transaction) bool { return t.Status == status }, offset, limit)
+}
+
+func (r *TransactionRepository) FindByType(ctx context.Context, transactionType entities.TransactionType, offset, limit int) ([]*entities.Transaction, int, error) {
+	return r.findPage(func(t *entities.Transaction) bool { return t.Type == transactionType }, offset, limit)
+}
+
+func (r *TransactionRepository) FindByDateRange(ctx context.Context, start, end time.Time, offset, limit int) ([]*entities.Transaction, int, error) {
+	return r.findPage(func(t *entities.Transaction) bool {
+		return !t.CreatedAt.Before(start) && !t.CreatedAt.After(end)
+	}, offset, limit)
+}
+
+func (r *TransactionRepository) Save(ctx context.Context, transaction *entities.Transaction) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.transactions[transaction.TransactionID] = copyTransaction(transaction)
+	return nil
+}
+
+func (r *TransactionRepository) Create(ctx context.Context, transaction *entities.Transaction) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.transactions[transaction.TransactionID]; exists {
+		return fmt.Errorf("transaction already exists: %s", transaction.TransactionID)
+	}
+	r.transactions[transaction.TransactionID] = copyTransaction(transaction)
+	return nil
+}
+
+func (r *TransactionRepository) Update(ctx context.Context, transaction *entities.Transaction) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.transactions[transaction.TransactionID]; !exists {
+		return repositories.NewNotFoundError("transaction", transaction.TransactionID)
+	}
+	r.transactions[transaction.TransactionID] = copyTransaction(transaction)
+	return nil
+}
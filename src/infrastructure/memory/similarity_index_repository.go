@@ -0,0 +1,74 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/Ceesaxp/autonomous-content-service/src/domain/entities"
+	"github.com/Ceesaxp/autonomous-content-service/src/domain/repositories"
+)
+
+// SimilarityIndexRepository implements the SimilarityIndexRepository interface in memory
+type SimilarityIndexRepository struct {
+	mu        sync.RWMutex
+	documents []*entities.SimilarityDocument // in indexing order
+	keys      map[string]bool
+	bands     map[uint64][]int // band to positions in documents
+}
+
+// NewSimilarityIndexRepository creates a new in-memory similarity index repository
+func NewSimilarityIndexRepository() repositories.SimilarityIndexRepository {
+	return &SimilarityIndexRepository{
+		keys:  make(map[string]bool),
+		bands: make(map[uint64][]int),
+	}
+}
+
+func (r *SimilarityIndexRepository) Save(ctx context.Context, document *entities.SimilarityDocument, bands []uint64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.keys[document.Key] {
+		return nil
+	}
+
+	copied := *document
+	position := len(r.documents)
+	r.documents = append(r.documents, &copied)
+	r.keys[document.Key] = true
+	for _, band := range bands {
+		positions := r.bands[band]
+		if len(positions) == 0 || positions[len(positions)-1] != position {
+			r.bands[band] = append(positions, position)
+		}
+	}
+	return nil
+}
+
+func (r *SimilarityIndexRepository) Exists(ctx context.Context, key string) (bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.keys[key], nil
+}
+
+func (r *SimilarityIndexRepository) FindByBands(ctx context.Context, bands []uint64) ([]*entities.SimilarityDocument, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	found := make(map[int]bool)
+	for _, band := range bands {
+		for _, position := range r.bands[band] {
+			found[position] = true
+		}
+	}
+
+	documents := []*entities.SimilarityDocument{}
+	for position, document := range r.documents {
+		if found[position] {
+			copied := *document
+			documents = append(documents, &copied)
+		}
+	}
+	return documents, nil
+}
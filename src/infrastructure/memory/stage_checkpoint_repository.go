@@ -0,0 +1,66 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/Ceesaxp/autonomous-content-service/src/domain/entities"
+	"github.com/Ceesaxp/autonomous-content-service/src/domain/repositories"
+	"github.com/google/uuid"
+)
+
+// stageKey identifies a checkpoint by content and stage
+type stageKey struct {
+	contentID uuid.UUID
+	stage     string
+}
+
+// StageCheckpointRepository implements the StageCheckpointRepository interface in memory
+type StageCheckpointRepository struct {
+	mu          sync.RWMutex
+	checkpoints map[stageKey]*entities.StageCheckpoint
+}
+
+// NewStageCheckpointRepository creates a new in-memory stage checkpoint repository
+func NewStageCheckpointRepository() repositories.StageCheckpointRepository {
+	return &StageCheckpointRepository{checkpoints: make(map[stageKey]*entities.StageCheckpoint)}
+}
+
+func copyStageCheckpoint(checkpoint *entities.StageCheckpoint) *entities.StageCheckpoint {
+	copied := *checkpoint
+	copied.Metadata = copyMetadata(checkpoint.Metadata)
+	return &copied
+}
+
+func (r *StageCheckpointRepository) FindByContentID(ctx context.Context, contentID uuid.UUID) ([]*entities.StageCheckpoint, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	matches := filter(r.checkpoints, func(c *entities.StageCheckpoint) bool { return c.ContentID == contentID })
+	sortByTime(matches, func(c *entities.StageCheckpoint) time.Time { return c.RecordedAt }, true)
+
+	result := make([]*entities.StageCheckpoint, len(matches))
+	for i, checkpoint := range matches {
+		result[i] = copyStageCheckpoint(checkpoint)
+	}
+	return result, nil
+}
+
+func (r *StageCheckpointRepository) Save(ctx context.Context, checkpoint *entities.StageCheckpoint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.checkpoints[stageKey{checkpoint.ContentID, checkpoint.Stage}] = copyStageCheckpoint(checkpoint)
+	return nil
+}
+
+func (r *StageCheckpointRepository) DeleteStages(ctx context.Context, contentID uuid.UUID, stages ...string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, stage := range stages {
+		delete(r.checkpoints, stageKey{contentID, stage})
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/Ceesaxp/autonomous-content-service/src/config"
+	"github.com/Ceesaxp/autonomous-content-service/src/services/content_creation"
+)
+
+// loadPipelineSchema reads the pipeline configuration file when one is set. Otherwise it
+// returns the default schema with its default LLM taken from the LLM_* settings.
+func loadPipelineSchema(cfg *config.Config) (*content_creation.PipelineConfigSchema, error) {
+	if cfg.PipelineConfigFile != "" {
+		data, err := os.ReadFile(cfg.PipelineConfigFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read pipeline config: %w", err)
+		}
+		return content_creation.LoadConfigFromJSON(data)
+	}
+
+	schema := content_creation.DefaultPipelineConfigSchema()
+	llmConfig := schema.LLMConfigs["default"]
+	llmConfig.Provider = cfg.LLMProvider
+	llmConfig.BaseURL = cfg.LLMBaseURL
+	llmConfig.Model = cfg.LLMModel
+	llmConfig.MaxTokens = cfg.LLMMaxTokens
+	llmConfig.Temperature = cfg.LLMTemperature
+	schema.LLMConfigs["default"] = llmConfig
+
+	return schema, nil
+}
+
+// newLLMClient builds the client for the schema's default LLM config, with retries,
+// provider limits and its fallback chain
+func newLLMClient(cfg *config.Config, schema *content_creation.PipelineConfigSchema, tokenizers *content_creation.TokenizerRegistry) (content_creation.LLMClient, error) {
+	if _, ok := schema.GetLLMConfig("default"); !ok {
+		return nil, fmt.Errorf("pipeline config has no default LLM config")
+	}
+
+	registry := content_creation.NewLLMProviderRegistry(map[string]string{
+		cfg.LLMProvider: cfg.LLMAPIKey,
+	})
+	client, err := content_creation.NewResilientLLMClient(registry, schema, "default")
+	if err != nil {
+		return nil, err
+	}
+	client.UseTokenizers(tokenizers)
+	return client, nil
+}
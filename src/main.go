@@ -13,65 +13,128 @@ import (
 	"github.com/Ceesaxp/autonomous-content-service/src/api"
 	"github.com/Ceesaxp/autonomous-content-service/src/api/handlers"
 	"github.com/Ceesaxp/autonomous-content-service/src/config"
-	"github.com/Ceesaxp/autonomous-content-service/src/infrastructure/database"
 	"github.com/Ceesaxp/autonomous-content-service/src/services/content_creation"
+	"github.com/Ceesaxp/autonomous-content-service/src/services/jobs"
+	"github.com/Ceesaxp/autonomous-content-service/src/services/pricing"
 	"github.com/gorilla/mux"
 )
 
 func main() {
+	// Handle subcommands before loading the full server configuration
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrateCommand(os.Args[2:]); err != nil {
+			log.Fatalf("Migration failed: %v", err)
+		}
+		return
+	}
+
 	// Load configuration
 	config, err := config.LoadConfig()
 	if err != nil {
 		log.Fatalf("Failed to load configuration: %v", err)
 	}
 
-	// Set up database connection
-	db, err := database.NewPostgresDB(config.GetDSN())
+	// Set up storage and repositories
+	store, err := openStorage(config)
 	if err != nil {
-		log.Fatalf("Failed to connect to database: %v", err)
+		log.Fatalf("Failed to initialize storage: %v", err)
 	}
-	defer db.Close()
+	defer store.close()
 
-	// Run migrations
-	if err := database.RunMigrations(db); err != nil {
-		log.Fatalf("Failed to run database migrations: %v", err)
+	clientRepo := store.clientRepo
+	projectRepo := store.projectRepo
+	contentRepo := store.contentRepo
+	contentVersionRepo := store.contentVersionRepo
+	feedbackRepo := store.feedbackRepo
+	eventRepo := store.eventRepo
+
+	// Initialize services
+	pipelineSchema, err := loadPipelineSchema(config)
+	if err != nil {
+		log.Fatalf("Failed to load pipeline configuration: %v", err)
 	}
 
-	// Initialize repositories
-	clientRepo := database.NewClientRepository(db)
-	projectRepo := database.NewProjectRepository(db)
-	contentRepo := database.NewContentRepository(db)
-	contentVersionRepo := database.NewContentVersionRepository(db)
-	feedbackRepo := database.NewFeedbackRepository(db)
-	eventRepo := database.NewEventRepository(db)
+	// Count tokens with the tokenizer of each configured model
+	tokenizers := content_creation.NewTokenizerRegistry(config.TokenizerVocabDir)
+	tokenizers.UseVocabularyURL(config.TokenizerVocabURL)
+	if err := tokenizers.LoadModels(pipelineSchema.GetLLMModels()...); err != nil {
+		if config.TokenizerStrict {
+			log.Fatalf("Failed to load tokenizer vocabularies from %s: %v", config.TokenizerVocabDir, err)
+		}
+		fmt.Printf("Warning: failed to load tokenizer vocabularies from %s, token counts are estimated: %v\n", config.TokenizerVocabDir, err)
+	}
 
-	// Initialize services
-	llmClient := content_creation.NewOpenAIClient(
-		config.LLMAPIKey,
-		config.LLMModel,
-		config.LLMMaxTokens,
-		config.LLMTemperature,
-	)
+	llmClient, err := newLLMClient(config, pipelineSchema, tokenizers)
+	if err != nil {
+		log.Fatalf("Failed to initialize LLM client: %v", err)
+	}
 
-	searchService := content_creation.NewWebSearchService(
-		config.SearchAPIKey,
-		config.SearchURL,
+	// Record the tokens and cost of every LLM call made for a content
+	usageTracker := content_creation.NewLLMUsageTracker(
+		store.llmUsageRepo,
+		projectRepo,
+		pricing.NewLLMCostCalculator(pipelineSchema.GetLLMRates()),
 	)
+	meteredClient := content_creation.NewMeteredLLMClient(llmClient, usageTracker, pipelineSchema.LLMConfigs["default"].Model)
+	meteredClient.UseTokenizers(tokenizers)
+	llmClient = meteredClient
 
+	// Serve repeated prompts from the cache; cache hits are not metered since they cost nothing
+	var llmCache *content_creation.CachingLLMClient
+	if store.llmCacheRepo != nil {
+		llmCache = content_creation.NewCachingLLMClient(
+			llmClient,
+			store.llmCacheRepo,
+			pipelineSchema.LLMConfigs["default"].Model,
+			time.Duration(config.LLMCacheTTLMinutes)*time.Minute,
+		)
+		llmClient = llmCache
+	}
+
+	// Fetch research pages politely and keep their responses on disk
+	fetcherConfig := content_creation.DefaultFetcherConfig()
+	if config.FetchUserAgent != "" {
+		fetcherConfig.UserAgent = config.FetchUserAgent
+	}
+	fetcherConfig.MaxBodyBytes = config.FetchMaxBodyBytes
+	fetcherConfig.HostDelay = time.Duration(config.FetchHostDelayMillis) * time.Millisecond
+	fetcherConfig.CacheDir = config.FetchCacheDir
+	fetcherConfig.CacheTTL = time.Duration(config.FetchCacheTTLMinutes) * time.Minute
+
+	searchService, err := content_creation.NewSearchProviderRegistry().Build(content_creation.SearchProviderConfig{
+		Provider:  config.SearchProvider,
+		BaseURL:   config.SearchURL,
+		APIKey:    config.SearchAPIKey,
+		CorpusDir: config.SearchCorpusDir,
+		Fetcher:   content_creation.NewPageFetcher(fetcherConfig),
+	})
+	if err != nil {
+		log.Fatalf("Failed to initialize search provider: %v", err)
+	}
+
+	// Index content versions and research sources for plagiarism and self-plagiarism checks
+	similarityIndex := content_creation.NewSimilarityIndex(store.similarityRepo, projectRepo)
 	plagiarismAPI := content_creation.NewSimplePlagiarismAPI()
-	readabilityScorer := content_creation.NewBasicReadabilityScorer()
-	seoAnalyzer := content_creation.NewBasicSEOAnalyzer()
+	plagiarismAPI.UseSimilarityIndex(similarityIndex)
 
-	contextManager := content_creation.NewInMemoryContextManager(
-		clientRepo,
+	// Keep project conversation context in storage, shared by API and worker processes
+	contextManager := content_creation.NewPersistentContextManager(
+		store.contextRepo,
 		config.ContextWindowSize,
+		config.ContextCacheSize,
+		time.Duration(config.ContextIdleMinutes)*time.Minute,
 	)
+	// Summarise evicted context entries into a pinned project memory instead of dropping them
+	contextManager.UseCompactor(content_creation.NewContextCompactor(llmClient))
+	contextManager.UseTokenizer(tokenizers.ForModel(pipelineSchema.LLMConfigs["default"].Model))
 
-	qualityChecker := content_creation.NewLLMQualityChecker(
-		llmClient,
-		plagiarismAPI,
-		readabilityScorer,
-		seoAnalyzer,
+	// Assess edited content with the quality assurance system and keep each report
+	qualityAssurance := content_creation.NewQualityAssuranceSystem(llmClient, searchService, plagiarismAPI)
+	qualityAssurance.UseSimilarityIndex(similarityIndex)
+	qualityChecker := content_creation.NewAssessingQualityChecker(
+		qualityAssurance,
+		store.qualityRepo,
+		pipelineSchema.QualityConfigs,
 	)
 
 	pipelineConfig := content_creation.PipelineConfig{
@@ -87,12 +150,15 @@ func main() {
 		llmClient,
 		searchService,
 	)
+	researcher.UseSimilarityIndex(similarityIndex)
 
 	contentPipeline := content_creation.NewContentPipeline(
 		contentRepo,
 		contentVersionRepo,
 		projectRepo,
 		eventRepo,
+		store.checkpointRepo,
+		content_creation.NewProgressBroker(0, 0),
 		llmClient,
 		contextManager,
 		researcher,
@@ -100,13 +166,50 @@ func main() {
 		pipelineConfig,
 	)
 
+	contentPipeline.UseSimilarityIndex(similarityIndex)
+
+	// Render stage prompts from stored templates, falling back to the built-in ones
+	promptTemplates := content_creation.NewPromptTemplateManager()
+	promptTemplates.UseRepository(store.promptTemplateRepo, clientRepo)
+	contentPipeline.UsePromptTemplates(promptTemplates)
+
+	// Build each content type's stages from the pipeline schema
+	contentPipeline.UseSchema(pipelineSchema)
+	for contentType := range pipelineSchema.ContentTypeConfigs {
+		if _, err := contentPipeline.StagePlan(contentType); err != nil {
+			log.Fatalf("Invalid pipeline stages: %v", err)
+		}
+	}
+
+	// Revise content that misses its quality thresholds and escalate what still fails
+	contentPipeline.UseQualityGate(content_creation.NewQualityGate(
+		llmClient,
+		qualityAssurance.ImprovementEngine(),
+		qualityAssurance.RevisionTracker(),
+		pipelineSchema,
+	))
+
+	// Start the background workers that run the content pipeline
+	jobManager := jobs.NewManager(store.contentJobRepo, contentPipeline, jobs.Config{
+		Workers:     config.JobWorkers,
+		MaxAttempts: config.JobMaxAttempts,
+	})
+	if err := jobManager.Start(context.Background()); err != nil {
+		log.Fatalf("Failed to start content jobs: %v", err)
+	}
+
 	// Initialize handlers
 	contentHandler := handlers.NewContentHandler(
 		contentRepo,
 		projectRepo,
 		feedbackRepo,
 		contentPipeline,
+		jobManager,
 	)
+	jobHandler := handlers.NewJobHandler(jobManager)
+	usageHandler := handlers.NewUsageHandler(usageTracker, llmCache, store.priceQuoteRepo)
+	qualityHandler := handlers.NewQualityHandler(store.qualityRepo)
+	promptTemplateHandler := handlers.NewPromptTemplateHandler(store.promptTemplateRepo, promptTemplates)
 
 	projectHandler := handlers.NewProjectHandler(
 		projectRepo,
@@ -123,7 +226,7 @@ func main() {
 
 	// Set up API routes
 	apiRouter := router.PathPrefix("/api/v1").Subrouter()
-	api.SetupRoutes(apiRouter, contentHandler, projectHandler, nil, dashboardHandler) // nil for onboarding handler until we initialize it
+	api.SetupRoutes(apiRouter, contentHandler, jobHandler, usageHandler, qualityHandler, promptTemplateHandler, projectHandler, nil, dashboardHandler) // nil for onboarding handler until we initialize it
 
 	// Set up server
 	server := &http.Server{
@@ -157,6 +260,11 @@ func main() {
 		log.Fatalf("Server forced to shutdown: %v", err)
 	}
 
+	// Stop the workers; interrupted jobs resume on the next start
+	if err := jobManager.Stop(ctx); err != nil {
+		log.Printf("Content jobs did not stop cleanly: %v", err)
+	}
+
 	log.Println("Server exited gracefully")
 }
 
@@ -0,0 +1,275 @@
+package content_creation
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Ceesaxp/autonomous-content-service/src/domain/entities"
+)
+
+// CitationStyle selects how citations and the bibliography are rendered
+type CitationStyle string
+
+const (
+	CitationStyleAPA        CitationStyle = "apa"
+	CitationStyleChicago    CitationStyle = "chicago"    // author-date
+	CitationStyleHyperlinks CitationStyle = "hyperlinks" // numbered links and a list of sources
+)
+
+// Valid reports whether the style is known
+func (s CitationStyle) Valid() bool {
+	switch s {
+	case CitationStyleAPA, CitationStyleChicago, CitationStyleHyperlinks:
+		return true
+	default:
+		return false
+	}
+}
+
+// citationMarkerPattern matches the inline markers drafts use to cite research sources,
+// numbered from 1 in the order of the research sources
+var citationMarkerPattern = regexp.MustCompile(`\[source:\s*(\d+)\]`)
+
+const keepCitationsInstruction = "\n\nKeep every citation marker such as [source:1] next to the claim it supports. " +
+	"Do not renumber, merge, invent or remove markers."
+
+// Citation is a research source cited in the content
+type Citation struct {
+	Source int    `json:"source"` // the number of the source in the research, as used by markers
+	Title  string `json:"title"`
+	URL    string `json:"url,omitempty"`
+	Entry  string `json:"entry"` // the rendered bibliography entry
+}
+
+// researchSources returns the research sources stored in the content metadata. Metadata
+// loaded from storage holds the research as decoded JSON, so it is converted either way.
+func researchSources(content *entities.Content) []ResearchSource {
+	research, ok := content.Metadata["research"]
+	if !ok {
+		return nil
+	}
+	data, err := json.Marshal(research)
+	if err != nil {
+		return nil
+	}
+
+	var decoded struct {
+		Sources []ResearchSource `json:"sources"`
+	}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return nil
+	}
+	return decoded.Sources
+}
+
+// citationStyleFor returns the citation style of the content: a citationStyle metadata
+// entry, then the style of its content type, then hyperlinks
+func citationStyleFor(content *entities.Content, schema *PipelineConfigSchema) CitationStyle {
+	if style, ok := content.Metadata["citationStyle"].(string); ok && CitationStyle(style).Valid() {
+		return CitationStyle(style)
+	}
+	if schema != nil {
+		if typeConfig, ok := schema.GetContentTypeConfig(content.Type); ok && typeConfig.CitationStyle.Valid() {
+			return typeConfig.CitationStyle
+		}
+	}
+	return CitationStyleHyperlinks
+}
+
+// citationInstructions tells the drafting model which sources it can cite and how
+func citationInstructions(sources []ResearchSource) string {
+	var instructions strings.Builder
+	instructions.WriteString("\n\nCite the research sources below with inline markers such as [source:2] placed right after the claims they support. ")
+	instructions.WriteString("Only cite these sources, by their numbers:\n")
+	for i, source := range sources {
+		fmt.Fprintf(&instructions, "[source:%d] %s", i+1, source.Title)
+		if source.URL != "" {
+			fmt.Fprintf(&instructions, " (%s)", source.URL)
+		}
+		instructions.WriteString("\n")
+	}
+	return instructions.String()
+}
+
+// checkCitations returns an error listing the markers that cite no research source
+func checkCitations(text string, sources []ResearchSource) error {
+	var unknown []string
+	for _, match := range citationMarkerPattern.FindAllStringSubmatch(text, -1) {
+		number, _ := strconv.Atoi(match[1])
+		if number < 1 || number > len(sources) {
+			unknown = append(unknown, match[0])
+		}
+	}
+	if len(unknown) > 0 {
+		return fmt.Errorf("citation markers %s do not match any of the %d research sources", strings.Join(unknown, ", "), len(sources))
+	}
+	return nil
+}
+
+// renderCitations replaces the citation markers in text with in-text citations in the
+// given style and appends a bibliography of the cited sources, both in the output format
+// of the template that wrote text. A marker citing a source that is not in the research
+// is an error.
+func renderCitations(text string, sources []ResearchSource, style CitationStyle, format entities.PromptOutputFormat) (string, []Citation, error) {
+	if err := checkCitations(text, sources); err != nil {
+		return "", nil, err
+	}
+
+	// Number the cited sources in order of first citation
+	order := make(map[int]int)
+	var cited []int
+	for _, match := range citationMarkerPattern.FindAllStringSubmatch(text, -1) {
+		number, _ := strconv.Atoi(match[1])
+		if _, seen := order[number]; !seen {
+			cited = append(cited, number)
+			order[number] = len(cited)
+		}
+	}
+	if len(cited) == 0 {
+		return text, []Citation{}, nil
+	}
+
+	rendered := citationMarkerPattern.ReplaceAllStringFunc(text, func(marker string) string {
+		number, _ := strconv.Atoi(citationMarkerPattern.FindStringSubmatch(marker)[1])
+		return inTextCitation(sources[number-1], order[number], style, format)
+	})
+
+	citations := make([]Citation, len(cited))
+	for i, number := range cited {
+		source := sources[number-1]
+		citations[i] = Citation{
+			Source: number,
+			Title:  source.Title,
+			URL:    source.URL,
+			Entry:  bibliographyEntry(source, style, format),
+		}
+	}
+
+	// Author-date styles list their sources alphabetically; numbered links keep citation order
+	entries := make([]Citation, len(citations))
+	copy(entries, citations)
+	heading := "Sources"
+	switch style {
+	case CitationStyleAPA:
+		heading = "References"
+	case CitationStyleChicago:
+		heading = "Bibliography"
+	}
+	if style != CitationStyleHyperlinks {
+		sort.SliceStable(entries, func(a, b int) bool {
+			return strings.ToLower(entries[a].Entry) < strings.ToLower(entries[b].Entry)
+		})
+	}
+
+	var bibliography strings.Builder
+	if format == entities.PromptOutputFormatHTML {
+		list := "ul"
+		if style == CitationStyleHyperlinks {
+			list = "ol"
+		}
+		fmt.Fprintf(&bibliography, "\n\n<h2>%s</h2>\n<%s>\n", heading, list)
+		for _, citation := range entries {
+			fmt.Fprintf(&bibliography, "<li>%s</li>\n", citation.Entry)
+		}
+		fmt.Fprintf(&bibliography, "</%s>\n", list)
+	} else {
+		fmt.Fprintf(&bibliography, "\n\n## %s\n\n", heading)
+		for i, citation := range entries {
+			if style == CitationStyleHyperlinks {
+				fmt.Fprintf(&bibliography, "%d. %s\n", i+1, citation.Entry)
+			} else {
+				fmt.Fprintf(&bibliography, "%s\n\n", citation.Entry)
+			}
+		}
+	}
+
+	return strings.TrimRight(rendered, "\n") + strings.TrimRight(bibliography.String(), "\n") + "\n", citations, nil
+}
+
+// inTextCitation renders the citation of a source in the text
+func inTextCitation(source ResearchSource, number int, style CitationStyle, format entities.PromptOutputFormat) string {
+	switch style {
+	case CitationStyleAPA:
+		return escapeCitation(fmt.Sprintf("(\"%s,\" %s)", shortTitle(source.Title), sourceYear(source)), format)
+	case CitationStyleChicago:
+		return escapeCitation(fmt.Sprintf("(\"%s\" %s)", shortTitle(source.Title), sourceYear(source)), format)
+	default:
+		if source.URL == "" {
+			return fmt.Sprintf("[%d]", number)
+		}
+		if format == entities.PromptOutputFormatHTML {
+			return fmt.Sprintf("<a href=\"%s\">[%d]</a>", html.EscapeString(source.URL), number)
+		}
+		return fmt.Sprintf("[[%d]](%s)", number, source.URL)
+	}
+}
+
+// bibliographyEntry renders the bibliography entry of a web source
+func bibliographyEntry(source ResearchSource, style CitationStyle, format entities.PromptOutputFormat) string {
+	if format == entities.PromptOutputFormatHTML && style == CitationStyleHyperlinks && source.URL != "" {
+		return fmt.Sprintf("<a href=\"%s\">%s</a>", html.EscapeString(source.URL), html.EscapeString(source.Title))
+	}
+	return escapeCitation(markdownBibliographyEntry(source, style), format)
+}
+
+// escapeCitation escapes citation text for HTML output
+func escapeCitation(text string, format entities.PromptOutputFormat) string {
+	if format == entities.PromptOutputFormatHTML {
+		return html.EscapeString(text)
+	}
+	return text
+}
+
+// markdownBibliographyEntry renders the Markdown bibliography entry of a web source
+func markdownBibliographyEntry(source ResearchSource, style CitationStyle) string {
+	site := extractDomain(source.URL)
+	switch style {
+	case CitationStyleAPA:
+		entry := fmt.Sprintf("%s. (%s).", strings.TrimSuffix(source.Title, "."), sourceYear(source))
+		if site != "" {
+			entry += " " + site + "."
+		}
+		if source.URL != "" {
+			entry += " " + source.URL
+		}
+		return entry
+	case CitationStyleChicago:
+		entry := fmt.Sprintf("\"%s.\"", strings.TrimSuffix(source.Title, "."))
+		if site != "" {
+			entry += " " + site + "."
+		}
+		if !source.LastUpdated.IsZero() {
+			entry += " Last modified " + source.LastUpdated.Format("January 2, 2006") + "."
+		}
+		if source.URL != "" {
+			entry += " " + source.URL + "."
+		}
+		return entry
+	default:
+		if source.URL == "" {
+			return source.Title
+		}
+		return fmt.Sprintf("[%s](%s)", source.Title, source.URL)
+	}
+}
+
+func sourceYear(source ResearchSource) string {
+	if source.LastUpdated.IsZero() {
+		return "n.d."
+	}
+	return strconv.Itoa(source.LastUpdated.Year())
+}
+
+// shortTitle shortens a title to its first few words for in-text citations
+func shortTitle(title string) string {
+	words := strings.Fields(strings.TrimSuffix(title, "."))
+	if len(words) <= 4 {
+		return strings.Join(words, " ")
+	}
+	return strings.Join(words[:4], " ") + "…"
+}
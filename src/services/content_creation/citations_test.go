@@ -0,0 +1,143 @@
+package content_creation
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Ceesaxp/autonomous-content-service/src/domain/entities"
+	"github.com/Ceesaxp/autonomous-content-service/src/infrastructure/memory"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var citedSources = []ResearchSource{
+	{Title: "Coffee Consumption Report", URL: "https://stats.example.org/coffee", LastUpdated: time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC)},
+	{Title: "Growing Arabica at Altitude", URL: "https://farms.example.com/arabica", LastUpdated: time.Date(2023, 7, 1, 0, 0, 0, 0, time.UTC)},
+}
+
+func TestRenderCitations(t *testing.T) {
+	text := "Arabica grows best above 1,000 metres [source:2]. Demand keeps rising [source:1][source:2]."
+
+	linked, citations, err := renderCitations(text, citedSources, CitationStyleHyperlinks, entities.PromptOutputFormatMarkdown)
+	require.NoError(t, err)
+	assert.Equal(t, "Arabica grows best above 1,000 metres [[1]](https://farms.example.com/arabica). "+
+		"Demand keeps rising [[2]](https://stats.example.org/coffee)[[1]](https://farms.example.com/arabica).\n\n"+
+		"## Sources\n\n"+
+		"1. [Growing Arabica at Altitude](https://farms.example.com/arabica)\n"+
+		"2. [Coffee Consumption Report](https://stats.example.org/coffee)\n", linked)
+	require.Len(t, citations, 2)
+	assert.Equal(t, 2, citations[0].Source)
+
+	apa, _, err := renderCitations(text, citedSources, CitationStyleAPA, entities.PromptOutputFormatMarkdown)
+	require.NoError(t, err)
+	assert.Contains(t, apa, `metres ("Growing Arabica at Altitude," 2023).`)
+	assert.True(t, strings.HasSuffix(apa, "## References\n\n"+
+		"Coffee Consumption Report. (2024). stats.example.org. https://stats.example.org/coffee\n\n"+
+		"Growing Arabica at Altitude. (2023). farms.example.com. https://farms.example.com/arabica\n"))
+
+	chicago, _, err := renderCitations(text, citedSources, CitationStyleChicago, entities.PromptOutputFormatMarkdown)
+	require.NoError(t, err)
+	assert.Contains(t, chicago, `"Coffee Consumption Report." stats.example.org. Last modified March 5, 2024. https://stats.example.org/coffee.`)
+
+	_, _, err = renderCitations("Unsupported claim [source:3].", citedSources, CitationStyleAPA, entities.PromptOutputFormatMarkdown)
+	assert.ErrorContains(t, err, "[source:3]")
+}
+
+func TestRenderCitations_HTML(t *testing.T) {
+	text := "<p>Arabica grows best above 1,000 metres [source:2]. Demand keeps rising [source:1].</p>"
+	sources := append([]ResearchSource{{Title: "Coffee & Tea Report", URL: "https://stats.example.org/coffee?year=2024&region=eu"}}, citedSources[1])
+
+	linked, citations, err := renderCitations(text, sources, CitationStyleHyperlinks, entities.PromptOutputFormatHTML)
+	require.NoError(t, err)
+	assert.Equal(t, "<p>Arabica grows best above 1,000 metres <a href=\"https://farms.example.com/arabica\">[1]</a>. "+
+		"Demand keeps rising <a href=\"https://stats.example.org/coffee?year=2024&amp;region=eu\">[2]</a>.</p>\n\n"+
+		"<h2>Sources</h2>\n<ol>\n"+
+		"<li><a href=\"https://farms.example.com/arabica\">Growing Arabica at Altitude</a></li>\n"+
+		"<li><a href=\"https://stats.example.org/coffee?year=2024&amp;region=eu\">Coffee &amp; Tea Report</a></li>\n"+
+		"</ol>\n", linked)
+	assert.Equal(t, "<a href=\"https://farms.example.com/arabica\">Growing Arabica at Altitude</a>", citations[0].Entry)
+
+	apa, _, err := renderCitations(text, sources, CitationStyleAPA, entities.PromptOutputFormatHTML)
+	require.NoError(t, err)
+	assert.Contains(t, apa, "rising (&#34;Coffee &amp; Tea Report,&#34; n.d.).</p>")
+	assert.True(t, strings.HasSuffix(apa, "<h2>References</h2>\n<ul>\n"+
+		"<li>Coffee &amp; Tea Report. (n.d.). stats.example.org. https://stats.example.org/coffee?year=2024&amp;region=eu</li>\n"+
+		"<li>Growing Arabica at Altitude. (2023). farms.example.com. https://farms.example.com/arabica</li>\n"+
+		"</ul>\n"))
+	assert.NotContains(t, apa, "##")
+}
+
+func TestContentPipeline_CitationsFollowTheFinalizeTemplateFormat(t *testing.T) {
+	ctx := context.Background()
+	repos := newTestRepositories()
+	llm := new(MockLLMClient)
+	contextManager := new(MockContextManager)
+	pipeline := repos.newPipeline(llm, contextManager, new(MockResearcher), new(MockQualityChecker), PipelineConfig{})
+	pipeline.UseSchema(DefaultPipelineConfigSchema())
+
+	manager := NewPromptTemplateManager()
+	manager.UseRepository(memory.NewPromptTemplateRepository(), nil)
+	pipeline.UsePromptTemplates(manager)
+
+	content, err := entities.NewContent(uuid.New(), "Coffee", entities.ContentTypeWebsiteCopy)
+	require.NoError(t, err)
+	content.Data = "Arabica grows best above 1,000 metres [source:2]."
+	content.UpdateMetadata("research", map[string]interface{}{"sources": citedSources})
+	project := repos.seedProject(t, content.ProjectID, entities.ContentTypeWebsiteCopy)
+
+	contextManager.On("AddEntry", mock.Anything, content.ProjectID, mock.Anything).Return(nil)
+	llm.On("Generate", mock.Anything, mock.Anything).Return("<p>Arabica grows best above 1,000 metres [source:2].</p>", nil)
+
+	// The built-in website copy template writes HTML
+	result, err := pipeline.finalizationStage(ctx, content)
+	require.NoError(t, err)
+	assert.Contains(t, result.Content, `<a href="https://farms.example.com/arabica">[1]</a>`)
+	assert.Contains(t, result.Content, "<h2>Sources</h2>")
+
+	// A client whose override writes Markdown gets Markdown citations
+	override, err := entities.NewPromptTemplate("finalize", entities.ContentTypeWebsiteCopy, "", &project.ClientID, "Finalize {{.AdditionalContext.EditedDraft}} as Markdown", "editor@example.com")
+	require.NoError(t, err)
+	require.NoError(t, manager.repository.Create(ctx, override))
+
+	result, err = pipeline.finalizationStage(ctx, content)
+	require.NoError(t, err)
+	assert.Contains(t, result.Content, "[[1]](https://farms.example.com/arabica)")
+	assert.Contains(t, result.Content, "## Sources")
+	assert.NotContains(t, result.Content, "<h2>")
+}
+
+func TestContentPipeline_FinalizationRejectsUnknownSources(t *testing.T) {
+	repos := newTestRepositories()
+	mockLLMClient := new(MockLLMClient)
+	mockContextManager := new(MockContextManager)
+	mockResearcher := new(MockResearcher)
+	mockQualityChecker := new(MockQualityChecker)
+
+	pipeline := repos.newPipeline(mockLLMClient, mockContextManager, mockResearcher, mockQualityChecker, PipelineConfig{
+		MaxRetries:          1,
+		StageTimeoutSeconds: 5,
+	})
+	pipeline.UseSchema(DefaultPipelineConfigSchema())
+
+	projectID := uuid.New()
+	repos.seedProject(t, projectID, entities.ContentTypeBlogPost)
+
+	body := strings.TrimSpace(strings.Repeat("Coffee ", 520))
+	mockContextManager.On("SwitchContext", mock.Anything, projectID).Return(nil)
+	mockContextManager.On("AddEntry", mock.Anything, projectID, mock.Anything).Return(nil)
+	mockResearcher.On("Research", mock.Anything, mock.Anything, mock.Anything).Return(&ResearchOutput{Summary: "Research", Sources: citedSources}, nil)
+	mockQualityChecker.On("CheckContent", mock.Anything, mock.Anything, mock.Anything).Return(QualityCheckOutput{}, nil)
+	mockLLMClient.On("Generate", mock.Anything, promptContaining("Finalize the following")).Return(body+" [source:3].", nil)
+	mockLLMClient.On("Generate", mock.Anything, promptContaining("[source:2] Growing Arabica at Altitude (https://farms.example.com/arabica)")).Return(body+" [source:1].", nil)
+	mockLLMClient.On("Generate", mock.Anything, mock.Anything).Return(body+" [source:1].", nil)
+
+	content, err := pipeline.CreateContent(context.Background(), projectID, "Coffee Trends", entities.ContentTypeBlogPost)
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "citation markers [source:3] do not match any of the 2 research sources")
+	assert.Equal(t, entities.ContentStatusEditing, content.Status)
+	assert.True(t, strings.HasSuffix(content.Data, "[source:1]."))
+}
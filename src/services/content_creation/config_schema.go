@@ -3,9 +3,11 @@ package content_creation
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/Ceesaxp/autonomous-content-service/src/domain/entities"
+	"github.com/Ceesaxp/autonomous-content-service/src/services/pricing"
 )
 
 // ContentTypeConfig defines configuration for a specific content type
@@ -18,6 +20,7 @@ type ContentTypeConfig struct {
 	QualityThresholds   QualityThresholds    `json:"qualityThresholds"`
 	TimeoutPerStage     time.Duration        `json:"timeoutPerStage"`
 	ResearchRequirements ResearchConfig      `json:"researchRequirements"`
+	CitationStyle       CitationStyle        `json:"citationStyle,omitempty"` // empty uses hyperlinks
 }
 
 // QualityThresholds defines minimum quality scores required
@@ -57,16 +60,21 @@ type PipelineConfigSchema struct {
 	StageConfigs      map[PipelineStage]StageConfig    `json:"stageConfigs"`
 	LLMConfigs        map[string]LLMConfig            `json:"llmConfigs"`
 	QualityConfigs    QualityConfig                   `json:"qualityConfigs"`
+	LLMRates          pricing.LLMRateTable            `json:"llmRates,omitempty"` // per-model prices, overriding the defaults
+	ProviderLimits    map[string]ProviderLimits       `json:"providerLimits,omitempty"` // request budgets and circuit breakers by provider
 }
 
 // LLMConfig defines configuration for LLM clients
 type LLMConfig struct {
 	Provider     string  `json:"provider"`
+	BaseURL      string  `json:"baseUrl,omitempty"`   // overrides the provider's default endpoint
+	APIKeyEnv    string  `json:"apiKeyEnv,omitempty"` // environment variable holding the API key
 	Model        string  `json:"model"`
 	Temperature  float64 `json:"temperature"`
 	MaxTokens    int     `json:"maxTokens"`
 	Timeout      time.Duration `json:"timeout"`
 	RetryPolicy  RetryConfig   `json:"retryPolicy"`
+	Fallbacks    []string      `json:"fallbacks,omitempty"` // LLM configs tried in order when this one fails
 }
 
 // RetryConfig defines retry behavior
@@ -77,6 +85,14 @@ type RetryConfig struct {
 	MaxDelay        time.Duration `json:"maxDelay"`
 }
 
+// ProviderLimits defines the budgets and circuit breaker shared by all LLM configs of a provider
+type ProviderLimits struct {
+	RequestsPerMinute int           `json:"requestsPerMinute,omitempty"` // zero is unlimited
+	TokensPerMinute   int           `json:"tokensPerMinute,omitempty"`   // zero is unlimited
+	FailureThreshold  int           `json:"failureThreshold,omitempty"`  // consecutive failures that open the circuit
+	CircuitCooldown   time.Duration `json:"circuitCooldown,omitempty"`   // how long an open circuit rejects calls
+}
+
 // QualityConfig defines quality checking configuration
 type QualityConfig struct {
 	EnableReadabilityCheck bool                      `json:"enableReadabilityCheck"`
@@ -84,11 +100,15 @@ type QualityConfig struct {
 	EnableEngagementCheck  bool                      `json:"enableEngagementCheck"`
 	EnablePlagiarismCheck  bool                      `json:"enablePlagiarismCheck"`
 	EnableFactCheck        bool                      `json:"enableFactCheck"`
+	EnableStyleCheck       bool                      `json:"enableStyleCheck"`
+	EnableMultiPassReview  bool                      `json:"enableMultiPassReview"`
 	ReadabilityTools      []string                  `json:"readabilityTools"`
 	SEOTools              []string                  `json:"seoTools"`
 	PlagiarismTools       []string                  `json:"plagiarismTools"`
 	FactCheckTools        []string                  `json:"factCheckTools"`
 	QualityThresholds     map[entities.ContentType]QualityThresholds `json:"qualityThresholds"`
+	MaxRevisions          *int                      `json:"maxRevisions,omitempty"` // improve-and-recheck rounds before escalating to human review; unset uses the default, 0 escalates at once
+	SuggestionsPerRevision int                      `json:"suggestionsPerRevision"` // top improvement suggestions applied per round
 }
 
 // DefaultPipelineConfigSchema returns a default configuration schema
@@ -175,6 +195,7 @@ func DefaultPipelineConfigSchema() *PipelineConfigSchema {
 					RequireRecent:     true,
 					FactCheckRequired: true,
 				},
+				CitationStyle: CitationStyleAPA,
 			},
 			entities.ContentTypeEmailNewsletter: {
 				Type:         entities.ContentTypeEmailNewsletter,
@@ -295,10 +316,13 @@ func DefaultPipelineConfigSchema() *PipelineConfigSchema {
 			EnableEngagementCheck:  true,
 			EnablePlagiarismCheck:  true,
 			EnableFactCheck:        true,
+			EnableStyleCheck:       true,
+			EnableMultiPassReview:  true,
 			ReadabilityTools:       []string{"flesch-kincaid", "coleman-liau"},
 			SEOTools:               []string{"keyword-density", "meta-analysis"},
 			PlagiarismTools:        []string{"similarity-check", "source-verification"},
 			FactCheckTools:         []string{"llm-verification", "source-validation"},
+			SuggestionsPerRevision: 3,
 			QualityThresholds: map[entities.ContentType]QualityThresholds{
 				entities.ContentTypeBlogPost: {
 					MinReadabilityScore: 70.0,
@@ -312,6 +336,24 @@ func DefaultPipelineConfigSchema() *PipelineConfigSchema {
 					MinEngagementScore:  85.0,
 					MinPlagiarismScore:  0.90,
 				},
+				entities.ContentTypeWebsiteCopy: {
+					MinReadabilityScore: 75.0,
+					MinSEOScore:         70.0,
+					MinEngagementScore:  75.0,
+					MinPlagiarismScore:  0.90,
+				},
+				entities.ContentTypeProductDescription: {
+					MinReadabilityScore: 75.0,
+					MinSEOScore:         65.0,
+					MinEngagementScore:  70.0,
+					MinPlagiarismScore:  0.90,
+				},
+				entities.ContentTypePressRelease: {
+					MinReadabilityScore: 60.0,
+					MinSEOScore:         50.0,
+					MinEngagementScore:  60.0,
+					MinPlagiarismScore:  0.90,
+				},
 			},
 		},
 	}
@@ -361,6 +403,10 @@ func (c *PipelineConfigSchema) Validate() error {
 		if len(config.RequiredStages) == 0 {
 			return fmt.Errorf("at least one required stage must be specified for %s", contentType)
 		}
+
+		if config.CitationStyle != "" && !config.CitationStyle.Valid() {
+			return fmt.Errorf("unknown citation style %q for %s", config.CitationStyle, contentType)
+		}
 	}
 	
 	// Validate stage configs
@@ -395,6 +441,19 @@ func (c *PipelineConfigSchema) Validate() error {
 		if config.MaxTokens <= 0 {
 			return fmt.Errorf("maxTokens must be positive for LLM config %s", name)
 		}
+
+		for _, fallback := range config.Fallbacks {
+			if fallback == name {
+				return fmt.Errorf("LLM config %s cannot fall back to itself", name)
+			}
+			if _, exists := c.LLMConfigs[fallback]; !exists {
+				return fmt.Errorf("unknown fallback %s for LLM config %s", fallback, name)
+			}
+		}
+	}
+
+	if c.QualityConfigs.MaxRevisions != nil && *c.QualityConfigs.MaxRevisions < 0 {
+		return fmt.Errorf("maxRevisions cannot be negative")
 	}
 	
 	return nil
@@ -406,6 +465,16 @@ func (c *PipelineConfigSchema) GetContentTypeConfig(contentType entities.Content
 	return config, exists
 }
 
+// GetQualityThresholds returns the quality thresholds for a content type. Thresholds in
+// the content type config take precedence over those in the quality config.
+func (c *PipelineConfigSchema) GetQualityThresholds(contentType entities.ContentType) (QualityThresholds, bool) {
+	if config, exists := c.ContentTypeConfigs[contentType]; exists && config.QualityThresholds != (QualityThresholds{}) {
+		return config.QualityThresholds, true
+	}
+	thresholds, exists := c.QualityConfigs.QualityThresholds[contentType]
+	return thresholds, exists
+}
+
 // GetStageConfig returns configuration for a specific stage
 func (c *PipelineConfigSchema) GetStageConfig(stage PipelineStage) (StageConfig, bool) {
 	config, exists := c.StageConfigs[stage]
@@ -418,6 +487,29 @@ func (c *PipelineConfigSchema) GetLLMConfig(name string) (LLMConfig, bool) {
 	return config, exists
 }
 
+// GetLLMRates returns the default LLM rates with the configured rates applied on top
+func (c *PipelineConfigSchema) GetLLMRates() pricing.LLMRateTable {
+	rates := pricing.DefaultLLMRates()
+	for model, rate := range c.LLMRates {
+		rates[model] = rate
+	}
+	return rates
+}
+
+// GetLLMModels returns the models of the LLM configs, sorted and without duplicates
+func (c *PipelineConfigSchema) GetLLMModels() []string {
+	seen := make(map[string]bool)
+	var models []string
+	for _, config := range c.LLMConfigs {
+		if config.Model != "" && !seen[config.Model] {
+			seen[config.Model] = true
+			models = append(models, config.Model)
+		}
+	}
+	sort.Strings(models)
+	return models
+}
+
 // CreatePipelineConfig creates a PipelineConfig from the schema for a specific content type
 func (c *PipelineConfigSchema) CreatePipelineConfig(contentType entities.ContentType) PipelineConfig {
 	contentConfig, exists := c.GetContentTypeConfig(contentType)
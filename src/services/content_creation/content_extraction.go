@@ -0,0 +1,612 @@
+package content_creation
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"time"
+
+	"golang.org/x/net/html"
+)
+
+// FetchedPage is the main content of a fetched page
+type FetchedPage struct {
+	URL         string    `json:"url"` // after redirects
+	ContentType string    `json:"contentType"`
+	Title       string    `json:"title"`
+	Text        string    `json:"text"` // headings and list items are marked up as in Markdown
+	PublishedAt time.Time `json:"publishedAt,omitempty"`
+}
+
+// Document returns the page as research text: the title, the publish date and the content
+func (p *FetchedPage) Document() string {
+	var document strings.Builder
+	if p.Title != "" && !strings.HasPrefix(p.Text, "# "+p.Title+"\n") && p.Text != "# "+p.Title {
+		fmt.Fprintf(&document, "# %s\n\n", p.Title)
+	}
+	if !p.PublishedAt.IsZero() {
+		fmt.Fprintf(&document, "Published: %s\n\n", p.PublishedAt.Format("2006-01-02"))
+	}
+	document.WriteString(p.Text)
+	return strings.TrimSpace(document.String())
+}
+
+var (
+	// Elements that never hold article content
+	boilerplateTags = map[string]bool{
+		"script": true, "style": true, "noscript": true, "template": true, "iframe": true,
+		"svg": true, "canvas": true, "form": true, "button": true, "input": true, "select": true,
+		"textarea": true, "nav": true, "aside": true, "footer": true, "dialog": true,
+		"object": true, "embed": true, "link": true, "meta": true,
+	}
+	boilerplateRoles = map[string]bool{
+		"navigation": true, "banner": true, "contentinfo": true, "complementary": true,
+		"dialog": true, "alertdialog": true, "menu": true, "menubar": true, "search": true,
+	}
+	unlikelyCandidatePattern = regexp.MustCompile(`(?i)banner|breadcrumb|combx|comment|community|consent|cookie|disqus|extra|foot|gdpr|header|legends|menu|modal|nav|newsletter|pager|pagination|popup|promo|related|remark|replies|rss|share|shoutbox|sidebar|skyscraper|social|sponsor|subscribe|ad-break|agegate`)
+	maybeCandidatePattern    = regexp.MustCompile(`(?i)and|article|body|column|content|main|shadow`)
+	positiveWeightPattern    = regexp.MustCompile(`(?i)article|body|content|entry|hentry|h-entry|main|page|post|text|blog|story`)
+	negativeWeightPattern    = regexp.MustCompile(`(?i)-ad-|hidden|^hid$| hid$| hid |^hid |banner|combx|comment|com-|contact|foot|footer|footnote|gdpr|masthead|media|meta|outbrain|promo|related|scroll|share|shoutbox|sidebar|skyscraper|sponsor|shopping|tags|tool|widget`)
+	blockTags                = map[string]bool{
+		"address": true, "article": true, "blockquote": true, "dd": true, "details": true, "div": true,
+		"dl": true, "dt": true, "figcaption": true, "figure": true, "h1": true, "h2": true, "h3": true,
+		"h4": true, "h5": true, "h6": true, "header": true, "hr": true, "li": true, "main": true,
+		"ol": true, "p": true, "pre": true, "section": true, "summary": true, "table": true,
+		"td": true, "th": true, "tr": true, "ul": true, "br": true,
+	}
+	publishDateMetaNames = map[string]bool{
+		"article:published_time": true, "og:published_time": true, "datepublished": true,
+		"date": true, "pubdate": true, "publishdate": true, "publish_date": true,
+		"publication_date": true, "dc.date": true, "dc.date.issued": true, "dcterms.created": true,
+		"dcterms.date": true, "dcterms.issued": true, "sailthru.date": true, "parsely-pub-date": true,
+		"citation_publication_date": true, "citation_date": true,
+	}
+	publishDateLayouts = []string{
+		time.RFC3339Nano, time.RFC3339, "2006-01-02T15:04:05Z0700", "2006-01-02T15:04:05",
+		"2006-01-02T15:04", "2006-01-02 15:04:05", "2006-01-02", "2006/01/02", time.RFC1123Z,
+		time.RFC1123, "January 2, 2006", "Jan 2, 2006", "2 January 2006", "02 Jan 2006", "20060102",
+	}
+	whitespacePattern = regexp.MustCompile(`\s+`)
+)
+
+// extractPage extracts the title, publish date and main content of a fetched body
+func extractPage(pageURL, contentType, body string) *FetchedPage {
+	page := &FetchedPage{URL: pageURL, ContentType: contentType}
+	if contentType == "text/plain" {
+		page.Text = strings.TrimSpace(body)
+		return page
+	}
+
+	root, err := html.Parse(strings.NewReader(body))
+	if err != nil {
+		page.Text = collapseWhitespace(body)
+		return page
+	}
+
+	// Metadata is read before boilerplate is pruned, since it often lives in the page header
+	page.Title = pageTitle(root)
+	page.PublishedAt = publishDate(root)
+
+	pruneBoilerplate(root, false)
+	article := mainContent(root)
+
+	writer := &articleWriter{}
+	for _, node := range article {
+		writer.block(node)
+	}
+	writer.flush()
+	page.Text = writer.String()
+
+	if page.PublishedAt.IsZero() {
+		for _, node := range article {
+			if published := timeElementDate(node); !published.IsZero() {
+				page.PublishedAt = published
+				break
+			}
+		}
+	}
+	return page
+}
+
+// pageTitle prefers the Open Graph title, then the document title, then the first heading
+func pageTitle(root *html.Node) string {
+	var ogTitle, docTitle, heading string
+	walkElements(root, func(n *html.Node) bool {
+		switch n.Data {
+		case "meta":
+			if name := strings.ToLower(attr(n, "property") + attr(n, "name")); (name == "og:title" || name == "twitter:title") && ogTitle == "" {
+				ogTitle = collapseWhitespace(attr(n, "content"))
+			}
+		case "title":
+			if docTitle == "" {
+				docTitle = textContent(n)
+			}
+		case "h1":
+			if heading == "" {
+				heading = textContent(n)
+			}
+		}
+		return true
+	})
+
+	for _, title := range []string{ogTitle, docTitle, heading} {
+		if title != "" {
+			return title
+		}
+	}
+	return ""
+}
+
+// publishDate reads the publish date from meta tags, microdata or JSON-LD
+func publishDate(root *html.Node) time.Time {
+	var published time.Time
+	walkElements(root, func(n *html.Node) bool {
+		if !published.IsZero() {
+			return false
+		}
+		switch {
+		case n.Data == "meta":
+			for _, key := range []string{"property", "name", "itemprop"} {
+				if published.IsZero() && publishDateMetaNames[strings.ToLower(attr(n, key))] {
+					published = parsePublishDate(attr(n, "content"))
+				}
+			}
+		case strings.EqualFold(attr(n, "itemprop"), "datePublished"):
+			for _, value := range []string{attr(n, "content"), attr(n, "datetime"), textContent(n)} {
+				if published = parsePublishDate(value); !published.IsZero() {
+					break
+				}
+			}
+		case n.Data == "script" && strings.EqualFold(attr(n, "type"), "application/ld+json"):
+			var data interface{}
+			if json.Unmarshal([]byte(textContent(n)), &data) == nil {
+				published = parsePublishDate(findJSONString(data, "datePublished"))
+			}
+		}
+		return true
+	})
+	return published
+}
+
+// timeElementDate returns the date of the first <time> element under n
+func timeElementDate(n *html.Node) time.Time {
+	var published time.Time
+	walkElements(n, func(e *html.Node) bool {
+		if published.IsZero() && e.Data == "time" {
+			published = parsePublishDate(attr(e, "datetime"))
+		}
+		return published.IsZero()
+	})
+	return published
+}
+
+// findJSONString finds the first string value of key in decoded JSON
+func findJSONString(data interface{}, key string) string {
+	switch value := data.(type) {
+	case map[string]interface{}:
+		if found, ok := value[key].(string); ok {
+			return found
+		}
+		for _, nested := range value {
+			if found := findJSONString(nested, key); found != "" {
+				return found
+			}
+		}
+	case []interface{}:
+		for _, nested := range value {
+			if found := findJSONString(nested, key); found != "" {
+				return found
+			}
+		}
+	}
+	return ""
+}
+
+func parsePublishDate(value string) time.Time {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}
+	}
+	for _, layout := range publishDateLayouts {
+		if published, err := time.Parse(layout, value); err == nil {
+			return published
+		}
+	}
+	return time.Time{}
+}
+
+// pruneBoilerplate removes scripts, navigation, cookie banners and other elements that are
+// not part of the article
+func pruneBoilerplate(n *html.Node, inArticle bool) {
+	for child := n.FirstChild; child != nil; {
+		next := child.NextSibling
+		switch {
+		case child.Type == html.CommentNode:
+			n.RemoveChild(child)
+		case child.Type == html.ElementNode && isBoilerplate(child, inArticle):
+			n.RemoveChild(child)
+		default:
+			pruneBoilerplate(child, inArticle || child.Data == "article" || child.Data == "main")
+		}
+		child = next
+	}
+}
+
+func isBoilerplate(n *html.Node, inArticle bool) bool {
+	if boilerplateTags[n.Data] || boilerplateRoles[strings.ToLower(attr(n, "role"))] {
+		return true
+	}
+	if hasAttr(n, "hidden") || attr(n, "aria-hidden") == "true" || strings.Contains(strings.ReplaceAll(attr(n, "style"), " ", ""), "display:none") {
+		return true
+	}
+	if n.Data == "header" && !inArticle {
+		return true
+	}
+	switch n.Data {
+	case "html", "body", "article", "main", "a":
+		return false
+	}
+	matchString := attr(n, "class") + " " + attr(n, "id")
+	return unlikelyCandidatePattern.MatchString(matchString) && !maybeCandidatePattern.MatchString(matchString)
+}
+
+// mainContent scores the blocks of the page by the paragraphs they hold and returns the
+// best one together with its siblings that look like part of the same article
+func mainContent(root *html.Node) []*html.Node {
+	scores := make(map[*html.Node]float64)
+	var candidates []*html.Node
+	addScore := func(n *html.Node, score float64) {
+		if n == nil || n.Type != html.ElementNode {
+			return
+		}
+		if _, ok := scores[n]; !ok {
+			scores[n] = baseScore(n)
+			candidates = append(candidates, n)
+		}
+		scores[n] += score
+	}
+
+	walkElements(root, func(n *html.Node) bool {
+		switch n.Data {
+		case "p", "pre", "td", "blockquote":
+		case "div":
+			if hasBlockChildren(n) {
+				return true
+			}
+		default:
+			return true
+		}
+		text := textContent(n)
+		if len([]rune(text)) < 25 {
+			return true
+		}
+
+		score := 1 + float64(strings.Count(text, ",")) + math.Min(float64(len([]rune(text)))/100, 3)
+		ancestor := n.Parent
+		for level := 0; level < 3 && ancestor != nil; level++ {
+			divider := 1.0
+			if level > 0 {
+				divider = float64(level) * 2
+			}
+			addScore(ancestor, score/divider)
+			ancestor = ancestor.Parent
+		}
+		return true
+	})
+
+	var top *html.Node
+	best := 0.0
+	for _, candidate := range candidates {
+		score := scores[candidate] * (1 - linkDensity(candidate))
+		scores[candidate] = score
+		if top == nil || score > best {
+			top, best = candidate, score
+		}
+	}
+	if top == nil {
+		if body := findElement(root, "body"); body != nil {
+			return []*html.Node{body}
+		}
+		return []*html.Node{root}
+	}
+	if top.Parent == nil {
+		return []*html.Node{top}
+	}
+
+	threshold := math.Max(10, best*0.2)
+	var content []*html.Node
+	for sibling := top.Parent.FirstChild; sibling != nil; sibling = sibling.NextSibling {
+		if sibling.Type != html.ElementNode {
+			continue
+		}
+		include := sibling == top
+		if score, ok := scores[sibling]; ok && score >= threshold {
+			include = true
+		}
+		switch sibling.Data {
+		case "p":
+			text := textContent(sibling)
+			density := linkDensity(sibling)
+			if (len(text) > 80 && density < 0.25) || (density == 0 && strings.Contains(text, ". ")) {
+				include = true
+			}
+		case "h1", "h2", "h3", "h4", "h5", "h6", "ul", "ol":
+			if linkDensity(sibling) < 0.25 && textContent(sibling) != "" {
+				include = true
+			}
+		}
+		if include {
+			content = append(content, sibling)
+		}
+	}
+	return content
+}
+
+// baseScore is the score a block starts with, from its tag and its class and id
+func baseScore(n *html.Node) float64 {
+	score := 0.0
+	switch n.Data {
+	case "article", "main":
+		score = 10
+	case "div":
+		score = 5
+	case "pre", "td", "blockquote":
+		score = 3
+	case "address", "ol", "ul", "dl", "dd", "dt", "li", "form":
+		score = -3
+	case "h1", "h2", "h3", "h4", "h5", "h6", "th":
+		score = -5
+	}
+	for _, value := range []string{attr(n, "class"), attr(n, "id")} {
+		if value == "" {
+			continue
+		}
+		if negativeWeightPattern.MatchString(value) {
+			score -= 25
+		}
+		if positiveWeightPattern.MatchString(value) {
+			score += 25
+		}
+	}
+	return score
+}
+
+// linkDensity is the share of a block's text that is link text
+func linkDensity(n *html.Node) float64 {
+	length := len(textContent(n))
+	if length == 0 {
+		return 0
+	}
+	linkLength := 0
+	walkElements(n, func(e *html.Node) bool {
+		if e.Data == "a" {
+			linkLength += len(textContent(e))
+			return false
+		}
+		return true
+	})
+	return float64(linkLength) / float64(length)
+}
+
+func hasBlockChildren(n *html.Node) bool {
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		if child.Type == html.ElementNode && blockTags[child.Data] {
+			return true
+		}
+	}
+	return false
+}
+
+// articleWriter renders article blocks as plain text, marking headings, list items and
+// quotes up as in Markdown
+type articleWriter struct {
+	blocks []articleBlock
+	inline strings.Builder
+}
+
+type articleBlock struct {
+	text     string
+	listItem bool
+}
+
+func (w *articleWriter) String() string {
+	var text strings.Builder
+	for i, block := range w.blocks {
+		if i > 0 {
+			if block.listItem && w.blocks[i-1].listItem {
+				text.WriteString("\n")
+			} else {
+				text.WriteString("\n\n")
+			}
+		}
+		text.WriteString(block.text)
+	}
+	return text.String()
+}
+
+// flush ends the paragraph being written
+func (w *articleWriter) flush() {
+	if text := collapseWhitespace(w.inline.String()); text != "" {
+		w.blocks = append(w.blocks, articleBlock{text: text})
+	}
+	w.inline.Reset()
+}
+
+func (w *articleWriter) block(n *html.Node) {
+	switch n.Type {
+	case html.TextNode:
+		w.inline.WriteString(n.Data)
+		return
+	case html.ElementNode, html.DocumentNode:
+	default:
+		return
+	}
+
+	switch n.Data {
+	case "h1", "h2", "h3", "h4", "h5", "h6":
+		w.flush()
+		if text := textContent(n); text != "" {
+			w.blocks = append(w.blocks, articleBlock{text: strings.Repeat("#", int(n.Data[1]-'0')) + " " + text})
+		}
+	case "ul", "ol":
+		w.flush()
+		w.list(n, 0)
+	case "pre":
+		w.flush()
+		if text := strings.TrimRight(rawText(n), " \n\t"); strings.TrimSpace(text) != "" {
+			w.blocks = append(w.blocks, articleBlock{text: text})
+		}
+	case "blockquote":
+		w.flush()
+		quote := &articleWriter{}
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			quote.block(child)
+		}
+		quote.flush()
+		for _, block := range quote.blocks {
+			w.blocks = append(w.blocks, articleBlock{text: "> " + strings.ReplaceAll(block.text, "\n", "\n> "), listItem: block.listItem})
+		}
+	case "table":
+		w.flush()
+		walkElements(n, func(row *html.Node) bool {
+			if row.Data != "tr" {
+				return true
+			}
+			var cells []string
+			for cell := row.FirstChild; cell != nil; cell = cell.NextSibling {
+				if cell.Type == html.ElementNode && (cell.Data == "td" || cell.Data == "th") {
+					cells = append(cells, textContent(cell))
+				}
+			}
+			if strings.TrimSpace(strings.Join(cells, "")) != "" {
+				w.blocks = append(w.blocks, articleBlock{text: strings.Join(cells, " | "), listItem: true})
+			}
+			return false
+		})
+	case "img":
+	default:
+		if blockTags[n.Data] {
+			w.flush()
+		}
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			w.block(child)
+		}
+		if blockTags[n.Data] {
+			w.flush()
+		}
+	}
+}
+
+// list writes the items of a list, indenting nested lists
+func (w *articleWriter) list(n *html.Node, depth int) {
+	number := 0
+	for item := n.FirstChild; item != nil; item = item.NextSibling {
+		if item.Type != html.ElementNode || item.Data != "li" {
+			continue
+		}
+		number++
+
+		var text strings.Builder
+		var nested []*html.Node
+		for child := item.FirstChild; child != nil; child = child.NextSibling {
+			if child.Type == html.ElementNode && (child.Data == "ul" || child.Data == "ol") {
+				nested = append(nested, child)
+				continue
+			}
+			text.WriteString(" " + textContent(child))
+		}
+
+		marker := "- "
+		if n.Data == "ol" {
+			marker = fmt.Sprintf("%d. ", number)
+		}
+		if line := collapseWhitespace(text.String()); line != "" {
+			w.blocks = append(w.blocks, articleBlock{text: strings.Repeat("  ", depth) + marker + line, listItem: true})
+		}
+		for _, list := range nested {
+			w.list(list, depth+1)
+		}
+	}
+}
+
+// textContent returns the text of a node with whitespace collapsed and block boundaries
+// kept as spaces
+func textContent(n *html.Node) string {
+	var text strings.Builder
+	var collect func(*html.Node)
+	collect = func(node *html.Node) {
+		switch node.Type {
+		case html.TextNode:
+			text.WriteString(node.Data)
+			return
+		case html.ElementNode:
+			if blockTags[node.Data] {
+				text.WriteString(" ")
+				defer text.WriteString(" ")
+			}
+		}
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			collect(child)
+		}
+	}
+	collect(n)
+	return collapseWhitespace(text.String())
+}
+
+// rawText returns the text of a node as is
+func rawText(n *html.Node) string {
+	if n.Type == html.TextNode {
+		return n.Data
+	}
+	var text strings.Builder
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		text.WriteString(rawText(child))
+	}
+	return text.String()
+}
+
+func collapseWhitespace(text string) string {
+	return strings.TrimSpace(whitespacePattern.ReplaceAllString(text, " "))
+}
+
+// walkElements visits the elements under n in document order; returning false from visit
+// skips the element's children
+func walkElements(n *html.Node, visit func(*html.Node) bool) {
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		if child.Type == html.ElementNode && !visit(child) {
+			continue
+		}
+		walkElements(child, visit)
+	}
+}
+
+func findElement(n *html.Node, tag string) *html.Node {
+	var found *html.Node
+	walkElements(n, func(e *html.Node) bool {
+		if found == nil && e.Data == tag {
+			found = e
+		}
+		return found == nil
+	})
+	return found
+}
+
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if strings.EqualFold(a.Key, key) {
+			return a.Val
+		}
+	}
+	return ""
+}
+
+func hasAttr(n *html.Node, key string) bool {
+	for _, a := range n.Attr {
+		if strings.EqualFold(a.Key, key) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,221 @@
+package content_creation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Ceesaxp/autonomous-content-service/src/domain/entities"
+)
+
+const (
+	// projectMemoryKey marks the metadata of the project memory entry
+	projectMemoryKey = "projectMemory"
+
+	// projectMemoryHeader introduces the project memory to the LLM
+	projectMemoryHeader = "Project memory (summary of the earlier conversation):\n"
+
+	// projectMemoryPriority is the priority of the project memory entry
+	projectMemoryPriority = 10
+
+	// compactionTargetPct is the share of the token budget a summarising compaction
+	// compacts down to, so that the next few entries fit without another LLM call
+	compactionTargetPct = 75
+
+	// projectMemorySharePct is the share of the token budget reserved for the project memory
+	projectMemorySharePct = 25
+)
+
+// IsProjectMemory reports whether an entry is the project memory, the pinned summary of
+// the entries evicted from a context window
+func IsProjectMemory(entry ContextEntry) bool {
+	isMemory, _ := entry.Metadata[projectMemoryKey].(bool)
+	return isMemory
+}
+
+// newProjectMemory creates the project memory entry holding a summary
+func newProjectMemory(summary string, at time.Time) ContextEntry {
+	return ContextEntry{
+		Role:      RoleSystem,
+		Content:   projectMemoryHeader + summary,
+		Timestamp: at,
+		Priority:  projectMemoryPriority,
+		MustKeep:  true,
+		Metadata:  map[string]interface{}{projectMemoryKey: true},
+	}
+}
+
+// ContextCompactor summarises the entries evicted from a context window into the
+// project memory, so that earlier instructions survive compaction
+type ContextCompactor struct {
+	llmClient LLMClient
+}
+
+// NewContextCompactor creates a compactor that summarises with the given LLM client
+func NewContextCompactor(llmClient LLMClient) *ContextCompactor {
+	return &ContextCompactor{llmClient: llmClient}
+}
+
+// MemoryBudget returns the tokens reserved for the project memory in a window of maxTokens
+func (c *ContextCompactor) MemoryBudget(maxTokens int) int {
+	return maxTokens * projectMemorySharePct / 100
+}
+
+// Summarize merges the current project memory with the evicted entries into a new summary
+func (c *ContextCompactor) Summarize(ctx context.Context, memory string, evicted []ContextEntry, maxTokens int) (string, error) {
+	var prompt strings.Builder
+	prompt.WriteString("Update the project memory of a content project with the conversation entries below, ")
+	prompt.WriteString("which are about to be removed from the conversation.\n\n")
+	prompt.WriteString("Keep every client instruction, requirement, preference, constraint and decision, ")
+	prompt.WriteString("and the facts and sources later stages rely on. Drop pleasantries and superseded drafts. ")
+	prompt.WriteString("Respond with the updated memory only, as concise bullet points.\n\n")
+	if memory != "" {
+		prompt.WriteString("Current project memory:\n")
+		prompt.WriteString(memory)
+		prompt.WriteString("\n\n")
+	}
+	prompt.WriteString("Entries to fold in:\n")
+	for _, entry := range evicted {
+		fmt.Fprintf(&prompt, "[%s", entry.Role)
+		if stage, ok := entry.Metadata["stage"].(string); ok {
+			fmt.Fprintf(&prompt, ", %s", stage)
+		}
+		fmt.Fprintf(&prompt, "] %s\n", entry.Content)
+	}
+
+	temperature := 0.2
+	request := NewPromptRequest(prompt.String())
+	request.Temperature = &temperature
+	request.MaxTokens = c.MemoryBudget(maxTokens)
+
+	response, err := c.llmClient.Generate(ctx, request)
+	if err != nil {
+		return "", err
+	}
+	summary := strings.TrimSpace(response.Content)
+	if summary == "" {
+		return "", errors.New("empty summary")
+	}
+	return summary, nil
+}
+
+// tokensToFree returns how many tokens a compaction must evict so that an entry of incoming
+// tokens fits. Without a compactor entries are dropped just until it fits; with one the
+// window is compacted further, leaving room for the project memory.
+func (c *ContextCompactor) tokensToFree(used, incoming, memoryTokens, maxTokens int) int {
+	if c == nil {
+		return used + incoming - maxTokens
+	}
+	return used + incoming - memoryTokens + c.MemoryBudget(maxTokens) - maxTokens*compactionTargetPct/100
+}
+
+// contextCompaction is a planned compaction of a context window
+type contextCompaction struct {
+	evict  []int // indexes of the entries to evict, in window order
+	memory int   // index of the project memory entry, or -1
+	freed  int   // tokens held by the evicted entries
+}
+
+// planCompaction picks the entries to evict to free tokensToFree tokens: lowest priority
+// first and, within a priority, oldest first. Must-keep entries and the project memory
+// are never evicted.
+func planCompaction(entries []ContextEntry, tokens []int, tokensToFree int) contextCompaction {
+	plan := contextCompaction{memory: -1}
+	candidates := make([]int, 0, len(entries))
+	for i, entry := range entries {
+		switch {
+		case IsProjectMemory(entry):
+			plan.memory = i
+		case !entry.MustKeep:
+			candidates = append(candidates, i)
+		}
+	}
+	sort.SliceStable(candidates, func(a, b int) bool {
+		return entries[candidates[a]].Priority < entries[candidates[b]].Priority
+	})
+
+	for _, i := range candidates {
+		if plan.freed >= tokensToFree {
+			break
+		}
+		plan.evict = append(plan.evict, i)
+		plan.freed += tokens[i]
+	}
+	sort.Ints(plan.evict)
+	return plan
+}
+
+// summarizeEvictions folds the planned evictions into the project memory and records the
+// outcome on the compaction. It returns the new project memory entry, or nil when the
+// entries are dropped unsummarised because there is no compactor or summarising failed.
+func summarizeEvictions(ctx context.Context, compactor *ContextCompactor, entries []ContextEntry, plan contextCompaction, maxTokens int, compaction *entities.ContextCompaction) *ContextEntry {
+	if compactor == nil {
+		return nil
+	}
+
+	memory := ""
+	if plan.memory >= 0 {
+		memory = strings.TrimPrefix(entries[plan.memory].Content, projectMemoryHeader)
+	}
+	evicted := make([]ContextEntry, len(plan.evict))
+	for i, index := range plan.evict {
+		evicted[i] = entries[index]
+	}
+
+	summary, err := compactor.Summarize(ctx, memory, evicted, maxTokens)
+	if err != nil {
+		fmt.Printf("Warning: failed to summarise evicted context entries: %v\n", err)
+		compaction.Error = err.Error()
+		return nil
+	}
+	compaction.Summarized = true
+	entry := newProjectMemory(summary, compaction.At)
+	return &entry
+}
+
+// recordCompaction adds a compaction to the window's history, keeping the most recent ones
+func (w *ContextWindow) recordCompaction(compaction entities.ContextCompaction) {
+	w.CompactionCount++
+	w.Compactions = append(w.Compactions, compaction)
+	if len(w.Compactions) > entities.MaxRecordedCompactions {
+		w.Compactions = w.Compactions[len(w.Compactions)-entities.MaxRecordedCompactions:]
+	}
+}
+
+// contextWindowMetrics returns the usage and compaction metrics of a context window
+func contextWindowMetrics(window *ContextWindow, tokenizer Tokenizer) map[string]interface{} {
+	mustKeep, memoryTokens := 0, 0
+	for _, entry := range window.Entries {
+		if IsProjectMemory(entry) {
+			memoryTokens = countTokens(tokenizer, entry.Content)
+		} else if entry.MustKeep {
+			mustKeep++
+		}
+	}
+
+	utilization := 0.0
+	if window.MaxTokens > 0 {
+		utilization = float64(window.CurrentTokenCount) / float64(window.MaxTokens) * 100
+	}
+	var lastCompaction interface{}
+	if len(window.Compactions) > 0 {
+		lastCompaction = window.Compactions[len(window.Compactions)-1]
+	}
+
+	return map[string]interface{}{
+		"entryCount":             len(window.Entries),
+		"tokenUsage":             window.CurrentTokenCount,
+		"tokenCapacity":          window.MaxTokens,
+		"utilizationPct":         utilization,
+		"lastAccessed":           window.LastAccessed,
+		"domainKnowledgeEntries": len(window.DomainKnowledge),
+		"mustKeepEntries":        mustKeep,
+		"projectMemoryTokens":    memoryTokens,
+		"compactionCount":        window.CompactionCount,
+		"lastCompaction":         lastCompaction,
+		"compactions":            window.Compactions,
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
-	"sort"
 	"sync"
 	"time"
 
@@ -19,6 +18,7 @@ type ContextEntry struct {
 	Content   string                 `json:"content"`
 	Timestamp time.Time              `json:"timestamp"`
 	Priority  int                    `json:"priority"` // Higher number = higher priority
+	MustKeep  bool                   `json:"mustKeep,omitempty"` // Never evicted by compaction
 	Metadata  map[string]interface{} `json:"metadata"` // Additional info about the entry
 }
 
@@ -32,6 +32,8 @@ type ContextWindow struct {
 	CurrentTokenCount  int                     `json:"currentTokenCount"`
 	LastAccessed       time.Time               `json:"lastAccessed"`
 	DomainKnowledge    map[string]interface{}  `json:"domainKnowledge"`
+	CompactionCount    int                     `json:"compactionCount,omitempty"`
+	Compactions        []entities.ContextCompaction `json:"compactions,omitempty"` // Most recent last
 }
 
 // ContextManager defines the interface for managing LLM conversation context
@@ -63,6 +65,8 @@ type InMemoryContextManager struct {
 	contextWindows     map[uuid.UUID]*ContextWindow
 	clientRepository   repositories.ClientRepository
 	maxWindowSize      int
+	compactor          *ContextCompactor
+	tokenizer          Tokenizer
 	mutex              sync.RWMutex
 }
 
@@ -76,6 +80,16 @@ func NewInMemoryContextManager(clientRepo repositories.ClientRepository, maxToke
 	}
 }
 
+// UseCompactor summarises evicted entries into the project memory instead of dropping them
+func (cm *InMemoryContextManager) UseCompactor(compactor *ContextCompactor) {
+	cm.compactor = compactor
+}
+
+// UseTokenizer counts entry tokens with the tokenizer of the conversation's model
+func (cm *InMemoryContextManager) UseTokenizer(tokenizer Tokenizer) {
+	cm.tokenizer = tokenizer
+}
+
 // GetContext retrieves the context for a specific project
 func (cm *InMemoryContextManager) GetContext(ctx context.Context, projectID uuid.UUID) (*ContextWindow, error) {
 	cm.mutex.RLock()
@@ -94,7 +108,8 @@ func (cm *InMemoryContextManager) GetContext(ctx context.Context, projectID uuid
 	return window, nil
 }
 
-// AddEntry adds a new entry to the context window with priority-based retention
+// AddEntry adds a new entry to the context window with priority-based retention.
+// The lock is held while evicted entries are summarised.
 func (cm *InMemoryContextManager) AddEntry(ctx context.Context, projectID uuid.UUID, entry ContextEntry) error {
 	cm.mutex.Lock()
 	defer cm.mutex.Unlock()
@@ -105,12 +120,12 @@ func (cm *InMemoryContextManager) AddEntry(ctx context.Context, projectID uuid.U
 	}
 	
 	// Estimate tokens in the new entry
-	entryTokens := estimateTokens(entry.Content)
+	entryTokens := countTokens(cm.tokenizer, entry.Content)
 	
 	// Check if adding this would exceed the window size
 	if window.CurrentTokenCount + entryTokens > window.MaxTokens {
-		// Need to remove entries to make space
-		cm.pruneContextWindow(window, entryTokens)
+		// Need to compact the window to make space
+		cm.compactContextWindow(ctx, window, entryTokens)
 	}
 	
 	// Add the new entry
@@ -121,33 +136,55 @@ func (cm *InMemoryContextManager) AddEntry(ctx context.Context, projectID uuid.U
 	return nil
 }
 
-// pruneContextWindow removes lower priority entries to make room for new content
-func (cm *InMemoryContextManager) pruneContextWindow(window *ContextWindow, requiredTokens int) {
-	// Sort entries by priority (ascending) so we remove lowest priority first
-	sort.Slice(window.Entries, func(i, j int) bool {
-		return window.Entries[i].Priority < window.Entries[j].Priority
-	})
-	
-	tokensToFree := window.CurrentTokenCount + requiredTokens - window.MaxTokens
-	freedTokens := 0
-	entriesRemoved := 0
-	
-	// Remove entries until we've freed enough tokens
+// compactContextWindow evicts lower priority entries to make room for new content,
+// summarising them into the project memory when a compactor is set
+func (cm *InMemoryContextManager) compactContextWindow(ctx context.Context, window *ContextWindow, requiredTokens int) {
+	tokens := make([]int, len(window.Entries))
+	memoryTokens := 0
 	for i, entry := range window.Entries {
-		entryTokens := estimateTokens(entry.Content)
-		freedTokens += entryTokens
-		entriesRemoved = i + 1
-		
-		if freedTokens >= tokensToFree {
-			break
+		tokens[i] = countTokens(cm.tokenizer, entry.Content)
+		if IsProjectMemory(entry) {
+			memoryTokens = tokens[i]
 		}
 	}
-	
-	// Remove the entries
-	if entriesRemoved > 0 {
-		window.Entries = window.Entries[entriesRemoved:]
-		window.CurrentTokenCount -= freedTokens
+
+	plan := planCompaction(window.Entries, tokens, cm.compactor.tokensToFree(window.CurrentTokenCount, requiredTokens, memoryTokens, window.MaxTokens))
+	if len(plan.evict) == 0 {
+		return
 	}
+
+	compaction := entities.ContextCompaction{
+		At:             time.Now(),
+		EntriesEvicted: len(plan.evict),
+		TokensBefore:   window.CurrentTokenCount,
+	}
+	memory := summarizeEvictions(ctx, cm.compactor, window.Entries, plan, window.MaxTokens, &compaction)
+
+	evicted := make(map[int]bool, len(plan.evict)+1)
+	for _, i := range plan.evict {
+		evicted[i] = true
+	}
+	tokenCount := window.CurrentTokenCount - plan.freed
+	entries := make([]ContextEntry, 0, len(window.Entries)-len(plan.evict)+1)
+	if memory != nil {
+		// The new project memory replaces the old one at the start of the window
+		if plan.memory >= 0 {
+			evicted[plan.memory] = true
+			tokenCount -= tokens[plan.memory]
+		}
+		entries = append(entries, *memory)
+		tokenCount += countTokens(cm.tokenizer, memory.Content)
+	}
+	for i, entry := range window.Entries {
+		if !evicted[i] {
+			entries = append(entries, entry)
+		}
+	}
+
+	window.Entries = entries
+	window.CurrentTokenCount = tokenCount
+	compaction.TokensAfter = tokenCount
+	window.recordCompaction(compaction)
 }
 
 // SwitchContext switches to a different project context
@@ -236,21 +273,6 @@ func (cm *InMemoryContextManager) GetContextMetrics(ctx context.Context, project
 		return nil, errors.New("context window not found for project")
 	}
 	
-	metrics := map[string]interface{}{
-		"entryCount":       len(window.Entries),
-		"tokenUsage":       window.CurrentTokenCount,
-		"tokenCapacity":    window.MaxTokens,
-		"utilizationPct":   float64(window.CurrentTokenCount) / float64(window.MaxTokens) * 100,
-		"lastAccessed":     window.LastAccessed,
-		"domainKnowledgeEntries": len(window.DomainKnowledge),
-	}
-	
+	metrics := contextWindowMetrics(window, cm.tokenizer)
 	return metrics, nil
 }
-
-// estimateTokens provides a rough estimate of tokens in a string
-// A more accurate implementation would use the tokenizer from the LLM provider
-func estimateTokens(text string) int {
-	// Simple approximation: 1 token ≈ 4 characters
-	return len(text) / 4
-}
\ No newline at end of file
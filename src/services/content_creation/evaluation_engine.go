@@ -96,7 +96,7 @@ func (e *EvaluationEngine) EvaluateContent(ctx context.Context, request Evaluati
 
 	// Generate detailed analysis
 	analysisPrompt := e.createAnalysisPrompt(request, evaluation)
-	analysis, err := e.llmClient.Generate(ctx, analysisPrompt)
+	analysis, err := generateText(ctx, e.llmClient, analysisPrompt)
 	if err != nil {
 		// Continue without detailed analysis if LLM fails
 		evaluation.DetailedAnalysis = "Detailed analysis unavailable"
@@ -168,7 +168,7 @@ Respond in JSON format:
   "confidence": <0-1>
 }`, contentType, content)
 
-	response, err := e.llmClient.Generate(ctx, prompt)
+	response, err := generateText(ctx, e.llmClient, prompt)
 	if err != nil {
 		return nil, err
 	}
@@ -199,7 +199,7 @@ Respond in JSON format:
   "confidence": <0-1>
 }`, contentType, content)
 
-	response, err := e.llmClient.Generate(ctx, prompt)
+	response, err := generateText(ctx, e.llmClient, prompt)
 	if err != nil {
 		return nil, err
 	}
@@ -231,7 +231,7 @@ Respond in JSON format:
   "confidence": <0-1>
 }`, contentType, targetAudience, content)
 
-	response, err := e.llmClient.Generate(ctx, prompt)
+	response, err := generateText(ctx, e.llmClient, prompt)
 	if err != nil {
 		return nil, err
 	}
@@ -262,7 +262,7 @@ Respond in JSON format:
   "confidence": <0-1>
 }`, contentType, content)
 
-	response, err := e.llmClient.Generate(ctx, prompt)
+	response, err := generateText(ctx, e.llmClient, prompt)
 	if err != nil {
 		return nil, err
 	}
@@ -293,7 +293,7 @@ Respond in JSON format:
   "confidence": <0-1>
 }`, contentType, content)
 
-	response, err := e.llmClient.Generate(ctx, prompt)
+	response, err := generateText(ctx, e.llmClient, prompt)
 	if err != nil {
 		return nil, err
 	}
@@ -324,7 +324,7 @@ Respond in JSON format:
   "confidence": <0-1>
 }`, contentType, content)
 
-	response, err := e.llmClient.Generate(ctx, prompt)
+	response, err := generateText(ctx, e.llmClient, prompt)
 	if err != nil {
 		return nil, err
 	}
@@ -355,7 +355,7 @@ Respond in JSON format:
   "confidence": <0-1>
 }`, contentType, targetAudience, content)
 
-	response, err := e.llmClient.Generate(ctx, prompt)
+	response, err := generateText(ctx, e.llmClient, prompt)
 	if err != nil {
 		return nil, err
 	}
@@ -386,7 +386,7 @@ Respond in JSON format:
   "confidence": <0-1>
 }`, contentType, content)
 
-	response, err := e.llmClient.Generate(ctx, prompt)
+	response, err := generateText(ctx, e.llmClient, prompt)
 	if err != nil {
 		return nil, err
 	}
@@ -417,7 +417,7 @@ Respond in JSON format:
   "confidence": <0-1>
 }`, contentType, targetAudience, content)
 
-	response, err := e.llmClient.Generate(ctx, prompt)
+	response, err := generateText(ctx, e.llmClient, prompt)
 	if err != nil {
 		return nil, err
 	}
@@ -448,7 +448,7 @@ Respond in JSON format:
   "confidence": <0-1>
 }`, contentType, content)
 
-	response, err := e.llmClient.Generate(ctx, prompt)
+	response, err := generateText(ctx, e.llmClient, prompt)
 	if err != nil {
 		return nil, err
 	}
@@ -479,7 +479,7 @@ Respond in JSON format:
   "confidence": <0-1>
 }`, contentType, content)
 
-	response, err := e.llmClient.Generate(ctx, prompt)
+	response, err := generateText(ctx, e.llmClient, prompt)
 	if err != nil {
 		return nil, err
 	}
@@ -510,7 +510,7 @@ Respond in JSON format:
   "confidence": <0-1>
 }`, contentType, content)
 
-	response, err := e.llmClient.Generate(ctx, prompt)
+	response, err := generateText(ctx, e.llmClient, prompt)
 	if err != nil {
 		return nil, err
 	}
@@ -541,7 +541,7 @@ Respond in JSON format:
   "confidence": <0-1>
 }`, contentType, content)
 
-	response, err := e.llmClient.Generate(ctx, prompt)
+	response, err := generateText(ctx, e.llmClient, prompt)
 	if err != nil {
 		return nil, err
 	}
@@ -572,7 +572,7 @@ Respond in JSON format:
   "confidence": <0-1>
 }`, contentType, targetAudience, content)
 
-	response, err := e.llmClient.Generate(ctx, prompt)
+	response, err := generateText(ctx, e.llmClient, prompt)
 	if err != nil {
 		return nil, err
 	}
@@ -603,7 +603,7 @@ Respond in JSON format:
   "confidence": <0-1>
 }`, contentType, content)
 
-	response, err := e.llmClient.Generate(ctx, prompt)
+	response, err := generateText(ctx, e.llmClient, prompt)
 	if err != nil {
 		return nil, err
 	}
@@ -630,7 +630,7 @@ Respond in JSON format:
   "confidence": <0-1>
 }`, contentType, criterion, content)
 
-	response, err := e.llmClient.Generate(ctx, prompt)
+	response, err := generateText(ctx, e.llmClient, prompt)
 	if err != nil {
 		return nil, err
 	}
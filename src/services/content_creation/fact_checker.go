@@ -119,6 +119,7 @@ const (
 	SourceEncyclopedia SourceType = "encyclopedia"
 	SourceOfficial     SourceType = "official"
 	SourceExpert       SourceType = "expert"
+	SourceInternal     SourceType = "internal" // content produced earlier by the service
 )
 
 // CheckFacts performs comprehensive fact checking
@@ -206,7 +207,7 @@ Respond in JSON format:
   ]
 }`, contentType, content)
 
-	response, err := f.llmClient.Generate(ctx, prompt)
+	response, err := generateText(ctx, f.llmClient, prompt)
 	if err != nil {
 		return nil, err
 	}
@@ -282,7 +283,7 @@ Respond in JSON format:
   "severity": "error|warning|notice"
 }`, claim.Text, evidenceText)
 
-	response, err := f.llmClient.Generate(ctx, prompt)
+	response, err := generateText(ctx, f.llmClient, prompt)
 	if err != nil {
 		return nil, err
 	}
@@ -333,7 +334,7 @@ Respond in JSON format:
   "correction": "<correction if needed>"
 }`, claim.Text, sourceContent)
 
-	response, err := f.llmClient.Generate(ctx, prompt)
+	response, err := generateText(ctx, f.llmClient, prompt)
 	if err != nil {
 		return nil, err
 	}
@@ -364,7 +365,7 @@ Respond in JSON format:
   ]
 }`, content)
 
-	response, err := f.llmClient.Generate(ctx, prompt)
+	response, err := generateText(ctx, f.llmClient, prompt)
 	if err != nil {
 		return nil, err
 	}
@@ -305,7 +305,7 @@ Respond in JSON format:
   ]
 }`, request.ContentType, weakness.Type, weakness.Score, weakness.Description, weakness.Evidence, request.Content)
 
-	response, err := ie.llmClient.Generate(ctx, prompt)
+	response, err := generateText(ctx, ie.llmClient, prompt)
 	if err != nil {
 		return nil, err
 	}
@@ -353,7 +353,7 @@ Respond in JSON format:
   ]
 }`, focusAreas, request.ContentType, request.CurrentScore, request.TargetScore, request.Content)
 
-	response, err := ie.llmClient.Generate(ctx, prompt)
+	response, err := generateText(ctx, ie.llmClient, prompt)
 	if err != nil {
 		return nil, err
 	}
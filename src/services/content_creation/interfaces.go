@@ -6,17 +6,89 @@ import (
 	"fmt"
 	"io"
 	"net/http"
-	"net/url"
 	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
+
+	"github.com/Ceesaxp/autonomous-content-service/src/domain/entities"
 )
 
 // LLMClient defines the interface for interacting with an LLM service
 type LLMClient interface {
 	// Generate creates content using the LLM
-	Generate(ctx context.Context, prompt interface{}) (string, error)
+	Generate(ctx context.Context, request LLMRequest) (*LLMResponse, error)
+}
+
+// Message roles in an LLM conversation
+const (
+	RoleSystem    = "system"
+	RoleUser      = "user"
+	RoleAssistant = "assistant"
+)
+
+// Normalized reasons for an LLM to stop generating
+const (
+	FinishReasonStop          = "stop"
+	FinishReasonLength        = "length"
+	FinishReasonContentFilter = "content_filter"
+)
+
+// LLMMessage is a role-tagged message in a conversation
+type LLMMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// LLMRequest is a request to an LLM. Zero-valued overrides fall back to the client's defaults.
+type LLMRequest struct {
+	SystemPrompt  string       `json:"systemPrompt,omitempty"`
+	Messages      []LLMMessage `json:"messages"`
+	Temperature   *float64     `json:"temperature,omitempty"`
+	MaxTokens     int          `json:"maxTokens,omitempty"`
+	StopSequences []string     `json:"stopSequences,omitempty"`
+	JSONMode      bool         `json:"jsonMode,omitempty"`
+}
+
+// NewPromptRequest creates a request with a single user message
+func NewPromptRequest(prompt string) LLMRequest {
+	return LLMRequest{Messages: []LLMMessage{{Role: RoleUser, Content: prompt}}}
+}
+
+// LastUserMessage returns the content of the last user message in the request
+func (r LLMRequest) LastUserMessage() string {
+	for i := len(r.Messages) - 1; i >= 0; i-- {
+		if r.Messages[i].Role == RoleUser {
+			return r.Messages[i].Content
+		}
+	}
+	return ""
+}
+
+// LLMUsage is the token usage reported for a request
+type LLMUsage struct {
+	PromptTokens     int `json:"promptTokens"`
+	CompletionTokens int `json:"completionTokens"`
+	TotalTokens      int `json:"totalTokens"`
+}
+
+// LLMResponse is the result of an LLM request
+type LLMResponse struct {
+	Content      string   `json:"content"`
+	FinishReason string   `json:"finishReason"`
+	Usage        LLMUsage `json:"usage"`
+	Model        string   `json:"model,omitempty"`
+}
+
+// generateText sends a single user prompt and returns the generated text
+func generateText(ctx context.Context, client LLMClient, prompt string) (string, error) {
+	response, err := client.Generate(ctx, NewPromptRequest(prompt))
+	if err != nil {
+		return "", err
+	}
+	touchStage(ctx)
+	return response.Content, nil
 }
 
 // ReadabilityScorer defines the interface for measuring content readability
@@ -39,9 +111,11 @@ type PlagiarismAPI interface {
 
 // PlagiarismDetail contains details about detected plagiarism
 type PlagiarismDetail struct {
-	Fragment   string  `json:"fragment"`
-	Source     string  `json:"source"`
-	Percentage float64 `json:"percentage"`
+	Fragment       string    `json:"fragment"`
+	Source         string    `json:"source"`
+	Percentage     float64   `json:"percentage"`
+	SourceFragment string    `json:"sourceFragment,omitempty"` // the matching passage of the source
+	Location       *Location `json:"location,omitempty"`       // where the fragment is in the checked content
 }
 
 // SearchService defines the interface for web search and content retrieval
@@ -53,6 +127,15 @@ type SearchService interface {
 	FetchContent(ctx context.Context, url string) (string, error)
 }
 
+// PageSearchService is a search service that also returns fetched pages with their title
+// and publish date
+type PageSearchService interface {
+	SearchService
+
+	// FetchPage retrieves the page at a URL and extracts its main content
+	FetchPage(ctx context.Context, url string) (*FetchedPage, error)
+}
+
 // SearchResult represents a search result
 type SearchResult struct {
 	Title         string `json:"title"`
@@ -63,9 +146,16 @@ type SearchResult struct {
 	Relevance     int    `json:"relevance"`
 }
 
-// OpenAIClient is a client for interacting with the OpenAI API
+// DefaultOpenAIBaseURL is the base URL of the hosted OpenAI API
+const DefaultOpenAIBaseURL = "https://api.openai.com/v1"
+
+// defaultSystemPrompt is sent when the caller does not provide a system prompt
+const defaultSystemPrompt = "You are a helpful content creation assistant."
+
+// OpenAIClient is a client for the OpenAI chat completions API and servers compatible with it
 type OpenAIClient struct {
 	APIKey      string
+	BaseURL     string
 	Model       string
 	MaxTokens   int
 	Temperature float64
@@ -74,8 +164,15 @@ type OpenAIClient struct {
 
 // NewOpenAIClient creates a new client for the OpenAI API
 func NewOpenAIClient(apiKey, model string, maxTokens int, temperature float64) *OpenAIClient {
+	return NewOpenAICompatibleClient(DefaultOpenAIBaseURL, apiKey, model, maxTokens, temperature)
+}
+
+// NewOpenAICompatibleClient creates a client for any server implementing the OpenAI
+// chat completions API, such as vLLM or Ollama. The API key may be empty.
+func NewOpenAICompatibleClient(baseURL, apiKey, model string, maxTokens int, temperature float64) *OpenAIClient {
 	return &OpenAIClient{
 		APIKey:      apiKey,
+		BaseURL:     strings.TrimRight(baseURL, "/"),
 		Model:       model,
 		MaxTokens:   maxTokens,
 		Temperature: temperature,
@@ -93,10 +190,19 @@ type OpenAIMessage struct {
 
 // OpenAIRequest represents a request to the OpenAI API
 type OpenAIRequest struct {
-	Model       string          `json:"model"`
-	Messages    []OpenAIMessage `json:"messages"`
-	MaxTokens   int             `json:"max_tokens"`
-	Temperature float64         `json:"temperature"`
+	Model          string                `json:"model"`
+	Messages       []OpenAIMessage       `json:"messages"`
+	MaxTokens      int                   `json:"max_tokens"`
+	Temperature    float64               `json:"temperature"`
+	Stop           []string              `json:"stop,omitempty"`
+	ResponseFormat *OpenAIResponseFormat `json:"response_format,omitempty"`
+	Stream         bool                  `json:"stream,omitempty"`
+	StreamOptions  *OpenAIStreamOptions  `json:"stream_options,omitempty"`
+}
+
+// OpenAIResponseFormat selects the output format of a chat completion
+type OpenAIResponseFormat struct {
+	Type string `json:"type"`
 }
 
 // OpenAIResponse represents a response from the OpenAI API
@@ -104,6 +210,7 @@ type OpenAIResponse struct {
 	ID      string `json:"id"`
 	Object  string `json:"object"`
 	Created int64  `json:"created"`
+	Model   string `json:"model"`
 	Choices []struct {
 		Index   int `json:"index"`
 		Message struct {
@@ -112,136 +219,199 @@ type OpenAIResponse struct {
 		} `json:"message"`
 		FinishReason string `json:"finish_reason"`
 	} `json:"choices"`
+	Usage struct {
+		PromptTokens     int `json:"prompt_tokens"`
+		CompletionTokens int `json:"completion_tokens"`
+		TotalTokens      int `json:"total_tokens"`
+	} `json:"usage"`
+}
+
+// requestOptions resolves the request's overrides against a client's defaults
+func requestOptions(request LLMRequest, maxTokens int, temperature float64) (int, float64, error) {
+	if len(request.Messages) == 0 {
+		return 0, 0, &InvalidRequestError{Reason: "no messages"}
+	}
+	for _, message := range request.Messages {
+		switch message.Role {
+		case RoleSystem, RoleUser, RoleAssistant:
+		default:
+			return 0, 0, &InvalidRequestError{Reason: fmt.Sprintf("unknown message role %q", message.Role)}
+		}
+	}
+
+	if request.MaxTokens > 0 {
+		maxTokens = request.MaxTokens
+	}
+	if request.Temperature != nil {
+		temperature = *request.Temperature
+	}
+	return maxTokens, temperature, nil
 }
 
-// Generate creates content using the OpenAI API
-func (c *OpenAIClient) Generate(ctx context.Context, prompt interface{}) (string, error) {
-	// Handle different prompt types
-	var messages []OpenAIMessage
-
-	switch p := prompt.(type) {
-	case string:
-		// Simple string prompt
-		messages = []OpenAIMessage{
-			{Role: "system", Content: "You are a helpful content creation assistant."},
-			{Role: "user", Content: p},
-		}
-	case []string:
-		// Context with strings
-		messages = []OpenAIMessage{
-			{Role: "system", Content: "You are a helpful content creation assistant."},
-		}
+// newRequest builds the chat completion request for an LLM request
+func (c *OpenAIClient) newRequest(request LLMRequest) (OpenAIRequest, error) {
+	maxTokens, temperature, err := requestOptions(request, c.MaxTokens, c.Temperature)
+	if err != nil {
+		return OpenAIRequest{}, err
+	}
 
-		// Context strings are added as separate messages
-		for i, content := range p {
-			role := "user"
-			if i > 0 {
-				role = "assistant"
-				if i%2 == 0 {
-					role = "user"
-				}
-			}
-			messages = append(messages, OpenAIMessage{Role: role, Content: content})
-		}
-	default:
-		return "", &InvalidPromptError{PromptType: prompt}
+	system := request.SystemPrompt
+	if system == "" {
+		system = defaultSystemPrompt
+	}
+	messages := []OpenAIMessage{{Role: RoleSystem, Content: system}}
+	for _, message := range request.Messages {
+		messages = append(messages, OpenAIMessage{Role: message.Role, Content: message.Content})
 	}
 
 	// Create the request body
-	request := OpenAIRequest{
+	body := OpenAIRequest{
 		Model:       c.Model,
 		Messages:    messages,
-		MaxTokens:   c.MaxTokens,
-		Temperature: c.Temperature,
+		MaxTokens:   maxTokens,
+		Temperature: temperature,
+		Stop:        request.StopSequences,
 	}
+	if request.JSONMode {
+		body.ResponseFormat = &OpenAIResponseFormat{Type: "json_object"}
+	}
+	return body, nil
+}
 
-	// Convert the request to JSON
-	requestBody, err := json.Marshal(request)
+// headers returns the authentication headers for the API
+func (c *OpenAIClient) headers() map[string]string {
+	headers := map[string]string{}
+	if c.APIKey != "" {
+		headers["Authorization"] = "Bearer " + c.APIKey
+	}
+	return headers
+}
+
+// Generate creates content using the OpenAI API
+func (c *OpenAIClient) Generate(ctx context.Context, request LLMRequest) (*LLMResponse, error) {
+	body, err := c.newRequest(request)
 	if err != nil {
-		return "", err
+		return nil, err
+	}
+
+	var response OpenAIResponse
+	if err := postJSON(ctx, c.HTTPClient, c.BaseURL+"/chat/completions", c.headers(), body, &response); err != nil {
+		return nil, err
+	}
+
+	// Extract the generated content
+	if len(response.Choices) == 0 {
+		return nil, &EmptyResponseError{}
+	}
+
+	model := response.Model
+	if model == "" {
+		model = c.Model
 	}
 
-	// Create the HTTP request
-	req, err := http.NewRequestWithContext(
-		ctx,
-		"POST",
-		"https://api.openai.com/v1/chat/completions",
-		strings.NewReader(string(requestBody)),
-	)
+	return &LLMResponse{
+		Content:      response.Choices[0].Message.Content,
+		FinishReason: response.Choices[0].FinishReason,
+		Usage: LLMUsage{
+			PromptTokens:     response.Usage.PromptTokens,
+			CompletionTokens: response.Usage.CompletionTokens,
+			TotalTokens:      response.Usage.TotalTokens,
+		},
+		Model: model,
+	}, nil
+}
+
+// postJSON sends body as JSON to url and decodes a successful JSON response into result.
+// Non-200 responses are returned as an APIError.
+func postJSON(ctx context.Context, client *http.Client, url string, headers map[string]string, body, result interface{}) error {
+	requestBody, err := json.Marshal(body)
 	if err != nil {
-		return "", err
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", url, strings.NewReader(string(requestBody)))
+	if err != nil {
+		return err
 	}
 
-	// Set headers
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", "Bearer "+c.APIKey)
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
 
-	// Send the request
-	resp, err := c.HTTPClient.Do(req)
+	resp, err := client.Do(req)
 	if err != nil {
-		return "", err
+		return err
 	}
 	defer resp.Body.Close()
 
-	// Read the response body
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return "", err
+		return err
 	}
 
 	// Check for API errors
 	if resp.StatusCode != http.StatusOK {
-		return "", &APIError{
+		return &APIError{
 			StatusCode: resp.StatusCode,
 			Message:    string(respBody),
+			RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After")),
 		}
 	}
 
-	// Parse the response
-	var response OpenAIResponse
-	err = json.Unmarshal(respBody, &response)
-	if err != nil {
-		return "", err
-	}
+	return json.Unmarshal(respBody, result)
+}
 
-	// Extract the generated content
-	if len(response.Choices) == 0 {
-		return "", &EmptyResponseError{}
+// parseRetryAfter reads a Retry-After header given in seconds or as an HTTP date
+func parseRetryAfter(header string) time.Duration {
+	if header == "" {
+		return 0
 	}
-
-	return response.Choices[0].Message.Content, nil
+	if seconds, err := strconv.Atoi(strings.TrimSpace(header)); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if at, err := http.ParseTime(header); err == nil {
+		if wait := time.Until(at); wait > 0 {
+			return wait
+		}
+	}
+	return 0
 }
 
-// WebSearchService implements the SearchService interface for web search
+// WebSearchService implements the SearchService interface for web search. Its engine
+// adapts the search API it talks to; pages are retrieved with its fetcher.
 type WebSearchService struct {
-	APIKey     string
-	HTTPClient *http.Client
-	SearchURL  string
+	APIKey      string
+	HTTPClient  *http.Client
+	SearchURL   string
+	Engine      SearchEngine
+	ResultCount int
+	fetcher     *PageFetcher
 }
 
-// NewWebSearchService creates a new web search service
+// NewWebSearchService creates a new web search service for an API returning results in the
+// generic JSON shape
 func NewWebSearchService(apiKey, searchURL string) *WebSearchService {
 	return &WebSearchService{
 		APIKey: apiKey,
 		HTTPClient: &http.Client{
 			Timeout: 10 * time.Second,
 		},
-		SearchURL: searchURL,
+		SearchURL:   searchURL,
+		Engine:      GenericSearchEngine{},
+		ResultCount: 10,
+		fetcher:     NewPageFetcher(DefaultFetcherConfig()),
 	}
 }
 
+// UseFetcher sets the fetcher used to retrieve pages
+func (s *WebSearchService) UseFetcher(fetcher *PageFetcher) {
+	s.fetcher = fetcher
+}
+
 // Search performs a web search and returns results
 func (s *WebSearchService) Search(ctx context.Context, query string) ([]SearchResult, error) {
-	// Build the search URL
-	params := url.Values{}
-	params.Add("q", query)
-	params.Add("key", s.APIKey)
-	params.Add("num", "10") // Number of results
-
-	searchURL := s.SearchURL + "?" + params.Encode()
-
-	// Create the HTTP request
-	req, err := http.NewRequestWithContext(ctx, "GET", searchURL, nil)
+	req, err := s.Engine.NewRequest(ctx, s.SearchURL, s.APIKey, query, s.ResultCount)
 	if err != nil {
 		return nil, err
 	}
@@ -264,128 +434,50 @@ func (s *WebSearchService) Search(ctx context.Context, query string) ([]SearchRe
 		return nil, &APIError{
 			StatusCode: resp.StatusCode,
 			Message:    string(respBody),
+			RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After")),
 		}
 	}
 
-	// Parse the response (implementation depends on the search API used)
-	results, err := parseSearchResults(respBody)
+	results, err := s.Engine.ParseResults(respBody)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to parse search results: %w", err)
+	}
+	if len(results) > s.ResultCount && s.ResultCount > 0 {
+		results = results[:s.ResultCount]
 	}
-
 	return results, nil
 }
 
-// FetchContent retrieves content from a URL
+// FetchContent retrieves the main content of the page at a URL as research text
 func (s *WebSearchService) FetchContent(ctx context.Context, urlStr string) (string, error) {
-	// Create the HTTP request
-	req, err := http.NewRequestWithContext(ctx, "GET", urlStr, nil)
-	if err != nil {
-		return "", err
-	}
-
-	// Set a user agent to avoid being blocked
-	req.Header.Set("User-Agent", "Mozilla/5.0 (compatible; ContentCreationService/1.0)")
-
-	// Send the request
-	resp, err := s.HTTPClient.Do(req)
-	if err != nil {
-		return "", err
-	}
-	defer resp.Body.Close()
-
-	// Check for HTTP errors
-	if resp.StatusCode != http.StatusOK {
-		return "", &APIError{
-			StatusCode: resp.StatusCode,
-			Message:    "Failed to fetch content",
-		}
-	}
-
-	// Read the response body
-	respBody, err := io.ReadAll(resp.Body)
+	page, err := s.FetchPage(ctx, urlStr)
 	if err != nil {
 		return "", err
 	}
-
-	// Extract text content from HTML (simplified; a real implementation would use a proper HTML parser)
-	content := extractTextFromHTML(string(respBody))
-
-	return content, nil
+	return page.Document(), nil
 }
 
-// parseSearchResults parses search results from API response
-func parseSearchResults(respBody []byte) ([]SearchResult, error) {
-	// This is a simplified implementation; the actual parsing depends on the search API used
-	// For example, if using Google Custom Search API, it would parse the JSON response
-
-	// Placeholder implementation
-	var results []SearchResult
-
-	// Parse JSON response (structure depends on the search API)
-	// Example for a generic JSON response
-	var response struct {
-		Items []struct {
-			Title       string `json:"title"`
-			Link        string `json:"link"`
-			Snippet     string `json:"snippet"`
-			Authors     string `json:"authors,omitempty"`
-			PublishedAt string `json:"publishedAt,omitempty"`
-		} `json:"items"`
-	}
-
-	err := json.Unmarshal(respBody, &response)
-	if err != nil {
-		return nil, err
-	}
-
-	// Convert API-specific format to SearchResult
-	for i, item := range response.Items {
-		results = append(results, SearchResult{
-			Title:         item.Title,
-			URL:           item.Link,
-			Snippet:       item.Snippet,
-			Authors:       item.Authors,
-			PublishedDate: item.PublishedAt,
-			Relevance:     len(response.Items) - i, // Higher relevance for earlier results
-		})
-	}
-
-	return results, nil
-}
-
-// extractTextFromHTML extracts text content from HTML
-func extractTextFromHTML(html string) string {
-	// This is a simplified implementation; a real one would use a proper HTML parser
-	// Remove HTML tags
-	tagRegex := regexp.MustCompile(`<[^>]*>`)
-	text := tagRegex.ReplaceAllString(html, " ")
-
-	// Remove extra whitespace
-	whitespaceRegex := regexp.MustCompile(`\s+`)
-	text = whitespaceRegex.ReplaceAllString(text, " ")
-
-	// Remove leading/trailing whitespace
-	text = strings.TrimSpace(text)
-
-	return text
+// FetchPage retrieves the page at a URL and extracts its title, publish date and main content
+func (s *WebSearchService) FetchPage(ctx context.Context, urlStr string) (*FetchedPage, error) {
+	return s.fetcher.Fetch(ctx, urlStr)
 }
 
 // Error types
 
-// InvalidPromptError represents an error with an invalid prompt
-type InvalidPromptError struct {
-	PromptType interface{}
+// InvalidRequestError represents an LLM request that cannot be sent
+type InvalidRequestError struct {
+	Reason string
 }
 
-func (e *InvalidPromptError) Error() string {
-	return fmt.Sprintf("invalid prompt type: %T", e.PromptType)
+func (e *InvalidRequestError) Error() string {
+	return fmt.Sprintf("invalid LLM request: %s", e.Reason)
 }
 
 // APIError represents an error from an API call
 type APIError struct {
 	StatusCode int
 	Message    string
+	RetryAfter time.Duration // from the Retry-After header, zero when absent
 }
 
 func (e *APIError) Error() string {
@@ -748,20 +840,53 @@ func countLinks(content string) int {
 	return len(links)
 }
 
-// SimplePlagiarismAPI implements a basic plagiarism detection mechanism
-type SimplePlagiarismAPI struct{}
+// SimplePlagiarismAPI implements a basic plagiarism detection mechanism, checking content
+// against the research sources in a similarity index
+type SimplePlagiarismAPI struct {
+	index *SimilarityIndex
+}
 
 // NewSimplePlagiarismAPI creates a new plagiarism detection API
 func NewSimplePlagiarismAPI() *SimplePlagiarismAPI {
 	return &SimplePlagiarismAPI{}
 }
 
-// CheckPlagiarism detects potential plagiarism in content
+// UseSimilarityIndex makes the API check content against the research sources in the index
+func (p *SimplePlagiarismAPI) UseSimilarityIndex(index *SimilarityIndex) {
+	p.index = index
+}
+
+// CheckPlagiarism detects passages of content copied from indexed research sources. It
+// returns the share of the content that is original and one detail per copied passage.
 func (p *SimplePlagiarismAPI) CheckPlagiarism(ctx context.Context, content string) (float64, []PlagiarismDetail, error) {
-	// This is a simplified implementation; a real one would use a proper plagiarism detection service
+	if p.index == nil {
+		// Without an index there is nothing to compare against
+		return 0.95, []PlagiarismDetail{}, nil
+	}
+
+	matches, err := p.index.FindMatches(ctx, content, entities.SimilarityDocumentSource, nil)
+	if err != nil {
+		return 0, nil, fmt.Errorf("similarity index lookup failed: %w", err)
+	}
+
+	originality := 1.0
+	details := []PlagiarismDetail{}
+	for _, match := range matches {
+		if 1-match.Containment < originality {
+			originality = 1 - match.Containment
+		}
+		for _, span := range match.Spans {
+			details = append(details, PlagiarismDetail{
+				Fragment:       span.Text,
+				Source:         match.Document.URL,
+				Percentage:     match.Containment * 100,
+				SourceFragment: span.SourceText,
+				Location:       &Location{StartChar: span.Start, EndChar: span.End},
+			})
+		}
+	}
 
-	// For now, we'll just return a high originality score and no detected plagiarism
-	return 0.95, []PlagiarismDetail{}, nil
+	return originality, details, nil
 }
 
 // extractKeywordsFromText extracts keywords from text
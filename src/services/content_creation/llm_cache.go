@@ -0,0 +1,241 @@
+package content_creation
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Ceesaxp/autonomous-content-service/src/domain/entities"
+	"github.com/Ceesaxp/autonomous-content-service/src/domain/repositories"
+)
+
+const (
+	// defaultLLMCacheTTL is how long responses are cached when no TTL is configured
+	defaultLLMCacheTTL = 24 * time.Hour
+
+	// llmCachePurgeInterval is the minimum time between removals of expired entries
+	llmCachePurgeInterval = 10 * time.Minute
+)
+
+// llmCachePolicy overrides the caching of the calls made with a context
+type llmCachePolicy struct {
+	skip bool
+	ttl  time.Duration
+}
+
+type llmCachePolicyKey struct{}
+
+// WithoutLLMCache returns a context whose LLM calls bypass the cache. Use it for creative
+// generations that should produce fresh text every time.
+func WithoutLLMCache(ctx context.Context) context.Context {
+	policy, _ := ctx.Value(llmCachePolicyKey{}).(llmCachePolicy)
+	policy.skip = true
+	return context.WithValue(ctx, llmCachePolicyKey{}, policy)
+}
+
+// WithLLMCacheTTL returns a context whose LLM responses are cached for ttl instead of the
+// client's default
+func WithLLMCacheTTL(ctx context.Context, ttl time.Duration) context.Context {
+	policy, _ := ctx.Value(llmCachePolicyKey{}).(llmCachePolicy)
+	policy.ttl = ttl
+	return context.WithValue(ctx, llmCachePolicyKey{}, policy)
+}
+
+// LLMCacheStats reports how a CachingLLMClient has served its calls
+type LLMCacheStats struct {
+	Hits     int64   `json:"hits"`
+	Misses   int64   `json:"misses"`
+	Bypassed int64   `json:"bypassed"` // calls made with WithoutLLMCache
+	Errors   int64   `json:"errors"`   // cache reads and writes that failed
+	HitRate  float64 `json:"hitRate"`  // hits over hits and misses
+}
+
+// CachingLLMClient wraps an LLMClient and serves repeated requests from a cache. Requests
+// are keyed by model, parameters and normalized prompt; failed calls are not cached.
+type CachingLLMClient struct {
+	client LLMClient
+	cache  repositories.LLMCacheRepository
+	model  string
+	ttl    time.Duration
+
+	hits     atomic.Int64
+	misses   atomic.Int64
+	bypassed atomic.Int64
+	errors   atomic.Int64
+
+	purgeMu   sync.Mutex
+	lastPurge time.Time
+}
+
+// NewCachingLLMClient creates a caching client. model is part of every cache key, so
+// clients for different models never share responses. A non-positive ttl selects the default.
+func NewCachingLLMClient(client LLMClient, cache repositories.LLMCacheRepository, model string, ttl time.Duration) *CachingLLMClient {
+	if ttl <= 0 {
+		ttl = defaultLLMCacheTTL
+	}
+	return &CachingLLMClient{
+		client:    client,
+		cache:     cache,
+		model:     model,
+		ttl:       ttl,
+		lastPurge: time.Now(),
+	}
+}
+
+// Generate returns the cached response for the request, or calls the wrapped client and caches its response
+func (c *CachingLLMClient) Generate(ctx context.Context, request LLMRequest) (*LLMResponse, error) {
+	return c.generate(ctx, request, func() (*LLMResponse, error) {
+		return c.client.Generate(ctx, request)
+	}, nil)
+}
+
+// GenerateStream streams from the wrapped client on a miss. A cached response is delivered as a single chunk.
+func (c *CachingLLMClient) GenerateStream(ctx context.Context, request LLMRequest, onChunk func(chunk string) error) (*LLMResponse, error) {
+	return c.generate(ctx, request, func() (*LLMResponse, error) {
+		return generateStream(ctx, c.client, request, onChunk)
+	}, onChunk)
+}
+
+// generate serves a call from the cache when possible, falling back to call
+func (c *CachingLLMClient) generate(ctx context.Context, request LLMRequest, call func() (*LLMResponse, error), onChunk func(chunk string) error) (*LLMResponse, error) {
+	policy, _ := ctx.Value(llmCachePolicyKey{}).(llmCachePolicy)
+	if policy.skip {
+		c.bypassed.Add(1)
+		return call()
+	}
+
+	key, err := LLMCacheKey(c.model, request)
+	if err != nil {
+		return nil, err
+	}
+
+	if cached, ok := c.lookup(ctx, key); ok {
+		c.hits.Add(1)
+		if onChunk != nil {
+			if err := onChunk(cached.Content); err != nil {
+				return nil, err
+			}
+		}
+		return cached, nil
+	}
+	c.misses.Add(1)
+
+	response, err := call()
+	if err != nil {
+		return nil, err
+	}
+
+	ttl := c.ttl
+	if policy.ttl > 0 {
+		ttl = policy.ttl
+	}
+	c.store(ctx, key, response, ttl)
+	return response, nil
+}
+
+// lookup returns the cached response for key. Cache failures are treated as misses.
+func (c *CachingLLMClient) lookup(ctx context.Context, key string) (*LLMResponse, bool) {
+	entry, err := c.cache.Get(ctx, key)
+	if err != nil {
+		if !repositories.IsNotFound(err) {
+			c.errors.Add(1)
+			fmt.Printf("Warning: failed to read LLM cache: %v\n", err)
+		}
+		return nil, false
+	}
+
+	var response LLMResponse
+	if err := json.Unmarshal(entry.Response, &response); err != nil {
+		c.errors.Add(1)
+		fmt.Printf("Warning: failed to decode cached LLM response %s: %v\n", key, err)
+		return nil, false
+	}
+	return &response, true
+}
+
+// store caches a response and occasionally removes expired entries. Caching must not
+// fail the call whose response it caches.
+func (c *CachingLLMClient) store(ctx context.Context, key string, response *LLMResponse, ttl time.Duration) {
+	data, err := json.Marshal(response)
+	if err == nil {
+		err = c.cache.Put(ctx, entities.NewLLMCacheEntry(key, c.model, data, ttl))
+	}
+	if err != nil {
+		c.errors.Add(1)
+		fmt.Printf("Warning: failed to write LLM cache: %v\n", err)
+	}
+
+	c.purgeMu.Lock()
+	due := time.Since(c.lastPurge) >= llmCachePurgeInterval
+	if due {
+		c.lastPurge = time.Now()
+	}
+	c.purgeMu.Unlock()
+
+	if due {
+		if _, err := c.cache.DeleteExpired(ctx, time.Now()); err != nil {
+			fmt.Printf("Warning: failed to remove expired LLM cache entries: %v\n", err)
+		}
+	}
+}
+
+// Stats returns the hit and miss counts of the client
+func (c *CachingLLMClient) Stats() LLMCacheStats {
+	stats := LLMCacheStats{
+		Hits:     c.hits.Load(),
+		Misses:   c.misses.Load(),
+		Bypassed: c.bypassed.Load(),
+		Errors:   c.errors.Load(),
+	}
+	if lookups := stats.Hits + stats.Misses; lookups > 0 {
+		stats.HitRate = float64(stats.Hits) / float64(lookups)
+	}
+	return stats
+}
+
+// llmCacheKeyFields are the parts of a request that determine its response
+type llmCacheKeyFields struct {
+	Model         string       `json:"model"`
+	SystemPrompt  string       `json:"systemPrompt"`
+	Messages      []LLMMessage `json:"messages"`
+	Temperature   *float64     `json:"temperature"`
+	MaxTokens     int          `json:"maxTokens"`
+	StopSequences []string     `json:"stopSequences"`
+	JSONMode      bool         `json:"jsonMode"`
+}
+
+// LLMCacheKey returns the cache key of a request to model: the SHA-256 of its model,
+// parameters and prompt, with whitespace in the prompt normalized
+func LLMCacheKey(model string, request LLMRequest) (string, error) {
+	fields := llmCacheKeyFields{
+		Model:         model,
+		SystemPrompt:  normalizePrompt(request.SystemPrompt),
+		Messages:      make([]LLMMessage, len(request.Messages)),
+		Temperature:   request.Temperature,
+		MaxTokens:     request.MaxTokens,
+		StopSequences: request.StopSequences,
+		JSONMode:      request.JSONMode,
+	}
+	for i, message := range request.Messages {
+		fields.Messages[i] = LLMMessage{Role: message.Role, Content: normalizePrompt(message.Content)}
+	}
+
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode cache key: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// normalizePrompt collapses runs of whitespace so prompts that differ only in
+// indentation or line breaks share a cache entry
+func normalizePrompt(prompt string) string {
+	return strings.Join(strings.Fields(prompt), " ")
+}
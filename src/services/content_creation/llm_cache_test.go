@@ -0,0 +1,90 @@
+package content_creation
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Ceesaxp/autonomous-content-service/src/infrastructure/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCachingLLMClient(t *testing.T) {
+	ctx := context.Background()
+	inner := new(MockLLMClient)
+	inner.On("Generate", mock.Anything, NewPromptRequest("Score this text:\n  hello world")).Return("score 7", nil).Once()
+	inner.On("Generate", mock.Anything, NewPromptRequest("Write a poem")).Return("poem", nil).Twice()
+	inner.On("Generate", mock.Anything, NewPromptRequest("failing")).Return(nil, errors.New("boom")).Once()
+	inner.On("Generate", mock.Anything, NewPromptRequest("failing")).Return("recovered", nil).Once()
+
+	client := NewCachingLLMClient(inner, memory.NewLLMCacheRepository(0), "gpt-4", time.Hour)
+
+	first, err := client.Generate(ctx, NewPromptRequest("Score this text:\n  hello world"))
+	require.NoError(t, err)
+	assert.Equal(t, "score 7", first.Content)
+
+	// Whitespace differences are normalized away
+	second, err := client.Generate(ctx, NewPromptRequest("Score this text: hello world"))
+	require.NoError(t, err)
+	assert.Equal(t, first, second)
+
+	// Creative calls opt out of the cache
+	for i := 0; i < 2; i++ {
+		_, err := client.Generate(WithoutLLMCache(ctx), NewPromptRequest("Write a poem"))
+		require.NoError(t, err)
+	}
+
+	// Failures are not cached
+	_, err = client.Generate(ctx, NewPromptRequest("failing"))
+	assert.Error(t, err)
+	recovered, err := client.Generate(ctx, NewPromptRequest("failing"))
+	require.NoError(t, err)
+	assert.Equal(t, "recovered", recovered.Content)
+
+	// A cached response is streamed as one chunk
+	var chunks []string
+	streamed, err := client.GenerateStream(ctx, NewPromptRequest("Score this text: hello world"), func(chunk string) error {
+		chunks = append(chunks, chunk)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"score 7"}, chunks)
+	assert.Equal(t, "score 7", streamed.Content)
+
+	inner.AssertExpectations(t)
+	assert.Equal(t, LLMCacheStats{Hits: 2, Misses: 3, Bypassed: 2, HitRate: 0.4}, client.Stats())
+}
+
+func TestCachingLLMClient_KeysAndExpiry(t *testing.T) {
+	ctx := context.Background()
+	request := NewPromptRequest("Summarize the research")
+
+	gpt4, err := LLMCacheKey("gpt-4", request)
+	require.NoError(t, err)
+	claude, err := LLMCacheKey("claude-sonnet", request)
+	require.NoError(t, err)
+	assert.NotEqual(t, gpt4, claude)
+
+	temperature := 0.0
+	deterministic := request
+	deterministic.Temperature = &temperature
+	withTemperature, err := LLMCacheKey("gpt-4", deterministic)
+	require.NoError(t, err)
+	assert.NotEqual(t, gpt4, withTemperature)
+
+	inner := new(MockLLMClient)
+	inner.On("Generate", mock.Anything, request).Return("summary", nil).Twice()
+	client := NewCachingLLMClient(inner, memory.NewLLMCacheRepository(0), "gpt-4", time.Hour)
+
+	_, err = client.Generate(WithLLMCacheTTL(ctx, time.Millisecond), request)
+	require.NoError(t, err)
+	time.Sleep(5 * time.Millisecond)
+
+	_, err = client.Generate(ctx, request)
+	require.NoError(t, err)
+	inner.AssertExpectations(t)
+	assert.Equal(t, int64(2), client.Stats().Misses)
+}
@@ -0,0 +1,143 @@
+package content_creation
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// LLMFixture is a recorded LLM response, stored as <hash>.json in a fixture directory
+type LLMFixture struct {
+	Hash     string      `json:"hash"`
+	Request  LLMRequest  `json:"request"`
+	Response LLMResponse `json:"response"`
+}
+
+// PromptHash returns the fixture key of a request: the SHA-256 of its JSON encoding
+func PromptHash(request LLMRequest) (string, error) {
+	data, err := json.Marshal(request)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode request: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// RecordingLLMClient wraps an LLMClient and saves every successful response as a fixture
+type RecordingLLMClient struct {
+	client LLMClient
+	dir    string
+	mu     sync.Mutex
+}
+
+// NewRecordingLLMClient creates a client that records the responses of client into dir
+func NewRecordingLLMClient(client LLMClient, dir string) (*RecordingLLMClient, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create fixture directory: %w", err)
+	}
+	return &RecordingLLMClient{client: client, dir: dir}, nil
+}
+
+// Generate calls the wrapped client and records the response
+func (c *RecordingLLMClient) Generate(ctx context.Context, request LLMRequest) (*LLMResponse, error) {
+	response, err := c.client.Generate(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.save(request, response); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// save writes the fixture through a temporary file so readers never see a partial write
+func (c *RecordingLLMClient) save(request LLMRequest, response *LLMResponse) error {
+	hash, err := PromptHash(request)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(LLMFixture{Hash: hash, Request: request, Response: *response}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode fixture: %w", err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	path := filepath.Join(c.dir, hash+".json")
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write fixture: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to write fixture: %w", err)
+	}
+	return nil
+}
+
+// ReplayLLMClient serves responses recorded by RecordingLLMClient without any network access
+type ReplayLLMClient struct {
+	dir      string
+	fixtures map[string]LLMResponse
+}
+
+// NewReplayLLMClient loads every fixture in dir
+func NewReplayLLMClient(dir string) (*ReplayLLMClient, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list fixtures: %w", err)
+	}
+
+	fixtures := make(map[string]LLMResponse, len(paths))
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read fixture %s: %w", path, err)
+		}
+
+		var fixture LLMFixture
+		if err := json.Unmarshal(data, &fixture); err != nil {
+			return nil, fmt.Errorf("failed to decode fixture %s: %w", path, err)
+		}
+		fixtures[fixture.Hash] = fixture.Response
+	}
+
+	return &ReplayLLMClient{dir: dir, fixtures: fixtures}, nil
+}
+
+// Generate returns the recorded response for the request, or an UnknownPromptError
+func (c *ReplayLLMClient) Generate(ctx context.Context, request LLMRequest) (*LLMResponse, error) {
+	hash, err := PromptHash(request)
+	if err != nil {
+		return nil, err
+	}
+
+	response, ok := c.fixtures[hash]
+	if !ok {
+		return nil, &UnknownPromptError{Hash: hash, Dir: c.dir, Request: request}
+	}
+	return &response, nil
+}
+
+// UnknownPromptError is returned by ReplayLLMClient for a request that has no fixture
+type UnknownPromptError struct {
+	Hash    string
+	Dir     string
+	Request LLMRequest
+}
+
+func (e *UnknownPromptError) Error() string {
+	excerpt := e.Request.LastUserMessage()
+	if len(excerpt) > 200 {
+		excerpt = excerpt[:200] + "..."
+	}
+	excerpt = strings.ReplaceAll(excerpt, "\n", " ")
+	return fmt.Sprintf("no LLM fixture %s.json in %s for prompt %q; re-record the fixtures", e.Hash, e.Dir, excerpt)
+}
@@ -0,0 +1,189 @@
+package content_creation
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/Ceesaxp/autonomous-content-service/src/domain/entities"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// Golden pipeline tests replay LLM responses recorded under testdata/llm_fixtures and
+// compare the finished content with testdata/golden. To re-record against a real model:
+//
+//	LLM_FIXTURES_RECORD=1 LLM_PROVIDER=openai LLM_MODEL=gpt-4 LLM_API_KEY=... go test -run Golden ./...
+//
+// Recording rewrites the fixtures and golden files of every case.
+const recordFixturesEnv = "LLM_FIXTURES_RECORD"
+
+func TestRecordingAndReplayLLMClient(t *testing.T) {
+	dir := t.TempDir()
+	ctx := context.Background()
+
+	inner := new(MockLLMClient)
+	first := NewPromptRequest("first prompt")
+	failing := NewPromptRequest("failing prompt")
+	inner.On("Generate", mock.Anything, first).Return(&LLMResponse{
+		Content:      "first response",
+		FinishReason: FinishReasonStop,
+		Usage:        LLMUsage{PromptTokens: 3, CompletionTokens: 2, TotalTokens: 5},
+	}, nil).Once()
+	inner.On("Generate", mock.Anything, failing).Return(nil, errors.New("boom")).Once()
+
+	recorder, err := NewRecordingLLMClient(inner, dir)
+	require.NoError(t, err)
+
+	response, err := recorder.Generate(ctx, first)
+	require.NoError(t, err)
+	assert.Equal(t, "first response", response.Content)
+
+	_, err = recorder.Generate(ctx, failing)
+	assert.Error(t, err)
+	inner.AssertExpectations(t)
+
+	replay, err := NewReplayLLMClient(dir)
+	require.NoError(t, err)
+
+	replayed, err := replay.Generate(ctx, first)
+	require.NoError(t, err)
+	assert.Equal(t, response, replayed)
+
+	// Failed calls are not recorded, so replaying them must fail loudly
+	_, err = replay.Generate(ctx, failing)
+	var unknown *UnknownPromptError
+	require.ErrorAs(t, err, &unknown)
+	assert.Contains(t, err.Error(), "failing prompt")
+	assert.Contains(t, err.Error(), "re-record")
+}
+
+func TestContentPipeline_Golden(t *testing.T) {
+	cases := []struct {
+		name        string
+		title       string
+		contentType entities.ContentType
+	}{
+		{name: "blog_post", title: "Why Small Teams Ship Faster", contentType: entities.ContentTypeBlogPost},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			fixtureDir := filepath.Join("testdata", "llm_fixtures", tc.name)
+			goldenPath := filepath.Join("testdata", "golden", tc.name+".golden")
+			recording := os.Getenv(recordFixturesEnv) == "1"
+
+			var llmClient LLMClient
+			if recording {
+				require.NoError(t, os.RemoveAll(fixtureDir))
+				recorder, err := NewRecordingLLMClient(newRecordingTargetClient(t), fixtureDir)
+				require.NoError(t, err)
+				llmClient = recorder
+			} else {
+				replay, err := NewReplayLLMClient(fixtureDir)
+				require.NoError(t, err)
+				llmClient = replay
+			}
+
+			result := runGoldenPipeline(t, llmClient, tc.title, tc.contentType)
+
+			if recording {
+				require.NoError(t, os.MkdirAll(filepath.Dir(goldenPath), 0o755))
+				require.NoError(t, os.WriteFile(goldenPath, []byte(result.Data), 0o644))
+				return
+			}
+
+			golden, err := os.ReadFile(goldenPath)
+			require.NoError(t, err)
+			assert.Equal(t, string(golden), result.Data)
+		})
+	}
+}
+
+// runGoldenPipeline runs the whole pipeline with fixed research and quality results,
+// so the only variable input is the LLM client
+func runGoldenPipeline(t *testing.T, llmClient LLMClient, title string, contentType entities.ContentType) *entities.Content {
+	t.Helper()
+
+	repos := newTestRepositories()
+	mockContextManager := new(MockContextManager)
+	mockResearcher := new(MockResearcher)
+	mockQualityChecker := new(MockQualityChecker)
+
+	projectID := uuid.New()
+	repos.seedProject(t, projectID, contentType)
+
+	mockContextManager.On("SwitchContext", mock.Anything, projectID).Return(nil)
+	mockContextManager.On("AddEntry", mock.Anything, projectID, mock.Anything).Return(nil)
+
+	mockResearcher.On("Research", mock.Anything, mock.Anything, mock.Anything).Return(&ResearchOutput{
+		Topics: []ResearchTopic{
+			{Topic: title, Keywords: []string{"small teams", "delivery"}, Priority: 8, MaxSources: 5},
+		},
+		Sources: []ResearchSource{
+			{
+				Type:        "web",
+				URL:         "https://example.com/small-teams",
+				Title:       "Team Size and Delivery Speed",
+				Content:     "Teams of five to seven people coordinate with fewer handoffs.",
+				Credibility: 0.8,
+				Relevance:   0.9,
+				LastUpdated: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		KeyFacts:   []string{"Communication paths grow quadratically with team size"},
+		References: []string{"Team Size and Delivery Speed, example.com"},
+		Summary:    "Smaller teams spend less time coordinating and more time delivering.",
+	}, nil)
+
+	mockQualityChecker.On("CheckContent", mock.Anything, mock.Anything, mock.Anything).Return(QualityCheckOutput{
+		ReadabilityScore: 85.0,
+		SEOScore:         80.0,
+		EngagementScore:  82.0,
+		PlagiarismScore:  0.97,
+		Keywords:         []string{"small teams", "delivery"},
+	}, nil)
+
+	pipeline := repos.newPipeline(llmClient, mockContextManager, mockResearcher, mockQualityChecker, PipelineConfig{
+		MaxRetries:          1,
+		ContextWindowSize:   4096,
+		StageTimeoutSeconds: 120,
+	})
+
+	result, err := pipeline.CreateContent(context.Background(), projectID, title, contentType)
+	require.NoError(t, err)
+	assert.Equal(t, entities.ContentStatusReview, result.Status)
+	return result
+}
+
+// newRecordingTargetClient builds the real client that fixtures are recorded from,
+// configured by the same LLM_* variables as the service
+func newRecordingTargetClient(t *testing.T) LLMClient {
+	t.Helper()
+
+	provider := os.Getenv("LLM_PROVIDER")
+	if provider == "" {
+		provider = ProviderOpenAI
+	}
+	maxTokens, err := strconv.Atoi(os.Getenv("LLM_MAX_TOKENS"))
+	if err != nil {
+		maxTokens = 2048
+	}
+
+	registry := NewLLMProviderRegistry(map[string]string{provider: os.Getenv("LLM_API_KEY")})
+	client, err := registry.Build(LLMConfig{
+		Provider:  provider,
+		Model:     os.Getenv("LLM_MODEL"),
+		BaseURL:   os.Getenv("LLM_BASE_URL"),
+		MaxTokens: maxTokens,
+		Timeout:   2 * time.Minute,
+	})
+	require.NoError(t, err)
+	return client
+}
@@ -15,6 +15,7 @@ type LLMOrchestrator struct {
 	ContextManager   ContextManager
 	TemplateManager  *PromptTemplateManager
 	MetricsCollector *MetricsCollector
+	Tokenizer        Tokenizer // counts tokens when the LLM does not report usage
 }
 
 // NewLLMOrchestrator creates a new LLM orchestrator
@@ -55,7 +56,7 @@ func (o *LLMOrchestrator) GenerateContent(
 
 	// Add the prompt to the context
 	promptEntry := ContextEntry{
-		Role:      "user",
+		Role:      RoleUser,
 		Content:   prompt,
 		Timestamp: time.Now(),
 		Priority:  5, // Medium priority
@@ -75,28 +76,29 @@ func (o *LLMOrchestrator) GenerateContent(
 		return "", fmt.Errorf("failed to get context: %w", err)
 	}
 
-	// Prepare the context for the LLM
-	var messages []string
+	// Prepare the context for the LLM, keeping the role of each entry
+	request := LLMRequest{Messages: make([]LLMMessage, 0, len(contextWindow.Entries))}
 	for _, entry := range contextWindow.Entries {
-		messages = append(messages, entry.Content)
+		request.Messages = append(request.Messages, LLMMessage{Role: entry.Role, Content: entry.Content})
 	}
 
 	// Generate the content
-	response, err := o.LLMClient.Generate(ctx, messages)
+	generated, err := o.LLMClient.Generate(ctx, request)
 	if err != nil {
 		return "", fmt.Errorf("LLM generation failed: %w", err)
 	}
+	response := generated.Content
 
 	// Add the response to the context
 	responseEntry := ContextEntry{
-		Role:      "assistant",
+		Role:      RoleAssistant,
 		Content:   response,
 		Timestamp: time.Now(),
 		Priority:  8, // High priority for model responses
 		Metadata: map[string]interface{}{
 			"stage":       stage,
 			"contentType": contentType,
-			"tokenCount":  estimateTokens(response),
+			"tokenCount":  countTokens(o.Tokenizer, response),
 		},
 	}
 	err = o.ContextManager.AddEntry(ctx, projectID, responseEntry)
@@ -105,12 +107,16 @@ func (o *LLMOrchestrator) GenerateContent(
 	}
 
 	// Collect metrics
+	promptTokens, responseTokens := generated.Usage.PromptTokens, generated.Usage.CompletionTokens
+	if promptTokens == 0 && responseTokens == 0 {
+		promptTokens, responseTokens = countTokens(o.Tokenizer, prompt), countTokens(o.Tokenizer, response)
+	}
 	o.MetricsCollector.RecordGeneration(
 		contentType,
 		stage,
 		time.Since(startTime),
-		estimateTokens(prompt),
-		estimateTokens(response),
+		promptTokens,
+		responseTokens,
 	)
 
 	return response, nil
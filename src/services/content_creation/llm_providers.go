@@ -0,0 +1,304 @@
+package content_creation
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// LLM providers supported by the default registry
+const (
+	ProviderOpenAI           = "openai"
+	ProviderAnthropic        = "anthropic"
+	ProviderOpenAICompatible = "openai-compatible"
+	ProviderVLLM             = "vllm"
+	ProviderOllama           = "ollama"
+)
+
+// Default endpoints for providers that do not require a base URL
+const (
+	DefaultAnthropicBaseURL = "https://api.anthropic.com"
+	DefaultVLLMBaseURL      = "http://localhost:8000/v1"
+	DefaultOllamaBaseURL    = "http://localhost:11434/v1"
+
+	anthropicVersion = "2023-06-01"
+)
+
+// LLMProviderFactory builds an LLMClient for a config, using the resolved API key
+type LLMProviderFactory func(config LLMConfig, apiKey string) (LLMClient, error)
+
+// LLMProviderRegistry builds LLMClient instances from LLMConfig entries by provider name
+type LLMProviderRegistry struct {
+	mu        sync.RWMutex
+	factories map[string]LLMProviderFactory
+	apiKeys   map[string]string
+}
+
+// NewLLMProviderRegistry creates a registry with the built-in providers.
+// apiKeys holds the API key per provider, used when a config does not name its own APIKeyEnv.
+func NewLLMProviderRegistry(apiKeys map[string]string) *LLMProviderRegistry {
+	r := &LLMProviderRegistry{
+		factories: make(map[string]LLMProviderFactory),
+		apiKeys:   make(map[string]string),
+	}
+	for provider, key := range apiKeys {
+		r.apiKeys[strings.ToLower(provider)] = key
+	}
+
+	r.Register(ProviderOpenAI, openAICompatibleFactory(DefaultOpenAIBaseURL))
+	r.Register(ProviderOpenAICompatible, openAICompatibleFactory(""))
+	r.Register(ProviderVLLM, openAICompatibleFactory(DefaultVLLMBaseURL))
+	r.Register(ProviderOllama, openAICompatibleFactory(DefaultOllamaBaseURL))
+	r.Register(ProviderAnthropic, anthropicFactory)
+
+	return r
+}
+
+// Register adds or replaces the factory for a provider name
+func (r *LLMProviderRegistry) Register(provider string, factory LLMProviderFactory) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.factories[strings.ToLower(provider)] = factory
+}
+
+// Providers returns the registered provider names in sorted order
+func (r *LLMProviderRegistry) Providers() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	names := make([]string, 0, len(r.factories))
+	for name := range r.factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Build creates an LLMClient for the config
+func (r *LLMProviderRegistry) Build(config LLMConfig) (LLMClient, error) {
+	provider := strings.ToLower(config.Provider)
+
+	r.mu.RLock()
+	factory, ok := r.factories[provider]
+	apiKey := r.apiKeys[provider]
+	r.mu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("unknown LLM provider %q (registered: %s)", config.Provider, strings.Join(r.Providers(), ", "))
+	}
+
+	if config.APIKeyEnv != "" {
+		apiKey = os.Getenv(config.APIKeyEnv)
+	}
+
+	return factory(config, apiKey)
+}
+
+// BuildAll creates an LLMClient for every entry in the schema's LLMConfigs, keyed by name
+func (r *LLMProviderRegistry) BuildAll(schema *PipelineConfigSchema) (map[string]LLMClient, error) {
+	clients := make(map[string]LLMClient, len(schema.LLMConfigs))
+	for name, config := range schema.LLMConfigs {
+		client, err := r.Build(config)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build LLM client %s: %w", name, err)
+		}
+		clients[name] = client
+	}
+	return clients, nil
+}
+
+// openAICompatibleFactory builds OpenAI chat completion clients, falling back to
+// defaultBaseURL when the config has none
+func openAICompatibleFactory(defaultBaseURL string) LLMProviderFactory {
+	return func(config LLMConfig, apiKey string) (LLMClient, error) {
+		baseURL := config.BaseURL
+		if baseURL == "" {
+			baseURL = defaultBaseURL
+		}
+		if baseURL == "" {
+			return nil, fmt.Errorf("baseUrl is required for provider %s", config.Provider)
+		}
+
+		client := NewOpenAICompatibleClient(baseURL, apiKey, config.Model, config.MaxTokens, config.Temperature)
+		if config.Timeout > 0 {
+			client.HTTPClient.Timeout = config.Timeout
+		}
+		return client, nil
+	}
+}
+
+func anthropicFactory(config LLMConfig, apiKey string) (LLMClient, error) {
+	if apiKey == "" {
+		return nil, fmt.Errorf("an API key is required for provider %s", config.Provider)
+	}
+
+	baseURL := config.BaseURL
+	if baseURL == "" {
+		baseURL = DefaultAnthropicBaseURL
+	}
+
+	client := NewAnthropicClient(baseURL, apiKey, config.Model, config.MaxTokens, config.Temperature)
+	if config.Timeout > 0 {
+		client.HTTPClient.Timeout = config.Timeout
+	}
+	return client, nil
+}
+
+// AnthropicClient is a client for the Anthropic Messages API
+type AnthropicClient struct {
+	APIKey      string
+	BaseURL     string
+	Model       string
+	MaxTokens   int
+	Temperature float64
+	HTTPClient  *http.Client
+}
+
+// NewAnthropicClient creates a new client for the Anthropic Messages API
+func NewAnthropicClient(baseURL, apiKey, model string, maxTokens int, temperature float64) *AnthropicClient {
+	return &AnthropicClient{
+		APIKey:      apiKey,
+		BaseURL:     strings.TrimRight(baseURL, "/"),
+		Model:       model,
+		MaxTokens:   maxTokens,
+		Temperature: temperature,
+		HTTPClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+	}
+}
+
+// anthropicJSONInstruction is added to the system prompt in JSON mode, which the
+// Messages API has no dedicated switch for
+const anthropicJSONInstruction = "Respond with a single valid JSON object and nothing else."
+
+// AnthropicRequest represents a request to the Anthropic Messages API
+type AnthropicRequest struct {
+	Model         string          `json:"model"`
+	System        string          `json:"system,omitempty"`
+	Messages      []OpenAIMessage `json:"messages"`
+	MaxTokens     int             `json:"max_tokens"`
+	Temperature   float64         `json:"temperature"`
+	StopSequences []string        `json:"stop_sequences,omitempty"`
+	Stream        bool            `json:"stream,omitempty"`
+}
+
+// AnthropicResponse represents a response from the Anthropic Messages API
+type AnthropicResponse struct {
+	ID      string `json:"id"`
+	Type    string `json:"type"`
+	Role    string `json:"role"`
+	Model   string `json:"model"`
+	Content []struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	} `json:"content"`
+	StopReason string `json:"stop_reason"`
+	Usage      struct {
+		InputTokens  int `json:"input_tokens"`
+		OutputTokens int `json:"output_tokens"`
+	} `json:"usage"`
+}
+
+// newRequest builds the Messages API request for an LLM request
+func (c *AnthropicClient) newRequest(request LLMRequest) (AnthropicRequest, error) {
+	maxTokens, temperature, err := requestOptions(request, c.MaxTokens, c.Temperature)
+	if err != nil {
+		return AnthropicRequest{}, err
+	}
+
+	// The Messages API takes a single system prompt, so system messages from the
+	// conversation are folded into it
+	system := []string{defaultSystemPrompt}
+	if request.SystemPrompt != "" {
+		system[0] = request.SystemPrompt
+	}
+	messages := make([]OpenAIMessage, 0, len(request.Messages))
+	for _, message := range request.Messages {
+		if message.Role == RoleSystem {
+			system = append(system, message.Content)
+			continue
+		}
+		messages = append(messages, OpenAIMessage{Role: message.Role, Content: message.Content})
+	}
+	if request.JSONMode {
+		system = append(system, anthropicJSONInstruction)
+	}
+
+	return AnthropicRequest{
+		Model:         c.Model,
+		System:        strings.Join(system, "\n\n"),
+		Messages:      messages,
+		MaxTokens:     maxTokens,
+		Temperature:   temperature,
+		StopSequences: request.StopSequences,
+	}, nil
+}
+
+// headers returns the authentication and version headers for the API
+func (c *AnthropicClient) headers() map[string]string {
+	return map[string]string{
+		"x-api-key":         c.APIKey,
+		"anthropic-version": anthropicVersion,
+	}
+}
+
+// Generate creates content using the Anthropic Messages API
+func (c *AnthropicClient) Generate(ctx context.Context, request LLMRequest) (*LLMResponse, error) {
+	body, err := c.newRequest(request)
+	if err != nil {
+		return nil, err
+	}
+
+	var response AnthropicResponse
+	if err := postJSON(ctx, c.HTTPClient, c.BaseURL+"/v1/messages", c.headers(), body, &response); err != nil {
+		return nil, err
+	}
+
+	// Join the text blocks of the reply
+	var text strings.Builder
+	for _, block := range response.Content {
+		if block.Type == "text" {
+			text.WriteString(block.Text)
+		}
+	}
+	if text.Len() == 0 {
+		return nil, &EmptyResponseError{}
+	}
+
+	model := response.Model
+	if model == "" {
+		model = c.Model
+	}
+
+	return &LLMResponse{
+		Content:      text.String(),
+		FinishReason: anthropicFinishReason(response.StopReason),
+		Usage: LLMUsage{
+			PromptTokens:     response.Usage.InputTokens,
+			CompletionTokens: response.Usage.OutputTokens,
+			TotalTokens:      response.Usage.InputTokens + response.Usage.OutputTokens,
+		},
+		Model: model,
+	}, nil
+}
+
+// anthropicFinishReason maps an Anthropic stop reason onto the normalized finish reasons
+func anthropicFinishReason(stopReason string) string {
+	switch stopReason {
+	case "end_turn", "stop_sequence":
+		return FinishReasonStop
+	case "max_tokens":
+		return FinishReasonLength
+	case "refusal":
+		return FinishReasonContentFilter
+	default:
+		return stopReason
+	}
+}
@@ -0,0 +1,135 @@
+package content_creation
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLLMProviderRegistry_OpenAICompatible(t *testing.T) {
+	var received OpenAIRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/chat/completions", r.URL.Path)
+		assert.Empty(t, r.Header.Get("Authorization"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+
+		w.Write([]byte(`{"model":"llama3","choices":[{"index":0,"message":{"role":"assistant","content":"local reply"},"finish_reason":"stop"}],"usage":{"prompt_tokens":12,"completion_tokens":3,"total_tokens":15}}`))
+	}))
+	defer server.Close()
+
+	registry := NewLLMProviderRegistry(nil)
+	client, err := registry.Build(LLMConfig{
+		Provider:    "ollama",
+		BaseURL:     server.URL + "/v1",
+		Model:       "llama3",
+		Temperature: 0.2,
+		MaxTokens:   256,
+	})
+	require.NoError(t, err)
+
+	temperature := 0.0
+	reply, err := client.Generate(context.Background(), LLMRequest{
+		SystemPrompt: "You are terse.",
+		Messages: []LLMMessage{
+			{Role: RoleUser, Content: "question"},
+			{Role: RoleAssistant, Content: "answer"},
+			{Role: RoleAssistant, Content: "more answer"},
+			{Role: RoleUser, Content: "follow-up"},
+		},
+		Temperature:   &temperature,
+		StopSequences: []string{"END"},
+		JSONMode:      true,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "local reply", reply.Content)
+	assert.Equal(t, FinishReasonStop, reply.FinishReason)
+	assert.Equal(t, LLMUsage{PromptTokens: 12, CompletionTokens: 3, TotalTokens: 15}, reply.Usage)
+	assert.Equal(t, "llama3", received.Model)
+	assert.Equal(t, 256, received.MaxTokens)
+	assert.Equal(t, 0.0, received.Temperature)
+	assert.Equal(t, []string{"END"}, received.Stop)
+	if assert.NotNil(t, received.ResponseFormat) {
+		assert.Equal(t, "json_object", received.ResponseFormat.Type)
+	}
+	if assert.Len(t, received.Messages, 5) {
+		assert.Equal(t, OpenAIMessage{Role: RoleSystem, Content: "You are terse."}, received.Messages[0])
+		assert.Equal(t, RoleAssistant, received.Messages[2].Role)
+		assert.Equal(t, RoleAssistant, received.Messages[3].Role)
+		assert.Equal(t, RoleUser, received.Messages[4].Role)
+	}
+}
+
+func TestLLMProviderRegistry_Anthropic(t *testing.T) {
+	var received AnthropicRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/messages", r.URL.Path)
+		assert.Equal(t, "test-key", r.Header.Get("x-api-key"))
+		assert.Equal(t, anthropicVersion, r.Header.Get("anthropic-version"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+
+		w.Write([]byte(`{"type":"message","role":"assistant","content":[{"type":"text","text":"Hello "},{"type":"text","text":"there"}],"stop_reason":"max_tokens","usage":{"input_tokens":9,"output_tokens":2}}`))
+	}))
+	defer server.Close()
+
+	t.Setenv("TEST_ANTHROPIC_KEY", "test-key")
+	registry := NewLLMProviderRegistry(nil)
+	client, err := registry.Build(LLMConfig{
+		Provider:  "anthropic",
+		BaseURL:   server.URL,
+		APIKeyEnv: "TEST_ANTHROPIC_KEY",
+		Model:     "claude-test",
+		MaxTokens: 512,
+	})
+	require.NoError(t, err)
+
+	reply, err := client.Generate(context.Background(), LLMRequest{
+		Messages: []LLMMessage{
+			{Role: RoleSystem, Content: "Project notes"},
+			{Role: RoleUser, Content: "Say hello"},
+		},
+		MaxTokens: 64,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "Hello there", reply.Content)
+	assert.Equal(t, FinishReasonLength, reply.FinishReason)
+	assert.Equal(t, 11, reply.Usage.TotalTokens)
+	assert.Equal(t, defaultSystemPrompt+"\n\nProject notes", received.System)
+	assert.Equal(t, 64, received.MaxTokens)
+	if assert.Len(t, received.Messages, 1) {
+		assert.Equal(t, RoleUser, received.Messages[0].Role)
+	}
+}
+
+func TestLLMProviderRegistry_Errors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "rate limited", http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	registry := NewLLMProviderRegistry(map[string]string{"openai": "sk-test"})
+
+	_, err := registry.Build(LLMConfig{Provider: "unknown", Model: "m"})
+	assert.ErrorContains(t, err, "unknown LLM provider")
+
+	_, err = registry.Build(LLMConfig{Provider: "openai-compatible", Model: "m"})
+	assert.ErrorContains(t, err, "baseUrl is required")
+
+	client, err := registry.Build(LLMConfig{Provider: "openai", BaseURL: server.URL, Model: "gpt-4"})
+	require.NoError(t, err)
+
+	_, err = client.Generate(context.Background(), LLMRequest{})
+	var invalid *InvalidRequestError
+	assert.ErrorAs(t, err, &invalid)
+
+	_, err = client.Generate(context.Background(), NewPromptRequest("prompt"))
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusTooManyRequests, apiErr.StatusCode)
+}
@@ -0,0 +1,470 @@
+package content_creation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Ceesaxp/autonomous-content-service/src/domain/entities"
+)
+
+const (
+	// defaultFailureThreshold is how many consecutive failures open a provider's circuit
+	defaultFailureThreshold = 5
+
+	// defaultCircuitCooldown is how long an open circuit rejects calls before trying again
+	defaultCircuitCooldown = 30 * time.Second
+
+	// defaultRetryDelay is the first retry delay when a retry policy sets none
+	defaultRetryDelay = time.Second
+
+	// rateWindow is the period that request and token budgets apply to
+	rateWindow = time.Minute
+)
+
+// CircuitOpenError is returned for calls to a provider whose circuit is open
+type CircuitOpenError struct {
+	Provider string
+	RetryAt  time.Time
+}
+
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("circuit open for LLM provider %s until %s", e.Provider, e.RetryAt.Format(time.RFC3339))
+}
+
+// LLMRetriesExhaustedError is returned by ResilientLLMClient when a call failed after the
+// retries and fallbacks of its chain, so callers should not retry it again
+type LLMRetriesExhaustedError struct {
+	Err error
+}
+
+func (e *LLMRetriesExhaustedError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *LLMRetriesExhaustedError) Unwrap() error {
+	return e.Err
+}
+
+// LLMFallback records a switch from one LLM config to the next in its fallback chain
+type LLMFallback struct {
+	From   string    `json:"from"`
+	To     string    `json:"to"`
+	Model  string    `json:"model"` // model of the config switched to
+	Stage  string    `json:"stage,omitempty"`
+	Reason string    `json:"reason"`
+	At     time.Time `json:"at"`
+}
+
+// llmFallbackLog collects the fallbacks of the calls made with a context
+type llmFallbackLog struct {
+	mu        sync.Mutex
+	fallbacks []LLMFallback
+}
+
+type llmFallbackLogKey struct{}
+
+// withLLMFallbackLog returns a context whose fallbacks are collected in the returned log
+func withLLMFallbackLog(ctx context.Context) (context.Context, *llmFallbackLog) {
+	log := &llmFallbackLog{}
+	return context.WithValue(ctx, llmFallbackLogKey{}, log), log
+}
+
+func (l *llmFallbackLog) add(fallback LLMFallback) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.fallbacks = append(l.fallbacks, fallback)
+}
+
+func (l *llmFallbackLog) entries() []LLMFallback {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]LLMFallback(nil), l.fallbacks...)
+}
+
+// recordLLMFallbacks appends the fallbacks in log to the content's "llmFallbacks" metadata
+func recordLLMFallbacks(content *entities.Content, log *llmFallbackLog) {
+	fallbacks := log.entries()
+	if len(fallbacks) == 0 {
+		return
+	}
+
+	recorded, _ := content.Metadata["llmFallbacks"].([]interface{})
+	for _, fallback := range fallbacks {
+		recorded = append(recorded, fallback)
+	}
+	content.UpdateMetadata("llmFallbacks", recorded)
+}
+
+// rateLimiter holds a provider's calls to its requests- and tokens-per-minute budgets
+type rateLimiter struct {
+	mu                sync.Mutex
+	requestsPerMinute int
+	tokensPerMinute   int
+	calls             []*rateLimitedCall // calls made in the last rateWindow, oldest first
+	pausedUntil       time.Time
+}
+
+// rateLimitedCall is a call counted against the budgets
+type rateLimitedCall struct {
+	at     time.Time
+	tokens int
+}
+
+func newRateLimiter(limits ProviderLimits) *rateLimiter {
+	return &rateLimiter{
+		requestsPerMinute: limits.RequestsPerMinute,
+		tokensPerMinute:   limits.TokensPerMinute,
+	}
+}
+
+// reserve waits until a call of the estimated size fits the budgets and counts it
+func (l *rateLimiter) reserve(ctx context.Context, tokens int) (*rateLimitedCall, error) {
+	for {
+		l.mu.Lock()
+		now := time.Now()
+		wait := l.delay(now, tokens)
+		if wait <= 0 {
+			call := &rateLimitedCall{at: now, tokens: tokens}
+			l.calls = append(l.calls, call)
+			l.mu.Unlock()
+			return call, nil
+		}
+		l.mu.Unlock()
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// delay returns how long a call of tokens must wait. The caller holds the lock.
+func (l *rateLimiter) delay(now time.Time, tokens int) time.Duration {
+	for len(l.calls) > 0 && now.Sub(l.calls[0].at) >= rateWindow {
+		l.calls = l.calls[1:]
+	}
+
+	wait := l.pausedUntil.Sub(now)
+	if l.requestsPerMinute > 0 && len(l.calls) >= l.requestsPerMinute {
+		wait = maxDuration(wait, l.calls[len(l.calls)-l.requestsPerMinute].at.Add(rateWindow).Sub(now))
+	}
+	if l.tokensPerMinute > 0 {
+		used := 0
+		for _, call := range l.calls {
+			used += call.tokens
+		}
+		// A call larger than the whole budget goes through once the window is empty
+		for _, call := range l.calls {
+			if used+tokens <= l.tokensPerMinute {
+				break
+			}
+			used -= call.tokens
+			wait = maxDuration(wait, call.at.Add(rateWindow).Sub(now))
+		}
+	}
+	return maxDuration(wait, 0)
+}
+
+// settle replaces the estimated tokens of a call with those it actually used
+func (l *rateLimiter) settle(call *rateLimitedCall, tokens int) {
+	if tokens <= 0 {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	call.tokens = tokens
+}
+
+// pause holds every call until the given time, as asked by a Retry-After header
+func (l *rateLimiter) pause(until time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if until.After(l.pausedUntil) {
+		l.pausedUntil = until
+	}
+}
+
+// circuitBreaker stops calls to a provider after repeated failures. Once the cooldown
+// has passed, calls are let through again and the next result closes or reopens it.
+type circuitBreaker struct {
+	mu        sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	failures  int
+	openUntil time.Time
+}
+
+func newCircuitBreaker(limits ProviderLimits) *circuitBreaker {
+	breaker := &circuitBreaker{threshold: limits.FailureThreshold, cooldown: limits.CircuitCooldown}
+	if breaker.threshold <= 0 {
+		breaker.threshold = defaultFailureThreshold
+	}
+	if breaker.cooldown <= 0 {
+		breaker.cooldown = defaultCircuitCooldown
+	}
+	return breaker
+}
+
+// allow reports whether calls may be made, and until when they are rejected if not
+func (b *circuitBreaker) allow(now time.Time) (bool, time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return !now.Before(b.openUntil), b.openUntil
+}
+
+func (b *circuitBreaker) success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures = 0
+}
+
+func (b *circuitBreaker) failure(now time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures++
+	if b.failures >= b.threshold {
+		b.openUntil = now.Add(b.cooldown)
+	}
+}
+
+// llmTarget is one LLM config of a fallback chain
+type llmTarget struct {
+	name    string
+	config  LLMConfig
+	client  LLMClient
+	limiter *rateLimiter
+	breaker *circuitBreaker
+}
+
+// ResilientLLMClient calls an LLM config with retries, per-provider budgets and circuit
+// breaking, and falls through the config's fallbacks when it keeps failing
+type ResilientLLMClient struct {
+	targets    []*llmTarget
+	tokenizers *TokenizerRegistry
+}
+
+// NewResilientLLMClient builds the named LLM config and its fallbacks from the schema.
+// Configs of the same provider share its budgets and circuit breaker.
+func NewResilientLLMClient(registry *LLMProviderRegistry, schema *PipelineConfigSchema, name string) (*ResilientLLMClient, error) {
+	primary, ok := schema.GetLLMConfig(name)
+	if !ok {
+		return nil, fmt.Errorf("unknown LLM config %s", name)
+	}
+
+	limiters := make(map[string]*rateLimiter)
+	breakers := make(map[string]*circuitBreaker)
+	client := &ResilientLLMClient{}
+
+	for _, targetName := range append([]string{name}, primary.Fallbacks...) {
+		config, ok := schema.GetLLMConfig(targetName)
+		if !ok {
+			return nil, fmt.Errorf("unknown fallback %s for LLM config %s", targetName, name)
+		}
+
+		built, err := registry.Build(config)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build LLM client %s: %w", targetName, err)
+		}
+
+		provider := strings.ToLower(config.Provider)
+		if _, ok := limiters[provider]; !ok {
+			limits := schema.ProviderLimits[provider]
+			limiters[provider] = newRateLimiter(limits)
+			breakers[provider] = newCircuitBreaker(limits)
+		}
+
+		client.targets = append(client.targets, &llmTarget{
+			name:    targetName,
+			config:  config,
+			client:  built,
+			limiter: limiters[provider],
+			breaker: breakers[provider],
+		})
+	}
+
+	return client, nil
+}
+
+// UseTokenizers counts the tokens requests reserve from provider budgets with each
+// config's model tokenizer
+func (c *ResilientLLMClient) UseTokenizers(tokenizers *TokenizerRegistry) {
+	c.tokenizers = tokenizers
+}
+
+// Generate calls the first config of the chain that succeeds
+func (c *ResilientLLMClient) Generate(ctx context.Context, request LLMRequest) (*LLMResponse, error) {
+	return c.generate(ctx, request, func(client LLMClient) (*LLMResponse, error) {
+		return client.Generate(ctx, request)
+	}, nil)
+}
+
+// GenerateStream streams from the first config of the chain that succeeds. Once text has
+// been delivered, a failure is returned rather than retried, so chunks are never repeated.
+func (c *ResilientLLMClient) GenerateStream(ctx context.Context, request LLMRequest, onChunk func(chunk string) error) (*LLMResponse, error) {
+	delivered := false
+	return c.generate(ctx, request, func(client LLMClient) (*LLMResponse, error) {
+		return generateStream(ctx, client, request, func(chunk string) error {
+			delivered = true
+			return onChunk(chunk)
+		})
+	}, func() bool { return delivered })
+}
+
+// generate runs call against each config of the chain until one succeeds. Failures that
+// the chain gave up on are returned as an LLMRetriesExhaustedError; cancellations, invalid
+// requests and streams cut off after delivering text are returned as they are.
+func (c *ResilientLLMClient) generate(ctx context.Context, request LLMRequest, call func(LLMClient) (*LLMResponse, error), delivered func() bool) (*LLMResponse, error) {
+	var lastErr error
+	for i, target := range c.targets {
+		if i > 0 {
+			c.recordFallback(ctx, c.targets[i-1], target, lastErr)
+		}
+
+		response, err := c.try(ctx, target, request, call, delivered)
+		if err == nil {
+			return response, nil
+		}
+		lastErr = err
+
+		var invalid *InvalidRequestError
+		if ctx.Err() != nil || errors.As(err, &invalid) || (delivered != nil && delivered()) {
+			return nil, err
+		}
+	}
+
+	if len(c.targets) > 1 {
+		lastErr = fmt.Errorf("all %d LLM configs failed: %w", len(c.targets), lastErr)
+	}
+	return nil, &LLMRetriesExhaustedError{Err: lastErr}
+}
+
+// try calls one config, retrying transient failures as its retry policy allows
+func (c *ResilientLLMClient) try(ctx context.Context, target *llmTarget, request LLMRequest, call func(LLMClient) (*LLMResponse, error), delivered func() bool) (*LLMResponse, error) {
+	policy := target.config.RetryPolicy
+
+	for attempt := 0; ; attempt++ {
+		if ok, retryAt := target.breaker.allow(time.Now()); !ok {
+			return nil, &CircuitOpenError{Provider: target.config.Provider, RetryAt: retryAt}
+		}
+
+		reservation, err := target.limiter.reserve(ctx, estimateRequestTokens(request, target.config, c.tokenizers.ForModel(target.config.Model)))
+		if err != nil {
+			return nil, err
+		}
+
+		response, err := call(target.client)
+		if err == nil {
+			target.limiter.settle(reservation, response.Usage.TotalTokens)
+			target.breaker.success()
+			return response, nil
+		}
+
+		if ctx.Err() != nil || !retryableLLMError(err) {
+			return nil, err
+		}
+		target.breaker.failure(time.Now())
+
+		if attempt >= policy.MaxRetries || (delivered != nil && delivered()) {
+			return nil, err
+		}
+
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.RetryAfter > 0 {
+			// Hold every call to the provider; the next reservation waits it out
+			target.limiter.pause(time.Now().Add(apiErr.RetryAfter))
+			if policy.MaxDelay > 0 && apiErr.RetryAfter > policy.MaxDelay {
+				// Falling back beats waiting this long
+				return nil, err
+			}
+			continue
+		}
+
+		timer := time.NewTimer(retryDelay(policy, attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// recordFallback adds a fallback to the context's log and reports it
+func (c *ResilientLLMClient) recordFallback(ctx context.Context, from, to *llmTarget, reason error) {
+	fallback := LLMFallback{
+		From:   from.name,
+		To:     to.name,
+		Model:  to.config.Model,
+		Reason: reason.Error(),
+		At:     time.Now(),
+	}
+	if info, ok := LLMCallInfoFromContext(ctx); ok {
+		fallback.Stage = info.Stage
+	}
+
+	fmt.Printf("Warning: LLM config %s failed, falling back to %s: %v\n", from.name, to.name, reason)
+	if log, ok := ctx.Value(llmFallbackLogKey{}).(*llmFallbackLog); ok {
+		log.add(fallback)
+	}
+}
+
+// retryableLLMError reports whether an error is transient: a rate limit, a server error
+// or a failure to reach the provider
+func retryableLLMError(err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode == http.StatusTooManyRequests ||
+			apiErr.StatusCode == http.StatusRequestTimeout ||
+			apiErr.StatusCode >= http.StatusInternalServerError
+	}
+
+	var invalid *InvalidRequestError
+	return !errors.As(err, &invalid)
+}
+
+// retryDelay returns the backoff before retry attempt+1 under the policy
+func retryDelay(policy RetryConfig, attempt int) time.Duration {
+	delay := policy.InitialDelay
+	if delay <= 0 {
+		delay = defaultRetryDelay
+	}
+	multiplier := policy.BackoffMultiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+
+	delay = time.Duration(float64(delay) * math.Pow(multiplier, float64(attempt)))
+	if policy.MaxDelay > 0 && delay > policy.MaxDelay {
+		delay = policy.MaxDelay
+	}
+	return delay
+}
+
+// estimateRequestTokens estimates the tokens a request counts against a budget:
+// its prompt plus the most it may generate
+func estimateRequestTokens(request LLMRequest, config LLMConfig, tokenizer Tokenizer) int {
+	tokens := tokenizer.CountTokens(request.SystemPrompt)
+	for _, message := range request.Messages {
+		tokens += tokenizer.CountTokens(message.Content)
+	}
+	if request.MaxTokens > 0 {
+		return tokens + request.MaxTokens
+	}
+	return tokens + config.MaxTokens
+}
+
+func maxDuration(a, b time.Duration) time.Duration {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,133 @@
+package content_creation
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Ceesaxp/autonomous-content-service/src/domain/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newCompletionServer serves chat completions, failing with status while fail reports true
+func newCompletionServer(t *testing.T, model string, status int, retryAfter string, fail func(call int64) bool) (*httptest.Server, *atomic.Int64) {
+	t.Helper()
+	calls := new(atomic.Int64)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		call := calls.Add(1)
+		if fail(call) {
+			if retryAfter != "" {
+				w.Header().Set("Retry-After", retryAfter)
+			}
+			http.Error(w, "unavailable", status)
+			return
+		}
+		w.Write([]byte(`{"model":"` + model + `","choices":[{"index":0,"message":{"role":"assistant","content":"reply"},"finish_reason":"stop"}]}`))
+	}))
+	t.Cleanup(server.Close)
+	return server, calls
+}
+
+func resilienceSchema(configs map[string]LLMConfig, limits map[string]ProviderLimits) *PipelineConfigSchema {
+	schema := DefaultPipelineConfigSchema()
+	schema.LLMConfigs = configs
+	schema.ProviderLimits = limits
+	return schema
+}
+
+func TestResilientLLMClient_HonoursRetryAfter(t *testing.T) {
+	server, calls := newCompletionServer(t, "local", http.StatusTooManyRequests, "1", func(call int64) bool { return call == 1 })
+
+	schema := resilienceSchema(map[string]LLMConfig{
+		"default": {Provider: ProviderVLLM, BaseURL: server.URL, Model: "local", MaxTokens: 64,
+			RetryPolicy: RetryConfig{MaxRetries: 2, InitialDelay: time.Millisecond, MaxDelay: 5 * time.Second}},
+	}, nil)
+	client, err := NewResilientLLMClient(NewLLMProviderRegistry(nil), schema, "default")
+	require.NoError(t, err)
+
+	start := time.Now()
+	response, err := client.Generate(context.Background(), NewPromptRequest("hello"))
+	require.NoError(t, err)
+	assert.Equal(t, "reply", response.Content)
+	assert.Equal(t, int64(2), calls.Load())
+	assert.GreaterOrEqual(t, time.Since(start), time.Second)
+}
+
+func TestResilientLLMClient_FallsBackAndRecordsIt(t *testing.T) {
+	primary, primaryCalls := newCompletionServer(t, "big", http.StatusServiceUnavailable, "", func(int64) bool { return true })
+	backup, _ := newCompletionServer(t, "small", http.StatusOK, "", func(int64) bool { return false })
+
+	schema := resilienceSchema(map[string]LLMConfig{
+		"default": {Provider: ProviderVLLM, BaseURL: primary.URL, Model: "big", MaxTokens: 64, Fallbacks: []string{"backup"},
+			RetryPolicy: RetryConfig{MaxRetries: 1, InitialDelay: time.Millisecond}},
+		"backup": {Provider: ProviderOllama, BaseURL: backup.URL, Model: "small", MaxTokens: 64},
+	}, nil)
+	require.NoError(t, schema.Validate())
+	client, err := NewResilientLLMClient(NewLLMProviderRegistry(nil), schema, "default")
+	require.NoError(t, err)
+
+	ctx := WithLLMCallInfo(context.Background(), LLMCallInfo{Stage: string(StageOutlining)})
+	ctx, log := withLLMFallbackLog(ctx)
+
+	response, err := client.Generate(ctx, NewPromptRequest("outline"))
+	require.NoError(t, err)
+	assert.Equal(t, "small", response.Model)
+	assert.Equal(t, int64(2), primaryCalls.Load())
+
+	content := &entities.Content{Metadata: map[string]interface{}{}}
+	recordLLMFallbacks(content, log)
+	recorded, ok := content.Metadata["llmFallbacks"].([]interface{})
+	require.True(t, ok)
+	if assert.Len(t, recorded, 1) {
+		fallback := recorded[0].(LLMFallback)
+		assert.Equal(t, "default", fallback.From)
+		assert.Equal(t, "backup", fallback.To)
+		assert.Equal(t, "small", fallback.Model)
+		assert.Equal(t, string(StageOutlining), fallback.Stage)
+		assert.Contains(t, fallback.Reason, "503")
+	}
+}
+
+func TestResilientLLMClient_OpensCircuit(t *testing.T) {
+	server, calls := newCompletionServer(t, "local", http.StatusBadGateway, "", func(int64) bool { return true })
+
+	schema := resilienceSchema(map[string]LLMConfig{
+		"default": {Provider: ProviderVLLM, BaseURL: server.URL, Model: "local", MaxTokens: 64},
+	}, map[string]ProviderLimits{
+		ProviderVLLM: {FailureThreshold: 2, CircuitCooldown: time.Minute},
+	})
+	client, err := NewResilientLLMClient(NewLLMProviderRegistry(nil), schema, "default")
+	require.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		_, err := client.Generate(context.Background(), NewPromptRequest("hello"))
+		var exhausted *LLMRetriesExhaustedError
+		assert.ErrorAs(t, err, &exhausted)
+	}
+
+	_, err = client.Generate(context.Background(), NewPromptRequest("hello"))
+	var open *CircuitOpenError
+	require.ErrorAs(t, err, &open)
+	assert.Equal(t, ProviderVLLM, open.Provider)
+	assert.Equal(t, int64(2), calls.Load())
+}
+
+func TestRateLimiter_Budgets(t *testing.T) {
+	now := time.Now()
+
+	requests := newRateLimiter(ProviderLimits{RequestsPerMinute: 2})
+	requests.calls = []*rateLimitedCall{{at: now.Add(-50 * time.Second)}, {at: now.Add(-10 * time.Second)}}
+	assert.InDelta(t, float64(10*time.Second), float64(requests.delay(now, 0)), float64(time.Millisecond))
+
+	tokens := newRateLimiter(ProviderLimits{TokensPerMinute: 1000})
+	tokens.calls = []*rateLimitedCall{{at: now.Add(-30 * time.Second), tokens: 600}}
+	assert.Equal(t, time.Duration(0), tokens.delay(now, 400))
+	assert.InDelta(t, float64(30*time.Second), float64(tokens.delay(now, 500)), float64(time.Millisecond))
+
+	// Calls older than the window no longer count
+	assert.Equal(t, time.Duration(0), tokens.delay(now.Add(31*time.Second), 1000))
+}
@@ -0,0 +1,264 @@
+package content_creation
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// StreamingLLMClient is an LLMClient that can deliver its output while it is generated
+type StreamingLLMClient interface {
+	LLMClient
+
+	// GenerateStream calls onChunk with each piece of text as it arrives and returns the
+	// complete response. Generation stops with the callback's error if onChunk fails.
+	GenerateStream(ctx context.Context, request LLMRequest, onChunk func(chunk string) error) (*LLMResponse, error)
+}
+
+// generateStream streams the response when the client supports it. Other clients
+// deliver their full response as a single chunk.
+func generateStream(ctx context.Context, client LLMClient, request LLMRequest, onChunk func(chunk string) error) (*LLMResponse, error) {
+	onActivity := func(chunk string) error {
+		touchStage(ctx)
+		return onChunk(chunk)
+	}
+
+	if streaming, ok := client.(StreamingLLMClient); ok {
+		return streaming.GenerateStream(ctx, request, onActivity)
+	}
+
+	response, err := client.Generate(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	if err := onActivity(response.Content); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// streamingHTTPClient returns a copy of client without its overall timeout, which would
+// cut off long streams; streams are bounded by their context instead
+func streamingHTTPClient(client *http.Client) *http.Client {
+	streaming := *client
+	streaming.Timeout = 0
+	return &streaming
+}
+
+// postStream sends body as JSON to url and calls onData with the data of each
+// Server-Sent Event in the response. Non-200 responses are returned as an APIError.
+func postStream(ctx context.Context, client *http.Client, url string, headers map[string]string, body interface{}, onData func(data []byte) error) error {
+	requestBody, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(requestBody))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "text/event-stream")
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := streamingHTTPClient(client).Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(resp.Body)
+		return &APIError{
+			StatusCode: resp.StatusCode,
+			Message:    string(respBody),
+			RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After")),
+		}
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, "data:") {
+			continue
+		}
+		data := strings.TrimSpace(strings.TrimPrefix(line, "data:"))
+		if data == "[DONE]" {
+			return nil
+		}
+		if err := onData([]byte(data)); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// OpenAIStreamOptions configures a streamed chat completion
+type OpenAIStreamOptions struct {
+	IncludeUsage bool `json:"include_usage"`
+}
+
+// OpenAIStreamChunk is one event of a streamed chat completion
+type OpenAIStreamChunk struct {
+	Model   string `json:"model"`
+	Choices []struct {
+		Index int `json:"index"`
+		Delta struct {
+			Content string `json:"content"`
+		} `json:"delta"`
+		FinishReason *string `json:"finish_reason"`
+	} `json:"choices"`
+	Usage *struct {
+		PromptTokens     int `json:"prompt_tokens"`
+		CompletionTokens int `json:"completion_tokens"`
+		TotalTokens      int `json:"total_tokens"`
+	} `json:"usage"`
+}
+
+// GenerateStream creates content using the OpenAI API, delivering it as it is generated
+func (c *OpenAIClient) GenerateStream(ctx context.Context, request LLMRequest, onChunk func(chunk string) error) (*LLMResponse, error) {
+	body, err := c.newRequest(request)
+	if err != nil {
+		return nil, err
+	}
+	body.Stream = true
+	body.StreamOptions = &OpenAIStreamOptions{IncludeUsage: true}
+
+	response := &LLMResponse{Model: c.Model}
+	var content strings.Builder
+
+	err = postStream(ctx, c.HTTPClient, c.BaseURL+"/chat/completions", c.headers(), body, func(data []byte) error {
+		var chunk OpenAIStreamChunk
+		if err := json.Unmarshal(data, &chunk); err != nil {
+			return fmt.Errorf("failed to decode stream chunk: %w", err)
+		}
+
+		if chunk.Model != "" {
+			response.Model = chunk.Model
+		}
+		if chunk.Usage != nil {
+			response.Usage = LLMUsage{
+				PromptTokens:     chunk.Usage.PromptTokens,
+				CompletionTokens: chunk.Usage.CompletionTokens,
+				TotalTokens:      chunk.Usage.TotalTokens,
+			}
+		}
+		for _, choice := range chunk.Choices {
+			if choice.Index != 0 {
+				continue
+			}
+			if choice.FinishReason != nil {
+				response.FinishReason = *choice.FinishReason
+			}
+			if choice.Delta.Content != "" {
+				content.WriteString(choice.Delta.Content)
+				if err := onChunk(choice.Delta.Content); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if content.Len() == 0 {
+		return nil, &EmptyResponseError{}
+	}
+	response.Content = content.String()
+	return response, nil
+}
+
+// AnthropicStreamEvent is one event of a streamed Messages API response
+type AnthropicStreamEvent struct {
+	Type    string `json:"type"`
+	Message *struct {
+		Model string `json:"model"`
+		Usage struct {
+			InputTokens  int `json:"input_tokens"`
+			OutputTokens int `json:"output_tokens"`
+		} `json:"usage"`
+	} `json:"message"`
+	Delta *struct {
+		Type       string `json:"type"`
+		Text       string `json:"text"`
+		StopReason string `json:"stop_reason"`
+	} `json:"delta"`
+	Usage *struct {
+		OutputTokens int `json:"output_tokens"`
+	} `json:"usage"`
+	Error *struct {
+		Type    string `json:"type"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// GenerateStream creates content using the Anthropic Messages API, delivering it as it is generated
+func (c *AnthropicClient) GenerateStream(ctx context.Context, request LLMRequest, onChunk func(chunk string) error) (*LLMResponse, error) {
+	body, err := c.newRequest(request)
+	if err != nil {
+		return nil, err
+	}
+	body.Stream = true
+
+	response := &LLMResponse{Model: c.Model}
+	var content strings.Builder
+
+	err = postStream(ctx, c.HTTPClient, c.BaseURL+"/v1/messages", c.headers(), body, func(data []byte) error {
+		var event AnthropicStreamEvent
+		if err := json.Unmarshal(data, &event); err != nil {
+			return fmt.Errorf("failed to decode stream event: %w", err)
+		}
+
+		switch event.Type {
+		case "message_start":
+			if event.Message != nil {
+				if event.Message.Model != "" {
+					response.Model = event.Message.Model
+				}
+				response.Usage.PromptTokens = event.Message.Usage.InputTokens
+				response.Usage.CompletionTokens = event.Message.Usage.OutputTokens
+			}
+		case "content_block_delta":
+			if event.Delta != nil && event.Delta.Type == "text_delta" && event.Delta.Text != "" {
+				content.WriteString(event.Delta.Text)
+				if err := onChunk(event.Delta.Text); err != nil {
+					return err
+				}
+			}
+		case "message_delta":
+			if event.Delta != nil && event.Delta.StopReason != "" {
+				response.FinishReason = anthropicFinishReason(event.Delta.StopReason)
+			}
+			if event.Usage != nil {
+				response.Usage.CompletionTokens = event.Usage.OutputTokens
+			}
+		case "error":
+			if event.Error != nil {
+				return fmt.Errorf("stream error (%s): %s", event.Error.Type, event.Error.Message)
+			}
+			return fmt.Errorf("stream error")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if content.Len() == 0 {
+		return nil, &EmptyResponseError{}
+	}
+	response.Content = content.String()
+	response.Usage.TotalTokens = response.Usage.PromptTokens + response.Usage.CompletionTokens
+	return response, nil
+}
@@ -0,0 +1,150 @@
+package content_creation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Ceesaxp/autonomous-content-service/src/domain/entities"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenAIClient_GenerateStream(t *testing.T) {
+	var received OpenAIRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		w.Header().Set("Content-Type", "text/event-stream")
+		for _, data := range []string{
+			`{"model":"gpt-4o","choices":[{"index":0,"delta":{"role":"assistant","content":"Hello"}}]}`,
+			`{"model":"gpt-4o","choices":[{"index":0,"delta":{"content":", world"},"finish_reason":"stop"}]}`,
+			`{"model":"gpt-4o","choices":[],"usage":{"prompt_tokens":7,"completion_tokens":3,"total_tokens":10}}`,
+			`[DONE]`,
+		} {
+			fmt.Fprintf(w, "data: %s\n\n", data)
+		}
+	}))
+	defer server.Close()
+
+	client := NewOpenAIClient("key", "gpt-4o", 256, 0.7)
+	client.BaseURL = server.URL
+
+	var chunks []string
+	response, err := client.GenerateStream(context.Background(), NewPromptRequest("greet"), func(chunk string) error {
+		chunks = append(chunks, chunk)
+		return nil
+	})
+	require.NoError(t, err)
+
+	assert.True(t, received.Stream)
+	if assert.NotNil(t, received.StreamOptions) {
+		assert.True(t, received.StreamOptions.IncludeUsage)
+	}
+	assert.Equal(t, []string{"Hello", ", world"}, chunks)
+	assert.Equal(t, "Hello, world", response.Content)
+	assert.Equal(t, FinishReasonStop, response.FinishReason)
+	assert.Equal(t, LLMUsage{PromptTokens: 7, CompletionTokens: 3, TotalTokens: 10}, response.Usage)
+}
+
+func TestAnthropicClient_GenerateStream(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var received AnthropicRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		assert.True(t, received.Stream)
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		for _, data := range []string{
+			`{"type":"message_start","message":{"model":"claude-test","usage":{"input_tokens":11,"output_tokens":1}}}`,
+			`{"type":"content_block_delta","index":0,"delta":{"type":"text_delta","text":"Long "}}`,
+			`{"type":"content_block_delta","index":0,"delta":{"type":"text_delta","text":"draft"}}`,
+			`{"type":"message_delta","delta":{"stop_reason":"max_tokens"},"usage":{"output_tokens":2}}`,
+			`{"type":"message_stop"}`,
+		} {
+			fmt.Fprintf(w, "event: message\ndata: %s\n\n", data)
+		}
+	}))
+	defer server.Close()
+
+	client := NewAnthropicClient(server.URL, "key", "claude-test", 256, 0.7)
+
+	var streamed strings.Builder
+	response, err := client.GenerateStream(context.Background(), NewPromptRequest("write"), func(chunk string) error {
+		streamed.WriteString(chunk)
+		return nil
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "Long draft", streamed.String())
+	assert.Equal(t, "Long draft", response.Content)
+	assert.Equal(t, FinishReasonLength, response.FinishReason)
+	assert.Equal(t, "claude-test", response.Model)
+	assert.Equal(t, LLMUsage{PromptTokens: 11, CompletionTokens: 2, TotalTokens: 13}, response.Usage)
+}
+
+// slowStreamingClient emits its chunks with a fixed delay between them
+type slowStreamingClient struct {
+	chunks []string
+	delay  time.Duration
+}
+
+func (c *slowStreamingClient) Generate(ctx context.Context, request LLMRequest) (*LLMResponse, error) {
+	return c.GenerateStream(ctx, request, func(string) error { return nil })
+}
+
+func (c *slowStreamingClient) GenerateStream(ctx context.Context, request LLMRequest, onChunk func(chunk string) error) (*LLMResponse, error) {
+	for _, chunk := range c.chunks {
+		select {
+		case <-time.After(c.delay):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		if err := onChunk(chunk); err != nil {
+			return nil, err
+		}
+	}
+	return &LLMResponse{Content: strings.Join(c.chunks, ""), FinishReason: FinishReasonStop}, nil
+}
+
+func TestStreamStageText_IdleTimeout(t *testing.T) {
+	repos := newTestRepositories()
+	content := &entities.Content{ContentID: uuid.New(), ProjectID: uuid.New()}
+
+	t.Run("steady output outlives the idle timeout", func(t *testing.T) {
+		client := &slowStreamingClient{chunks: []string{"one ", "two ", "three ", "four ", "five"}, delay: 40 * time.Millisecond}
+		pipeline := repos.newPipeline(client, new(MockContextManager), new(MockResearcher), new(MockQualityChecker), PipelineConfig{})
+
+		ctx, cancel := withIdleTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+
+		text, err := pipeline.streamStageText(ctx, content, StageDrafting, "draft it")
+		require.NoError(t, err)
+		assert.Equal(t, "one two three four five", text)
+		assert.False(t, stageIdle(ctx))
+
+		// Only the latest partial draft is kept for replay
+		replay, _, unsubscribe := pipeline.SubscribeProgress(content.ContentID, 0)
+		defer unsubscribe()
+		if assert.Len(t, replay, 1) {
+			assert.Equal(t, "streaming", replay[0].Status)
+			assert.Equal(t, "one ", replay[0].Partial)
+		}
+	})
+
+	t.Run("stalled output times out", func(t *testing.T) {
+		client := &slowStreamingClient{chunks: []string{"one ", "two"}, delay: 300 * time.Millisecond}
+		pipeline := repos.newPipeline(client, new(MockContextManager), new(MockResearcher), new(MockQualityChecker), PipelineConfig{})
+
+		ctx, cancel := withIdleTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+
+		_, err := pipeline.streamStageText(ctx, content, StageDrafting, "draft it")
+		assert.Error(t, err)
+		assert.True(t, stageIdle(ctx))
+	})
+}
@@ -0,0 +1,228 @@
+package content_creation
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Ceesaxp/autonomous-content-service/src/domain/entities"
+	"github.com/Ceesaxp/autonomous-content-service/src/domain/repositories"
+	"github.com/Ceesaxp/autonomous-content-service/src/services/pricing"
+	"github.com/google/uuid"
+)
+
+// llmCostCurrency is the currency of the LLM rate table
+const llmCostCurrency = "USD"
+
+// LLMCallInfo identifies the content and stage an LLM call is made for
+type LLMCallInfo struct {
+	ContentID uuid.UUID
+	ProjectID uuid.UUID
+	ClientID  uuid.UUID
+	Stage     string
+}
+
+type llmCallInfoKey struct{}
+
+// WithLLMCallInfo returns a context whose LLM calls are attributed to info
+func WithLLMCallInfo(ctx context.Context, info LLMCallInfo) context.Context {
+	return context.WithValue(ctx, llmCallInfoKey{}, info)
+}
+
+// LLMCallInfoFromContext returns the attribution set by WithLLMCallInfo
+func LLMCallInfoFromContext(ctx context.Context) (LLMCallInfo, bool) {
+	info, ok := ctx.Value(llmCallInfoKey{}).(LLMCallInfo)
+	return info, ok
+}
+
+// LLMCostCalculator prices LLM usage. Any pricing.CostCalculationEngine satisfies it.
+type LLMCostCalculator interface {
+	CalculateLLMCost(ctx context.Context, req *pricing.LLMCostRequest) (*pricing.LLMCostResponse, error)
+}
+
+// LLMUsageTracker records the tokens and cost of LLM calls and rolls them up
+// per content, project and client
+type LLMUsageTracker struct {
+	usageRepo   repositories.LLMUsageRepository
+	projectRepo repositories.ProjectRepository
+	calculator  LLMCostCalculator
+}
+
+// NewLLMUsageTracker creates a new LLM usage tracker
+func NewLLMUsageTracker(usageRepo repositories.LLMUsageRepository, projectRepo repositories.ProjectRepository, calculator LLMCostCalculator) *LLMUsageTracker {
+	return &LLMUsageTracker{
+		usageRepo:   usageRepo,
+		projectRepo: projectRepo,
+		calculator:  calculator,
+	}
+}
+
+// Record prices and stores the usage of a call. Calls to models without a rate are
+// stored with a zero cost.
+func (t *LLMUsageTracker) Record(ctx context.Context, info LLMCallInfo, model string, usage LLMUsage) (*entities.LLMUsageRecord, error) {
+	clientID := info.ClientID
+	if clientID == uuid.Nil {
+		project, err := t.projectRepo.FindByID(ctx, info.ProjectID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get project for LLM usage: %w", err)
+		}
+		clientID = project.ClientID
+	}
+
+	cost := entities.Money{Currency: llmCostCurrency}
+	priced, err := t.calculator.CalculateLLMCost(ctx, &pricing.LLMCostRequest{
+		ModelName:    model,
+		InputTokens:  usage.PromptTokens,
+		OutputTokens: usage.CompletionTokens,
+		RequestCount: 1,
+	})
+	if err != nil {
+		fmt.Printf("Warning: LLM usage for content %s recorded without cost: %v\n", info.ContentID, err)
+	} else {
+		cost.Amount = priced.TotalCost
+	}
+
+	record := entities.NewLLMUsageRecord(info.ContentID, info.ProjectID, clientID, model, info.Stage,
+		usage.PromptTokens, usage.CompletionTokens, cost)
+	if err := t.usageRepo.Create(ctx, record); err != nil {
+		return nil, fmt.Errorf("failed to save LLM usage: %w", err)
+	}
+	return record, nil
+}
+
+// ContentUsage rolls up the LLM usage of a content
+func (t *LLMUsageTracker) ContentUsage(ctx context.Context, contentID uuid.UUID) (*entities.LLMUsageSummary, error) {
+	records, err := t.usageRepo.FindByContentID(ctx, contentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get LLM usage: %w", err)
+	}
+	return entities.SummarizeLLMUsage(records, llmCostCurrency), nil
+}
+
+// ProjectUsage rolls up the LLM usage of all contents in a project
+func (t *LLMUsageTracker) ProjectUsage(ctx context.Context, projectID uuid.UUID) (*entities.LLMUsageSummary, error) {
+	records, err := t.usageRepo.FindByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get LLM usage: %w", err)
+	}
+	return entities.SummarizeLLMUsage(records, llmCostCurrency), nil
+}
+
+// ClientUsage rolls up the LLM usage of all projects of a client
+func (t *LLMUsageTracker) ClientUsage(ctx context.Context, clientID uuid.UUID) (*entities.LLMUsageSummary, error) {
+	records, err := t.usageRepo.FindByClientID(ctx, clientID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get LLM usage: %w", err)
+	}
+	return entities.SummarizeLLMUsage(records, llmCostCurrency), nil
+}
+
+// QuoteMargin compares a quoted price with the LLM cost of the quoted project
+type QuoteMargin struct {
+	QuoteID       string                    `json:"quoteId"`
+	Price         float64                   `json:"price"`
+	LLMCost       float64                   `json:"llmCost"`
+	Margin        float64                   `json:"margin"`
+	MarginPercent float64                   `json:"marginPercent"`
+	Currency      string                    `json:"currency"`
+	Usage         *entities.LLMUsageSummary `json:"usage"`
+}
+
+// QuoteMargin calculates the margin left on a quote after the LLM cost of its project
+func (t *LLMUsageTracker) QuoteMargin(ctx context.Context, quote *entities.PriceQuote) (*QuoteMargin, error) {
+	projectID, err := uuid.Parse(quote.ProjectID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid quote project ID: %w", err)
+	}
+
+	usage, err := t.ProjectUsage(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if quote.Currency != "" && quote.Currency != usage.Currency {
+		return nil, fmt.Errorf("quote currency %s does not match LLM cost currency %s", quote.Currency, usage.Currency)
+	}
+
+	margin := &QuoteMargin{
+		QuoteID:  quote.ID,
+		Price:    quote.FinalPrice,
+		LLMCost:  usage.Cost,
+		Margin:   quote.FinalPrice - usage.Cost,
+		Currency: usage.Currency,
+		Usage:    usage,
+	}
+	if quote.FinalPrice > 0 {
+		margin.MarginPercent = margin.Margin / quote.FinalPrice * 100
+	}
+	return margin, nil
+}
+
+// MeteredLLMClient wraps an LLMClient and records the usage of calls whose context
+// carries LLMCallInfo
+type MeteredLLMClient struct {
+	client     LLMClient
+	tracker    *LLMUsageTracker
+	model      string
+	tokenizers *TokenizerRegistry
+}
+
+// NewMeteredLLMClient creates a metered client. model is recorded when a response
+// does not name the model that served it.
+func NewMeteredLLMClient(client LLMClient, tracker *LLMUsageTracker, model string) *MeteredLLMClient {
+	return &MeteredLLMClient{client: client, tracker: tracker, model: model}
+}
+
+// UseTokenizers counts the tokens of calls whose provider does not report usage with
+// the tokenizer of the model that served them
+func (c *MeteredLLMClient) UseTokenizers(tokenizers *TokenizerRegistry) {
+	c.tokenizers = tokenizers
+}
+
+// Generate calls the wrapped client and records the usage of the call
+func (c *MeteredLLMClient) Generate(ctx context.Context, request LLMRequest) (*LLMResponse, error) {
+	response, err := c.client.Generate(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	c.record(ctx, request, response)
+	return response, nil
+}
+
+// GenerateStream streams from the wrapped client and records the usage of the call
+func (c *MeteredLLMClient) GenerateStream(ctx context.Context, request LLMRequest, onChunk func(chunk string) error) (*LLMResponse, error) {
+	response, err := generateStream(ctx, c.client, request, onChunk)
+	if err != nil {
+		return nil, err
+	}
+	c.record(ctx, request, response)
+	return response, nil
+}
+
+// record stores the usage of a call whose context carries LLMCallInfo
+func (c *MeteredLLMClient) record(ctx context.Context, request LLMRequest, response *LLMResponse) {
+	info, ok := LLMCallInfoFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	model := response.Model
+	if model == "" {
+		model = c.model
+	}
+
+	usage := response.Usage
+	if usage.PromptTokens == 0 && usage.CompletionTokens == 0 {
+		// The provider did not report usage, so count it with the model's tokenizer
+		tokenizer := c.tokenizers.ForModel(model)
+		usage.PromptTokens = tokenizer.CountTokens(request.SystemPrompt)
+		for _, message := range request.Messages {
+			usage.PromptTokens += tokenizer.CountTokens(message.Content)
+		}
+		usage.CompletionTokens = tokenizer.CountTokens(response.Content)
+		usage.TotalTokens = usage.PromptTokens + usage.CompletionTokens
+	}
+
+	// Accounting must not fail the generation it accounts for
+	if _, err := c.tracker.Record(ctx, info, model, usage); err != nil {
+		fmt.Printf("Warning: failed to record LLM usage: %v\n", err)
+	}
+}
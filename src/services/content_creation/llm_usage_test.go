@@ -0,0 +1,87 @@
+package content_creation
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/Ceesaxp/autonomous-content-service/src/domain/entities"
+	"github.com/Ceesaxp/autonomous-content-service/src/infrastructure/memory"
+	"github.com/Ceesaxp/autonomous-content-service/src/services/pricing"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLLMUsageTracker_RollsUpPipelineUsage(t *testing.T) {
+	repos := newTestRepositories()
+	mockLLMClient := new(MockLLMClient)
+	mockContextManager := new(MockContextManager)
+	mockResearcher := new(MockResearcher)
+	mockQualityChecker := new(MockQualityChecker)
+
+	tracker := NewLLMUsageTracker(memory.NewLLMUsageRepository(), repos.project, pricing.NewLLMCostCalculator(pricing.LLMRateTable{
+		"test-model": {InputPerMillion: 1, OutputPerMillion: 2},
+	}))
+	metered := NewMeteredLLMClient(mockLLMClient, tracker, "test-model")
+
+	projectID := uuid.New()
+	project := repos.seedProject(t, projectID, entities.ContentTypeBlogPost)
+
+	mockContextManager.On("SwitchContext", mock.Anything, projectID).Return(nil)
+	mockContextManager.On("AddEntry", mock.Anything, projectID, mock.Anything).Return(nil)
+	mockResearcher.On("Research", mock.Anything, mock.Anything, mock.Anything).Return(&ResearchOutput{
+		Summary: "Research summary",
+	}, nil)
+	mockQualityChecker.On("CheckContent", mock.Anything, mock.Anything, mock.Anything).Return(QualityCheckOutput{}, nil)
+
+	generated := strings.TrimSpace(strings.Repeat("Generated content ", 300))
+	mockLLMClient.On("Generate", mock.Anything, mock.Anything).Return(&LLMResponse{
+		Content:      generated,
+		FinishReason: FinishReasonStop,
+		Usage:        LLMUsage{PromptTokens: 1000, CompletionTokens: 500, TotalTokens: 1500},
+		Model:        "test-model-2024",
+	}, nil)
+
+	pipeline := repos.newPipeline(metered, mockContextManager, mockResearcher, mockQualityChecker, PipelineConfig{MaxRetries: 1})
+
+	ctx := context.Background()
+	content, err := pipeline.CreateContent(ctx, projectID, "Usage Test", entities.ContentTypeBlogPost)
+	require.NoError(t, err)
+
+	// Calls outside a pipeline stage are not attributed to any content
+	_, err = metered.Generate(ctx, NewPromptRequest("unattributed"))
+	require.NoError(t, err)
+
+	usage, err := tracker.ContentUsage(ctx, content.ContentID)
+	require.NoError(t, err)
+
+	// Outline, draft, edit and finalize each make one call at $0.002
+	assert.Equal(t, 4, usage.Calls)
+	assert.Equal(t, 4000, usage.PromptTokens)
+	assert.Equal(t, 2000, usage.CompletionTokens)
+	assert.InDelta(t, 0.008, usage.Cost, 1e-9)
+	assert.Equal(t, "USD", usage.Currency)
+	assert.Len(t, usage.ByStage, 4)
+	assert.Equal(t, 1, usage.ByStage[string(StageDrafting)].Calls)
+	assert.Equal(t, 4, usage.ByModel["test-model-2024"].Calls)
+
+	projectUsage, err := tracker.ProjectUsage(ctx, projectID)
+	require.NoError(t, err)
+	assert.Equal(t, usage.LLMUsageTotals, projectUsage.LLMUsageTotals)
+
+	clientUsage, err := tracker.ClientUsage(ctx, project.ClientID)
+	require.NoError(t, err)
+	assert.Equal(t, usage.LLMUsageTotals, clientUsage.LLMUsageTotals)
+
+	margin, err := tracker.QuoteMargin(ctx, &entities.PriceQuote{
+		ID:         "quote-1",
+		ProjectID:  projectID.String(),
+		FinalPrice: 100,
+		Currency:   "USD",
+	})
+	require.NoError(t, err)
+	assert.InDelta(t, 99.992, margin.Margin, 1e-9)
+	assert.InDelta(t, 99.992, margin.MarginPercent, 1e-9)
+}
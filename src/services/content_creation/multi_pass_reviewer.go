@@ -249,7 +249,7 @@ Respond in JSON format:
   "confidence": <0-1>
 }`, request.ContentType, request.TargetAudience, content)
 
-	response, err := r.llmClient.Generate(ctx, prompt)
+	response, err := generateText(ctx, r.llmClient, prompt)
 	if err != nil {
 		return nil, err
 	}
@@ -291,7 +291,7 @@ Respond in JSON format:
   "confidence": <0-1>
 }`, request.ContentType, request.TargetAudience, content)
 
-	response, err := r.llmClient.Generate(ctx, prompt)
+	response, err := generateText(ctx, r.llmClient, prompt)
 	if err != nil {
 		return nil, err
 	}
@@ -333,7 +333,7 @@ Respond in JSON format:
   "confidence": <0-1>
 }`, request.ContentType, content)
 
-	response, err := r.llmClient.Generate(ctx, prompt)
+	response, err := generateText(ctx, r.llmClient, prompt)
 	if err != nil {
 		return nil, err
 	}
@@ -375,7 +375,7 @@ Respond in JSON format:
   "confidence": <0-1>
 }`, request.ContentType, request.TargetAudience, content)
 
-	response, err := r.llmClient.Generate(ctx, prompt)
+	response, err := generateText(ctx, r.llmClient, prompt)
 	if err != nil {
 		return nil, err
 	}
@@ -417,7 +417,7 @@ Respond in JSON format:
   "confidence": <0-1>
 }`, request.ContentType, request.TargetAudience, content)
 
-	response, err := r.llmClient.Generate(ctx, prompt)
+	response, err := generateText(ctx, r.llmClient, prompt)
 	if err != nil {
 		return nil, err
 	}
@@ -459,7 +459,7 @@ Respond in JSON format:
   "confidence": <0-1>
 }`, request.ContentType, request.TargetAudience, content)
 
-	response, err := r.llmClient.Generate(ctx, prompt)
+	response, err := generateText(ctx, r.llmClient, prompt)
 	if err != nil {
 		return nil, err
 	}
@@ -494,7 +494,7 @@ Respond in JSON format:
   "confidence": <0-1>
 }`, pass, request.ContentType, request.TargetAudience, content, pass)
 
-	response, err := r.llmClient.Generate(ctx, prompt)
+	response, err := generateText(ctx, r.llmClient, prompt)
 	if err != nil {
 		return nil, err
 	}
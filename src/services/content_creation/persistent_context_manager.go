@@ -0,0 +1,546 @@
+package content_creation
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Ceesaxp/autonomous-content-service/src/domain/entities"
+	"github.com/Ceesaxp/autonomous-content-service/src/domain/repositories"
+	"github.com/google/uuid"
+)
+
+var (
+	// errContextNotFound is returned when a project has no context window yet
+	errContextNotFound = errors.New("context window not found for project")
+
+	// errCompactionNeeded stops an update that needs a compaction, which is planned and
+	// summarised outside the repository's lock
+	errCompactionNeeded = errors.New("context compaction needed")
+
+	// errContextChanged stops a compaction whose planned entries have changed meanwhile
+	errContextChanged = errors.New("context changed during compaction")
+)
+
+// maxCompactionAttempts is the number of times a summarising compaction is retried when
+// another process changes the context meanwhile, before entries are dropped unsummarised
+const maxCompactionAttempts = 3
+
+// cachedContextWindow is a context window held in the cache with the stored version it was
+// loaded at
+type cachedContextWindow struct {
+	window   *ContextWindow
+	version  int64
+	lastUsed time.Time
+}
+
+// PersistentContextManager implements ContextManager on a project context repository. Windows
+// are loaded lazily into a bounded LRU cache and evicted once idle; every access checks the
+// stored version, so changes made by other API or worker processes are picked up.
+type PersistentContextManager struct {
+	repository repositories.ProjectContextRepository
+	maxTokens  int
+	cacheSize  int
+	idleTTL    time.Duration
+	compactor  *ContextCompactor
+	tokenizer  Tokenizer
+	now        func() time.Time
+
+	mutex   sync.Mutex
+	lru     *list.List // of uuid.UUID, most recently used first
+	entries map[uuid.UUID]*list.Element
+	windows map[uuid.UUID]*cachedContextWindow
+}
+
+// NewPersistentContextManager creates a context manager backed by the given repository. A
+// cacheSize of zero or less keeps every loaded window; an idleTTL of zero never evicts idle ones.
+func NewPersistentContextManager(repository repositories.ProjectContextRepository, maxTokens, cacheSize int, idleTTL time.Duration) *PersistentContextManager {
+	return &PersistentContextManager{
+		repository: repository,
+		maxTokens:  maxTokens,
+		cacheSize:  cacheSize,
+		idleTTL:    idleTTL,
+		now:        time.Now,
+		lru:        list.New(),
+		entries:    make(map[uuid.UUID]*list.Element),
+		windows:    make(map[uuid.UUID]*cachedContextWindow),
+	}
+}
+
+// GetContext retrieves the context for a specific project
+func (cm *PersistentContextManager) GetContext(ctx context.Context, projectID uuid.UUID) (*ContextWindow, error) {
+	window, _, err := cm.window(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	return window, nil
+}
+
+// UseTokenizer counts entry tokens with the tokenizer of the conversation's model
+func (cm *PersistentContextManager) UseTokenizer(tokenizer Tokenizer) {
+	cm.tokenizer = tokenizer
+}
+
+// UseCompactor summarises evicted entries into the project memory instead of dropping them
+func (cm *PersistentContextManager) UseCompactor(compactor *ContextCompactor) {
+	cm.compactor = compactor
+}
+
+// AddEntry adds a new entry to the stored context, compacting the context when the window
+// would exceed its token budget
+func (cm *PersistentContextManager) AddEntry(ctx context.Context, projectID uuid.UUID, entry ContextEntry) error {
+	if entry.Timestamp.IsZero() {
+		entry.Timestamp = cm.now()
+	}
+	added := &entities.ProjectContextEntry{
+		EntryID:    uuid.New(),
+		ProjectID:  projectID,
+		Role:       entry.Role,
+		Content:    entry.Content,
+		Priority:   entry.Priority,
+		MustKeep:   entry.MustKeep,
+		Metadata:   entry.Metadata,
+		TokenCount: countTokens(cm.tokenizer, entry.Content),
+		CreatedAt:  entry.Timestamp,
+	}
+
+	err := cm.update(ctx, projectID, func(projectContext *entities.ProjectContext, entries []*entities.ProjectContextEntry) (entities.ProjectContextChange, error) {
+		if projectContext.TokenCount+added.TokenCount > projectContext.MaxTokens && hasEvictableEntries(entries) {
+			return entities.ProjectContextChange{}, errCompactionNeeded
+		}
+		projectContext.TokenCount += added.TokenCount
+		projectContext.LastAccessed = cm.now()
+		return entities.ProjectContextChange{Add: []*entities.ProjectContextEntry{added}}, nil
+	})
+	if !errors.Is(err, errCompactionNeeded) {
+		return err
+	}
+
+	// Summarising calls the LLM, so it is planned on a snapshot and applied only if the
+	// planned entries are unchanged, instead of holding the context locked meanwhile
+	for attempt := 1; attempt <= maxCompactionAttempts; attempt++ {
+		projectContext, entries, err := cm.repository.Load(ctx, projectID)
+		if err != nil {
+			return fmt.Errorf("failed to load context: %w", err)
+		}
+		window := contextEntriesFromEntities(entries)
+		plan := cm.planCompaction(projectContext, entries, window, added.TokenCount)
+		compaction := entities.ContextCompaction{
+			At:             cm.now(),
+			EntriesEvicted: len(plan.evict),
+			TokensBefore:   projectContext.TokenCount,
+		}
+		memory := summarizeEvictions(ctx, cm.compactor, window, plan, projectContext.MaxTokens, &compaction)
+
+		err = cm.update(ctx, projectID, func(current *entities.ProjectContext, currentEntries []*entities.ProjectContextEntry) (entities.ProjectContextChange, error) {
+			if !containsEntries(currentEntries, entries, plan) {
+				return entities.ProjectContextChange{}, errContextChanged
+			}
+			compaction.TokensBefore = current.TokenCount
+			return cm.applyCompaction(current, entries, plan, memory, compaction, added), nil
+		})
+		if !errors.Is(err, errContextChanged) {
+			return err
+		}
+	}
+
+	// The context keeps changing under us, so evict without summarising
+	return cm.update(ctx, projectID, func(projectContext *entities.ProjectContext, entries []*entities.ProjectContextEntry) (entities.ProjectContextChange, error) {
+		window := contextEntriesFromEntities(entries)
+		plan := planCompaction(window, entryTokens(entries), projectContext.TokenCount+added.TokenCount-projectContext.MaxTokens)
+		compaction := entities.ContextCompaction{
+			At:             cm.now(),
+			EntriesEvicted: len(plan.evict),
+			TokensBefore:   projectContext.TokenCount,
+			Error:          errContextChanged.Error(),
+		}
+		return cm.applyCompaction(projectContext, entries, plan, nil, compaction, added), nil
+	})
+}
+
+// planCompaction plans the compaction needed to add an entry of incoming tokens
+func (cm *PersistentContextManager) planCompaction(projectContext *entities.ProjectContext, entries []*entities.ProjectContextEntry, window []ContextEntry, incoming int) contextCompaction {
+	tokens := entryTokens(entries)
+	memoryTokens := 0
+	for i, entry := range window {
+		if IsProjectMemory(entry) {
+			memoryTokens = tokens[i]
+		}
+	}
+	return planCompaction(window, tokens, cm.compactor.tokensToFree(projectContext.TokenCount, incoming, memoryTokens, projectContext.MaxTokens))
+}
+
+// applyCompaction evicts the planned entries, replaces the project memory when there is a
+// new one, records the compaction and adds the new entry
+func (cm *PersistentContextManager) applyCompaction(projectContext *entities.ProjectContext, entries []*entities.ProjectContextEntry, plan contextCompaction, memory *ContextEntry, compaction entities.ContextCompaction, added *entities.ProjectContextEntry) entities.ProjectContextChange {
+	var change entities.ProjectContextChange
+	for _, i := range plan.evict {
+		change.Remove = append(change.Remove, entries[i].EntryID)
+	}
+	projectContext.TokenCount -= plan.freed
+
+	if memory != nil {
+		if plan.memory >= 0 {
+			change.Remove = append(change.Remove, entries[plan.memory].EntryID)
+			projectContext.TokenCount -= entries[plan.memory].TokenCount
+		}
+		memoryEntry := &entities.ProjectContextEntry{
+			EntryID:    uuid.New(),
+			ProjectID:  projectContext.ProjectID,
+			Role:       memory.Role,
+			Content:    memory.Content,
+			Priority:   memory.Priority,
+			MustKeep:   memory.MustKeep,
+			Metadata:   memory.Metadata,
+			TokenCount: countTokens(cm.tokenizer, memory.Content),
+			CreatedAt:  memory.Timestamp,
+		}
+		change.Add = append(change.Add, memoryEntry)
+		projectContext.TokenCount += memoryEntry.TokenCount
+	}
+
+	compaction.TokensAfter = projectContext.TokenCount
+	projectContext.RecordCompaction(compaction)
+
+	change.Add = append(change.Add, added)
+	projectContext.TokenCount += added.TokenCount
+	projectContext.LastAccessed = cm.now()
+	return change
+}
+
+// hasEvictableEntries reports whether a compaction could evict any of the entries
+func hasEvictableEntries(entries []*entities.ProjectContextEntry) bool {
+	for _, entry := range entries {
+		if !entry.MustKeep {
+			return true
+		}
+	}
+	return false
+}
+
+// containsEntries reports whether the planned evictions and project memory of a snapshot
+// are still in the current entries
+func containsEntries(current, snapshot []*entities.ProjectContextEntry, plan contextCompaction) bool {
+	ids := make(map[uuid.UUID]bool, len(current))
+	for _, entry := range current {
+		ids[entry.EntryID] = true
+	}
+	for _, i := range plan.evict {
+		if !ids[snapshot[i].EntryID] {
+			return false
+		}
+	}
+	if plan.memory >= 0 && !ids[snapshot[plan.memory].EntryID] {
+		return false
+	}
+	// A project memory added meanwhile would be orphaned by the planned one
+	for _, entry := range current {
+		if entry.Metadata[projectMemoryKey] == true && (plan.memory < 0 || entry.EntryID != snapshot[plan.memory].EntryID) {
+			return false
+		}
+	}
+	return true
+}
+
+// entryTokens returns the token counts of stored entries
+func entryTokens(entries []*entities.ProjectContextEntry) []int {
+	tokens := make([]int, len(entries))
+	for i, entry := range entries {
+		tokens[i] = entry.TokenCount
+	}
+	return tokens
+}
+
+// SwitchContext switches to a project context, creating it when the project has none
+func (cm *PersistentContextManager) SwitchContext(ctx context.Context, projectID uuid.UUID) error {
+	if err := cm.repository.Create(ctx, entities.NewProjectContext(projectID, cm.maxTokens)); err != nil {
+		return err
+	}
+	if err := cm.repository.Touch(ctx, projectID, cm.now()); err != nil {
+		return err
+	}
+	_, _, err := cm.window(ctx, projectID)
+	return err
+}
+
+// InjectDomainKnowledge adds domain-specific knowledge to the stored context
+func (cm *PersistentContextManager) InjectDomainKnowledge(ctx context.Context, projectID uuid.UUID, knowledge map[string]interface{}) error {
+	return cm.update(ctx, projectID, func(projectContext *entities.ProjectContext, entries []*entities.ProjectContextEntry) (entities.ProjectContextChange, error) {
+		if projectContext.DomainKnowledge == nil {
+			projectContext.DomainKnowledge = make(map[string]interface{})
+		}
+		for k, v := range knowledge {
+			projectContext.DomainKnowledge[k] = v
+		}
+		return entities.ProjectContextChange{}, nil
+	})
+}
+
+// SerializeContext converts context to a storable format
+func (cm *PersistentContextManager) SerializeContext(ctx context.Context, projectID uuid.UUID) (string, error) {
+	window, _, err := cm.window(ctx, projectID)
+	if err != nil {
+		return "", err
+	}
+
+	serialized, err := json.Marshal(window)
+	if err != nil {
+		return "", err
+	}
+	return string(serialized), nil
+}
+
+// DeserializeContext replaces a project's stored context with a serialized one
+func (cm *PersistentContextManager) DeserializeContext(ctx context.Context, serialized string) (uuid.UUID, error) {
+	var window ContextWindow
+	if err := json.Unmarshal([]byte(serialized), &window); err != nil {
+		return uuid.Nil, err
+	}
+	if window.ProjectID == uuid.Nil {
+		return uuid.Nil, errors.New("serialized context has no project ID")
+	}
+	if window.MaxTokens <= 0 {
+		window.MaxTokens = cm.maxTokens
+	}
+
+	if err := cm.repository.Create(ctx, entities.NewProjectContext(window.ProjectID, window.MaxTokens)); err != nil {
+		return uuid.Nil, err
+	}
+
+	err := cm.update(ctx, window.ProjectID, func(projectContext *entities.ProjectContext, entries []*entities.ProjectContextEntry) (entities.ProjectContextChange, error) {
+		var change entities.ProjectContextChange
+		for _, entry := range entries {
+			change.Remove = append(change.Remove, entry.EntryID)
+		}
+
+		projectContext.TokenCount = 0
+		for _, entry := range window.Entries {
+			added := &entities.ProjectContextEntry{
+				EntryID:    uuid.New(),
+				ProjectID:  window.ProjectID,
+				Role:       entry.Role,
+				Content:    entry.Content,
+				Priority:   entry.Priority,
+				MustKeep:   entry.MustKeep,
+				Metadata:   entry.Metadata,
+				TokenCount: countTokens(cm.tokenizer, entry.Content),
+				CreatedAt:  entry.Timestamp,
+			}
+			change.Add = append(change.Add, added)
+			projectContext.TokenCount += added.TokenCount
+		}
+
+		projectContext.ClientID = window.ClientID
+		projectContext.ContentType = window.ContentType
+		projectContext.MaxTokens = window.MaxTokens
+		projectContext.CompactionCount = window.CompactionCount
+		projectContext.Compactions = window.Compactions
+		projectContext.DomainKnowledge = window.DomainKnowledge
+		if projectContext.DomainKnowledge == nil {
+			projectContext.DomainKnowledge = make(map[string]interface{})
+		}
+		projectContext.LastAccessed = cm.now()
+		return change, nil
+	})
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return window.ProjectID, nil
+}
+
+// GetContextMetrics retrieves metrics about context usage
+func (cm *PersistentContextManager) GetContextMetrics(ctx context.Context, projectID uuid.UUID) (map[string]interface{}, error) {
+	window, version, err := cm.window(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	metrics := contextWindowMetrics(window, cm.tokenizer)
+	metrics["version"] = version
+	return metrics, nil
+}
+
+// window returns a copy of a project's context window, reloading it from the repository
+// when it is not cached or another process has changed it since it was cached
+func (cm *PersistentContextManager) window(ctx context.Context, projectID uuid.UUID) (*ContextWindow, int64, error) {
+	version, err := cm.repository.Version(ctx, projectID)
+	if repositories.IsNotFound(err) {
+		cm.forget(projectID)
+		return nil, 0, errContextNotFound
+	}
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to read context version: %w", err)
+	}
+
+	cm.mutex.Lock()
+	cm.evictIdle()
+	if cached, exists := cm.windows[projectID]; exists && cached.version == version {
+		defer cm.mutex.Unlock()
+		cached.lastUsed = cm.now()
+		cached.window.LastAccessed = cached.lastUsed
+		cm.use(projectID)
+		return copyContextWindow(cached.window), cached.version, nil
+	}
+	cm.mutex.Unlock()
+
+	projectContext, entries, err := cm.repository.Load(ctx, projectID)
+	if repositories.IsNotFound(err) {
+		cm.forget(projectID)
+		return nil, 0, errContextNotFound
+	}
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to load context: %w", err)
+	}
+	return cm.store(projectContext, entries)
+}
+
+// update applies an update to a stored context and caches the result
+func (cm *PersistentContextManager) update(ctx context.Context, projectID uuid.UUID, update repositories.ProjectContextUpdate) error {
+	projectContext, entries, err := cm.repository.Update(ctx, projectID, update)
+	if repositories.IsNotFound(err) {
+		cm.forget(projectID)
+		return errContextNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update context: %w", err)
+	}
+	_, _, err = cm.store(projectContext, entries)
+	return err
+}
+
+// store caches a loaded context window, unless a newer version is already cached, and
+// returns a copy of the cached window
+func (cm *PersistentContextManager) store(projectContext *entities.ProjectContext, entries []*entities.ProjectContextEntry) (*ContextWindow, int64, error) {
+	cm.mutex.Lock()
+	defer cm.mutex.Unlock()
+
+	projectID := projectContext.ProjectID
+	cached := cm.windows[projectID]
+	if cached == nil || cached.version <= projectContext.Version {
+		cached = &cachedContextWindow{
+			window:  contextWindowFromEntities(projectContext, entries),
+			version: projectContext.Version,
+		}
+		cm.windows[projectID] = cached
+	}
+	cached.lastUsed = cm.now()
+	cm.use(projectID)
+
+	for cm.cacheSize > 0 && cm.lru.Len() > cm.cacheSize {
+		cm.remove(cm.lru.Back().Value.(uuid.UUID))
+	}
+	return copyContextWindow(cached.window), cached.version, nil
+}
+
+// use marks a cached window as the most recently used; the caller holds the mutex
+func (cm *PersistentContextManager) use(projectID uuid.UUID) {
+	if element, exists := cm.entries[projectID]; exists {
+		cm.lru.MoveToFront(element)
+		return
+	}
+	cm.entries[projectID] = cm.lru.PushFront(projectID)
+}
+
+// evictIdle drops windows unused for longer than the idle TTL; the caller holds the mutex
+func (cm *PersistentContextManager) evictIdle() {
+	if cm.idleTTL <= 0 {
+		return
+	}
+	cutoff := cm.now().Add(-cm.idleTTL)
+	for element := cm.lru.Back(); element != nil; element = cm.lru.Back() {
+		projectID := element.Value.(uuid.UUID)
+		if cm.windows[projectID].lastUsed.After(cutoff) {
+			return
+		}
+		cm.remove(projectID)
+	}
+}
+
+// remove drops a window from the cache; the caller holds the mutex
+func (cm *PersistentContextManager) remove(projectID uuid.UUID) {
+	if element, exists := cm.entries[projectID]; exists {
+		cm.lru.Remove(element)
+		delete(cm.entries, projectID)
+	}
+	delete(cm.windows, projectID)
+}
+
+// forget drops a window whose stored context no longer exists
+func (cm *PersistentContextManager) forget(projectID uuid.UUID) {
+	cm.mutex.Lock()
+	defer cm.mutex.Unlock()
+	cm.remove(projectID)
+}
+
+// CachedWindows returns the number of context windows currently held in memory
+func (cm *PersistentContextManager) CachedWindows() int {
+	cm.mutex.Lock()
+	defer cm.mutex.Unlock()
+	return cm.lru.Len()
+}
+
+// contextWindowFromEntities converts a stored project context to a context window, with
+// the project memory first
+func contextWindowFromEntities(projectContext *entities.ProjectContext, entries []*entities.ProjectContextEntry) *ContextWindow {
+	window := &ContextWindow{
+		Entries:           make([]ContextEntry, 0, len(entries)),
+		ProjectID:         projectContext.ProjectID,
+		ClientID:          projectContext.ClientID,
+		ContentType:       projectContext.ContentType,
+		MaxTokens:         projectContext.MaxTokens,
+		CurrentTokenCount: projectContext.TokenCount,
+		LastAccessed:      projectContext.LastAccessed,
+		DomainKnowledge:   projectContext.DomainKnowledge,
+		CompactionCount:   projectContext.CompactionCount,
+		Compactions:       projectContext.Compactions,
+	}
+	if window.DomainKnowledge == nil {
+		window.DomainKnowledge = make(map[string]interface{})
+	}
+
+	converted := contextEntriesFromEntities(entries)
+	for _, entry := range converted {
+		if IsProjectMemory(entry) {
+			window.Entries = append(window.Entries, entry)
+		}
+	}
+	for _, entry := range converted {
+		if !IsProjectMemory(entry) {
+			window.Entries = append(window.Entries, entry)
+		}
+	}
+	return window
+}
+
+// contextEntriesFromEntities converts stored entries to context entries, in stored order
+func contextEntriesFromEntities(entries []*entities.ProjectContextEntry) []ContextEntry {
+	converted := make([]ContextEntry, len(entries))
+	for i, entry := range entries {
+		converted[i] = ContextEntry{
+			Role:      entry.Role,
+			Content:   entry.Content,
+			Timestamp: entry.CreatedAt,
+			Priority:  entry.Priority,
+			MustKeep:  entry.MustKeep,
+			Metadata:  entry.Metadata,
+		}
+	}
+	return converted
+}
+
+// copyContextWindow copies a window so callers cannot change the cached one
+func copyContextWindow(window *ContextWindow) *ContextWindow {
+	copied := *window
+	copied.Entries = make([]ContextEntry, len(window.Entries))
+	copy(copied.Entries, window.Entries)
+	copied.Compactions = append([]entities.ContextCompaction(nil), window.Compactions...)
+	copied.DomainKnowledge = make(map[string]interface{}, len(window.DomainKnowledge))
+	for k, v := range window.DomainKnowledge {
+		copied.DomainKnowledge[k] = v
+	}
+	return &copied
+}
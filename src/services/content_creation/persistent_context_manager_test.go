@@ -0,0 +1,180 @@
+package content_creation
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Ceesaxp/autonomous-content-service/src/domain/entities"
+	"github.com/Ceesaxp/autonomous-content-service/src/infrastructure/memory"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPersistentContextManager_SharesContextAcrossProcesses(t *testing.T) {
+	repo := memory.NewProjectContextRepository()
+	api := NewPersistentContextManager(repo, 1000, 10, time.Hour)
+	worker := NewPersistentContextManager(repo, 1000, 10, time.Hour)
+	ctx := context.Background()
+	projectID := uuid.New()
+
+	_, err := api.GetContext(ctx, projectID)
+	assert.ErrorIs(t, err, errContextNotFound)
+
+	require.NoError(t, api.SwitchContext(ctx, projectID))
+	require.NoError(t, api.AddEntry(ctx, projectID, ContextEntry{Role: "user", Content: "Write about team size", Priority: 1}))
+
+	// The worker loads the window lazily and sees the API's entry
+	window, err := worker.GetContext(ctx, projectID)
+	require.NoError(t, err)
+	require.Len(t, window.Entries, 1)
+
+	require.NoError(t, worker.AddEntry(ctx, projectID, ContextEntry{Role: "assistant", Content: "Draft ready", Priority: 1}))
+	require.NoError(t, worker.InjectDomainKnowledge(ctx, projectID, map[string]interface{}{"industry": "software"}))
+
+	// The API's cached window is stale and is reloaded
+	window, err = api.GetContext(ctx, projectID)
+	require.NoError(t, err)
+	require.Len(t, window.Entries, 2)
+	assert.Equal(t, "Draft ready", window.Entries[1].Content)
+	assert.Equal(t, "software", window.DomainKnowledge["industry"])
+
+	// Callers get copies, so changing one does not change the cache
+	window.Entries[0].Content = "changed"
+	window, err = api.GetContext(ctx, projectID)
+	require.NoError(t, err)
+	assert.Equal(t, "Write about team size", window.Entries[0].Content)
+
+	metrics, err := api.GetContextMetrics(ctx, projectID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, metrics["entryCount"])
+	assert.Equal(t, int64(4), metrics["version"])
+
+	// A serialized context round-trips into another project's store
+	serialized, err := api.SerializeContext(ctx, projectID)
+	require.NoError(t, err)
+	restored := NewPersistentContextManager(memory.NewProjectContextRepository(), 1000, 10, time.Hour)
+	restoredID, err := restored.DeserializeContext(ctx, serialized)
+	require.NoError(t, err)
+	assert.Equal(t, projectID, restoredID)
+	window, err = restored.GetContext(ctx, projectID)
+	require.NoError(t, err)
+	assert.Len(t, window.Entries, 2)
+	assert.Equal(t, "software", window.DomainKnowledge["industry"])
+}
+
+func TestPersistentContextManager_EvictsAndReloadsWindows(t *testing.T) {
+	repo := memory.NewProjectContextRepository()
+	manager := NewPersistentContextManager(repo, 1000, 2, time.Minute)
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	manager.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	projects := []uuid.UUID{uuid.New(), uuid.New(), uuid.New()}
+	for _, projectID := range projects {
+		require.NoError(t, manager.SwitchContext(ctx, projectID))
+		require.NoError(t, manager.AddEntry(ctx, projectID, ContextEntry{Role: "user", Content: "Brief " + projectID.String()}))
+	}
+	assert.Equal(t, 2, manager.CachedWindows())
+
+	// The least recently used window was evicted and is loaded again on access
+	window, err := manager.GetContext(ctx, projects[0])
+	require.NoError(t, err)
+	assert.Equal(t, "Brief "+projects[0].String(), window.Entries[0].Content)
+	assert.Equal(t, 2, manager.CachedWindows())
+
+	// Idle windows are dropped on the next access
+	now = now.Add(2 * time.Minute)
+	_, err = manager.GetContext(ctx, projects[1])
+	require.NoError(t, err)
+	assert.Equal(t, 1, manager.CachedWindows())
+}
+
+func TestPersistentContextManager_PrunesLowestPriorityEntries(t *testing.T) {
+	manager := NewPersistentContextManager(memory.NewProjectContextRepository(), 30, 10, 0)
+	manager.UseTokenizer(quarterTokenizer)
+	ctx := context.Background()
+	projectID := uuid.New()
+	require.NoError(t, manager.SwitchContext(ctx, projectID))
+
+	require.NoError(t, manager.AddEntry(ctx, projectID, sizedEntry("brief", 5)))
+	require.NoError(t, manager.AddEntry(ctx, projectID, sizedEntry("chatter", 1)))
+	require.NoError(t, manager.AddEntry(ctx, projectID, sizedEntry("outline", 3)))
+	require.NoError(t, manager.AddEntry(ctx, projectID, sizedEntry("draft", 3)))
+
+	window, err := manager.GetContext(ctx, projectID)
+	require.NoError(t, err)
+	require.Len(t, window.Entries, 3)
+	assert.True(t, strings.HasPrefix(window.Entries[0].Content, "brief"))
+	assert.True(t, strings.HasPrefix(window.Entries[1].Content, "outline"))
+	assert.True(t, strings.HasPrefix(window.Entries[2].Content, "draft"))
+	assert.Equal(t, 30, window.CurrentTokenCount)
+}
+
+// quarterTokenizer counts a token per four bytes, keeping entry sizes easy to reason about
+var quarterTokenizer = TokenizerFunc(func(text string) int { return len(text) / 4 })
+
+// sizedEntry creates an entry of 40 characters, which count as 10 tokens
+func sizedEntry(name string, priority int) ContextEntry {
+	return ContextEntry{Role: "user", Content: name + strings.Repeat(".", 40-len(name)), Priority: priority}
+}
+
+func TestPersistentContextManager_SummarisesEvictedEntriesIntoProjectMemory(t *testing.T) {
+	llm := new(MockLLMClient)
+	llm.On("Generate", mock.Anything, promptContaining("chatter")).Return("- Use British spelling", nil).Once()
+	llm.On("Generate", mock.Anything, promptContaining("edit")).Return("", errors.New("provider unavailable")).Once()
+
+	manager := NewPersistentContextManager(memory.NewProjectContextRepository(), 40, 10, 0)
+	manager.UseTokenizer(quarterTokenizer)
+	manager.UseCompactor(NewContextCompactor(llm))
+	ctx := context.Background()
+	projectID := uuid.New()
+	require.NoError(t, manager.SwitchContext(ctx, projectID))
+
+	brief := sizedEntry("brief", 1)
+	brief.MustKeep = true
+	for _, entry := range []ContextEntry{brief, sizedEntry("chatter", 1), sizedEntry("outline", 3), sizedEntry("draft", 3)} {
+		require.NoError(t, manager.AddEntry(ctx, projectID, entry))
+	}
+
+	// The window is full, so the entries that may go are summarised into the project memory
+	require.NoError(t, manager.AddEntry(ctx, projectID, sizedEntry("edit", 5)))
+	window, err := manager.GetContext(ctx, projectID)
+	require.NoError(t, err)
+	require.Len(t, window.Entries, 3)
+	assert.True(t, IsProjectMemory(window.Entries[0]))
+	assert.Equal(t, RoleSystem, window.Entries[0].Role)
+	assert.Contains(t, window.Entries[0].Content, "- Use British spelling")
+	assert.True(t, strings.HasPrefix(window.Entries[1].Content, "brief"))
+	assert.True(t, strings.HasPrefix(window.Entries[2].Content, "edit"))
+	assert.LessOrEqual(t, window.CurrentTokenCount, window.MaxTokens)
+
+	metrics, err := manager.GetContextMetrics(ctx, projectID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, metrics["compactionCount"])
+	assert.Equal(t, 1, metrics["mustKeepEntries"])
+	last := metrics["lastCompaction"].(entities.ContextCompaction)
+	assert.True(t, last.Summarized)
+	assert.Equal(t, 3, last.EntriesEvicted)
+
+	// When summarising fails the entries are dropped, keeping the memory and must-keep entries
+	require.NoError(t, manager.AddEntry(ctx, projectID, sizedEntry("final", 5)))
+	window, err = manager.GetContext(ctx, projectID)
+	require.NoError(t, err)
+	require.Len(t, window.Entries, 3)
+	assert.Contains(t, window.Entries[0].Content, "- Use British spelling")
+	assert.True(t, strings.HasPrefix(window.Entries[1].Content, "brief"))
+	assert.True(t, strings.HasPrefix(window.Entries[2].Content, "final"))
+
+	metrics, err = manager.GetContextMetrics(ctx, projectID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, metrics["compactionCount"])
+	last = metrics["lastCompaction"].(entities.ContextCompaction)
+	assert.False(t, last.Summarized)
+	assert.Equal(t, "provider unavailable", last.Error)
+	llm.AssertExpectations(t)
+}
@@ -2,6 +2,7 @@ package content_creation
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -24,13 +25,20 @@ const (
 
 // PipelineProgressEvent represents a progress event in the content creation pipeline
 type PipelineProgressEvent struct {
+	EventID     int64         `json:"eventId"` // sequence number within the content, assigned by the ProgressBroker
 	ContentID   uuid.UUID     `json:"contentId"`
 	ProjectID   uuid.UUID     `json:"projectId"`
 	Stage       PipelineStage `json:"stage"`
-	Status      string        `json:"status"` // started, completed, failed
+	Status      string        `json:"status"` // started, streaming, completed, skipped, timeout, error, failed
 	TimeElapsed time.Duration `json:"timeElapsed"`
 	Details     string        `json:"details,omitempty"`
 	Timestamp   time.Time     `json:"timestamp"`
+	Final       bool          `json:"final,omitempty"` // set on the last event of a pipeline run
+
+	// Streaming events carry the stage text written since the previous streaming event,
+	// starting PartialOffset characters into the text; apply as text[:PartialOffset] + Partial
+	Partial       string `json:"partial,omitempty"`
+	PartialOffset int    `json:"partialOffset,omitempty"`
 }
 
 // PipelineConfig contains configuration options for the content pipeline
@@ -40,7 +48,7 @@ type PipelineConfig struct {
 	EnableFactChecking    bool `json:"enableFactChecking"`
 	EnablePlagiarismCheck bool `json:"enablePlagiarismCheck"`
 	SEOOptimization       bool `json:"seoOptimization"`
-	StageTimeoutSeconds   int  `json:"stageTimeoutSeconds"`
+	StageTimeoutSeconds   int  `json:"stageTimeoutSeconds"` // idle timeout: a stage fails after this long without LLM output
 }
 
 // StageResult contains the result of executing a pipeline stage
@@ -58,11 +66,19 @@ type ContentPipeline struct {
 	ContentVersionRepo repositories.ContentVersionRepository
 	projectRepo        repositories.ProjectRepository
 	eventRepo          repositories.EventRepository
+	checkpointRepo     repositories.StageCheckpointRepository
+	progress           *ProgressBroker
 	llmClient          LLMClient
 	contextManager     ContextManager
 	researcher         Researcher
 	qualityChecker     QualityChecker
 	config             PipelineConfig
+	schema             *PipelineConfigSchema
+	qualityGate        *QualityGate
+	similarityIndex    *SimilarityIndex
+	promptTemplates    *PromptTemplateManager
+	stages             map[PipelineStage]*StageDefinition
+	stageOrder         []PipelineStage // registration order
 }
 
 // NewContentPipeline creates a new content creation pipeline
@@ -71,29 +87,91 @@ func NewContentPipeline(
 	contentVersionRepo repositories.ContentVersionRepository,
 	projectRepo repositories.ProjectRepository,
 	eventRepo repositories.EventRepository,
+	checkpointRepo repositories.StageCheckpointRepository,
+	progress *ProgressBroker,
 	llmClient LLMClient,
 	contextManager ContextManager,
 	researcher Researcher,
 	qualityChecker QualityChecker,
 	config PipelineConfig,
 ) *ContentPipeline {
-	return &ContentPipeline{
+	p := &ContentPipeline{
 		contentRepo:        contentRepo,
 		ContentVersionRepo: contentVersionRepo,
 		projectRepo:        projectRepo,
 		eventRepo:          eventRepo,
+		checkpointRepo:     checkpointRepo,
+		progress:           progress,
 		llmClient:          llmClient,
 		contextManager:     contextManager,
 		researcher:         researcher,
 		qualityChecker:     qualityChecker,
 		config:             config,
+		promptTemplates:    NewPromptTemplateManager(),
+		stages:             make(map[PipelineStage]*StageDefinition),
+	}
+	p.registerBuiltinStages()
+	return p
+}
+
+// pipelineStages lists the built-in stages in the order the default pipeline runs them
+var pipelineStages = []PipelineStage{
+	StageResearch,
+	StageOutlining,
+	StageDrafting,
+	StageEditing,
+	StageFinalization,
+}
+
+// ErrPipelineComplete is returned when resuming content whose stages have all completed
+var ErrPipelineComplete = errors.New("all pipeline stages have completed")
+
+// NextStage returns the built-in stage that runs after stage in the default pipeline, or an
+// empty stage when stage is the last one
+func NextStage(stage PipelineStage) PipelineStage {
+	for i, s := range pipelineStages {
+		if s == stage && i+1 < len(pipelineStages) {
+			return pipelineStages[i+1]
+		}
 	}
+	return ""
+}
+
+// StageObserver is notified as RunContent moves a piece of content through the pipeline
+type StageObserver interface {
+	// StageStarted is called before each attempt of a stage
+	StageStarted(stage PipelineStage, attempt int)
+
+	// StageFailed is called when an attempt of a stage fails or times out
+	StageFailed(stage PipelineStage, attempt int, err error)
+
+	// StageCompleted is called once the result of a stage has been stored on the content
+	StageCompleted(stage PipelineStage)
 }
 
+// nopObserver ignores all stage notifications
+type nopObserver struct{}
+
+func (nopObserver) StageStarted(PipelineStage, int)       {}
+func (nopObserver) StageFailed(PipelineStage, int, error) {}
+func (nopObserver) StageCompleted(PipelineStage)          {}
+
 // CreateContent orchestrates the full content creation process
 func (p *ContentPipeline) CreateContent(ctx context.Context, projectID uuid.UUID, title string, contentType entities.ContentType) (*entities.Content, error) {
-	startTime := time.Now()
+	content, err := p.StartContent(ctx, projectID, title, contentType)
+	if err != nil {
+		return nil, err
+	}
 
+	plan, err := p.stagePlan(contentType)
+	if err != nil {
+		return content, err
+	}
+	return p.RunContent(ctx, content.ContentID, plan[0].Stage, nil)
+}
+
+// StartContent creates and persists a new content item without running any stages
+func (p *ContentPipeline) StartContent(ctx context.Context, projectID uuid.UUID, title string, contentType entities.ContentType) (*entities.Content, error) {
 	// Create the content entity
 	content, err := entities.NewContent(projectID, title, contentType)
 	if err != nil {
@@ -106,175 +184,327 @@ func (p *ContentPipeline) CreateContent(ctx context.Context, projectID uuid.UUID
 		return nil, fmt.Errorf("failed to persist initial content: %w", err)
 	}
 
+	return content, nil
+}
+
+// ResumeContent runs stored content from the first stage without a completed checkpoint,
+// reusing the results of the stages that already succeeded
+func (p *ContentPipeline) ResumeContent(ctx context.Context, contentID uuid.UUID) (*entities.Content, error) {
+	from, err := p.FirstIncompleteStage(ctx, contentID)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.RunContent(ctx, contentID, from, nil)
+}
+
+// FirstIncompleteStage returns the first stage of the content's pipeline without a completed
+// or skipped checkpoint, or ErrPipelineComplete when every stage is done
+func (p *ContentPipeline) FirstIncompleteStage(ctx context.Context, contentID uuid.UUID) (PipelineStage, error) {
+	content, err := p.contentRepo.FindByID(ctx, contentID)
+	if err != nil {
+		return "", fmt.Errorf("failed to load content: %w", err)
+	}
+
+	plan, err := p.stagePlan(content.Type)
+	if err != nil {
+		return "", err
+	}
+
+	checkpoints, err := p.checkpointRepo.FindByContentID(ctx, contentID)
+	if err != nil {
+		return "", fmt.Errorf("failed to load stage checkpoints: %w", err)
+	}
+
+	completed := make(map[PipelineStage]bool)
+	for _, checkpoint := range checkpoints {
+		if checkpoint.IsCompleted() || checkpoint.IsSkipped() {
+			completed[PipelineStage(checkpoint.Stage)] = true
+		}
+	}
+
+	for _, planned := range plan {
+		if !completed[planned.Stage] {
+			return planned.Stage, nil
+		}
+	}
+	return "", ErrPipelineComplete
+}
+
+// RunContent runs the stored content through the pipeline, starting at the given stage.
+// Stages before from are assumed to have completed and stored their results on the content;
+// checkpoints of from and later stages are discarded and recorded again as the stages run.
+func (p *ContentPipeline) RunContent(ctx context.Context, contentID uuid.UUID, from PipelineStage, observer StageObserver) (*entities.Content, error) {
+	if observer == nil {
+		observer = nopObserver{}
+	}
+
+	content, err := p.contentRepo.FindByID(ctx, contentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load content: %w", err)
+	}
+
+	stages, err := p.stagesFrom(content.Type, from)
+	if err != nil {
+		return content, err
+	}
+
+	stale := make([]string, len(stages))
+	for i, planned := range stages {
+		stale[i] = string(planned.Stage)
+	}
+	if err := p.checkpointRepo.DeleteStages(ctx, contentID, stale...); err != nil {
+		return content, fmt.Errorf("failed to clear stage checkpoints: %w", err)
+	}
+
+	startTime := time.Now()
+
 	// Record event
-	p.recordEvent(ctx, content.ContentID, content.ProjectID, StageResearch, "started", time.Since(startTime), "Starting content creation process")
+	p.recordEvent(ctx, content.ContentID, content.ProjectID, stages[0].Stage, "started", 0, "Starting content creation process")
 
 	// Run the pipeline
-	err = p.executePipeline(ctx, content)
+	err = p.executePipeline(ctx, content, stages, observer)
 	if err != nil {
-		// Update content status to indicate error
-		content.UpdateStatus(entities.ContentStatusPlanning)
+		// Keep the status and results of completed stages so the content can be resumed
 		content.UpdateMetadata("error", err.Error())
 		p.contentRepo.Update(ctx, content)
 
 		// Record event
-		p.recordEvent(ctx, content.ContentID, content.ProjectID, StageResearch, "failed", time.Since(startTime), fmt.Sprintf("Pipeline failed: %v", err))
+		p.recordFinalEvent(ctx, content.ContentID, content.ProjectID, stages[0].Stage, "failed", time.Since(startTime), fmt.Sprintf("Pipeline failed: %v", err))
 
 		return content, fmt.Errorf("pipeline execution failed: %w", err)
 	}
 
+	// Hand the content over for review and clear the error left by an earlier failed run.
+	// Content that missed its quality thresholds goes to an editor instead.
+	details := "Content creation completed successfully"
+	if qualityGateFailed(content) {
+		content.UpdateStatus(entities.ContentStatusHumanReview)
+		details = "Content creation completed below quality thresholds; escalated for human review"
+	} else {
+		content.UpdateStatus(entities.ContentStatusReview)
+	}
+	delete(content.Metadata, "error")
+	if err := p.contentRepo.Update(ctx, content); err != nil {
+		return content, fmt.Errorf("failed to store completed content: %w", err)
+	}
+
 	// Record final event
-	p.recordEvent(ctx, content.ContentID, content.ProjectID, StageFinalization, "completed", time.Since(startTime), "Content creation completed successfully")
+	p.recordFinalEvent(ctx, content.ContentID, content.ProjectID, stages[len(stages)-1].Stage, "completed", time.Since(startTime), details)
 
 	return content, nil
 }
 
-// executePipeline runs the content through all pipeline stages
-func (p *ContentPipeline) executePipeline(ctx context.Context, content *entities.Content) error {
-	// Research stage
-	content.UpdateStatus(entities.ContentStatusResearching)
-	p.contentRepo.Update(ctx, content)
-
-	researchResult, err := p.executeStage(ctx, content, StageResearch)
+// stagesFrom returns the stages of the content type's pipeline starting at from
+func (p *ContentPipeline) stagesFrom(contentType entities.ContentType, from PipelineStage) ([]plannedStage, error) {
+	plan, err := p.stagePlan(contentType)
 	if err != nil {
-		return fmt.Errorf("research stage failed: %w", err)
+		return nil, err
 	}
 
-	// Store research data in content metadata
-	content.UpdateMetadata("research", researchResult.Metadata)
-	p.contentRepo.Update(ctx, content)
-
-	// Outlining stage
-	outlineResult, err := p.executeStage(ctx, content, StageOutlining)
-	if err != nil {
-		return fmt.Errorf("outlining stage failed: %w", err)
+	for i, planned := range plan {
+		if planned.Stage == from {
+			return plan[i:], nil
+		}
 	}
+	return nil, fmt.Errorf("unknown pipeline stage for %s: %s", contentType, from)
+}
 
-	// Store outline in content metadata and update content
-	content.UpdateMetadata("outline", outlineResult.Content)
-	p.contentRepo.Update(ctx, content)
+// executePipeline runs the content through the given pipeline stages
+func (p *ContentPipeline) executePipeline(ctx context.Context, content *entities.Content, stages []plannedStage, observer StageObserver) error {
+	for _, planned := range stages {
+		stage := planned.Stage
+		definition := p.stages[stage]
+
+		if planned.Optional && definition.Skip != nil {
+			if skip, reason := definition.Skip(ctx, content); skip {
+				if err := p.skipStage(ctx, content, stage, reason); err != nil {
+					return err
+				}
+				observer.StageCompleted(stage)
+				continue
+			}
+		}
 
-	// Drafting stage
-	content.UpdateStatus(entities.ContentStatusDrafting)
-	p.contentRepo.Update(ctx, content)
+		if definition.Status != "" {
+			content.UpdateStatus(definition.Status)
+			p.contentRepo.Update(ctx, content)
+		}
 
-	draftResult, err := p.executeStage(ctx, content, StageDrafting)
-	if err != nil {
-		return fmt.Errorf("drafting stage failed: %w", err)
+		startTime := time.Now()
+
+		result, err := p.runStage(ctx, content, stage, observer)
+		if err != nil {
+			err = fmt.Errorf("%s stage failed: %w", stage, err)
+			p.saveCheckpoint(ctx, content, stage, nil, time.Since(startTime), err)
+			return err
+		}
+
+		if err := p.applyStageResult(ctx, content, stage, result); err != nil {
+			p.saveCheckpoint(ctx, content, stage, result, time.Since(startTime), err)
+			return err
+		}
+
+		if err := p.contentRepo.Update(ctx, content); err != nil {
+			return fmt.Errorf("failed to store %s result: %w", stage, err)
+		}
+		p.indexContent(ctx, content)
+
+		// Only checkpoint the stage once its result is stored on the content
+		if err := p.saveCheckpoint(ctx, content, stage, result, time.Since(startTime), nil); err != nil {
+			return err
+		}
+		observer.StageCompleted(stage)
 	}
 
-	// Update content with draft
-	err = content.UpdateContent(draftResult.Content, string(StageDrafting))
-	if err != nil {
-		return fmt.Errorf("failed to update content with draft: %w", err)
+	return nil
+}
+
+// saveCheckpoint records the outcome of a stage; stageErr marks the stage as failed
+func (p *ContentPipeline) saveCheckpoint(ctx context.Context, content *entities.Content, stage PipelineStage, result *StageResult, elapsed time.Duration, stageErr error) error {
+	checkpoint := &entities.StageCheckpoint{
+		ContentID:   content.ContentID,
+		Stage:       string(stage),
+		Status:      entities.StageCheckpointCompleted,
+		ElapsedTime: elapsed,
+		RecordedAt:  time.Now(),
+	}
+	if result != nil {
+		checkpoint.Output = result.Content
+		checkpoint.Metadata = result.Metadata
+	}
+	if stageErr != nil {
+		checkpoint.Status = entities.StageCheckpointFailed
+		checkpoint.Error = stageErr.Error()
+	}
+
+	if err := p.checkpointRepo.Save(ctx, checkpoint); err != nil {
+		return fmt.Errorf("failed to checkpoint %s stage: %w", stage, err)
 	}
-	p.contentRepo.Update(ctx, content)
+	return nil
+}
 
-	// Editing stage
-	content.UpdateStatus(entities.ContentStatusEditing)
-	p.contentRepo.Update(ctx, content)
+// skipStage records an optional stage that does not apply to the content
+func (p *ContentPipeline) skipStage(ctx context.Context, content *entities.Content, stage PipelineStage, reason string) error {
+	p.recordEvent(ctx, content.ContentID, content.ProjectID, stage, "skipped", 0, fmt.Sprintf("Skipped %s stage: %s", stage, reason))
 
-	editResult, err := p.executeStage(ctx, content, StageEditing)
-	if err != nil {
-		return fmt.Errorf("editing stage failed: %w", err)
+	checkpoint := &entities.StageCheckpoint{
+		ContentID:  content.ContentID,
+		Stage:      string(stage),
+		Status:     entities.StageCheckpointSkipped,
+		Metadata:   map[string]interface{}{"reason": reason},
+		RecordedAt: time.Now(),
 	}
+	if err := p.checkpointRepo.Save(ctx, checkpoint); err != nil {
+		return fmt.Errorf("failed to checkpoint %s stage: %w", stage, err)
+	}
+	return nil
+}
 
-	// Update content with edited version
-	err = content.UpdateContent(editResult.Content, string(StageEditing))
-	if err != nil {
-		return fmt.Errorf("failed to update content with edited version: %w", err)
+// applyStageResult stores the output of a completed stage on the content
+func (p *ContentPipeline) applyStageResult(ctx context.Context, content *entities.Content, stage PipelineStage, result *StageResult) error {
+	definition, ok := p.stages[stage]
+	if !ok {
+		return fmt.Errorf("unknown pipeline stage: %s", stage)
 	}
-	p.contentRepo.Update(ctx, content)
 
-	// Quality check
-	qualityInput := QualityCheckInput{
-		Content:           editResult.Content,
-		CheckPlagiarism:   p.config.EnablePlagiarismCheck,
-		CheckFactAccuracy: p.config.EnableFactChecking,
-		EvaluateSEO:       p.config.SEOOptimization,
+	if ref, ok := result.Metadata[promptTemplateMetadataKey].(PromptTemplateRef); ok {
+		recordPromptTemplate(content, stage, ref)
 	}
 
-	qualityOutput, err := p.qualityChecker.CheckContent(ctx, content, qualityInput)
+	if definition.Apply == nil {
+		content.UpdateMetadata(string(stage), result.Content)
+		return nil
+	}
+	return definition.Apply(ctx, content, result)
+}
+
+// checkQuality scores the edited content, stores the statistics and suggestions on it and
+// applies the quality gate
+func (p *ContentPipeline) checkQuality(ctx context.Context, content *entities.Content, edited string) {
+	ctx = WithLLMCallInfo(ctx, LLMCallInfo{ContentID: content.ContentID, ProjectID: content.ProjectID, Stage: "quality"})
+	ctx, fallbacks := withLLMFallbackLog(ctx)
+	defer recordLLMFallbacks(content, fallbacks)
+	qualityOutput, err := p.qualityChecker.CheckContent(ctx, content, p.qualityCheckInput(edited))
 	if err != nil {
 		// Log but don't fail the pipeline
 		fmt.Printf("Warning: Quality check encountered errors: %v\n", err)
-	} else {
-		// Update content statistics
-		content.UpdateStatistics(entities.ContentStatistics{
-			ReadabilityScore: qualityOutput.ReadabilityScore,
-			SEOScore:         qualityOutput.SEOScore,
-			EngagementScore:  qualityOutput.EngagementScore,
-			PlagiarismScore:  qualityOutput.PlagiarismScore,
-		})
-
-		// Add suggestions to metadata
-		content.UpdateMetadata("qualitySuggestions", qualityOutput.SuggestionsByCategory)
-		content.UpdateMetadata("keywords", qualityOutput.Keywords)
-		p.contentRepo.Update(ctx, content)
+		return
 	}
 
-	// Finalization stage
-	finalResult, err := p.executeStage(ctx, content, StageFinalization)
-	if err != nil {
-		return fmt.Errorf("finalization stage failed: %w", err)
-	}
+	p.storeQualityScores(content, qualityOutput)
+	p.enforceQualityThresholds(ctx, content, qualityOutput)
+}
 
-	// Update content with final version
-	err = content.UpdateContent(finalResult.Content, string(StageFinalization))
-	if err != nil {
-		return fmt.Errorf("failed to update content with final version: %w", err)
+// qualityCheckInput returns the quality checks the pipeline runs on text
+func (p *ContentPipeline) qualityCheckInput(text string) QualityCheckInput {
+	return QualityCheckInput{
+		Content:           text,
+		CheckPlagiarism:   p.config.EnablePlagiarismCheck,
+		CheckFactAccuracy: p.config.EnableFactChecking,
+		EvaluateSEO:       p.config.SEOOptimization,
 	}
+}
 
-	// Update content status to review
-	content.UpdateStatus(entities.ContentStatusReview)
-	p.contentRepo.Update(ctx, content)
-
-	return nil
+// storeQualityScores stores quality scores and suggestions on the content
+func (p *ContentPipeline) storeQualityScores(content *entities.Content, qualityOutput QualityCheckOutput) {
+	// Update content statistics
+	content.UpdateStatistics(entities.ContentStatistics{
+		ReadabilityScore: qualityOutput.ReadabilityScore,
+		SEOScore:         qualityOutput.SEOScore,
+		EngagementScore:  qualityOutput.EngagementScore,
+		PlagiarismScore:  qualityOutput.PlagiarismScore,
+	})
+
+	// Add suggestions to metadata
+	content.UpdateMetadata("qualitySuggestions", qualityOutput.SuggestionsByCategory)
+	content.UpdateMetadata("keywords", qualityOutput.Keywords)
 }
 
 // executeStage executes a specific pipeline stage with retry logic
 func (p *ContentPipeline) executeStage(ctx context.Context, content *entities.Content, stage PipelineStage) (*StageResult, error) {
+	return p.runStage(ctx, content, stage, nopObserver{})
+}
+
+// runStage executes a stage with retry logic, reporting each attempt to observer
+func (p *ContentPipeline) runStage(ctx context.Context, content *entities.Content, stage PipelineStage, observer StageObserver) (*StageResult, error) {
 	var result *StageResult
 	var err error
 	var attemptCount int
 
-	stageTimeout := time.Duration(p.config.StageTimeoutSeconds) * time.Second
-	if stageTimeout == 0 {
-		stageTimeout = 60 * time.Second // default 60 second timeout
+	definition, ok := p.stages[stage]
+	if !ok {
+		return nil, fmt.Errorf("unknown pipeline stage: %s", stage)
 	}
+	maxAttempts, stageTimeout := p.stageSettings(stage)
 
 	// Record start of stage
 	startTime := time.Now()
 	p.recordEvent(ctx, content.ContentID, content.ProjectID, stage, "started", 0, fmt.Sprintf("Starting %s stage", stage))
 
+	// Attribute the stage's LLM calls to the content and note any model fallbacks on it
+	llmCtx := WithLLMCallInfo(ctx, LLMCallInfo{ContentID: content.ContentID, ProjectID: content.ProjectID, Stage: string(stage)})
+	llmCtx, fallbacks := withLLMFallbackLog(llmCtx)
+	defer recordLLMFallbacks(content, fallbacks)
+
 	// Execute stage with retries
-	for attemptCount = 1; attemptCount <= p.config.MaxRetries; attemptCount++ {
-		// Create a timeout context for this stage
-		stageCtx, cancel := context.WithTimeout(ctx, stageTimeout)
+	for attemptCount = 1; attemptCount <= maxAttempts; attemptCount++ {
+		// Create an idle timeout context for this stage
+		stageCtx, cancel := withIdleTimeout(llmCtx, stageTimeout)
 		defer cancel()
 
-		switch stage {
-		case StageResearch:
-			result, err = p.researchStage(stageCtx, content)
-		case StageOutlining:
-			result, err = p.outliningStage(stageCtx, content)
-		case StageDrafting:
-			result, err = p.draftingStage(stageCtx, content)
-		case StageEditing:
-			result, err = p.editingStage(stageCtx, content)
-		case StageFinalization:
-			result, err = p.finalizationStage(stageCtx, content)
-		default:
-			return nil, fmt.Errorf("unknown pipeline stage: %s", stage)
-		}
+		observer.StageStarted(stage, attemptCount)
 
-		// Check if context timed out
-		if stageCtx.Err() == context.DeadlineExceeded {
+		result, err = definition.Run(stageCtx, content)
+
+		// Check if the stage went idle
+		if stageIdle(stageCtx) {
 			p.recordEvent(ctx, content.ContentID, content.ProjectID, stage, "timeout", time.Since(startTime),
-				fmt.Sprintf("Stage timed out after %v. Attempt %d of %d", stageTimeout, attemptCount, p.config.MaxRetries))
+				fmt.Sprintf("Stage timed out after %v without output. Attempt %d of %d", stageTimeout, attemptCount, maxAttempts))
+			observer.StageFailed(stage, attemptCount, fmt.Errorf("stage %s timed out after %v without output", stage, stageTimeout))
 
 			// If we've exhausted retries, fail
-			if attemptCount == p.config.MaxRetries {
+			if attemptCount == maxAttempts {
 				return nil, fmt.Errorf("stage %s timed out after %d attempts", stage, attemptCount)
 			}
 
@@ -284,15 +514,23 @@ func (p *ContentPipeline) executeStage(ctx context.Context, content *entities.Co
 
 		if err != nil {
 			p.recordEvent(ctx, content.ContentID, content.ProjectID, stage, "error", time.Since(startTime),
-				fmt.Sprintf("Stage error: %v. Attempt %d of %d", err, attemptCount, p.config.MaxRetries))
+				fmt.Sprintf("Stage error: %v. Attempt %d of %d", err, attemptCount, maxAttempts))
+			observer.StageFailed(stage, attemptCount, err)
 
-			// If we've exhausted retries, fail
-			if attemptCount == p.config.MaxRetries {
+			// Fail once retries are exhausted, or when the LLM client already retried the call
+			var exhausted *LLMRetriesExhaustedError
+			if attemptCount == maxAttempts || errors.As(err, &exhausted) {
 				return nil, fmt.Errorf("stage %s failed after %d attempts: %w", stage, attemptCount, err)
 			}
 
-			// Otherwise retry
-			time.Sleep(time.Duration(attemptCount) * time.Second) // Exponential backoff
+			// Otherwise retry after a linear backoff
+			timer := time.NewTimer(time.Duration(attemptCount) * time.Second)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return nil, fmt.Errorf("stage %s stopped after %d attempts: %w", stage, attemptCount, ctx.Err())
+			case <-timer.C:
+			}
 			continue
 		}
 
@@ -307,22 +545,121 @@ func (p *ContentPipeline) executeStage(ctx context.Context, content *entities.Co
 	return result, nil
 }
 
+// SubscribeProgress returns the progress events of the content after afterID and a channel
+// of the events that follow. It returns no events if the pipeline has no progress broker.
+func (p *ContentPipeline) SubscribeProgress(contentID uuid.UUID, afterID int64) ([]PipelineProgressEvent, <-chan PipelineProgressEvent, func()) {
+	if p.progress == nil {
+		ch := make(chan PipelineProgressEvent)
+		return []PipelineProgressEvent{}, ch, func() {}
+	}
+	return p.progress.Subscribe(contentID, afterID)
+}
+
+// UseSimilarityIndex makes the pipeline add every stored version of content to the index
+func (p *ContentPipeline) UseSimilarityIndex(index *SimilarityIndex) {
+	p.similarityIndex = index
+}
+
+// UsePromptTemplates makes the stages render their prompts with the manager's templates
+func (p *ContentPipeline) UsePromptTemplates(manager *PromptTemplateManager) {
+	p.promptTemplates = manager
+}
+
+// promptTemplateMetadataKey holds the template a stage rendered its prompt from in the
+// stage result metadata
+const promptTemplateMetadataKey = "promptTemplate"
+
+// renderPrompt renders a stage prompt with the template that applies to the project's client
+func (p *ContentPipeline) renderPrompt(ctx context.Context, project *entities.Project, content *entities.Content, templateName string, data PromptData) (string, PromptTemplateRef, error) {
+	return p.promptTemplates.Render(ctx, content.Type, templateName, p.promptScope(ctx, project), data)
+}
+
+// hasPromptTemplate reports whether a stage template applies to the content, stored for
+// its client, industry or content type, or built in
+func (p *ContentPipeline) hasPromptTemplate(ctx context.Context, content *entities.Content, templateName string) bool {
+	project, err := p.projectRepo.FindByID(ctx, content.ProjectID)
+	if err != nil {
+		project = nil
+	}
+	_, _, err = p.promptTemplates.Resolve(ctx, content.Type, templateName, p.promptScope(ctx, project))
+	return err == nil
+}
+
+// promptScope returns the scope of the stored templates that apply to the project's client
+func (p *ContentPipeline) promptScope(ctx context.Context, project *entities.Project) PromptScope {
+	if project == nil {
+		return PromptScope{}
+	}
+	return p.promptTemplates.ScopeForClient(ctx, project.ClientID)
+}
+
+// recordPromptTemplate stamps the template a stage rendered its prompt from into the
+// content's "promptTemplates" metadata, by stage
+func recordPromptTemplate(content *entities.Content, stage PipelineStage, ref PromptTemplateRef) {
+	templates := make(map[string]interface{})
+	if existing, ok := content.Metadata["promptTemplates"].(map[string]interface{}); ok {
+		for name, used := range existing {
+			templates[name] = used
+		}
+	}
+	templates[string(stage)] = ref
+	content.UpdateMetadata("promptTemplates", templates)
+}
+
+// indexContent adds the stored versions of the content to the similarity index
+func (p *ContentPipeline) indexContent(ctx context.Context, content *entities.Content) {
+	if p.similarityIndex == nil {
+		return
+	}
+	if err := p.similarityIndex.IndexContent(ctx, content); err != nil {
+		fmt.Printf("Warning: Failed to index content %s: %v\n", content.ContentID, err)
+	}
+}
+
 // recordEvent creates and stores an event for pipeline progress
 func (p *ContentPipeline) recordEvent(ctx context.Context, contentID, projectID uuid.UUID, stage PipelineStage, status string, elapsed time.Duration, details string) {
+	p.publishEvent(newProgressEvent(contentID, projectID, stage, status, elapsed, details))
+}
+
+// recordFinalEvent records the event that ends a pipeline run
+func (p *ContentPipeline) recordFinalEvent(ctx context.Context, contentID, projectID uuid.UUID, stage PipelineStage, status string, elapsed time.Duration, details string) {
+	progress := newProgressEvent(contentID, projectID, stage, status, elapsed, details)
+	progress.Final = true
+	p.publishEvent(progress)
+}
+
+func newProgressEvent(contentID, projectID uuid.UUID, stage PipelineStage, status string, elapsed time.Duration, details string) PipelineProgressEvent {
+	return PipelineProgressEvent{
+		ContentID:   contentID,
+		ProjectID:   projectID,
+		Stage:       stage,
+		Status:      status,
+		TimeElapsed: elapsed,
+		Details:     details,
+		Timestamp:   time.Now(),
+	}
+}
+
+// publishEvent delivers a progress event to live subscribers and stores it in the event repository
+func (p *ContentPipeline) publishEvent(progress PipelineProgressEvent) {
+	if p.progress != nil {
+		progress = p.progress.Publish(progress)
+	}
+
 	event := &events.ContentRequestedEvent{
 		BaseEvent: events.BaseEvent{
 			EventID:   uuid.New(),
-			EventType: fmt.Sprintf("pipeline.%s.%s", stage, status),
-			Timestamp: time.Now(),
+			EventType: fmt.Sprintf("pipeline.%s.%s", progress.Stage, progress.Status),
+			Timestamp: progress.Timestamp,
 			Data: map[string]interface{}{
-				"stage":       stage,
-				"status":      status,
-				"timeElapsed": elapsed.String(),
-				"details":     details,
+				"stage":       progress.Stage,
+				"status":      progress.Status,
+				"timeElapsed": progress.TimeElapsed.String(),
+				"details":     progress.Details,
 			},
 		},
-		ContentID: contentID,
-		ProjectID: projectID,
+		ContentID: progress.ContentID,
+		ProjectID: progress.ProjectID,
 	}
 
 	// Non-blocking event recording
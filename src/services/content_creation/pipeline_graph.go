@@ -0,0 +1,285 @@
+package content_creation
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Ceesaxp/autonomous-content-service/src/domain/entities"
+)
+
+// StageFunc runs a pipeline stage for a piece of content
+type StageFunc func(ctx context.Context, content *entities.Content) (*StageResult, error)
+
+// StageDefinition describes a stage of the pipeline graph
+type StageDefinition struct {
+	Stage PipelineStage
+
+	// DependsOn lists the stages that run first. Dependencies that are not part of a
+	// content type's pipeline are ignored.
+	DependsOn []PipelineStage
+
+	// Run executes the stage
+	Run StageFunc
+
+	// Apply stores the result on the content. When nil, the result's content is stored
+	// in the content metadata under the stage name.
+	Apply func(ctx context.Context, content *entities.Content, result *StageResult) error
+
+	// Skip reports whether the stage should be skipped for the content, and why. It is
+	// only consulted where the content type lists the stage as optional.
+	Skip func(ctx context.Context, content *entities.Content) (bool, string)
+
+	// Status is the content status while the stage runs; empty leaves the status unchanged
+	Status entities.ContentStatus
+}
+
+// plannedStage is a stage of a content type's pipeline
+type plannedStage struct {
+	Stage    PipelineStage
+	Optional bool
+}
+
+// RegisterStage adds a custom stage to the pipeline graph. Content types run it once
+// the schema lists it in their required or optional stages.
+func (p *ContentPipeline) RegisterStage(definition StageDefinition) error {
+	if definition.Stage == "" {
+		return fmt.Errorf("stage name is required")
+	}
+	if definition.Run == nil {
+		return fmt.Errorf("stage %s has no run function", definition.Stage)
+	}
+	if _, exists := p.stages[definition.Stage]; exists {
+		return fmt.Errorf("stage %s is already registered", definition.Stage)
+	}
+
+	p.stages[definition.Stage] = &definition
+	p.stageOrder = append(p.stageOrder, definition.Stage)
+	return nil
+}
+
+// UseSchema makes the pipeline run the stages the schema lists for each content type,
+// with the retries and timeouts of its stage configs
+func (p *ContentPipeline) UseSchema(schema *PipelineConfigSchema) {
+	p.schema = schema
+}
+
+// StagePlan returns the stages run for a content type, in order
+func (p *ContentPipeline) StagePlan(contentType entities.ContentType) ([]PipelineStage, error) {
+	plan, err := p.stagePlan(contentType)
+	if err != nil {
+		return nil, err
+	}
+
+	stages := make([]PipelineStage, len(plan))
+	for i, planned := range plan {
+		stages[i] = planned.Stage
+	}
+	return stages, nil
+}
+
+// stagePlan selects the stages of a content type and orders them by their dependencies.
+// Without a schema, or for a content type the schema does not configure, every
+// registered stage runs.
+func (p *ContentPipeline) stagePlan(contentType entities.ContentType) ([]plannedStage, error) {
+	optional := make(map[PipelineStage]bool)
+	if typeConfig, ok := p.contentTypeConfig(contentType); ok {
+		for _, stage := range typeConfig.OptionalStages {
+			optional[stage] = true
+		}
+		for _, stage := range typeConfig.RequiredStages {
+			optional[stage] = false
+		}
+	} else {
+		for _, stage := range p.stageOrder {
+			optional[stage] = false
+		}
+	}
+
+	for stage := range optional {
+		if _, ok := p.stages[stage]; !ok {
+			return nil, fmt.Errorf("unknown pipeline stage %s for content type %s", stage, contentType)
+		}
+	}
+
+	// Order the selected stages by dependency, keeping registration order among independent stages
+	plan := make([]plannedStage, 0, len(optional))
+	done := make(map[PipelineStage]bool, len(optional))
+	for len(plan) < len(optional) {
+		progressed := false
+		for _, stage := range p.stageOrder {
+			if _, selected := optional[stage]; !selected || done[stage] {
+				continue
+			}
+
+			ready := true
+			for _, dependency := range p.stages[stage].DependsOn {
+				if _, selected := optional[dependency]; selected && !done[dependency] {
+					ready = false
+					break
+				}
+			}
+			if !ready {
+				continue
+			}
+
+			plan = append(plan, plannedStage{Stage: stage, Optional: optional[stage]})
+			done[stage] = true
+			progressed = true
+			break
+		}
+		if !progressed {
+			return nil, fmt.Errorf("pipeline stages for content type %s have a dependency cycle", contentType)
+		}
+	}
+
+	return plan, nil
+}
+
+// contentTypeConfig returns the schema's configuration for a content type
+func (p *ContentPipeline) contentTypeConfig(contentType entities.ContentType) (ContentTypeConfig, bool) {
+	if p.schema == nil {
+		return ContentTypeConfig{}, false
+	}
+	return p.schema.GetContentTypeConfig(contentType)
+}
+
+// stageSettings returns the attempts and idle timeout of a stage. A stage config in the
+// schema overrides the pipeline config.
+func (p *ContentPipeline) stageSettings(stage PipelineStage) (int, time.Duration) {
+	attempts := p.config.MaxRetries
+	timeout := time.Duration(p.config.StageTimeoutSeconds) * time.Second
+	if timeout == 0 {
+		timeout = 60 * time.Second // default 60 second timeout
+	}
+
+	if p.schema != nil {
+		if stageConfig, ok := p.schema.GetStageConfig(stage); ok {
+			if stageConfig.MaxRetries > 0 {
+				attempts = stageConfig.MaxRetries
+			}
+			if stageConfig.Timeout > 0 {
+				timeout = stageConfig.Timeout
+			}
+		}
+	}
+
+	if attempts < 1 {
+		attempts = 1
+	}
+	return attempts, timeout
+}
+
+// registerBuiltinStages adds the research, outline, draft, edit and finalize stages
+func (p *ContentPipeline) registerBuiltinStages() {
+	builtins := []StageDefinition{
+		{
+			Stage:  StageResearch,
+			Run:    p.researchStage,
+			Apply:  applyResearch,
+			Status: entities.ContentStatusResearching,
+		},
+		{
+			Stage:     StageOutlining,
+			DependsOn: []PipelineStage{StageResearch},
+			Run:       p.outliningStage,
+			Apply:     applyOutline,
+			Skip:      p.skipWithoutTemplate("outline"),
+		},
+		{
+			Stage:     StageDrafting,
+			DependsOn: []PipelineStage{StageResearch, StageOutlining},
+			Run:       p.draftingStage,
+			Apply:     applyContentVersion(StageDrafting),
+			Status:    entities.ContentStatusDrafting,
+		},
+		{
+			Stage:     StageEditing,
+			DependsOn: []PipelineStage{StageDrafting},
+			Run:       p.editingStage,
+			Apply:     p.applyEdit,
+			Status:    entities.ContentStatusEditing,
+		},
+		{
+			Stage:     StageFinalization,
+			DependsOn: []PipelineStage{StageEditing},
+			Run:       p.finalizationStage,
+			Apply:     applyFinalization,
+			Skip:      p.skipWithoutTemplate("finalize"),
+		},
+	}
+
+	for _, definition := range builtins {
+		if err := p.RegisterStage(definition); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// skipWithoutTemplate skips a stage for content that has no template for it, stored or
+// built in
+func (p *ContentPipeline) skipWithoutTemplate(templateName string) func(ctx context.Context, content *entities.Content) (bool, string) {
+	return func(ctx context.Context, content *entities.Content) (bool, string) {
+		if p.hasPromptTemplate(ctx, content, templateName) {
+			return false, ""
+		}
+		return true, fmt.Sprintf("no %s template for %s", templateName, content.Type)
+	}
+}
+
+func applyResearch(ctx context.Context, content *entities.Content, result *StageResult) error {
+	// Store research data in content metadata
+	content.UpdateMetadata("research", result.Metadata)
+	return nil
+}
+
+func applyOutline(ctx context.Context, content *entities.Content, result *StageResult) error {
+	// Store outline in content metadata
+	content.UpdateMetadata("outline", result.Content)
+	return nil
+}
+
+// applyContentVersion stores the result as a new version of the content
+func applyContentVersion(stage PipelineStage) func(ctx context.Context, content *entities.Content, result *StageResult) error {
+	return func(ctx context.Context, content *entities.Content, result *StageResult) error {
+		if err := content.UpdateContent(result.Content, string(stage)); err != nil {
+			return fmt.Errorf("failed to update content with %s result: %w", stage, err)
+		}
+		return nil
+	}
+}
+
+// applyFinalization stores the final version of the content and the sources it cites
+func applyFinalization(ctx context.Context, content *entities.Content, result *StageResult) error {
+	if err := applyContentVersion(StageFinalization)(ctx, content, result); err != nil {
+		return err
+	}
+	if citations, ok := result.Metadata["citations"]; ok {
+		content.UpdateMetadata("citations", citations)
+		content.UpdateMetadata("citationStyle", result.Metadata["citationStyle"])
+	}
+	return nil
+}
+
+// rendersCitations reports whether the content's pipeline ends with a finalization stage,
+// which turns citation markers into citations
+func (p *ContentPipeline) rendersCitations(ctx context.Context, content *entities.Content) bool {
+	plan, err := p.stagePlan(content.Type)
+	if err != nil {
+		return false
+	}
+	for _, planned := range plan {
+		if planned.Stage == StageFinalization {
+			return !planned.Optional || p.hasPromptTemplate(ctx, content, "finalize")
+		}
+	}
+	return false
+}
+
+func (p *ContentPipeline) applyEdit(ctx context.Context, content *entities.Content, result *StageResult) error {
+	if err := content.UpdateContent(result.Content, string(StageEditing)); err != nil {
+		return fmt.Errorf("failed to update content with edited version: %w", err)
+	}
+	p.checkQuality(ctx, content, result.Content)
+	return nil
+}
@@ -0,0 +1,98 @@
+package content_creation
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/Ceesaxp/autonomous-content-service/src/domain/entities"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContentPipeline_SchemaSkipsOptionalStages(t *testing.T) {
+	repos := newTestRepositories()
+	mockLLMClient := new(MockLLMClient)
+	mockContextManager := new(MockContextManager)
+	mockResearcher := new(MockResearcher)
+	mockQualityChecker := new(MockQualityChecker)
+
+	pipeline := repos.newPipeline(mockLLMClient, mockContextManager, mockResearcher, mockQualityChecker, PipelineConfig{
+		MaxRetries:          1,
+		StageTimeoutSeconds: 5,
+	})
+	pipeline.UseSchema(DefaultPipelineConfigSchema())
+
+	ctx := context.Background()
+	projectID := uuid.New()
+	repos.seedProject(t, projectID, entities.ContentTypeSocialPost)
+
+	mockContextManager.On("SwitchContext", mock.Anything, projectID).Return(nil)
+	mockContextManager.On("AddEntry", mock.Anything, projectID, mock.Anything).Return(nil)
+	mockResearcher.On("Research", mock.Anything, mock.Anything, mock.Anything).Return(&ResearchOutput{Summary: "Research"}, nil)
+	mockQualityChecker.On("CheckContent", mock.Anything, mock.Anything, mock.Anything).Return(QualityCheckOutput{}, nil)
+	mockLLMClient.On("Generate", mock.Anything, mock.Anything).Return(strings.TrimSpace(strings.Repeat("Post ", 80)), nil)
+
+	result, err := pipeline.CreateContent(ctx, projectID, "Launch Post", entities.ContentTypeSocialPost)
+	require.NoError(t, err)
+	assert.Equal(t, entities.ContentStatusReview, result.Status)
+	assert.NotContains(t, result.Metadata, "outline")
+
+	// Social posts have no outline or finalize template, so only drafting and editing call the LLM
+	mockLLMClient.AssertNumberOfCalls(t, "Generate", 2)
+
+	checkpoints, err := repos.checkpoint.FindByContentID(ctx, result.ContentID)
+	require.NoError(t, err)
+	statuses := make(map[string]entities.StageCheckpointStatus)
+	for _, checkpoint := range checkpoints {
+		statuses[checkpoint.Stage] = checkpoint.Status
+	}
+	assert.Equal(t, map[string]entities.StageCheckpointStatus{
+		string(StageResearch):     entities.StageCheckpointCompleted,
+		string(StageOutlining):    entities.StageCheckpointSkipped,
+		string(StageDrafting):     entities.StageCheckpointCompleted,
+		string(StageEditing):      entities.StageCheckpointCompleted,
+		string(StageFinalization): entities.StageCheckpointSkipped,
+	}, statuses)
+
+	_, err = pipeline.ResumeContent(ctx, result.ContentID)
+	assert.ErrorIs(t, err, ErrPipelineComplete)
+}
+
+func TestContentPipeline_RegisterStage(t *testing.T) {
+	repos := newTestRepositories()
+	pipeline := repos.newPipeline(new(MockLLMClient), new(MockContextManager), new(MockResearcher), new(MockQualityChecker), PipelineConfig{MaxRetries: 1})
+
+	factCheck := func(ctx context.Context, content *entities.Content) (*StageResult, error) {
+		return &StageResult{Content: "checked"}, nil
+	}
+	require.NoError(t, pipeline.RegisterStage(StageDefinition{
+		Stage:     "factCheck",
+		DependsOn: []PipelineStage{StageEditing},
+		Run:       factCheck,
+	}))
+	assert.Error(t, pipeline.RegisterStage(StageDefinition{Stage: StageDrafting, Run: factCheck}))
+
+	schema := DefaultPipelineConfigSchema()
+	blogConfig := schema.ContentTypeConfigs[entities.ContentTypeBlogPost]
+	blogConfig.RequiredStages = []PipelineStage{StageFinalization, "factCheck", StageEditing, StageDrafting, StageResearch}
+	blogConfig.OptionalStages = nil
+	schema.ContentTypeConfigs[entities.ContentTypeBlogPost] = blogConfig
+	schema.StageConfigs["factCheck"] = StageConfig{MaxRetries: 4}
+	pipeline.UseSchema(schema)
+
+	// The custom stage runs after editing, in registration order with finalization
+	plan, err := pipeline.StagePlan(entities.ContentTypeBlogPost)
+	require.NoError(t, err)
+	assert.Equal(t, []PipelineStage{StageResearch, StageDrafting, StageEditing, StageFinalization, "factCheck"}, plan)
+
+	attempts, _ := pipeline.stageSettings("factCheck")
+	assert.Equal(t, 4, attempts)
+
+	blogConfig.RequiredStages = append(blogConfig.RequiredStages, "translation")
+	schema.ContentTypeConfigs[entities.ContentTypeBlogPost] = blogConfig
+	_, err = pipeline.StagePlan(entities.ContentTypeBlogPost)
+	assert.ErrorContains(t, err, "unknown pipeline stage translation")
+}
@@ -75,6 +75,7 @@ func (p *ContentPipeline) outliningStage(ctx context.Context, content *entities.
 	// Get project context for more personalized outlining
 	project, err := p.projectRepo.FindByID(ctx, content.ProjectID)
 	if err == nil {
+		promptData.ClientName = getClientNameFromProject(project)
 		promptData.ProjectTitle = project.Title
 		promptData.TargetAudience = getTargetAudienceFromProject(project)
 		promptData.BrandVoice = getBrandVoiceFromProject(project)
@@ -88,14 +89,13 @@ func (p *ContentPipeline) outliningStage(ctx context.Context, content *entities.
 	}
 
 	// Generate outline using template
-	templateManager := NewPromptTemplateManager()
-	prompt, err := templateManager.GeneratePrompt(content.Type, "outline", promptData)
+	prompt, promptTemplate, err := p.renderPrompt(ctx, project, content, "outline", promptData)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate outline prompt: %w", err)
 	}
 
 	// Generate outline using LLM
-	outline, err := p.llmClient.Generate(ctx, prompt)
+	outline, err := generateText(ctx, p.llmClient, prompt)
 	if err != nil {
 		return nil, fmt.Errorf("outline generation failed: %w", err)
 	}
@@ -119,7 +119,7 @@ func (p *ContentPipeline) outliningStage(ctx context.Context, content *entities.
 	return &StageResult{
 		Content:     outline,
 		Status:      "completed",
-		Metadata:    map[string]interface{}{"stage": "outline"},
+		Metadata:    map[string]interface{}{"stage": "outline", promptTemplateMetadataKey: promptTemplate},
 		ElapsedTime: time.Since(startTime),
 	}, nil
 }
@@ -128,10 +128,10 @@ func (p *ContentPipeline) outliningStage(ctx context.Context, content *entities.
 func (p *ContentPipeline) draftingStage(ctx context.Context, content *entities.Content) (*StageResult, error) {
 	startTime := time.Now()
 
-	// Get outline from content metadata
+	// Get outline from content metadata; content types that skip outlining draft from the research alone
 	outline, exists := content.Metadata["outline"]
 	if !exists {
-		return nil, fmt.Errorf("outline not found in content metadata")
+		outline = ""
 	}
 
 	// Get research data
@@ -150,6 +150,7 @@ func (p *ContentPipeline) draftingStage(ctx context.Context, content *entities.C
 	// Get project context
 	project, err := p.projectRepo.FindByID(ctx, content.ProjectID)
 	if err == nil {
+		promptData.ClientName = getClientNameFromProject(project)
 		promptData.ProjectTitle = project.Title
 		promptData.TargetAudience = getTargetAudienceFromProject(project)
 		promptData.BrandVoice = getBrandVoiceFromProject(project)
@@ -158,14 +159,18 @@ func (p *ContentPipeline) draftingStage(ctx context.Context, content *entities.C
 	}
 
 	// Generate draft using template
-	templateManager := NewPromptTemplateManager()
-	prompt, err := templateManager.GeneratePrompt(content.Type, "draft", promptData)
+	prompt, promptTemplate, err := p.renderPrompt(ctx, project, content, "draft", promptData)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate draft prompt: %w", err)
 	}
 
+	// Cite research sources when finalization will turn the markers into citations
+	if sources := researchSources(content); len(sources) > 0 && p.rendersCitations(ctx, content) {
+		prompt += citationInstructions(sources)
+	}
+
 	// Generate draft using LLM
-	draft, err := p.llmClient.Generate(ctx, prompt)
+	draft, err := p.streamStageText(ctx, content, StageDrafting, prompt)
 	if err != nil {
 		return nil, fmt.Errorf("draft generation failed: %w", err)
 	}
@@ -190,7 +195,7 @@ func (p *ContentPipeline) draftingStage(ctx context.Context, content *entities.C
 	return &StageResult{
 		Content:     draft,
 		Status:      "completed",
-		Metadata:    map[string]interface{}{"stage": "draft", "wordCount": estimateWords(draft)},
+		Metadata:    map[string]interface{}{"stage": "draft", "wordCount": estimateWords(draft), promptTemplateMetadataKey: promptTemplate},
 		ElapsedTime: time.Since(startTime),
 	}, nil
 }
@@ -223,14 +228,16 @@ func (p *ContentPipeline) editingStage(ctx context.Context, content *entities.Co
 	}
 
 	// Generate editing prompt using template
-	templateManager := NewPromptTemplateManager()
-	prompt, err := templateManager.GeneratePrompt(content.Type, "edit", promptData)
+	prompt, promptTemplate, err := p.renderPrompt(ctx, project, content, "edit", promptData)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate edit prompt: %w", err)
 	}
+	if citationMarkerPattern.MatchString(content.Data) {
+		prompt += keepCitationsInstruction
+	}
 
 	// Generate edited content using LLM
-	editedContent, err := p.llmClient.Generate(ctx, prompt)
+	editedContent, err := p.streamStageText(ctx, content, StageEditing, prompt)
 	if err != nil {
 		return nil, fmt.Errorf("editing failed: %w", err)
 	}
@@ -255,7 +262,7 @@ func (p *ContentPipeline) editingStage(ctx context.Context, content *entities.Co
 	return &StageResult{
 		Content:     editedContent,
 		Status:      "completed",
-		Metadata:    map[string]interface{}{"stage": "edit", "wordCount": estimateWords(editedContent)},
+		Metadata:    map[string]interface{}{"stage": "edit", "wordCount": estimateWords(editedContent), promptTemplateMetadataKey: promptTemplate},
 		ElapsedTime: time.Since(startTime),
 	}, nil
 }
@@ -293,14 +300,23 @@ func (p *ContentPipeline) finalizationStage(ctx context.Context, content *entiti
 	}
 
 	// Generate finalization prompt using template
-	templateManager := NewPromptTemplateManager()
-	prompt, err := templateManager.GeneratePrompt(content.Type, "finalize", promptData)
+	prompt, promptTemplate, err := p.renderPrompt(ctx, project, content, "finalize", promptData)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate finalization prompt: %w", err)
 	}
+	if citationMarkerPattern.MatchString(content.Data) {
+		prompt += keepCitationsInstruction
+	}
 
 	// Generate final content using LLM
-	finalContent, err := p.llmClient.Generate(ctx, prompt)
+	finalContent, err := generateText(ctx, p.llmClient, prompt)
+	if err != nil {
+		return nil, fmt.Errorf("finalization failed: %w", err)
+	}
+
+	// Turn citation markers into citations and a bibliography
+	style := citationStyleFor(content, p.schema)
+	finalContent, citations, err := renderCitations(finalContent, researchSources(content), style, promptTemplate.OutputFormat)
 	if err != nil {
 		return nil, fmt.Errorf("finalization failed: %w", err)
 	}
@@ -312,6 +328,9 @@ func (p *ContentPipeline) finalizationStage(ctx context.Context, content *entiti
 		"deliveryReady":  true,
 		"finalizedAt":    time.Now(),
 		"contentFormat":  getContentFormat(content.Type),
+		"citations":      citations,
+		"citationStyle":  style,
+		promptTemplateMetadataKey: promptTemplate,
 	}
 
 	// Add final content to context
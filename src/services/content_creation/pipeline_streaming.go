@@ -0,0 +1,97 @@
+package content_creation
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Ceesaxp/autonomous-content-service/src/domain/entities"
+)
+
+// partialDraftInterval is the minimum time between partial draft events of a stage
+const partialDraftInterval = 750 * time.Millisecond
+
+// errStageIdle is the cancellation cause of a stage that produced no output for its idle timeout
+var errStageIdle = errors.New("stage idle timeout")
+
+// stageWatchdog cancels a stage's context when it has not been touched for the idle timeout
+type stageWatchdog struct {
+	mu      sync.Mutex
+	timer   *time.Timer
+	timeout time.Duration
+}
+
+type stageWatchdogKey struct{}
+
+// withIdleTimeout returns a context that is cancelled with errStageIdle when the stage
+// shows no activity for timeout. LLM responses and streamed chunks count as activity.
+func withIdleTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	watchdog := &stageWatchdog{timeout: timeout}
+	watchdog.timer = time.AfterFunc(timeout, func() { cancel(errStageIdle) })
+
+	ctx = context.WithValue(ctx, stageWatchdogKey{}, watchdog)
+	return ctx, func() {
+		watchdog.timer.Stop()
+		cancel(context.Canceled)
+	}
+}
+
+// touchStage resets the idle timeout of the stage running in ctx, if any
+func touchStage(ctx context.Context) {
+	watchdog, ok := ctx.Value(stageWatchdogKey{}).(*stageWatchdog)
+	if !ok || ctx.Err() != nil {
+		return
+	}
+
+	watchdog.mu.Lock()
+	defer watchdog.mu.Unlock()
+	watchdog.timer.Reset(watchdog.timeout)
+}
+
+// stageIdle reports whether the stage context was cancelled by its idle timeout
+func stageIdle(ctx context.Context) bool {
+	return errors.Is(context.Cause(ctx), errStageIdle)
+}
+
+// streamStageText generates text for a stage, publishing the text written since the
+// previous partial progress event so consumers can follow long drafts. Stage text is
+// creative, so it always bypasses the LLM cache.
+func (p *ContentPipeline) streamStageText(ctx context.Context, content *entities.Content, stage PipelineStage, prompt string) (string, error) {
+	ctx = WithoutLLMCache(ctx)
+	startTime := time.Now()
+	var unpublished strings.Builder
+	var offset int
+	var lastPublished time.Time
+
+	response, err := generateStream(ctx, p.llmClient, NewPromptRequest(prompt), func(chunk string) error {
+		unpublished.WriteString(chunk)
+		if time.Since(lastPublished) >= partialDraftInterval {
+			lastPublished = time.Now()
+			delta := unpublished.String()
+			p.publishPartial(content, stage, time.Since(startTime), delta, offset)
+			offset += utf8.RuneCountInString(delta)
+			unpublished.Reset()
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return response.Content, nil
+}
+
+// publishPartial delivers the text appended to a partial draft at offset to live
+// subscribers. Partial drafts are not stored in the event repository.
+func (p *ContentPipeline) publishPartial(content *entities.Content, stage PipelineStage, elapsed time.Duration, text string, offset int) {
+	if p.progress == nil {
+		return
+	}
+	progress := newProgressEvent(content.ContentID, content.ProjectID, stage, "streaming", elapsed, "")
+	progress.Partial = text
+	progress.PartialOffset = offset
+	p.progress.Publish(progress)
+}
@@ -16,6 +16,7 @@ type PlagiarismDetector struct {
 	plagiarismAPI PlagiarismAPI
 	llmClient     LLMClient
 	fingerprinter *ContentFingerprinter
+	index         *SimilarityIndex // nil leaves nothing to check the database against
 }
 
 // NewPlagiarismDetector creates a new plagiarism detector
@@ -27,9 +28,15 @@ func NewPlagiarismDetector(plagiarismAPI PlagiarismAPI, llmClient LLMClient) *Pl
 	}
 }
 
+// UseSimilarityIndex makes database checks look for passages of earlier content in the index
+func (p *PlagiarismDetector) UseSimilarityIndex(index *SimilarityIndex) {
+	p.index = index
+}
+
 // PlagiarismCheckRequest contains parameters for plagiarism detection
 type PlagiarismCheckRequest struct {
 	Content       string
+	Subject       *entities.Content // the content being checked, whose own versions are not matches; may be nil
 	ContentType   entities.ContentType
 	CheckWeb      bool
 	CheckDatabase bool
@@ -167,8 +174,10 @@ func (p *PlagiarismDetector) CheckPlagiarism(ctx context.Context, request Plagia
 
 	// 3. Check against database if enabled
 	if request.CheckDatabase {
-		dbMatches, err := p.checkAgainstDatabase(ctx, fingerprint)
-		if err == nil {
+		dbMatches, err := p.checkAgainstDatabase(ctx, request)
+		if err != nil {
+			fmt.Printf("Warning: Similarity index lookup failed: %v\n", err)
+		} else {
 			result.Matches = append(result.Matches, dbMatches...)
 		}
 	}
@@ -211,6 +220,7 @@ func (p *PlagiarismDetector) checkAgainstWeb(ctx context.Context, content string
 			match := PlagiarismMatch{
 				ID:              generateMatchID(detail.Fragment, detail.Source),
 				MatchedText:     detail.Fragment,
+				SourceText:      detail.SourceFragment,
 				SimilarityScore: detail.Percentage / 100.0,
 				Source: Source{
 					URL:    detail.Source,
@@ -220,6 +230,9 @@ func (p *PlagiarismDetector) checkAgainstWeb(ctx context.Context, content string
 				RiskLevel: p.determineRiskLevel(detail.Percentage / 100.0),
 				Type:      MatchExact,
 			}
+			if detail.Location != nil {
+				match.Location = *detail.Location
+			}
 			matches = append(matches, match)
 		}
 
@@ -230,11 +243,52 @@ func (p *PlagiarismDetector) checkAgainstWeb(ctx context.Context, content string
 	return p.simulateWebCheck(content), nil
 }
 
-// checkAgainstDatabase checks content against internal database
-func (p *PlagiarismDetector) checkAgainstDatabase(ctx context.Context, fingerprint *ContentFingerprint) ([]PlagiarismMatch, error) {
-	// In a real implementation, this would check against a database of previously processed content
-	// For now, we'll return empty results
-	return []PlagiarismMatch{}, nil
+// checkAgainstDatabase checks content against the earlier content in the similarity index,
+// reporting each shared passage. Passages reused from another client's content are the
+// riskier kind of self-plagiarism.
+func (p *PlagiarismDetector) checkAgainstDatabase(ctx context.Context, request PlagiarismCheckRequest) ([]PlagiarismMatch, error) {
+	if p.index == nil {
+		return []PlagiarismMatch{}, nil
+	}
+
+	similar, err := p.index.FindMatches(ctx, request.Content, entities.SimilarityDocumentContent, request.Subject)
+	if err != nil {
+		return nil, err
+	}
+
+	matches := []PlagiarismMatch{}
+	for _, match := range similar {
+		risk := p.determineRiskLevel(match.Containment)
+		note := "Repeats content written for the same client"
+		if match.CrossClient {
+			note = "Reuses content written for another client"
+			if risk == RiskNone || risk == RiskLow {
+				risk = RiskMedium
+			}
+		} else if risk == RiskNone {
+			risk = RiskLow
+		}
+
+		for _, span := range match.Spans {
+			matches = append(matches, PlagiarismMatch{
+				ID:              generateMatchID(span.Text, match.Document.Key),
+				MatchedText:     span.Text,
+				SourceText:      span.SourceText,
+				SimilarityScore: match.Containment,
+				Source: Source{
+					URL:   match.Document.Key,
+					Title: match.Document.Title,
+					Type:  SourceInternal,
+				},
+				RiskLevel: risk,
+				Location:  Location{StartChar: span.Start, EndChar: span.End},
+				Type:      MatchExact,
+				Context:   note,
+			})
+		}
+	}
+
+	return matches, nil
 }
 
 // detectSuspiciousPatterns identifies patterns that might indicate plagiarism
@@ -265,7 +319,7 @@ Respond in JSON format:
   ]
 }`, content)
 
-	response, err := p.llmClient.Generate(ctx, prompt)
+	response, err := generateText(ctx, p.llmClient, prompt)
 	if err != nil {
 		return nil, err
 	}
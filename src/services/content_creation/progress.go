@@ -0,0 +1,164 @@
+package content_creation
+
+import (
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// defaultProgressHistory is how many events are kept per content for replay
+	defaultProgressHistory = 256
+
+	// defaultProgressRetention is how long an idle content's events are kept
+	defaultProgressRetention = time.Hour
+
+	// subscriberBuffer is how many undelivered events a subscriber may fall behind by
+	// before it is disconnected
+	subscriberBuffer = 64
+)
+
+// ProgressBroker is an in-process pub/sub for pipeline progress events.
+// Events are numbered per content and the most recent ones are kept, so late
+// subscribers can replay what they missed.
+type ProgressBroker struct {
+	mu          sync.Mutex
+	streams     map[uuid.UUID]*progressStream
+	historySize int
+	retention   time.Duration
+}
+
+// progressStream holds the events and subscribers of one piece of content
+type progressStream struct {
+	history     []PipelineProgressEvent
+	lastID      int64
+	subscribers map[chan PipelineProgressEvent]struct{}
+	updatedAt   time.Time
+}
+
+// NewProgressBroker creates a broker that keeps historySize events per content for
+// retention after the last event. Non-positive values select the defaults.
+func NewProgressBroker(historySize int, retention time.Duration) *ProgressBroker {
+	if historySize <= 0 {
+		historySize = defaultProgressHistory
+	}
+	if retention <= 0 {
+		retention = defaultProgressRetention
+	}
+
+	return &ProgressBroker{
+		streams:     make(map[uuid.UUID]*progressStream),
+		historySize: historySize,
+		retention:   retention,
+	}
+}
+
+// Publish numbers the event, stores it for replay and delivers it to the content's subscribers.
+// Subscribers that have fallen too far behind are disconnected by closing their channel.
+func (b *ProgressBroker) Publish(event PipelineProgressEvent) PipelineProgressEvent {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	b.prune(now)
+
+	stream, ok := b.streams[event.ContentID]
+	if !ok {
+		stream = &progressStream{subscribers: make(map[chan PipelineProgressEvent]struct{})}
+		b.streams[event.ContentID] = stream
+	}
+
+	stream.lastID++
+	event.EventID = stream.lastID
+	stream.updatedAt = now
+
+	stored := event
+	if event.Partial != "" {
+		// Subscribers get the text appended since the last partial event, while replay keeps
+		// one event per stage holding the whole draft so far
+		stream.history, stored = mergePartial(stream.history, event)
+	}
+	stream.history = append(stream.history, stored)
+	if len(stream.history) > b.historySize {
+		stream.history = stream.history[len(stream.history)-b.historySize:]
+	}
+
+	for ch := range stream.subscribers {
+		select {
+		case ch <- event:
+		default:
+			delete(stream.subscribers, ch)
+			close(ch)
+		}
+	}
+
+	return event
+}
+
+// mergePartial removes the partial draft events of the event's stage from history and
+// returns the event to store in their place. When the event continues the stored draft,
+// the stored text is prepended so the replayed event carries the draft from its start.
+func mergePartial(history []PipelineProgressEvent, event PipelineProgressEvent) ([]PipelineProgressEvent, PipelineProgressEvent) {
+	kept := history[:0]
+	for _, stored := range history {
+		if stored.Partial == "" || stored.Stage != event.Stage {
+			kept = append(kept, stored)
+			continue
+		}
+		if stored.PartialOffset+utf8.RuneCountInString(stored.Partial) == event.PartialOffset {
+			event.Partial = stored.Partial + event.Partial
+			event.PartialOffset = stored.PartialOffset
+		}
+	}
+	return kept, event
+}
+
+// Subscribe returns the stored events for the content with an ID above afterID, and a
+// channel that receives later events. The channel is closed if the subscriber falls
+// behind; call the returned function to unsubscribe.
+func (b *ProgressBroker) Subscribe(contentID uuid.UUID, afterID int64) ([]PipelineProgressEvent, <-chan PipelineProgressEvent, func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	stream, ok := b.streams[contentID]
+	if !ok {
+		stream = &progressStream{
+			subscribers: make(map[chan PipelineProgressEvent]struct{}),
+			updatedAt:   time.Now(),
+		}
+		b.streams[contentID] = stream
+	}
+
+	replay := []PipelineProgressEvent{}
+	for _, event := range stream.history {
+		if event.EventID > afterID {
+			replay = append(replay, event)
+		}
+	}
+
+	ch := make(chan PipelineProgressEvent, subscriberBuffer)
+	stream.subscribers[ch] = struct{}{}
+
+	unsubscribe := func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+
+		if _, ok := stream.subscribers[ch]; ok {
+			delete(stream.subscribers, ch)
+			close(ch)
+		}
+	}
+
+	return replay, ch, unsubscribe
+}
+
+// prune drops the history of contents without subscribers that have been idle past the retention
+func (b *ProgressBroker) prune(now time.Time) {
+	for contentID, stream := range b.streams {
+		if len(stream.subscribers) == 0 && now.Sub(stream.updatedAt) > b.retention {
+			delete(b.streams, contentID)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package content_creation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProgressBroker_ReplayAndLiveEvents(t *testing.T) {
+	broker := NewProgressBroker(3, 0)
+	contentID := uuid.New()
+
+	for _, status := range []string{"started", "completed", "started", "completed"} {
+		broker.Publish(PipelineProgressEvent{ContentID: contentID, Stage: StageResearch, Status: status})
+	}
+
+	// Only the last three events are kept; Last-Event-ID 2 skips the ones already seen
+	replay, updates, unsubscribe := broker.Subscribe(contentID, 2)
+	defer unsubscribe()
+
+	if assert.Len(t, replay, 2) {
+		assert.Equal(t, int64(3), replay[0].EventID)
+		assert.Equal(t, int64(4), replay[1].EventID)
+	}
+
+	published := broker.Publish(PipelineProgressEvent{ContentID: contentID, Stage: StageOutlining, Status: "started"})
+	assert.Equal(t, int64(5), published.EventID)
+	assert.Equal(t, published, <-updates)
+
+	// Events for other content are not delivered
+	broker.Publish(PipelineProgressEvent{ContentID: uuid.New(), Status: "started"})
+	assert.Len(t, updates, 0)
+}
+
+func TestProgressBroker_SendsPartialDeltasAndReplaysWholeDraft(t *testing.T) {
+	broker := NewProgressBroker(0, 0)
+	contentID := uuid.New()
+	_, updates, unsubscribe := broker.Subscribe(contentID, 0)
+	defer unsubscribe()
+
+	partial := func(stage PipelineStage, text string, offset int) PipelineProgressEvent {
+		return PipelineProgressEvent{ContentID: contentID, Stage: stage, Status: "streaming", Partial: text, PartialOffset: offset}
+	}
+	broker.Publish(partial(StageDrafting, "Café ", 0))
+	broker.Publish(partial(StageDrafting, "au ", 5))
+	broker.Publish(PipelineProgressEvent{ContentID: contentID, Stage: StageDrafting, Status: "timeout"})
+	broker.Publish(partial(StageDrafting, "lait", 8))
+
+	// Live subscribers only receive what was appended
+	var live []string
+	for len(updates) > 0 {
+		event := <-updates
+		live = append(live, event.Partial)
+	}
+	assert.Equal(t, []string{"Café ", "au ", "", "lait"}, live)
+
+	// Replay keeps a single partial event holding the draft from its start
+	replay, _, unsubscribeReplay := broker.Subscribe(contentID, 0)
+	defer unsubscribeReplay()
+	if assert.Len(t, replay, 2) {
+		assert.Equal(t, "timeout", replay[0].Status)
+		assert.Equal(t, int64(4), replay[1].EventID)
+		assert.Equal(t, "Café au lait", replay[1].Partial)
+		assert.Zero(t, replay[1].PartialOffset)
+	}
+
+	// A new attempt restarts the draft
+	broker.Publish(partial(StageDrafting, "Tea", 0))
+	replay, _, unsubscribeRestart := broker.Subscribe(contentID, 0)
+	defer unsubscribeRestart()
+	if assert.Len(t, replay, 2) {
+		assert.Equal(t, "Tea", replay[1].Partial)
+	}
+}
+
+func TestProgressBroker_DisconnectsSlowSubscribers(t *testing.T) {
+	broker := NewProgressBroker(0, 0)
+	contentID := uuid.New()
+
+	_, updates, unsubscribe := broker.Subscribe(contentID, 0)
+	defer unsubscribe()
+
+	for i := 0; i <= subscriberBuffer; i++ {
+		broker.Publish(PipelineProgressEvent{ContentID: contentID, Status: "started"})
+	}
+
+	received := 0
+	for range updates {
+		received++
+	}
+	assert.Equal(t, subscriberBuffer, received)
+}
+
+func TestContentPipeline_PublishesProgress(t *testing.T) {
+	repos := newTestRepositories()
+	pipeline := repos.newPipeline(new(MockLLMClient), new(MockContextManager), new(MockResearcher), new(MockQualityChecker), PipelineConfig{})
+	contentID := uuid.New()
+
+	pipeline.recordEvent(context.Background(), contentID, uuid.New(), StageDrafting, "started", 0, "Starting drafting stage")
+	pipeline.recordFinalEvent(context.Background(), contentID, uuid.New(), StageFinalization, "completed", 0, "Done")
+
+	replay, _, unsubscribe := pipeline.SubscribeProgress(contentID, 0)
+	defer unsubscribe()
+
+	if assert.Len(t, replay, 2) {
+		assert.Equal(t, StageDrafting, replay[0].Stage)
+		assert.False(t, replay[0].Final)
+		assert.True(t, replay[1].Final)
+	}
+}
@@ -0,0 +1,247 @@
+package content_creation
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"text/template"
+
+	"github.com/Ceesaxp/autonomous-content-service/src/domain/entities"
+	"github.com/Ceesaxp/autonomous-content-service/src/domain/repositories"
+	"github.com/google/uuid"
+)
+
+// PromptScope selects the stored templates that apply to a prompt: a client's overrides
+// first, then its industry's
+type PromptScope struct {
+	ClientID *uuid.UUID
+	Industry string
+}
+
+// PromptTemplateRef identifies the template a prompt was rendered from. Built-in templates
+// have no ID or version.
+type PromptTemplateRef struct {
+	Name         string                       `json:"name"`
+	Scope        entities.PromptTemplateScope `json:"scope"`
+	TemplateID   *uuid.UUID                   `json:"templateId,omitempty"`
+	Version      int                          `json:"version,omitempty"`
+	Author       string                       `json:"author,omitempty"`
+	OutputFormat entities.PromptOutputFormat  `json:"outputFormat,omitempty"` // the markup the template asks for
+}
+
+// promptTemplateKey identifies a parsed version of a stored template
+type promptTemplateKey struct {
+	templateID uuid.UUID
+	version    int
+}
+
+// UseRepository makes the manager prefer stored templates over the built-in ones. The
+// client repository provides the industry of a client's scope and may be nil.
+func (m *PromptTemplateManager) UseRepository(templates repositories.PromptTemplateRepository, clients repositories.ClientRepository) {
+	m.repository = templates
+	m.clients = clients
+}
+
+// stagePromptContext lists the additional context each stage renders its template with
+var stagePromptContext = map[string][]string{
+	"outline":  {"research"},
+	"draft":    {"Outline", "research"},
+	"edit":     {"Draft"},
+	"finalize": {"EditedDraft"},
+}
+
+// SamplePromptData returns prompt data with every field set, including the additional
+// context the named template is rendered with by its stage. Templates of other names get
+// the context of every stage.
+func SamplePromptData(contentType entities.ContentType, templateName string) PromptData {
+	keys, ok := stagePromptContext[templateName]
+	if !ok {
+		for _, stageKeys := range stagePromptContext {
+			keys = append(keys, stageKeys...)
+		}
+	}
+	additional := make(map[string]interface{}, len(keys))
+	for _, key := range keys {
+		additional[key] = "Sample " + key
+	}
+
+	return PromptData{
+		ClientName:        "Sample Client",
+		ProjectTitle:      "Sample Project",
+		ContentTitle:      "Sample Title",
+		ContentType:       contentType,
+		ContentGoals:      []string{"inform"},
+		TargetAudience:    "general readers",
+		BrandVoice:        "friendly",
+		Keywords:          []string{"sample"},
+		StyleGuide:        map[string]interface{}{"tone": "friendly"},
+		DomainKnowledge:   map[string]interface{}{"industry": "sample"},
+		AdditionalContext: additional,
+	}
+}
+
+// ValidateTemplate checks that a template body parses and renders the sample prompt data
+// of its stage, so a body that would fail every pipeline run is rejected before it is
+// stored. Map keys missing from the sample data, such as a misspelt stage context, are
+// errors; keys that may be absent can be read with index instead.
+func (m *PromptTemplateManager) ValidateTemplate(contentType entities.ContentType, templateName, templateText string) error {
+	tmpl, err := template.New(templateName).Option("missingkey=error").Parse(templateText)
+	if err != nil {
+		return err
+	}
+	_, err = executeTemplate(tmpl, SamplePromptData(contentType, templateName))
+	return err
+}
+
+// ScopeForClient returns the scope of a client's prompts, with the industry of its profile
+func (m *PromptTemplateManager) ScopeForClient(ctx context.Context, clientID uuid.UUID) PromptScope {
+	scope := PromptScope{ClientID: &clientID}
+	if m.clients == nil {
+		return scope
+	}
+
+	client, err := m.clients.FindByID(ctx, clientID)
+	if err != nil {
+		if !repositories.IsNotFound(err) {
+			fmt.Printf("Warning: failed to load client %s for its prompt templates: %v\n", clientID, err)
+		}
+		return scope
+	}
+	if client.Profile != nil {
+		scope.Industry = entities.NormalizeIndustry(client.Profile.Industry)
+	}
+	return scope
+}
+
+// Resolve returns the template for a stage of a content type in a scope. Lookup goes from
+// the client's override to the industry's template, the content type's stored template and
+// finally the built-in template.
+func (m *PromptTemplateManager) Resolve(ctx context.Context, contentType entities.ContentType, templateName string, scope PromptScope) (*template.Template, PromptTemplateRef, error) {
+	if m.repository != nil {
+		var candidates []PromptScope
+		if scope.ClientID != nil {
+			candidates = append(candidates, PromptScope{ClientID: scope.ClientID})
+		}
+		if scope.Industry != "" {
+			candidates = append(candidates, PromptScope{Industry: scope.Industry})
+		}
+		candidates = append(candidates, PromptScope{})
+
+		for _, candidate := range candidates {
+			stored, err := m.repository.FindByScope(ctx, templateName, contentType, candidate.Industry, candidate.ClientID)
+			if repositories.IsNotFound(err) {
+				continue
+			}
+			if err != nil {
+				return nil, PromptTemplateRef{}, fmt.Errorf("failed to look up prompt template %s: %w", templateName, err)
+			}
+			tmpl, err := m.parseStored(stored.CurrentVersion(), templateName)
+			if err != nil {
+				return nil, PromptTemplateRef{}, err
+			}
+			return tmpl, storedTemplateRef(stored), nil
+		}
+	}
+
+	tmpl, exists := m.templates[contentType][templateName]
+	if !exists {
+		return nil, PromptTemplateRef{}, fmt.Errorf("template not found: %s for content type: %s", templateName, contentType)
+	}
+	return tmpl, PromptTemplateRef{
+		Name:         templateName,
+		Scope:        entities.PromptTemplateScopeBuiltin,
+		OutputFormat: m.builtinFormat(contentType, templateName),
+	}, nil
+}
+
+// Render renders the prompt for a stage of a content type in a scope, returning the
+// template it was rendered from
+func (m *PromptTemplateManager) Render(ctx context.Context, contentType entities.ContentType, templateName string, scope PromptScope, data PromptData) (string, PromptTemplateRef, error) {
+	tmpl, ref, err := m.Resolve(ctx, contentType, templateName, scope)
+	if err != nil {
+		return "", PromptTemplateRef{}, err
+	}
+	prompt, err := executeTemplate(tmpl, data)
+	if err != nil {
+		return "", PromptTemplateRef{}, err
+	}
+	return prompt, ref, nil
+}
+
+// RenderVersion renders a version of a stored template; version 0 renders the current one
+func (m *PromptTemplateManager) RenderVersion(ctx context.Context, templateID uuid.UUID, version int, data PromptData) (string, PromptTemplateRef, error) {
+	if m.repository == nil {
+		return "", PromptTemplateRef{}, fmt.Errorf("no prompt template repository configured")
+	}
+
+	stored, err := m.repository.FindByID(ctx, templateID)
+	if err != nil {
+		return "", PromptTemplateRef{}, err
+	}
+	ref := storedTemplateRef(stored)
+	selected := stored.CurrentVersion()
+	if version != 0 && version != stored.Version {
+		if selected, err = m.repository.FindVersion(ctx, templateID, version); err != nil {
+			return "", PromptTemplateRef{}, err
+		}
+		ref.Version, ref.Author, ref.OutputFormat = selected.Version, selected.Author, selected.OutputFormat
+	}
+
+	tmpl, err := m.parseStored(selected, stored.Name)
+	if err != nil {
+		return "", PromptTemplateRef{}, err
+	}
+	prompt, err := executeTemplate(tmpl, data)
+	if err != nil {
+		return "", PromptTemplateRef{}, err
+	}
+	return prompt, ref, nil
+}
+
+// RenderText renders an unsaved template body, to preview a change before storing it
+func (m *PromptTemplateManager) RenderText(templateName, templateText string, data PromptData) (string, error) {
+	tmpl, err := template.New(templateName).Parse(templateText)
+	if err != nil {
+		return "", err
+	}
+	return executeTemplate(tmpl, data)
+}
+
+// parseStored parses a version of a stored template. Versions never change, so each is
+// parsed once.
+func (m *PromptTemplateManager) parseStored(version *entities.PromptTemplateVersion, templateName string) (*template.Template, error) {
+	key := promptTemplateKey{templateID: version.TemplateID, version: version.Version}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if tmpl, ok := m.parsed[key]; ok {
+		return tmpl, nil
+	}
+	tmpl, err := template.New(templateName).Parse(version.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse prompt template %s version %d: %w", version.TemplateID, version.Version, err)
+	}
+	m.parsed[key] = tmpl
+	return tmpl, nil
+}
+
+func storedTemplateRef(stored *entities.PromptTemplate) PromptTemplateRef {
+	templateID := stored.TemplateID
+	return PromptTemplateRef{
+		Name:         stored.Name,
+		Scope:        stored.Scope(),
+		TemplateID:   &templateID,
+		Version:      stored.Version,
+		Author:       stored.Author,
+		OutputFormat: stored.OutputFormat,
+	}
+}
+
+// executeTemplate renders a template with the prompt data
+func executeTemplate(tmpl *template.Template, data PromptData) (string, error) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,207 @@
+package content_creation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Ceesaxp/autonomous-content-service/src/domain/entities"
+	"github.com/Ceesaxp/autonomous-content-service/src/domain/repositories"
+	"github.com/Ceesaxp/autonomous-content-service/src/infrastructure/memory"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func storeTemplate(t *testing.T, manager *PromptTemplateManager, name, industry string, clientID *uuid.UUID, body string) *entities.PromptTemplate {
+	t.Helper()
+	template, err := entities.NewPromptTemplate(name, entities.ContentTypeBlogPost, industry, clientID, body, "editor@example.com")
+	require.NoError(t, err)
+	require.NoError(t, manager.repository.Create(context.Background(), template))
+	return template
+}
+
+func TestPromptTemplateManager_ResolvesClientIndustryContentTypeThenBuiltin(t *testing.T) {
+	ctx := context.Background()
+	clients := memory.NewClientRepository()
+	client, err := entities.NewClient("Acme", "team@acme.example", "+15555550100", entities.Address{Street: "1 Main St", City: "Springfield", Country: "US"}, "UTC")
+	require.NoError(t, err)
+	client.Profile = &entities.ClientProfile{Industry: "Healthcare"}
+	require.NoError(t, clients.Create(ctx, client))
+
+	manager := NewPromptTemplateManager()
+	manager.UseRepository(memory.NewPromptTemplateRepository(), clients)
+	scope := manager.ScopeForClient(ctx, client.ClientID)
+	assert.Equal(t, "healthcare", scope.Industry)
+	data := PromptData{ContentTitle: "Sleep"}
+
+	render := func() (string, PromptTemplateRef) {
+		prompt, ref, err := manager.Render(ctx, entities.ContentTypeBlogPost, "draft", scope, data)
+		require.NoError(t, err)
+		return prompt, ref
+	}
+
+	_, ref := render()
+	assert.Equal(t, entities.PromptTemplateScopeBuiltin, ref.Scope)
+
+	storeTemplate(t, manager, "draft", "", nil, "Default draft of {{.ContentTitle}}")
+	prompt, ref := render()
+	assert.Equal(t, "Default draft of Sleep", prompt)
+	assert.Equal(t, entities.PromptTemplateScopeContentType, ref.Scope)
+
+	storeTemplate(t, manager, "draft", "healthcare", nil, "Clinical draft of {{.ContentTitle}}")
+	prompt, ref = render()
+	assert.Equal(t, "Clinical draft of Sleep", prompt)
+	assert.Equal(t, entities.PromptTemplateScopeIndustry, ref.Scope)
+
+	override := storeTemplate(t, manager, "draft", "", &client.ClientID, "Acme draft of {{.ContentTitle}}")
+	_, err = manager.repository.AddVersion(ctx, override.TemplateID, "Acme draft v2 of {{.ContentTitle}}", "", "lead@example.com")
+	require.NoError(t, err)
+	prompt, ref = render()
+	assert.Equal(t, "Acme draft v2 of Sleep", prompt)
+	assert.Equal(t, PromptTemplateRef{
+		Name:       "draft",
+		Scope:      entities.PromptTemplateScopeClient,
+		TemplateID: &override.TemplateID,
+		Version:    2,
+		Author:     "lead@example.com",
+	}, ref)
+
+	// Past versions render for a dry run
+	prompt, ref, err = manager.RenderVersion(ctx, override.TemplateID, 1, data)
+	require.NoError(t, err)
+	assert.Equal(t, "Acme draft of Sleep", prompt)
+	assert.Equal(t, 1, ref.Version)
+
+	// Other clients in other industries get the content type's template
+	prompt, _, err = manager.Render(ctx, entities.ContentTypeBlogPost, "draft", PromptScope{Industry: "retail"}, data)
+	require.NoError(t, err)
+	assert.Equal(t, "Default draft of Sleep", prompt)
+}
+
+func TestContentPipeline_StampsPromptTemplateVersions(t *testing.T) {
+	repos := newTestRepositories()
+	llm := new(MockLLMClient)
+	contextManager := new(MockContextManager)
+	pipeline := repos.newPipeline(llm, contextManager, new(MockResearcher), new(MockQualityChecker), PipelineConfig{})
+
+	manager := NewPromptTemplateManager()
+	manager.UseRepository(memory.NewPromptTemplateRepository(), nil)
+	pipeline.UsePromptTemplates(manager)
+
+	content, err := entities.NewContent(uuid.New(), "Sleep", entities.ContentTypeBlogPost)
+	require.NoError(t, err)
+	content.UpdateMetadata("research", map[string]interface{}{"summary": "research"})
+	project := repos.seedProject(t, content.ProjectID, entities.ContentTypeBlogPost)
+	override := storeTemplate(t, manager, "outline", "", &project.ClientID, "Client outline of {{.ContentTitle}}")
+
+	contextManager.On("SwitchContext", mock.Anything, content.ProjectID).Return(nil)
+	contextManager.On("AddEntry", mock.Anything, content.ProjectID, mock.Anything).Return(nil)
+	llm.On("Generate", mock.Anything, promptContaining("Client outline of Sleep")).Return("Outline", nil).Once()
+
+	ctx := context.Background()
+	result, err := pipeline.outliningStage(ctx, content)
+	require.NoError(t, err)
+	require.NoError(t, pipeline.applyStageResult(ctx, content, StageOutlining, result))
+
+	stamped := content.Metadata["promptTemplates"].(map[string]interface{})
+	ref := stamped[string(StageOutlining)].(PromptTemplateRef)
+	assert.Equal(t, entities.PromptTemplateScopeClient, ref.Scope)
+	assert.Equal(t, override.TemplateID, *ref.TemplateID)
+	assert.Equal(t, 1, ref.Version)
+	llm.AssertExpectations(t)
+}
+
+func TestPromptTemplateManager_ValidatesBodiesAgainstSampleData(t *testing.T) {
+	manager := NewPromptTemplateManager()
+
+	// The built-in templates render with the sample data of their stage
+	for contentType, templates := range manager.templates {
+		for name := range templates {
+			_, err := manager.GeneratePrompt(contentType, name, SamplePromptData(contentType, name))
+			assert.NoError(t, err, "%s %s template", contentType, name)
+		}
+	}
+
+	assert.NoError(t, manager.ValidateTemplate(entities.ContentTypeBlogPost, "draft", "Draft {{.ContentTitle}} from {{.AdditionalContext.Outline}}"))
+	assert.Error(t, manager.ValidateTemplate(entities.ContentTypeBlogPost, "draft", "Draft {{.ContentTitle"))
+	assert.Error(t, manager.ValidateTemplate(entities.ContentTypeBlogPost, "draft", "Draft {{.Nope}}"))
+	assert.Error(t, manager.ValidateTemplate(entities.ContentTypeBlogPost, "draft", "Draft {{.AdditionalContext.Drft.X}}"))
+	assert.Error(t, manager.ValidateTemplate(entities.ContentTypeBlogPost, "edit", "Edit {{.AdditionalContext.Outline}}"))
+	assert.NoError(t, manager.ValidateTemplate(entities.ContentTypeBlogPost, "edit", `Edit {{.AdditionalContext.Draft}} {{index .StyleGuide "audience"}}`))
+}
+
+func TestPromptTemplateManager_DeletedTemplatesStopApplyingButStayReadable(t *testing.T) {
+	ctx := context.Background()
+	manager := NewPromptTemplateManager()
+	manager.UseRepository(memory.NewPromptTemplateRepository(), nil)
+	clientID := uuid.New()
+	scope := PromptScope{ClientID: &clientID}
+	data := PromptData{ContentTitle: "Sleep"}
+
+	override := storeTemplate(t, manager, "draft", "", &clientID, "Acme draft of {{.ContentTitle}}")
+	require.NoError(t, manager.repository.Delete(ctx, override.TemplateID))
+	assert.True(t, repositories.IsNotFound(manager.repository.Delete(ctx, override.TemplateID)))
+
+	_, ref, err := manager.Render(ctx, entities.ContentTypeBlogPost, "draft", scope, data)
+	require.NoError(t, err)
+	assert.Equal(t, entities.PromptTemplateScopeBuiltin, ref.Scope)
+
+	listed, err := manager.repository.List(ctx, repositories.PromptTemplateFilter{ClientID: &clientID})
+	require.NoError(t, err)
+	assert.Empty(t, listed)
+
+	// Content stamped with the deleted template can still look it up
+	deleted, err := manager.repository.FindByID(ctx, override.TemplateID)
+	require.NoError(t, err)
+	assert.NotNil(t, deleted.DeletedAt)
+	prompt, _, err := manager.RenderVersion(ctx, override.TemplateID, 1, data)
+	require.NoError(t, err)
+	assert.Equal(t, "Acme draft of Sleep", prompt)
+	_, err = manager.repository.AddVersion(ctx, override.TemplateID, "Acme draft v2", "", "lead@example.com")
+	assert.True(t, repositories.IsNotFound(err))
+
+	// The scope can be given a new template
+	replacement := storeTemplate(t, manager, "draft", "", &clientID, "New Acme draft of {{.ContentTitle}}")
+	_, ref, err = manager.Render(ctx, entities.ContentTypeBlogPost, "draft", scope, data)
+	require.NoError(t, err)
+	assert.Equal(t, replacement.TemplateID, *ref.TemplateID)
+}
+
+func TestContentPipeline_StoredTemplatesEnableOptionalStages(t *testing.T) {
+	ctx := context.Background()
+	repos := newTestRepositories()
+	pipeline := repos.newPipeline(new(MockLLMClient), new(MockContextManager), new(MockResearcher), new(MockQualityChecker), PipelineConfig{})
+	pipeline.UseSchema(DefaultPipelineConfigSchema())
+
+	manager := NewPromptTemplateManager()
+	manager.UseRepository(memory.NewPromptTemplateRepository(), nil)
+	pipeline.UsePromptTemplates(manager)
+
+	content, err := entities.NewContent(uuid.New(), "Launch post", entities.ContentTypeSocialPost)
+	require.NoError(t, err)
+	project := repos.seedProject(t, content.ProjectID, entities.ContentTypeSocialPost)
+	otherClient := uuid.New()
+
+	// Social posts have no built-in outline or finalize template
+	skip, _ := pipeline.skipWithoutTemplate("outline")(ctx, content)
+	assert.True(t, skip)
+	assert.False(t, pipeline.rendersCitations(ctx, content))
+
+	store := func(name string, clientID *uuid.UUID) {
+		template, err := entities.NewPromptTemplate(name, entities.ContentTypeSocialPost, "", clientID, "Social "+name+" of {{.ContentTitle}}", "editor@example.com")
+		require.NoError(t, err)
+		require.NoError(t, manager.repository.Create(ctx, template))
+	}
+
+	// Another client's templates do not apply
+	store("outline", &otherClient)
+	skip, _ = pipeline.skipWithoutTemplate("outline")(ctx, content)
+	assert.True(t, skip)
+
+	store("outline", &project.ClientID)
+	store("finalize", nil)
+	skip, _ = pipeline.skipWithoutTemplate("outline")(ctx, content)
+	assert.False(t, skip)
+	assert.True(t, pipeline.rendersCitations(ctx, content))
+}
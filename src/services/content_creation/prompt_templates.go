@@ -3,9 +3,11 @@ package content_creation
 import (
 	"bytes"
 	"fmt"
+	"sync"
 	"text/template"
 
 	"github.com/Ceesaxp/autonomous-content-service/src/domain/entities"
+	"github.com/Ceesaxp/autonomous-content-service/src/domain/repositories"
 )
 
 // PromptTemplate defines a template for generating prompts
@@ -30,15 +32,24 @@ type PromptData struct {
 	AdditionalContext map[string]interface{}
 }
 
-// PromptTemplateManager manages prompt templates for different content types
+// PromptTemplateManager manages prompt templates for different content types. Stored
+// templates, when a repository is configured, take precedence over the built-in ones.
 type PromptTemplateManager struct {
-	templates map[entities.ContentType]map[string]*template.Template
+	templates  map[entities.ContentType]map[string]*template.Template
+	formats    map[entities.ContentType]map[string]entities.PromptOutputFormat // of built-in templates not in Markdown
+	repository repositories.PromptTemplateRepository // nil: built-in templates only
+	clients    repositories.ClientRepository
+
+	mutex  sync.Mutex
+	parsed map[promptTemplateKey]*template.Template
 }
 
 // NewPromptTemplateManager creates a new prompt template manager
 func NewPromptTemplateManager() *PromptTemplateManager {
 	manager := &PromptTemplateManager{
 		templates: make(map[entities.ContentType]map[string]*template.Template),
+		formats:   make(map[entities.ContentType]map[string]entities.PromptOutputFormat),
+		parsed:    make(map[promptTemplateKey]*template.Template),
 	}
 	
 	// Register default templates
@@ -67,8 +78,37 @@ func (m *PromptTemplateManager) registerDefaultTemplates() {
 	m.RegisterTemplate(entities.ContentTypeTechnicalArticle, "draft", technicalArticleDraftTemplate)
 	m.RegisterTemplate(entities.ContentTypeTechnicalArticle, "edit", technicalArticleEditTemplate)
 	m.RegisterTemplate(entities.ContentTypeTechnicalArticle, "finalize", technicalArticleFinalizeTemplate)
-	
-	// Add other content types as needed...
+
+	// Email newsletter templates
+	m.RegisterTemplate(entities.ContentTypeEmailNewsletter, "research", emailNewsletterResearchTemplate)
+	m.RegisterTemplate(entities.ContentTypeEmailNewsletter, "outline", emailNewsletterOutlineTemplate)
+	m.RegisterTemplate(entities.ContentTypeEmailNewsletter, "draft", emailNewsletterDraftTemplate)
+	m.RegisterTemplate(entities.ContentTypeEmailNewsletter, "edit", emailNewsletterEditTemplate)
+	m.RegisterTemplate(entities.ContentTypeEmailNewsletter, "finalize", emailNewsletterFinalizeTemplate)
+	m.RegisterTemplateFormat(entities.ContentTypeEmailNewsletter, "finalize", entities.PromptOutputFormatHTML)
+
+	// Website copy templates
+	m.RegisterTemplate(entities.ContentTypeWebsiteCopy, "research", websiteCopyResearchTemplate)
+	m.RegisterTemplate(entities.ContentTypeWebsiteCopy, "outline", websiteCopyOutlineTemplate)
+	m.RegisterTemplate(entities.ContentTypeWebsiteCopy, "draft", websiteCopyDraftTemplate)
+	m.RegisterTemplate(entities.ContentTypeWebsiteCopy, "edit", websiteCopyEditTemplate)
+	m.RegisterTemplate(entities.ContentTypeWebsiteCopy, "finalize", websiteCopyFinalizeTemplate)
+	m.RegisterTemplateFormat(entities.ContentTypeWebsiteCopy, "finalize", entities.PromptOutputFormatHTML)
+
+	// Product description templates
+	m.RegisterTemplate(entities.ContentTypeProductDescription, "research", productDescriptionResearchTemplate)
+	m.RegisterTemplate(entities.ContentTypeProductDescription, "outline", productDescriptionOutlineTemplate)
+	m.RegisterTemplate(entities.ContentTypeProductDescription, "draft", productDescriptionDraftTemplate)
+	m.RegisterTemplate(entities.ContentTypeProductDescription, "edit", productDescriptionEditTemplate)
+	m.RegisterTemplate(entities.ContentTypeProductDescription, "finalize", productDescriptionFinalizeTemplate)
+	m.RegisterTemplateFormat(entities.ContentTypeProductDescription, "finalize", entities.PromptOutputFormatHTML)
+
+	// Press release templates
+	m.RegisterTemplate(entities.ContentTypePressRelease, "research", pressReleaseResearchTemplate)
+	m.RegisterTemplate(entities.ContentTypePressRelease, "outline", pressReleaseOutlineTemplate)
+	m.RegisterTemplate(entities.ContentTypePressRelease, "draft", pressReleaseDraftTemplate)
+	m.RegisterTemplate(entities.ContentTypePressRelease, "edit", pressReleaseEditTemplate)
+	m.RegisterTemplate(entities.ContentTypePressRelease, "finalize", pressReleaseFinalizeTemplate)
 }
 
 // RegisterTemplate registers a new template
@@ -90,6 +130,29 @@ func (m *PromptTemplateManager) RegisterTemplate(contentType entities.ContentTyp
 	return nil
 }
 
+// RegisterTemplateFormat declares the markup a built-in template asks the model to write;
+// templates without a declared format write Markdown
+func (m *PromptTemplateManager) RegisterTemplateFormat(contentType entities.ContentType, name string, format entities.PromptOutputFormat) {
+	if _, exists := m.formats[contentType]; !exists {
+		m.formats[contentType] = make(map[string]entities.PromptOutputFormat)
+	}
+	m.formats[contentType][name] = format
+}
+
+// builtinFormat returns the output format of a built-in template
+func (m *PromptTemplateManager) builtinFormat(contentType entities.ContentType, templateName string) entities.PromptOutputFormat {
+	if format, ok := m.formats[contentType][templateName]; ok {
+		return format
+	}
+	return entities.PromptOutputFormatMarkdown
+}
+
+// HasTemplate reports whether a built-in template is registered for the content type
+func (m *PromptTemplateManager) HasTemplate(contentType entities.ContentType, templateName string) bool {
+	_, exists := m.templates[contentType][templateName]
+	return exists
+}
+
 // GeneratePrompt generates a prompt using the specified template
 func (m *PromptTemplateManager) GeneratePrompt(contentType entities.ContentType, templateName string, data PromptData) (string, error) {
 	// Check if template exists
@@ -273,4 +336,305 @@ Prepare for technical publication with:
 - Technical glossary for key terms (if appropriate)
 - Table of contents for navigation
 - SEO optimization for technical search terms`
-)
\ No newline at end of file
+)
+// Email newsletter templates
+const (
+	emailNewsletterResearchTemplate = `You are conducting research for an email newsletter titled "{{.ContentTitle}}" for {{.ClientName}}.
+The subscribers are {{.TargetAudience}}.
+The newsletter should serve these goals: {{range .ContentGoals}}
+- {{.}}{{end}}
+
+Identify the timely news, insights, and practical tips this audience would open an email for.
+For each item, note why it matters to subscribers now and a source that supports it.
+Suggest one clear action readers could take after reading.
+{{if .DomainKnowledge}}Additional context to consider:
+{{range $key, $value := .DomainKnowledge}}
+{{$key}}: {{$value}}{{end}}{{end}}`
+
+	emailNewsletterOutlineTemplate = `Create an outline for an email newsletter titled "{{.ContentTitle}}" for {{.ClientName}}.
+The subscribers are {{.TargetAudience}} and the newsletter uses a {{.BrandVoice}} brand voice.
+
+Base the outline on this research:
+{{.AdditionalContext.research}}
+
+The outline must include:
+1. Three subject line options (under 50 characters each)
+2. A preheader (40-90 characters) that complements rather than repeats the subject line
+3. A short personal opening
+4. Two to four content blocks, each with a heading, its key point and a link placeholder
+5. One primary call to action with its button text
+6. A sign-off`
+
+	emailNewsletterDraftTemplate = `Write an email newsletter titled "{{.ContentTitle}}" for {{.ClientName}}.
+Follow this outline:
+{{.AdditionalContext.Outline}}
+
+Write in a {{.BrandVoice}} tone for {{.TargetAudience}}, as one person writing to another.
+Naturally incorporate these keywords: {{range .Keywords}}{{.}}, {{end}}
+
+Format requirements:
+- Start with "Subject:" and "Preheader:" lines, using the strongest options from the outline
+- Keep the body between 300 and 1200 words
+- Keep paragraphs to three sentences or fewer so the email reads well on mobile
+- Give each content block a short heading
+- End with a single primary call to action and its button text in [brackets]`
+
+	emailNewsletterEditTemplate = `Edit the following email newsletter draft to improve open rate, readability and clicks.
+Title: {{.ContentTitle}}
+Client: {{.ClientName}}
+Audience: {{.TargetAudience}}
+Brand Voice: {{.BrandVoice}}
+
+Draft to edit:
+{{.AdditionalContext.Draft}}
+
+Focus on:
+1. A subject line under 50 characters and a preheader of 40-90 characters that work together
+2. Avoiding spam trigger words, excessive capitals and exclamation marks
+3. Short, scannable paragraphs suited to mobile screens
+4. A single, clear call to action that stands out
+5. Consistent tone and a personal voice
+6. Naturally incorporating these keywords: {{range .Keywords}}{{.}}, {{end}}
+
+Keep the "Subject:" and "Preheader:" lines at the top.`
+
+	emailNewsletterFinalizeTemplate = `Finalize the following email newsletter for sending.
+Title: {{.ContentTitle}}
+Client: {{.ClientName}}
+
+Content:
+{{.AdditionalContext.EditedDraft}}
+
+Deliver the newsletter in this exact structure:
+- "Subject:" line (under 50 characters)
+- "Preheader:" line (40-90 characters)
+- The body as email-safe HTML: simple headings, paragraphs and links only, no scripts or external stylesheets
+- The call to action as a clearly labelled button link with a placeholder URL
+- A footer with {{.ClientName}}'s name, a mailing address placeholder and an unsubscribe link placeholder`
+)
+
+// Website copy templates
+const (
+	websiteCopyResearchTemplate = `You are conducting research for website copy titled "{{.ContentTitle}}" for {{.ClientName}}.
+The visitors are {{.TargetAudience}}.
+The page should serve these goals: {{range .ContentGoals}}
+- {{.}}{{end}}
+
+Identify the visitors' main problems, the questions they ask before acting, and the objections that stop them.
+List the benefits, proof points (figures, testimonials, credentials) and differentiators the page can use.
+Note the search terms visitors would use to find this page.
+{{if .DomainKnowledge}}Additional context to consider:
+{{range $key, $value := .DomainKnowledge}}
+{{$key}}: {{$value}}{{end}}{{end}}`
+
+	websiteCopyOutlineTemplate = `Create a section-by-section outline for website copy titled "{{.ContentTitle}}" for {{.ClientName}}.
+The visitors are {{.TargetAudience}} and the copy uses a {{.BrandVoice}} brand voice.
+
+Base the outline on this research:
+{{.AdditionalContext.research}}
+
+The outline must include:
+1. A hero section: headline (under 10 words), subheadline and primary call to action
+2. A problem or value proposition section
+3. Three to five benefit sections, each with a heading and supporting proof
+4. A social proof section
+5. An objection-handling or FAQ section
+6. A closing call to action`
+
+	websiteCopyDraftTemplate = `Write website copy titled "{{.ContentTitle}}" for {{.ClientName}}.
+Follow this outline:
+{{.AdditionalContext.Outline}}
+
+Write in a {{.BrandVoice}} tone for {{.TargetAudience}}, addressing the visitor as "you".
+Naturally incorporate these keywords: {{range .Keywords}}{{.}}, {{end}}
+
+Format requirements:
+- Label each section with its name in [brackets] so designers can place it
+- Keep the hero headline under 10 words and the subheadline under 25 words
+- Lead with benefits before features
+- Keep paragraphs to two or three sentences; use bullet lists for features
+- Write call to action button text of two to five words
+- Write at least 200 words in total`
+
+	websiteCopyEditTemplate = `Edit the following website copy to improve clarity, persuasion and conversion.
+Title: {{.ContentTitle}}
+Client: {{.ClientName}}
+Audience: {{.TargetAudience}}
+Brand Voice: {{.BrandVoice}}
+
+Draft to edit:
+{{.AdditionalContext.Draft}}
+
+Focus on:
+1. A hero headline that states the main benefit in under 10 words
+2. Cutting jargon, filler and claims without proof
+3. Scannability: short paragraphs, descriptive headings, bullet lists
+4. Consistent, specific calls to action
+5. Keeping the [section] labels
+6. Naturally incorporating these keywords: {{range .Keywords}}{{.}}, {{end}}`
+
+	websiteCopyFinalizeTemplate = `Finalize the following website copy for publication.
+Title: {{.ContentTitle}}
+Client: {{.ClientName}}
+
+Content:
+{{.AdditionalContext.EditedDraft}}
+
+Deliver the page as semantic HTML:
+- A single H1 for the hero headline and H2 headings for the sections
+- Each section in its own <section> element, replacing the [section] labels
+- Calls to action as links with placeholder URLs
+- An SEO title tag suggestion (under 60 characters) and meta description (under 160 characters)
+- Image placeholders with descriptive alt text where visuals would help
+- SEO optimization for the target keywords: {{range .Keywords}}{{.}}, {{end}}`
+)
+
+// Product description templates
+const (
+	productDescriptionResearchTemplate = `You are conducting research for a product description titled "{{.ContentTitle}}" for {{.ClientName}}.
+The buyers are {{.TargetAudience}}.
+
+Identify the product's key attributes: materials or components, dimensions, specifications, compatibility and what is included.
+For each attribute, note the benefit it gives the buyer.
+List the questions buyers ask before purchasing and how competing products are usually described.
+Note the search terms buyers use for this kind of product.
+{{if .DomainKnowledge}}Additional context to consider:
+{{range $key, $value := .DomainKnowledge}}
+{{$key}}: {{$value}}{{end}}{{end}}`
+
+	productDescriptionOutlineTemplate = `Create an outline for a product description titled "{{.ContentTitle}}" for {{.ClientName}}.
+The buyers are {{.TargetAudience}} and the description uses a {{.BrandVoice}} brand voice.
+
+Base the outline on this research:
+{{.AdditionalContext.research}}
+
+The outline must include:
+1. A product headline
+2. An opening sentence or two on who the product is for and its main benefit
+3. Five to seven attribute bullets, each pairing a feature with its benefit
+4. A short usage or care paragraph
+5. A specifications list
+6. A closing line that encourages purchase`
+
+	productDescriptionDraftTemplate = `Write a product description titled "{{.ContentTitle}}" for {{.ClientName}}.
+Follow this outline:
+{{.AdditionalContext.Outline}}
+
+Write in a {{.BrandVoice}} tone for {{.TargetAudience}}.
+Naturally incorporate these keywords: {{range .Keywords}}{{.}}, {{end}}
+
+Format requirements:
+- Open with one or two sentences on the main benefit, not a list of features
+- Follow with five to seven attribute bullets in the form "Feature: benefit"
+- Keep each bullet under 20 words
+- Include a "Specifications" list of attribute and value pairs
+- Use only facts from the research; never invent measurements, materials or certifications
+- Write at least 150 words in total`
+
+	productDescriptionEditTemplate = `Edit the following product description to make it clearer and more persuasive.
+Title: {{.ContentTitle}}
+Client: {{.ClientName}}
+Audience: {{.TargetAudience}}
+Brand Voice: {{.BrandVoice}}
+
+Draft to edit:
+{{.AdditionalContext.Draft}}
+
+Focus on:
+1. Benefit-led attribute bullets in the form "Feature: benefit", each under 20 words
+2. Removing vague superlatives and unsupported claims
+3. Consistent units and formatting in the specifications
+4. Answering the questions buyers ask before purchasing
+5. Naturally incorporating these keywords: {{range .Keywords}}{{.}}, {{end}}`
+
+	productDescriptionFinalizeTemplate = `Finalize the following product description for a product page.
+Title: {{.ContentTitle}}
+Client: {{.ClientName}}
+
+Content:
+{{.AdditionalContext.EditedDraft}}
+
+Deliver, in this order:
+1. SEO suggestions: an SEO product title (under 70 characters) and a meta description (under 160 characters)
+2. Plain text of under 500 characters for marketplaces and product feeds, without citation markers
+3. Last, the HTML version: a short paragraph, the attribute bullets as a <ul> list and the specifications as a <table>
+
+Optimize for the target keywords: {{range .Keywords}}{{.}}, {{end}}`
+)
+
+// Press release templates
+const (
+	pressReleaseResearchTemplate = `You are conducting research for a press release titled "{{.ContentTitle}}" for {{.ClientName}}.
+The readers are {{.TargetAudience}}, starting with the journalists who decide whether to cover it.
+
+Identify the news: who, what, when, where and why it matters now.
+Gather supporting facts and figures with their sources, and note who could be quoted from {{.ClientName}} and its partners.
+Collect the facts needed for the company boilerplate: what {{.ClientName}} does, for whom, and its key figures.
+{{if .DomainKnowledge}}Additional context to consider:
+{{range $key, $value := .DomainKnowledge}}
+{{$key}}: {{$value}}{{end}}{{end}}`
+
+	pressReleaseOutlineTemplate = `Create an outline for a press release titled "{{.ContentTitle}}" for {{.ClientName}}.
+The readers are {{.TargetAudience}} and the release uses a {{.BrandVoice}} brand voice.
+
+Base the outline on this research:
+{{.AdditionalContext.research}}
+
+The outline must follow the inverted pyramid:
+1. Headline and optional subheadline
+2. Dateline (CITY, State – Month Day, Year) and a lead paragraph answering who, what, when, where and why
+3. Supporting paragraphs with details and figures, most important first
+4. One or two attributed quotes
+5. Availability, pricing or event details where relevant
+6. "About {{.ClientName}}" boilerplate
+7. Media contact`
+
+	pressReleaseDraftTemplate = `Write a press release titled "{{.ContentTitle}}" for {{.ClientName}}.
+Follow this outline:
+{{.AdditionalContext.Outline}}
+
+Write in the third person, in a factual, {{.BrandVoice}} news style for {{.TargetAudience}}.
+Naturally incorporate these keywords: {{range .Keywords}}{{.}}, {{end}}
+
+Format requirements:
+- Start with "FOR IMMEDIATE RELEASE", then the headline
+- Begin the first paragraph with a dateline: CITY, State – Month Day, Year –
+- Answer who, what, when, where and why in the first paragraph, in under 40 words
+- Attribute every quote with the speaker's full name and title; use [NAME] and [TITLE] placeholders when unknown
+- Avoid promotional superlatives; state facts and let the quotes carry opinion
+- Close with an "About {{.ClientName}}" boilerplate paragraph and a "Media Contact" block with placeholders
+- Keep the release between 400 and 800 words`
+
+	pressReleaseEditTemplate = `Edit the following press release to meet newsroom standards.
+Title: {{.ContentTitle}}
+Client: {{.ClientName}}
+Audience: {{.TargetAudience}}
+Brand Voice: {{.BrandVoice}}
+
+Draft to edit:
+{{.AdditionalContext.Draft}}
+
+Focus on:
+1. A headline under 100 characters that states the news
+2. A correctly formatted dateline and a lead paragraph that stands on its own
+3. Inverted pyramid order, with the most newsworthy facts first
+4. Removing marketing language, superlatives and unsupported claims
+5. Correctly attributed quotes that add perspective rather than repeat facts
+6. Keeping the "About {{.ClientName}}" boilerplate and "Media Contact" block
+7. Naturally incorporating these keywords: {{range .Keywords}}{{.}}, {{end}}`
+
+	pressReleaseFinalizeTemplate = `Finalize the following press release for distribution.
+Title: {{.ContentTitle}}
+Client: {{.ClientName}}
+
+Content:
+{{.AdditionalContext.EditedDraft}}
+
+Deliver the release as plain text in this exact structure:
+- "FOR IMMEDIATE RELEASE"
+- Headline, and subheadline if any
+- Body beginning with the dateline: CITY, State – Month Day, Year –
+- "About {{.ClientName}}" boilerplate paragraph
+- "Media Contact" block: name, email and phone placeholders
+- "###" on its own line to mark the end of the release`
+)
@@ -0,0 +1,98 @@
+package content_creation
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/Ceesaxp/autonomous-content-service/src/domain/entities"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// stageTemplateNames maps the built-in stages to the template each one renders
+var stageTemplateNames = map[PipelineStage]string{
+	StageResearch:     "research",
+	StageOutlining:    "outline",
+	StageDrafting:     "draft",
+	StageEditing:      "edit",
+	StageFinalization: "finalize",
+}
+
+// stageRunners are the pipeline stages that render the template of the same name
+var stageRunners = map[string]func(p *ContentPipeline, ctx context.Context, content *entities.Content) (*StageResult, error){
+	"outline":  (*ContentPipeline).outliningStage,
+	"draft":    (*ContentPipeline).draftingStage,
+	"edit":     (*ContentPipeline).editingStage,
+	"finalize": (*ContentPipeline).finalizationStage,
+}
+
+// promptFieldPattern matches the references to top-level prompt data fields in a template
+var promptFieldPattern = regexp.MustCompile(`\{\{[^}]*?\.(ClientName|ProjectTitle|ContentTitle|TargetAudience|BrandVoice|ContentGoals)\b`)
+
+func TestPromptTemplates_CoverEveryRequiredStageOfEveryContentType(t *testing.T) {
+	repos := newTestRepositories()
+	schemaPipeline := repos.newPipeline(new(MockLLMClient), new(MockContextManager), new(MockResearcher), new(MockQualityChecker), PipelineConfig{})
+	schemaPipeline.UseSchema(DefaultPipelineConfigSchema())
+	manager := NewPromptTemplateManager()
+
+	for _, contentType := range entities.AllContentTypes() {
+		plan, err := schemaPipeline.stagePlan(contentType)
+		require.NoError(t, err)
+
+		for _, planned := range plan {
+			templateName, ok := stageTemplateNames[planned.Stage]
+			if !ok || planned.Optional {
+				continue
+			}
+			if !assert.True(t, manager.HasTemplate(contentType, templateName), "%s has no %s template", contentType, templateName) {
+				continue
+			}
+			runStage, ok := stageRunners[templateName]
+			if !ok {
+				// Research prompts are rendered by the researcher
+				continue
+			}
+
+			// Render the prompt with the data the stage itself gathers
+			llm := new(MockLLMClient)
+			contextManager := new(MockContextManager)
+			contextManager.On("SwitchContext", mock.Anything, mock.Anything).Return(nil)
+			contextManager.On("AddEntry", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			var prompt string
+			llm.On("Generate", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+				prompt = args.Get(1).(LLMRequest).LastUserMessage()
+			}).Return("Stage output", nil)
+
+			pipeline := repos.newPipeline(llm, contextManager, new(MockResearcher), new(MockQualityChecker), PipelineConfig{})
+			pipeline.UseSchema(DefaultPipelineConfigSchema())
+			content, err := entities.NewContent(uuid.New(), "Spring launch", contentType)
+			require.NoError(t, err)
+			content.Data = "Current draft"
+			content.UpdateMetadata("research", map[string]interface{}{"summary": "research notes"})
+			content.UpdateMetadata("outline", "outline")
+			project := repos.seedProject(t, content.ProjectID, contentType)
+
+			_, err = runStage(pipeline, context.Background(), content)
+			if !assert.NoError(t, err, "%s %s stage", contentType, templateName) {
+				continue
+			}
+
+			// Every field the template reads must be filled in by the stage
+			expected := map[string]string{
+				"ClientName":     getClientNameFromProject(project),
+				"ProjectTitle":   project.Title,
+				"ContentTitle":   content.Title,
+				"TargetAudience": getTargetAudienceFromProject(project),
+				"BrandVoice":     getBrandVoiceFromProject(project),
+				"ContentGoals":   getContentGoalsFromProject(project)[0],
+			}
+			body := manager.templates[contentType][templateName].Tree.Root.String()
+			for _, match := range promptFieldPattern.FindAllStringSubmatch(body, -1) {
+				assert.Contains(t, prompt, expected[match[1]], "%s %s template reads .%s", contentType, templateName, match[1])
+			}
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package content_creation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/Ceesaxp/autonomous-content-service/src/domain/entities"
+	"github.com/Ceesaxp/autonomous-content-service/src/domain/repositories"
+)
+
+// requiredAssessmentScore is the overall score an assessment needs to pass
+const requiredAssessmentScore = 70.0
+
+// AssessingQualityChecker checks content with the quality assurance system, running the
+// checks enabled in the quality config, and stores each assessment as the content's
+// quality report
+type AssessingQualityChecker struct {
+	qa     *QualityAssuranceSystem
+	repo   repositories.QualityAssessmentRepository
+	config QualityConfig
+}
+
+// NewAssessingQualityChecker creates a quality checker backed by the quality assurance system
+func NewAssessingQualityChecker(qa *QualityAssuranceSystem, repo repositories.QualityAssessmentRepository, config QualityConfig) *AssessingQualityChecker {
+	return &AssessingQualityChecker{
+		qa:     qa,
+		repo:   repo,
+		config: config,
+	}
+}
+
+// CheckContent assesses the content and maps the assessment to the pipeline's quality scores
+func (c *AssessingQualityChecker) CheckContent(ctx context.Context, content *entities.Content, input QualityCheckInput) (QualityCheckOutput, error) {
+	checks := QualityAssessmentChecks{
+		MultiPassReview: c.config.EnableMultiPassReview,
+		FactCheck:       c.config.EnableFactCheck && input.CheckFactAccuracy,
+		Plagiarism:      c.config.EnablePlagiarismCheck && input.CheckPlagiarism,
+		Style:           c.config.EnableStyleCheck,
+	}
+	criteria := c.criteria(content.Type, input)
+
+	result, err := c.qa.PerformAssessment(ctx, QualityAssessmentRequest{
+		Content:            content,
+		ContentText:        input.Content,
+		EvaluationCriteria: criteria,
+		RequiredThreshold:  requiredAssessmentScore,
+		Checks:             &checks,
+	})
+	if err != nil {
+		return QualityCheckOutput{}, fmt.Errorf("quality assessment failed: %w", err)
+	}
+
+	report, err := json.Marshal(result)
+	if err != nil {
+		return QualityCheckOutput{}, fmt.Errorf("failed to encode quality assessment: %w", err)
+	}
+	assessment := entities.NewQualityAssessment(content, result.OverallScore, string(result.Grade), result.PassedThreshold, report)
+	if err := c.repo.Save(ctx, assessment); err != nil {
+		return QualityCheckOutput{}, fmt.Errorf("failed to save quality assessment: %w", err)
+	}
+
+	return qualityCheckOutput(result, criteria), nil
+}
+
+// criteria returns the evaluation criteria of a content type, limited to the scores the
+// quality config enables
+func (c *AssessingQualityChecker) criteria(contentType entities.ContentType, input QualityCheckInput) []EvaluationCriterion {
+	enabled := map[EvaluationCriterion]bool{
+		CriterionReadability: c.config.EnableReadabilityCheck,
+		CriterionSEO:         c.config.EnableSEOCheck && input.EvaluateSEO,
+		CriterionEngagement:  c.config.EnableEngagementCheck,
+	}
+
+	var criteria []EvaluationCriterion
+	seen := make(map[EvaluationCriterion]bool)
+	add := func(criterion EvaluationCriterion) {
+		if on, scored := enabled[criterion]; (scored && !on) || seen[criterion] {
+			return
+		}
+		seen[criterion] = true
+		criteria = append(criteria, criterion)
+	}
+
+	for _, criterion := range GetCriteriaForContentType(contentType) {
+		add(criterion)
+	}
+	// The pipeline's thresholds need every enabled score, whatever the content type evaluates
+	for _, criterion := range []EvaluationCriterion{CriterionReadability, CriterionSEO, CriterionEngagement} {
+		add(criterion)
+	}
+	return criteria
+}
+
+// qualityCheckOutput maps an assessment to the pipeline's quality scores
+func qualityCheckOutput(result *QualityAssessmentResult, criteria []EvaluationCriterion) QualityCheckOutput {
+	output := QualityCheckOutput{
+		ReadabilityScore:      result.CriteriaScores[string(CriterionReadability)],
+		SEOScore:              result.CriteriaScores[string(CriterionSEO)],
+		EngagementScore:       result.CriteriaScores[string(CriterionEngagement)],
+		SuggestionsByCategory: make(map[string][]string),
+	}
+
+	evaluated := make(map[EvaluationCriterion]bool, len(criteria))
+	for _, criterion := range criteria {
+		evaluated[criterion] = true
+	}
+	for _, criterion := range []EvaluationCriterion{CriterionReadability, CriterionSEO, CriterionEngagement} {
+		if !evaluated[criterion] {
+			output.Unchecked = append(output.Unchecked, string(criterion))
+		}
+	}
+
+	if result.Checks.Plagiarism {
+		output.PlagiarismScore = result.PlagiarismResults.OriginalityScore / 100
+	} else {
+		output.Unchecked = append(output.Unchecked, "originality")
+	}
+
+	for _, issue := range result.FactCheckResults.FactualErrors {
+		output.FactualErrors = append(output.FactualErrors, FactualError{
+			ErrorText:  issue.Claim,
+			Correction: issue.Correction,
+		})
+	}
+
+	for _, suggestion := range result.ImprovementSuggestions {
+		category := string(suggestion.Category)
+		output.SuggestionsByCategory[category] = append(output.SuggestionsByCategory[category], suggestion.Title)
+	}
+
+	return output
+}
@@ -0,0 +1,45 @@
+package content_creation
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/Ceesaxp/autonomous-content-service/src/infrastructure/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAssessingQualityChecker_StoresReportForConfiguredChecks(t *testing.T) {
+	repo := memory.NewQualityAssessmentRepository()
+	config := DefaultPipelineConfigSchema().QualityConfigs
+	config.EnableSEOCheck = false
+	config.EnablePlagiarismCheck = false
+	checker := NewAssessingQualityChecker(createMockQASystem(), repo, config)
+
+	ctx := context.Background()
+	content := createTestContent()
+	output, err := checker.CheckContent(ctx, content, QualityCheckInput{
+		Content:           content.Data,
+		CheckPlagiarism:   true,
+		CheckFactAccuracy: true,
+		EvaluateSEO:       true,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"seo", "originality"}, output.Unchecked)
+	assert.Greater(t, output.ReadabilityScore, 0.0)
+	assert.Empty(t, QualityThresholds{MinSEOScore: 90, MinPlagiarismScore: 0.9}.Failures(output))
+
+	assessment, err := repo.FindLatestByContentID(ctx, content.ContentID)
+	require.NoError(t, err)
+	assert.Equal(t, content.Version, assessment.ContentVersion)
+	assert.NotEmpty(t, assessment.Grade)
+
+	var report QualityAssessmentResult
+	require.NoError(t, json.Unmarshal(assessment.Report, &report))
+	assert.Equal(t, QualityAssessmentChecks{MultiPassReview: true, FactCheck: true, Style: true}, report.Checks)
+	assert.Equal(t, assessment.Grade, string(report.Grade))
+	assert.NotContains(t, report.CriteriaScores, "seo")
+	assert.Empty(t, report.PlagiarismResults.Matches)
+}
@@ -49,6 +49,21 @@ func NewQualityAssuranceSystem(
 	}
 }
 
+// ImprovementEngine returns the engine the system generates improvement suggestions with
+func (qa *QualityAssuranceSystem) ImprovementEngine() *ImprovementEngine {
+	return qa.improvementEngine
+}
+
+// RevisionTracker returns the tracker the system records assessments in
+func (qa *QualityAssuranceSystem) RevisionTracker() *RevisionTracker {
+	return qa.revisionTracker
+}
+
+// UseSimilarityIndex makes plagiarism checks look for passages of earlier content in the index
+func (qa *QualityAssuranceSystem) UseSimilarityIndex(index *SimilarityIndex) {
+	qa.plagiarismDetector.UseSimilarityIndex(index)
+}
+
 // QualityAssessmentRequest contains the parameters for quality assessment
 type QualityAssessmentRequest struct {
 	Content            *entities.Content
@@ -58,12 +73,30 @@ type QualityAssessmentRequest struct {
 	MaxRevisions       int
 	TargetAudience     string
 	IndustryBenchmark  string
+	Checks             *QualityAssessmentChecks // nil runs every check
+}
+
+// QualityAssessmentChecks selects the optional checks of an assessment
+type QualityAssessmentChecks struct {
+	MultiPassReview bool `json:"multiPassReview"`
+	FactCheck       bool `json:"factCheck"`
+	Plagiarism      bool `json:"plagiarism"`
+	Style           bool `json:"style"`
+}
+
+// QualityFinding is a problem found by one of the checks of an assessment
+type QualityFinding struct {
+	Check       string `json:"check"` // evaluation, factCheck, plagiarism or style
+	Severity    string `json:"severity,omitempty"`
+	Description string `json:"description"`
 }
 
 // QualityAssessmentResult contains the complete quality assessment results
 type QualityAssessmentResult struct {
 	OverallScore           float64                 `json:"overallScore"`
+	Grade                  QualityGrade            `json:"grade"`
 	PassedThreshold        bool                    `json:"passedThreshold"`
+	Checks                 QualityAssessmentChecks `json:"checks"`
 	CriteriaScores         map[string]float64      `json:"criteriaScores"`
 	DetailedEvaluation     DetailedEvaluation      `json:"detailedEvaluation"`
 	MultiPassResults       []PassResult            `json:"multiPassResults"`
@@ -74,32 +107,48 @@ type QualityAssessmentResult struct {
 	RevisionHistory        []RevisionRecord        `json:"revisionHistory"`
 	BenchmarkComparison    BenchmarkComparison     `json:"benchmarkComparison"`
 	RecommendedActions     []string                `json:"recommendedActions"`
+	Findings               []QualityFinding        `json:"findings"`
 }
 
 // PerformAssessment conducts a comprehensive quality assessment
 func (qa *QualityAssuranceSystem) PerformAssessment(ctx context.Context, request QualityAssessmentRequest) (*QualityAssessmentResult, error) {
+	if request.Content == nil {
+		return nil, fmt.Errorf("content is required for quality assessment")
+	}
+
+	checks := QualityAssessmentChecks{MultiPassReview: true, FactCheck: true, Plagiarism: true, Style: true}
+	if request.Checks != nil {
+		checks = *request.Checks
+	}
+
 	result := &QualityAssessmentResult{
+		Checks:                 checks,
 		CriteriaScores:         make(map[string]float64),
 		MultiPassResults:       []PassResult{},
 		ImprovementSuggestions: []ImprovementSuggestion{},
 		RevisionHistory:        []RevisionRecord{},
 		RecommendedActions:     []string{},
+		Findings:               []QualityFinding{},
 	}
 
 	// Track this assessment
 	revisionID := qa.revisionTracker.StartRevision(request.Content.ContentID, request.ContentText)
 
 	// 1. Perform multi-pass review
-	multiPassResults, err := qa.multiPassReviewer.PerformMultiPassReview(ctx, MultiPassRequest{
-		Content:        request.ContentText,
-		ContentType:    request.Content.Type,
-		TargetAudience: request.TargetAudience,
-		Criteria:       request.EvaluationCriteria,
-	})
-	if err != nil {
-		return nil, fmt.Errorf("multi-pass review failed: %w", err)
+	var multiPassResults *MultiPassResult
+	if checks.MultiPassReview {
+		var err error
+		multiPassResults, err = qa.multiPassReviewer.PerformMultiPassReview(ctx, MultiPassRequest{
+			Content:        request.ContentText,
+			ContentType:    request.Content.Type,
+			TargetAudience: request.TargetAudience,
+			Criteria:       request.EvaluationCriteria,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("multi-pass review failed: %w", err)
+		}
+		result.MultiPassResults = multiPassResults.Passes
 	}
-	result.MultiPassResults = multiPassResults.Passes
 
 	// 2. Evaluate content against criteria
 	evaluation, err := qa.evaluationEngine.EvaluateContent(ctx, EvaluationRequest{
@@ -119,46 +168,68 @@ func (qa *QualityAssuranceSystem) PerformAssessment(ctx context.Context, request
 		result.CriteriaScores[string(criterion)] = score
 	}
 
+	// A skipped check counts at the criteria score, so it neither raises nor lowers the overall score
+	criteriaScore := qa.scoringEngine.calculateWeightedCriteriaScore(result.CriteriaScores)
+	scores := OverallScoreRequest{
+		CriteriaScores:  result.CriteriaScores,
+		FactCheckScore:  criteriaScore,
+		PlagiarismScore: criteriaScore,
+		StyleScore:      criteriaScore,
+		MultiPassScore:  criteriaScore,
+	}
+	if multiPassResults != nil {
+		scores.MultiPassScore = multiPassResults.OverallScore
+	}
+
 	// 4. Fact-check content
-	factCheckResult, err := qa.factChecker.CheckFacts(ctx, FactCheckRequest{
-		Content:     request.ContentText,
-		ContentType: request.Content.Type,
-		Sources:     []string{}, // Will be automatically determined
-	})
-	if err != nil {
-		return nil, fmt.Errorf("fact checking failed: %w", err)
+	var factCheckResult *FactCheckResult
+	if checks.FactCheck {
+		factCheckResult, err = qa.factChecker.CheckFacts(ctx, FactCheckRequest{
+			Content:     request.ContentText,
+			ContentType: request.Content.Type,
+			Sources:     []string{}, // Will be automatically determined
+		})
+		if err != nil {
+			return nil, fmt.Errorf("fact checking failed: %w", err)
+		}
+		result.FactCheckResults = *factCheckResult
+		scores.FactCheckScore = factCheckResult.OverallScore
 	}
-	result.FactCheckResults = *factCheckResult
 
 	// 5. Check for plagiarism
-	plagiarismResult, err := qa.plagiarismDetector.CheckPlagiarism(ctx, PlagiarismCheckRequest{
-		Content:     request.ContentText,
-		ContentType: request.Content.Type,
-	})
-	if err != nil {
-		return nil, fmt.Errorf("plagiarism detection failed: %w", err)
+	if checks.Plagiarism {
+		plagiarismResult, err := qa.plagiarismDetector.CheckPlagiarism(ctx, PlagiarismCheckRequest{
+			Content:       request.ContentText,
+			Subject:       request.Content,
+			ContentType:   request.Content.Type,
+			CheckWeb:      true,
+			CheckDatabase: true,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("plagiarism detection failed: %w", err)
+		}
+		result.PlagiarismResults = *plagiarismResult
+		scores.PlagiarismScore = plagiarismResult.OriginalityScore
 	}
-	result.PlagiarismResults = *plagiarismResult
 
 	// 6. Analyze style consistency
-	styleResult, err := qa.styleChecker.AnalyzeStyle(ctx, StyleCheckRequest{
-		Content:        request.ContentText,
-		ContentType:    request.Content.Type,
-		TargetAudience: request.TargetAudience,
-	})
-	if err != nil {
-		return nil, fmt.Errorf("style analysis failed: %w", err)
+	var styleResult *StyleAnalysisResult
+	if checks.Style {
+		styleResult, err = qa.styleChecker.AnalyzeStyle(ctx, StyleCheckRequest{
+			Content:        request.ContentText,
+			ContentType:    request.Content.Type,
+			TargetAudience: request.TargetAudience,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("style analysis failed: %w", err)
+		}
+		result.StyleAnalysis = *styleResult
+		scores.StyleScore = styleResult.OverallScore
 	}
-	result.StyleAnalysis = *styleResult
 
 	// 7. Calculate overall score
-	result.OverallScore = qa.scoringEngine.CalculateOverallScore(OverallScoreRequest{
-		CriteriaScores:  result.CriteriaScores,
-		FactCheckScore:  factCheckResult.OverallScore,
-		PlagiarismScore: plagiarismResult.OriginalityScore,
-		StyleScore:      styleResult.OverallScore,
-		MultiPassScore:  multiPassResults.OverallScore,
-	})
+	result.OverallScore = qa.scoringEngine.CalculateOverallScore(scores)
+	result.Grade = qa.scoringEngine.determineQualityGrade(result.OverallScore)
 
 	// 8. Check if threshold is met
 	result.PassedThreshold = result.OverallScore >= request.RequiredThreshold
@@ -196,6 +267,7 @@ func (qa *QualityAssuranceSystem) PerformAssessment(ctx context.Context, request
 
 	// 11. Generate recommended actions
 	result.RecommendedActions = qa.generateRecommendedActions(result)
+	result.Findings = qa.collectFindings(result)
 
 	// 12. Record revision
 	qa.revisionTracker.CompleteRevision(revisionID, RevisionResult{
@@ -223,12 +295,12 @@ func (qa *QualityAssuranceSystem) generateRecommendedActions(result *QualityAsse
 	}
 
 	// Plagiarism issues
-	if result.PlagiarismResults.OriginalityScore < 0.8 {
+	if result.Checks.Plagiarism && result.PlagiarismResults.OriginalityScore < 0.8 {
 		actions = append(actions, "Rewrite content to improve originality - potential plagiarism detected")
 	}
 
 	// Style issues
-	if result.StyleAnalysis.ConsistencyScore < 0.7 {
+	if result.Checks.Style && result.StyleAnalysis.ConsistencyScore < 0.7 {
 		actions = append(actions, "Improve style consistency throughout the content")
 	}
 
@@ -247,6 +319,41 @@ func (qa *QualityAssuranceSystem) generateRecommendedActions(result *QualityAsse
 	return actions
 }
 
+// collectFindings gathers the problems reported by the checks of an assessment
+func (qa *QualityAssuranceSystem) collectFindings(result *QualityAssessmentResult) []QualityFinding {
+	findings := []QualityFinding{}
+
+	for _, weakness := range result.DetailedEvaluation.Weaknesses {
+		findings = append(findings, QualityFinding{Check: "evaluation", Description: weakness})
+	}
+
+	for _, issue := range result.FactCheckResults.FactualErrors {
+		findings = append(findings, QualityFinding{
+			Check:       "factCheck",
+			Severity:    string(issue.Severity),
+			Description: fmt.Sprintf("%s: %s", issue.Claim, issue.Issue),
+		})
+	}
+
+	for _, match := range result.PlagiarismResults.Matches {
+		findings = append(findings, QualityFinding{
+			Check:       "plagiarism",
+			Severity:    string(match.RiskLevel),
+			Description: fmt.Sprintf("%.0f%% similar to %s", match.SimilarityScore*100, match.Source.URL),
+		})
+	}
+
+	for _, issue := range result.StyleAnalysis.StyleIssues {
+		findings = append(findings, QualityFinding{
+			Check:       "style",
+			Severity:    string(issue.Severity),
+			Description: issue.Description,
+		})
+	}
+
+	return findings
+}
+
 // EvaluationCriterion defines different content evaluation criteria
 type EvaluationCriterion string
 
@@ -9,44 +9,6 @@ import (
 	"github.com/google/uuid"
 )
 
-// Mock implementations for testing
-
-type MockQALLMClient struct{}
-
-func (m *MockQALLMClient) Generate(ctx context.Context, prompt interface{}) (string, error) {
-	// Return mock responses based on prompt type
-	return `{
-		"score": 75,
-		"explanation": "Content demonstrates good quality with room for improvement",
-		"evidence": ["Clear structure", "Engaging tone"],
-		"suggestions": ["Improve readability", "Add more examples"],
-		"confidence": 0.8
-	}`, nil
-}
-
-type MockSearchService struct{}
-
-func (m *MockSearchService) Search(ctx context.Context, query string) ([]SearchResult, error) {
-	return []SearchResult{
-		{
-			Title:     "Sample Article",
-			URL:       "https://example.com/article",
-			Snippet:   "This is a sample search result",
-			Relevance: 8,
-		},
-	}, nil
-}
-
-func (m *MockSearchService) FetchContent(ctx context.Context, url string) (string, error) {
-	return "Sample content from the URL", nil
-}
-
-type MockPlagiarismAPI struct{}
-
-func (m *MockPlagiarismAPI) CheckPlagiarism(ctx context.Context, content string) (float64, []PlagiarismDetail, error) {
-	return 0.95, []PlagiarismDetail{}, nil
-}
-
 // Test fixtures
 
 func createTestContent() *entities.Content {
@@ -26,6 +26,7 @@ type QualityCheckOutput struct {
 	FactualErrors      []FactualError        `json:"factualErrors,omitempty"`
 	SuggestionsByCategory map[string][]string `json:"suggestionsByCategory,omitempty"`
 	Keywords           []string              `json:"keywords,omitempty"`
+	Unchecked          []string              `json:"unchecked,omitempty"` // scores not assessed, e.g. seo when the SEO check is off
 }
 
 // FactualError represents a factual error in content
@@ -154,7 +155,7 @@ func (q *LLMQualityChecker) analyzeEngagement(ctx context.Context, content strin
 	)
 
 	// Use LLM to analyze engagement
-	response, err := q.LLMClient.Generate(ctx, prompt)
+	response, err := generateText(ctx, q.LLMClient, prompt)
 	if err != nil {
 		return 0, nil, fmt.Errorf("engagement analysis generation failed: %w", err)
 	}
@@ -248,7 +249,7 @@ func (q *LLMQualityChecker) checkFactualAccuracy(ctx context.Context, content st
 	)
 
 	// Use LLM to fact-check content
-	response, err := q.LLMClient.Generate(ctx, prompt)
+	response, err := generateText(ctx, q.LLMClient, prompt)
 	if err != nil {
 		return nil, fmt.Errorf("fact-checking failed: %w", err)
 	}
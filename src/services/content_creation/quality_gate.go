@@ -0,0 +1,265 @@
+package content_creation
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Ceesaxp/autonomous-content-service/src/domain/entities"
+)
+
+const (
+	defaultMaxRevisions           = 2
+	defaultSuggestionsPerRevision = 3
+)
+
+// QualityGate holds edited content to the quality thresholds of its content type. Content
+// below a threshold is revised with the improvement engine's top suggestions and scored
+// again; content that still fails is escalated to human review.
+type QualityGate struct {
+	llmClient              LLMClient
+	improvementEngine      *ImprovementEngine
+	revisionTracker        *RevisionTracker
+	schema                 *PipelineConfigSchema
+	maxRevisions           int
+	suggestionsPerRevision int
+}
+
+// NewQualityGate creates a quality gate enforcing the thresholds of the schema
+func NewQualityGate(llmClient LLMClient, improvementEngine *ImprovementEngine, revisionTracker *RevisionTracker, schema *PipelineConfigSchema) *QualityGate {
+	maxRevisions := defaultMaxRevisions
+	if schema.QualityConfigs.MaxRevisions != nil {
+		maxRevisions = *schema.QualityConfigs.MaxRevisions
+	}
+	suggestions := schema.QualityConfigs.SuggestionsPerRevision
+	if suggestions <= 0 {
+		suggestions = defaultSuggestionsPerRevision
+	}
+
+	return &QualityGate{
+		llmClient:              llmClient,
+		improvementEngine:      improvementEngine,
+		revisionTracker:        revisionTracker,
+		schema:                 schema,
+		maxRevisions:           maxRevisions,
+		suggestionsPerRevision: suggestions,
+	}
+}
+
+// QualityGateResult is the outcome of the quality gate, stored in the content metadata
+type QualityGateResult struct {
+	Passed      bool      `json:"passed"`
+	Revisions   int       `json:"revisions"`
+	Failures    []string  `json:"failures,omitempty"`    // thresholds still missed
+	ScoresStale bool      `json:"scoresStale,omitempty"` // the last revision could not be scored, so the scores predate it
+	CheckedAt   time.Time `json:"checkedAt"`
+}
+
+// UseQualityGate makes the pipeline enforce quality thresholds after editing
+func (p *ContentPipeline) UseQualityGate(gate *QualityGate) {
+	p.qualityGate = gate
+}
+
+// enforceQualityThresholds revises content whose scores miss the thresholds of its content
+// type, recording each round in the revision tracker and the outcome in the content metadata
+func (p *ContentPipeline) enforceQualityThresholds(ctx context.Context, content *entities.Content, scores QualityCheckOutput) {
+	delete(content.Metadata, "qualityGate")
+	gate := p.qualityGate
+	if gate == nil {
+		return
+	}
+	thresholds, ok := gate.schema.GetQualityThresholds(content.Type)
+	if !ok {
+		return
+	}
+
+	failures := thresholds.Failures(scores)
+	revisions := 0
+	stale := false
+	for revisions < gate.maxRevisions && len(failures) > 0 {
+		revised, applied := gate.revise(ctx, content, scores, failures)
+		if applied == 0 {
+			break
+		}
+		revisions++
+
+		rescored, err := p.qualityChecker.CheckContent(ctx, content, p.qualityCheckInput(content.Data))
+		if err != nil {
+			// The revision stays on the content, so its scores are those of the previous text
+			fmt.Printf("Warning: Quality check of revision %d encountered errors: %v\n", revisions, err)
+			gate.revisionTracker.CompleteRevision(revised, RevisionResult{Score: scores.OverallScore(), Improvements: applied})
+			stale = true
+			break
+		}
+
+		scores = rescored
+		failures = thresholds.Failures(scores)
+		gate.revisionTracker.AddQualityCheckPoint(revised, scores.OverallScore(), scores.scoreMap(), CheckFinal)
+		gate.revisionTracker.CompleteRevision(revised, RevisionResult{
+			Score:           scores.OverallScore(),
+			PassedThreshold: len(failures) == 0,
+			Improvements:    applied,
+		})
+		p.storeQualityScores(content, scores)
+	}
+
+	content.UpdateMetadata("qualityGate", QualityGateResult{
+		Passed:      len(failures) == 0,
+		Revisions:   revisions,
+		Failures:    failures,
+		ScoresStale: stale,
+		CheckedAt:   time.Now(),
+	})
+}
+
+// revise applies the top improvement suggestions for the failed thresholds to the content.
+// It returns the ID of the tracked revision and the number of suggestions applied, which
+// is zero when no revision could be made.
+func (g *QualityGate) revise(ctx context.Context, content *entities.Content, scores QualityCheckOutput, failures []string) (string, int) {
+	improvements, err := g.improvementEngine.GenerateImprovements(ctx, ImprovementRequest{
+		Content:        content.Data,
+		ContentType:    content.Type,
+		TargetScore:    100,
+		CurrentScore:   scores.OverallScore(),
+		MaxSuggestions: g.suggestionsPerRevision,
+		Focus:          improvementFocus(failures),
+	})
+	if err != nil {
+		fmt.Printf("Warning: Improvement generation failed: %v\n", err)
+		return "", 0
+	}
+	if len(improvements.Suggestions) == 0 {
+		return "", 0
+	}
+
+	revisionID := g.revisionTracker.StartRevision(content.ContentID, content.Data)
+	g.revisionTracker.AddQualityCheckPoint(revisionID, scores.OverallScore(), scores.scoreMap(), CheckInitial)
+
+	changeIDs := make([]string, len(improvements.Suggestions))
+	for i, suggestion := range improvements.Suggestions {
+		changeIDs[i] = g.revisionTracker.AddPendingChange(revisionID, changeTypeFor(suggestion.Category),
+			suggestion.Title, suggestion.Implementation, suggestion.ExpectedGain)
+	}
+
+	// Revisions are rewrites of the content, so they never come from the cache
+	revised, err := generateText(WithoutLLMCache(ctx), g.llmClient, revisionPrompt(content, improvements.Suggestions, failures))
+	if err != nil {
+		fmt.Printf("Warning: Applying improvements failed: %v\n", err)
+		g.revisionTracker.CompleteRevision(revisionID, RevisionResult{Score: scores.OverallScore()})
+		return "", 0
+	}
+
+	before := *content
+	if err := content.UpdateContent(strings.TrimSpace(revised), "quality-revision"); err != nil {
+		fmt.Printf("Warning: Discarding revision: %v\n", err)
+		*content = before
+		g.revisionTracker.CompleteRevision(revisionID, RevisionResult{Score: scores.OverallScore()})
+		return "", 0
+	}
+
+	for _, changeID := range changeIDs {
+		g.revisionTracker.ApplyChange(revisionID, changeID, before.Data, content.Data, 0)
+	}
+	return revisionID, len(changeIDs)
+}
+
+// Failures lists the thresholds the scores miss. A zero threshold, or one for a score that
+// was not assessed, is not enforced.
+func (t QualityThresholds) Failures(scores QualityCheckOutput) []string {
+	unchecked := make(map[string]bool, len(scores.Unchecked))
+	for _, name := range scores.Unchecked {
+		unchecked[name] = true
+	}
+
+	var failures []string
+	check := func(name string, score, minimum float64) {
+		if minimum > 0 && !unchecked[name] && score < minimum {
+			failures = append(failures, fmt.Sprintf("%s %.2f below %.2f", name, score, minimum))
+		}
+	}
+	check("readability", scores.ReadabilityScore, t.MinReadabilityScore)
+	check("seo", scores.SEOScore, t.MinSEOScore)
+	check("engagement", scores.EngagementScore, t.MinEngagementScore)
+	check("originality", scores.PlagiarismScore, t.MinPlagiarismScore)
+	return failures
+}
+
+// OverallScore averages the readability, SEO and engagement scores
+func (o QualityCheckOutput) OverallScore() float64 {
+	return (o.ReadabilityScore + o.SEOScore + o.EngagementScore) / 3
+}
+
+func (o QualityCheckOutput) scoreMap() map[string]float64 {
+	return map[string]float64{
+		"readability": o.ReadabilityScore,
+		"seo":         o.SEOScore,
+		"engagement":  o.EngagementScore,
+		"originality": o.PlagiarismScore,
+	}
+}
+
+// improvementFocus maps failed thresholds to the improvement engine's focus areas
+func improvementFocus(failures []string) []ImprovementFocus {
+	focusByScore := map[string]ImprovementFocus{
+		"readability": FocusReadability,
+		"seo":         FocusOptimization,
+		"engagement":  FocusEngagement,
+		"originality": FocusStyle, // rewording in the brand's own voice raises originality
+	}
+
+	var focus []ImprovementFocus
+	for _, failure := range failures {
+		score, _, _ := strings.Cut(failure, " ")
+		focus = append(focus, focusByScore[score])
+	}
+	return focus
+}
+
+// changeTypeFor maps an improvement category to the revision tracker's change types
+func changeTypeFor(category ImprovementCategory) ChangeType {
+	switch category {
+	case CategoryStructure:
+		return ChangeStructural
+	case CategoryLanguage, CategoryAccessibility:
+		return ChangeLanguage
+	case CategorySEO, CategoryOptimization, CategoryTechnical:
+		return ChangeOptimization
+	default:
+		return ChangeContent
+	}
+}
+
+func revisionPrompt(content *entities.Content, suggestions []ImprovementSuggestion, failures []string) string {
+	var prompt strings.Builder
+	fmt.Fprintf(&prompt, "Revise the following %s titled %q. It does not yet meet these quality thresholds: %s.\n\n",
+		content.Type, content.Title, strings.Join(failures, "; "))
+	prompt.WriteString("Apply these improvements:\n")
+	for i, suggestion := range suggestions {
+		fmt.Fprintf(&prompt, "%d. %s: %s\n", i+1, suggestion.Title, suggestion.Description)
+		if suggestion.Implementation != "" {
+			fmt.Fprintf(&prompt, "   How: %s\n", suggestion.Implementation)
+		}
+	}
+	prompt.WriteString("\nKeep the facts, structure and voice unless an improvement calls for a change. ")
+	if citationMarkerPattern.MatchString(content.Data) {
+		prompt.WriteString(strings.TrimSpace(keepCitationsInstruction) + " ")
+	}
+	prompt.WriteString("Return only the revised content.\n\nContent:\n")
+	prompt.WriteString(content.Data)
+	return prompt.String()
+}
+
+// qualityGateFailed reports whether the content missed its quality thresholds after all
+// revisions. Metadata loaded from storage holds the gate result as a decoded JSON object.
+func qualityGateFailed(content *entities.Content) bool {
+	switch result := content.Metadata["qualityGate"].(type) {
+	case QualityGateResult:
+		return !result.Passed
+	case map[string]interface{}:
+		passed, _ := result["passed"].(bool)
+		return !passed
+	default:
+		return false
+	}
+}
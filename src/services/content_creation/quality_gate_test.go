@@ -0,0 +1,145 @@
+package content_creation
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Ceesaxp/autonomous-content-service/src/domain/entities"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const gateSuggestions = `{"suggestions": [
+	{"title": "Shorter sentences", "description": "Split long sentences", "implementation": "Keep sentences under 20 words", "expectedGain": 12, "effort": "low"},
+	{"title": "Stronger hook", "description": "Open with a question", "implementation": "Rewrite the first line", "expectedGain": 9, "effort": "low"}
+]}`
+
+func promptContaining(text string) interface{} {
+	return mock.MatchedBy(func(request LLMRequest) bool {
+		return strings.Contains(request.LastUserMessage(), text)
+	})
+}
+
+// runGatedSocialPost runs a social post through a pipeline with a quality gate, with the
+// quality checker returning scores in turn and then checkErr, when set
+func runGatedSocialPost(t *testing.T, schema *PipelineConfigSchema, checkErr error, scores ...QualityCheckOutput) (*entities.Content, *RevisionTracker, *MockLLMClient) {
+	t.Helper()
+	repos := newTestRepositories()
+	mockLLMClient := new(MockLLMClient)
+	mockContextManager := new(MockContextManager)
+	mockResearcher := new(MockResearcher)
+	mockQualityChecker := new(MockQualityChecker)
+
+	pipeline := repos.newPipeline(mockLLMClient, mockContextManager, mockResearcher, mockQualityChecker, PipelineConfig{
+		MaxRetries:          1,
+		StageTimeoutSeconds: 5,
+	})
+	pipeline.UseSchema(schema)
+	tracker := NewRevisionTracker()
+	pipeline.UseQualityGate(NewQualityGate(mockLLMClient, NewImprovementEngine(mockLLMClient, NewEvaluationEngine(mockLLMClient)), tracker, schema))
+
+	projectID := uuid.New()
+	repos.seedProject(t, projectID, entities.ContentTypeSocialPost)
+
+	mockContextManager.On("SwitchContext", mock.Anything, projectID).Return(nil)
+	mockContextManager.On("AddEntry", mock.Anything, projectID, mock.Anything).Return(nil)
+	mockResearcher.On("Research", mock.Anything, mock.Anything, mock.Anything).Return(&ResearchOutput{Summary: "Research"}, nil)
+	for _, score := range scores {
+		mockQualityChecker.On("CheckContent", mock.Anything, mock.Anything, mock.Anything).Return(score, nil).Once()
+	}
+	if checkErr != nil {
+		mockQualityChecker.On("CheckContent", mock.Anything, mock.Anything, mock.Anything).Return(QualityCheckOutput{}, checkErr).Once()
+	}
+
+	mockLLMClient.On("Generate", mock.Anything, promptContaining("Generate improvement suggestions")).Return(gateSuggestions, nil)
+	mockLLMClient.On("Generate", mock.Anything, promptContaining("Revise the following")).Return(strings.TrimSpace(strings.Repeat("Revised ", 80)), nil)
+	mockLLMClient.On("Generate", mock.Anything, mock.Anything).Return(strings.TrimSpace(strings.Repeat("Post ", 80)), nil)
+
+	content, err := pipeline.CreateContent(context.Background(), projectID, "Launch Post", entities.ContentTypeSocialPost)
+	require.NoError(t, err)
+	return content, tracker, mockLLMClient
+}
+
+func TestQualityGate_RevisesUntilThresholdsPass(t *testing.T) {
+	low := QualityCheckOutput{ReadabilityScore: 60, SEOScore: 70, EngagementScore: 90, PlagiarismScore: 0.95}
+	passing := QualityCheckOutput{ReadabilityScore: 85, SEOScore: 70, EngagementScore: 90, PlagiarismScore: 0.95}
+
+	content, tracker, llm := runGatedSocialPost(t, DefaultPipelineConfigSchema(), nil, low, passing)
+
+	assert.Equal(t, entities.ContentStatusReview, content.Status)
+	assert.True(t, strings.HasPrefix(content.Data, "Revised"))
+	assert.Equal(t, 85.0, content.Statistics.ReadabilityScore)
+	assert.Equal(t, QualityGateResult{Passed: true, Revisions: 1}, withoutCheckTime(content.Metadata["qualityGate"]))
+
+	history := tracker.GetRevisionHistory(content.ContentID)
+	require.Len(t, history, 1)
+	assert.Len(t, history[0].ChangesApplied, 2)
+	assert.Greater(t, history[0].ScoreImprovement, 0.0)
+	llm.AssertNumberOfCalls(t, "Generate", 4) // draft, edit, suggestions, revision
+}
+
+func TestQualityGate_EscalatesAfterMaxRevisions(t *testing.T) {
+	low := QualityCheckOutput{ReadabilityScore: 60, SEOScore: 70, EngagementScore: 60, PlagiarismScore: 0.95}
+
+	content, tracker, _ := runGatedSocialPost(t, DefaultPipelineConfigSchema(), nil, low, low, low)
+
+	assert.Equal(t, entities.ContentStatusHumanReview, content.Status)
+	result := withoutCheckTime(content.Metadata["qualityGate"])
+	assert.False(t, result.Passed)
+	assert.Equal(t, 2, result.Revisions)
+	assert.Equal(t, []string{"readability 60.00 below 80.00", "engagement 60.00 below 85.00"}, result.Failures)
+	assert.Len(t, tracker.GetRevisionHistory(content.ContentID), 2)
+}
+
+func TestDefaultPipelineConfigSchema_HasQualityThresholdsForEveryContentType(t *testing.T) {
+	schema := DefaultPipelineConfigSchema()
+	for _, contentType := range entities.AllContentTypes() {
+		thresholds, ok := schema.GetQualityThresholds(contentType)
+		if assert.True(t, ok, "no quality thresholds for %s", contentType) {
+			assert.NotEqual(t, QualityThresholds{}, thresholds, "empty quality thresholds for %s", contentType)
+		}
+	}
+}
+
+func TestQualityGate_ZeroMaxRevisionsEscalatesAtOnce(t *testing.T) {
+	low := QualityCheckOutput{ReadabilityScore: 60, SEOScore: 70, EngagementScore: 90, PlagiarismScore: 0.95}
+	schema := DefaultPipelineConfigSchema()
+	noRevisions := 0
+	schema.QualityConfigs.MaxRevisions = &noRevisions
+	require.NoError(t, schema.Validate())
+
+	content, tracker, llm := runGatedSocialPost(t, schema, nil, low)
+
+	assert.Equal(t, entities.ContentStatusHumanReview, content.Status)
+	result := withoutCheckTime(content.Metadata["qualityGate"])
+	assert.False(t, result.Passed)
+	assert.Zero(t, result.Revisions)
+	assert.Empty(t, tracker.GetRevisionHistory(content.ContentID))
+	llm.AssertNumberOfCalls(t, "Generate", 2) // draft, edit
+}
+
+func TestQualityGate_CountsRevisionWhoseRescoreFailed(t *testing.T) {
+	low := QualityCheckOutput{ReadabilityScore: 60, SEOScore: 70, EngagementScore: 90, PlagiarismScore: 0.95}
+
+	content, tracker, _ := runGatedSocialPost(t, DefaultPipelineConfigSchema(), errors.New("checker unavailable"), low)
+
+	assert.Equal(t, entities.ContentStatusHumanReview, content.Status)
+	assert.True(t, strings.HasPrefix(content.Data, "Revised"))
+	result := withoutCheckTime(content.Metadata["qualityGate"])
+	assert.False(t, result.Passed)
+	assert.Equal(t, 1, result.Revisions)
+	assert.True(t, result.ScoresStale)
+	assert.Equal(t, 60.0, content.Statistics.ReadabilityScore)
+	assert.Len(t, tracker.GetRevisionHistory(content.ContentID), 1)
+}
+
+func withoutCheckTime(value interface{}) QualityGateResult {
+	result, _ := value.(QualityGateResult)
+	result.CheckedAt = time.Time{}
+	return result
+}
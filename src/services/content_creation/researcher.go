@@ -65,6 +65,7 @@ type ResearchRequirements struct {
 type LLMResearcher struct {
 	llmClient     LLMClient
 	searchService SearchService
+	index         *SimilarityIndex // nil when fetched sources are not indexed
 }
 
 // NewLLMResearcher creates a new LLM-based researcher
@@ -75,6 +76,12 @@ func NewLLMResearcher(llmClient LLMClient, searchService SearchService) *LLMRese
 	}
 }
 
+// UseSimilarityIndex makes the researcher add every fetched source page to the index, so
+// that content can be checked for passages copied from its research
+func (r *LLMResearcher) UseSimilarityIndex(index *SimilarityIndex) {
+	r.index = index
+}
+
 // Research conducts comprehensive research for content creation
 func (r *LLMResearcher) Research(ctx context.Context, content *entities.Content, requirements ResearchRequirements) (*ResearchOutput, error) {
 	output := &ResearchOutput{
@@ -175,7 +182,7 @@ Format your response as a structured list with clear sections for each topic.
 		strings.Join(requirements.Topics, ", "),
 	)
 
-	response, err := r.llmClient.Generate(ctx, prompt)
+	response, err := generateText(ctx, r.llmClient, prompt)
 	if err != nil {
 		return nil, fmt.Errorf("LLM topic generation failed: %w", err)
 	}
@@ -268,10 +275,19 @@ func (r *LLMResearcher) gatherSources(ctx context.Context, topic ResearchTopic,
 			}
 
 			// Fetch content for better analysis
-			content, err := r.searchService.FetchContent(ctx, result.URL)
+			content, published, err := r.fetchSource(ctx, result.URL)
 			if err != nil {
 				fmt.Printf("Warning: Failed to fetch content from '%s': %v\n", result.URL, err)
 				content = result.Snippet // Fallback to snippet
+			} else if r.index != nil {
+				if err := r.index.IndexSource(ctx, result.URL, result.Title, content); err != nil {
+					fmt.Printf("Warning: Failed to index source '%s': %v\n", result.URL, err)
+				}
+			}
+
+			// Sources without a publish date on the page are dated when they were read
+			if published.IsZero() {
+				published = time.Now()
 			}
 
 			source := ResearchSource{
@@ -281,7 +297,7 @@ func (r *LLMResearcher) gatherSources(ctx context.Context, topic ResearchTopic,
 				Content:     content,
 				Credibility: 0.5, // Will be evaluated later
 				Relevance:   float64(result.Relevance) / 10.0,
-				LastUpdated: time.Now(), // We don't have this info from search
+				LastUpdated: published,
 			}
 
 			sources = append(sources, source)
@@ -291,6 +307,20 @@ func (r *LLMResearcher) gatherSources(ctx context.Context, topic ResearchTopic,
 	return sources, nil
 }
 
+// fetchSource retrieves the content of a source and, when the search service extracts it,
+// the date the page was published
+func (r *LLMResearcher) fetchSource(ctx context.Context, url string) (string, time.Time, error) {
+	if pages, ok := r.searchService.(PageSearchService); ok {
+		page, err := pages.FetchPage(ctx, url)
+		if err != nil {
+			return "", time.Time{}, err
+		}
+		return page.Document(), page.PublishedAt, nil
+	}
+	content, err := r.searchService.FetchContent(ctx, url)
+	return content, time.Time{}, err
+}
+
 // EvaluateSourceCredibility assesses the credibility of a source using LLM
 func (r *LLMResearcher) EvaluateSourceCredibility(ctx context.Context, source ResearchSource) (float64, error) {
 	prompt := fmt.Sprintf(`
@@ -314,7 +344,7 @@ Respond with only a decimal number between 0.0 and 1.0.
 		truncateString(source.Content, 500),
 	)
 
-	response, err := r.llmClient.Generate(ctx, prompt)
+	response, err := generateText(ctx, r.llmClient, prompt)
 	if err != nil {
 		return 0.5, fmt.Errorf("LLM credibility evaluation failed: %w", err)
 	}
@@ -370,7 +400,7 @@ Research Sources:
 		combinedContent,
 	)
 
-	response, err := r.llmClient.Generate(ctx, prompt)
+	response, err := generateText(ctx, r.llmClient, prompt)
 	if err != nil {
 		return []string{}, fmt.Errorf("LLM fact extraction failed: %w", err)
 	}
@@ -395,7 +425,7 @@ Write a 2-3 sentence summary highlighting the main research findings and their r
 		strings.Join(research.KeyFacts, "\n"),
 	)
 
-	response, err := r.llmClient.Generate(ctx, prompt)
+	response, err := generateText(ctx, r.llmClient, prompt)
 	if err != nil {
 		return "", fmt.Errorf("LLM summary generation failed: %w", err)
 	}
@@ -0,0 +1,306 @@
+package content_creation
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// BM25 ranking parameters
+const (
+	bm25K1 = 1.2
+	bm25B  = 0.75
+
+	maxCorpusSnippetLength = 300
+)
+
+// LocalCorpusSearchService searches a directory of documents with BM25 ranking, so that
+// research and fact checking can run without network access. Markdown and text documents
+// may start with a front matter block giving their title, url and date; documents without
+// a url are addressed by file:// URLs. HTML documents are reduced to their main content.
+type LocalCorpusSearchService struct {
+	ResultCount int
+
+	documents []*corpusDocument
+	byURL     map[string]*corpusDocument
+	postings  map[string][]corpusPosting // term to the documents containing it
+	avgLength float64
+}
+
+type corpusDocument struct {
+	page   *FetchedPage
+	length int // terms in the document
+}
+
+type corpusPosting struct {
+	document  int
+	frequency int
+}
+
+// NewLocalCorpusSearchService indexes the .md, .markdown, .txt, .html and .htm files under dir
+func NewLocalCorpusSearchService(dir string) (*LocalCorpusSearchService, error) {
+	s := &LocalCorpusSearchService{
+		ResultCount: 10,
+		byURL:       make(map[string]*corpusDocument),
+		postings:    make(map[string][]corpusPosting),
+	}
+
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		page, ok, err := loadCorpusDocument(path)
+		if err != nil {
+			return fmt.Errorf("failed to load corpus document %s: %w", path, err)
+		}
+		if ok {
+			s.add(page)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(s.documents) == 0 {
+		return nil, fmt.Errorf("no documents found in corpus directory %s", dir)
+	}
+
+	total := 0
+	for _, document := range s.documents {
+		total += document.length
+	}
+	s.avgLength = float64(total) / float64(len(s.documents))
+	return s, nil
+}
+
+func (s *LocalCorpusSearchService) add(page *FetchedPage) {
+	frequencies := make(map[string]int)
+	length := 0
+	for _, term := range corpusTerms(page.Title + "\n" + page.Text) {
+		frequencies[term]++
+		length++
+	}
+
+	index := len(s.documents)
+	document := &corpusDocument{page: page, length: length}
+	s.documents = append(s.documents, document)
+	s.byURL[page.URL] = document
+	for term, frequency := range frequencies {
+		s.postings[term] = append(s.postings[term], corpusPosting{document: index, frequency: frequency})
+	}
+}
+
+// Search ranks the documents against the query with BM25
+func (s *LocalCorpusSearchService) Search(ctx context.Context, query string) ([]SearchResult, error) {
+	queryTerms := uniqueStrings(corpusTerms(query))
+	scores := make(map[int]float64)
+	total := float64(len(s.documents))
+	for _, term := range queryTerms {
+		postings := s.postings[term]
+		if len(postings) == 0 {
+			continue
+		}
+		idf := math.Log(1 + (total-float64(len(postings))+0.5)/(float64(len(postings))+0.5))
+		for _, posting := range postings {
+			length := float64(s.documents[posting.document].length)
+			frequency := float64(posting.frequency)
+			scores[posting.document] += idf * frequency * (bm25K1 + 1) /
+				(frequency + bm25K1*(1-bm25B+bm25B*length/s.avgLength))
+		}
+	}
+
+	ranked := make([]int, 0, len(scores))
+	for document := range scores {
+		ranked = append(ranked, document)
+	}
+	sort.Slice(ranked, func(a, b int) bool {
+		if scores[ranked[a]] != scores[ranked[b]] {
+			return scores[ranked[a]] > scores[ranked[b]]
+		}
+		return s.documents[ranked[a]].page.URL < s.documents[ranked[b]].page.URL
+	})
+	if s.ResultCount > 0 && len(ranked) > s.ResultCount {
+		ranked = ranked[:s.ResultCount]
+	}
+
+	results := make([]SearchResult, 0, len(ranked))
+	for _, index := range ranked {
+		page := s.documents[index].page
+		result := SearchResult{
+			Title:   page.Title,
+			URL:     page.URL,
+			Snippet: corpusSnippet(page.Text, queryTerms),
+			// Scaled so that the best match is 10, like the position-based relevance of web results
+			Relevance: int(math.Max(1, math.Round(10*scores[index]/scores[ranked[0]]))),
+		}
+		if !page.PublishedAt.IsZero() {
+			result.PublishedDate = page.PublishedAt.Format("2006-01-02")
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// FetchContent returns the research text of a corpus document
+func (s *LocalCorpusSearchService) FetchContent(ctx context.Context, url string) (string, error) {
+	page, err := s.FetchPage(ctx, url)
+	if err != nil {
+		return "", err
+	}
+	return page.Document(), nil
+}
+
+// FetchPage returns a corpus document by its URL
+func (s *LocalCorpusSearchService) FetchPage(ctx context.Context, url string) (*FetchedPage, error) {
+	document, ok := s.byURL[url]
+	if !ok {
+		return nil, fmt.Errorf("no corpus document has the URL %s", url)
+	}
+	page := *document.page
+	return &page, nil
+}
+
+// loadCorpusDocument reads a corpus file; ok is false for files of other types
+func loadCorpusDocument(path string) (*FetchedPage, bool, error) {
+	extension := strings.ToLower(filepath.Ext(path))
+	switch extension {
+	case ".md", ".markdown", ".txt", ".html", ".htm":
+	default:
+		return nil, false, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false, err
+	}
+	absolute, err := filepath.Abs(path)
+	if err != nil {
+		return nil, false, err
+	}
+	fileURL := "file://" + filepath.ToSlash(absolute)
+
+	if extension == ".html" || extension == ".htm" {
+		page := extractPage(fileURL, "text/html", string(data))
+		if page.Title == "" {
+			page.Title = corpusFileTitle(path)
+		}
+		return page, true, nil
+	}
+
+	fields, body := splitFrontMatter(string(data))
+	page := &FetchedPage{
+		URL:         fields["url"],
+		ContentType: "text/plain",
+		Title:       fields["title"],
+		Text:        strings.TrimSpace(body),
+	}
+	if page.URL == "" {
+		page.URL = fileURL
+	}
+	for _, key := range []string{"date", "published"} {
+		if published := parsePublishDate(fields[key]); !published.IsZero() {
+			page.PublishedAt = published
+			break
+		}
+	}
+	if page.Title == "" {
+		page.Title = markdownTitle(page.Text)
+	}
+	if page.Title == "" {
+		page.Title = corpusFileTitle(path)
+	}
+	if extension != ".txt" {
+		page.ContentType = "text/markdown"
+	}
+	return page, true, nil
+}
+
+// splitFrontMatter separates a leading block of key: value lines between --- lines
+func splitFrontMatter(text string) (map[string]string, string) {
+	fields := make(map[string]string)
+	if !strings.HasPrefix(text, "---\n") && !strings.HasPrefix(text, "---\r\n") {
+		return fields, text
+	}
+	lines := strings.SplitAfter(text, "\n")
+	for i := 1; i < len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
+		if line == "---" {
+			return fields, strings.Join(lines[i+1:], "")
+		}
+		if key, value, found := strings.Cut(line, ":"); found {
+			fields[strings.ToLower(strings.TrimSpace(key))] = strings.Trim(strings.TrimSpace(value), `"'`)
+		}
+	}
+	// An unterminated block is not front matter
+	return map[string]string{}, text
+}
+
+// markdownTitle returns the first level-one heading of a markdown text
+func markdownTitle(text string) string {
+	for _, line := range strings.Split(text, "\n") {
+		if strings.HasPrefix(line, "# ") {
+			return strings.TrimSpace(line[2:])
+		}
+	}
+	return ""
+}
+
+func corpusFileTitle(path string) string {
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	return strings.TrimSpace(strings.NewReplacer("-", " ", "_", " ").Replace(name))
+}
+
+// corpusTerms splits text into lowercased terms, leaving out stop words
+func corpusTerms(text string) []string {
+	var terms []string
+	for _, word := range shingleTokenPattern.FindAllString(text, -1) {
+		word = strings.ToLower(word)
+		if !isStopWord(word) {
+			terms = append(terms, word)
+		}
+	}
+	return terms
+}
+
+// corpusSnippet returns the paragraph of text that holds the most query terms
+func corpusSnippet(text string, queryTerms []string) string {
+	wanted := make(map[string]bool, len(queryTerms))
+	for _, term := range queryTerms {
+		wanted[term] = true
+	}
+
+	best, bestMatches := "", -1
+	for _, paragraph := range strings.Split(text, "\n\n") {
+		paragraph = collapseWhitespace(paragraph)
+		if paragraph == "" || strings.HasPrefix(paragraph, "#") {
+			continue
+		}
+		matches := 0
+		for _, term := range corpusTerms(paragraph) {
+			if wanted[term] {
+				matches++
+			}
+		}
+		if matches > bestMatches {
+			best, bestMatches = paragraph, matches
+		}
+	}
+	return truncateString(best, maxCorpusSnippetLength)
+}
+
+func uniqueStrings(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	unique := make([]string, 0, len(values))
+	for _, value := range values {
+		if !seen[value] {
+			seen[value] = true
+			unique = append(unique, value)
+		}
+	}
+	return unique
+}
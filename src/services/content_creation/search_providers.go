@@ -0,0 +1,336 @@
+package content_creation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/html"
+)
+
+// Search providers supported by the default registry
+const (
+	SearchProviderGeneric = "generic" // an API returning Custom Search style items
+	SearchProviderSearXNG = "searxng"
+	SearchProviderBrave   = "brave"
+	SearchProviderBing    = "bing" // the Bing Web Search API or a compatible endpoint
+	SearchProviderLocal   = "local"
+)
+
+// Default endpoints for search providers that do not require a base URL
+const (
+	DefaultBraveSearchURL = "https://api.search.brave.com/res/v1/web/search"
+	DefaultBingSearchURL  = "https://api.bing.microsoft.com/v7.0/search"
+)
+
+// SearchProviderConfig configures a search provider
+type SearchProviderConfig struct {
+	Provider    string
+	BaseURL     string
+	APIKey      string
+	CorpusDir   string        // documents searched by the local provider
+	ResultCount int           // results per search; zero selects 10
+	Timeout     time.Duration // for search API requests
+	Fetcher     *PageFetcher  // retrieves pages for web providers; nil selects the default fetcher
+}
+
+// SearchProviderFactory builds a SearchService for a config
+type SearchProviderFactory func(config SearchProviderConfig) (SearchService, error)
+
+// SearchProviderRegistry builds SearchService instances by provider name
+type SearchProviderRegistry struct {
+	mu        sync.RWMutex
+	factories map[string]SearchProviderFactory
+}
+
+// NewSearchProviderRegistry creates a registry with the built-in providers
+func NewSearchProviderRegistry() *SearchProviderRegistry {
+	r := &SearchProviderRegistry{
+		factories: make(map[string]SearchProviderFactory),
+	}
+
+	r.Register(SearchProviderGeneric, webSearchFactory(GenericSearchEngine{}, "", false))
+	r.Register(SearchProviderSearXNG, webSearchFactory(SearXNGSearchEngine{}, "", false))
+	r.Register(SearchProviderBrave, webSearchFactory(BraveSearchEngine{}, DefaultBraveSearchURL, true))
+	r.Register(SearchProviderBing, webSearchFactory(BingSearchEngine{}, DefaultBingSearchURL, true))
+	r.Register(SearchProviderLocal, localCorpusFactory)
+
+	return r
+}
+
+// Register adds or replaces the factory for a provider name
+func (r *SearchProviderRegistry) Register(provider string, factory SearchProviderFactory) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.factories[strings.ToLower(provider)] = factory
+}
+
+// Providers returns the registered provider names in sorted order
+func (r *SearchProviderRegistry) Providers() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	names := make([]string, 0, len(r.factories))
+	for name := range r.factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Build creates a SearchService for the config
+func (r *SearchProviderRegistry) Build(config SearchProviderConfig) (SearchService, error) {
+	r.mu.RLock()
+	factory, ok := r.factories[strings.ToLower(config.Provider)]
+	r.mu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("unknown search provider %q (registered: %s)", config.Provider, strings.Join(r.Providers(), ", "))
+	}
+	return factory(config)
+}
+
+// webSearchFactory builds web search services using engine, falling back to defaultURL when
+// the config has no base URL
+func webSearchFactory(engine SearchEngine, defaultURL string, requiresKey bool) SearchProviderFactory {
+	return func(config SearchProviderConfig) (SearchService, error) {
+		baseURL := config.BaseURL
+		if baseURL == "" {
+			baseURL = defaultURL
+		}
+		if requiresKey && config.APIKey == "" {
+			return nil, fmt.Errorf("an API key is required for search provider %s", config.Provider)
+		}
+
+		service := NewWebSearchService(config.APIKey, baseURL)
+		service.Engine = engine
+		if config.ResultCount > 0 {
+			service.ResultCount = config.ResultCount
+		}
+		if config.Timeout > 0 {
+			service.HTTPClient.Timeout = config.Timeout
+		}
+		if config.Fetcher != nil {
+			service.UseFetcher(config.Fetcher)
+		}
+		return service, nil
+	}
+}
+
+func localCorpusFactory(config SearchProviderConfig) (SearchService, error) {
+	if config.CorpusDir == "" {
+		return nil, fmt.Errorf("a corpus directory is required for search provider %s", config.Provider)
+	}
+	corpus, err := NewLocalCorpusSearchService(config.CorpusDir)
+	if err != nil {
+		return nil, err
+	}
+	if config.ResultCount > 0 {
+		corpus.ResultCount = config.ResultCount
+	}
+	return corpus, nil
+}
+
+// SearchEngine adapts a web search API: it builds the request for a query and reads the
+// results from the response
+type SearchEngine interface {
+	NewRequest(ctx context.Context, baseURL, apiKey, query string, count int) (*http.Request, error)
+	ParseResults(body []byte) ([]SearchResult, error)
+}
+
+// GenericSearchEngine talks to APIs taking q, key and num parameters and answering with
+// Custom Search style items
+type GenericSearchEngine struct{}
+
+// NewRequest builds the search request
+func (GenericSearchEngine) NewRequest(ctx context.Context, baseURL, apiKey, query string, count int) (*http.Request, error) {
+	params := url.Values{}
+	params.Add("q", query)
+	params.Add("key", apiKey)
+	params.Add("num", strconv.Itoa(count))
+	return http.NewRequestWithContext(ctx, "GET", baseURL+"?"+params.Encode(), nil)
+}
+
+// ParseResults reads the items of the response
+func (GenericSearchEngine) ParseResults(body []byte) ([]SearchResult, error) {
+	var response struct {
+		Items []struct {
+			Title       string `json:"title"`
+			Link        string `json:"link"`
+			Snippet     string `json:"snippet"`
+			Authors     string `json:"authors,omitempty"`
+			PublishedAt string `json:"publishedAt,omitempty"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, err
+	}
+
+	results := make([]SearchResult, 0, len(response.Items))
+	for i, item := range response.Items {
+		results = append(results, SearchResult{
+			Title:         item.Title,
+			URL:           item.Link,
+			Snippet:       item.Snippet,
+			Authors:       item.Authors,
+			PublishedDate: item.PublishedAt,
+			Relevance:     len(response.Items) - i, // Higher relevance for earlier results
+		})
+	}
+	return results, nil
+}
+
+// SearXNGSearchEngine talks to the JSON API of a SearXNG instance, which must have the json
+// format enabled
+type SearXNGSearchEngine struct{}
+
+// NewRequest builds the search request
+func (SearXNGSearchEngine) NewRequest(ctx context.Context, baseURL, apiKey, query string, count int) (*http.Request, error) {
+	params := url.Values{}
+	params.Add("q", query)
+	params.Add("format", "json")
+	params.Add("pageno", "1")
+	return http.NewRequestWithContext(ctx, "GET", strings.TrimSuffix(baseURL, "/")+"/search?"+params.Encode(), nil)
+}
+
+// ParseResults reads the results of the response
+func (SearXNGSearchEngine) ParseResults(body []byte) ([]SearchResult, error) {
+	var response struct {
+		Results []struct {
+			Title         string  `json:"title"`
+			URL           string  `json:"url"`
+			Content       string  `json:"content"`
+			Author        string  `json:"author"`
+			PublishedDate *string `json:"publishedDate"`
+		} `json:"results"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, err
+	}
+
+	results := make([]SearchResult, 0, len(response.Results))
+	for i, item := range response.Results {
+		result := SearchResult{
+			Title:     item.Title,
+			URL:       item.URL,
+			Snippet:   item.Content,
+			Authors:   item.Author,
+			Relevance: len(response.Results) - i,
+		}
+		if item.PublishedDate != nil {
+			result.PublishedDate = *item.PublishedDate
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// BraveSearchEngine talks to the Brave Search web API
+type BraveSearchEngine struct{}
+
+// NewRequest builds the search request
+func (BraveSearchEngine) NewRequest(ctx context.Context, baseURL, apiKey, query string, count int) (*http.Request, error) {
+	params := url.Values{}
+	params.Add("q", query)
+	params.Add("count", strconv.Itoa(count))
+	req, err := http.NewRequestWithContext(ctx, "GET", baseURL+"?"+params.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("X-Subscription-Token", apiKey)
+	return req, nil
+}
+
+// ParseResults reads the web results of the response
+func (BraveSearchEngine) ParseResults(body []byte) ([]SearchResult, error) {
+	var response struct {
+		Web struct {
+			Results []struct {
+				Title       string `json:"title"`
+				URL         string `json:"url"`
+				Description string `json:"description"`
+				PageAge     string `json:"page_age"`
+			} `json:"results"`
+		} `json:"web"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, err
+	}
+
+	items := response.Web.Results
+	results := make([]SearchResult, 0, len(items))
+	for i, item := range items {
+		results = append(results, SearchResult{
+			Title:         plainSnippet(item.Title),
+			URL:           item.URL,
+			Snippet:       plainSnippet(item.Description),
+			PublishedDate: item.PageAge,
+			Relevance:     len(items) - i,
+		})
+	}
+	return results, nil
+}
+
+// BingSearchEngine talks to the Bing Web Search API and endpoints compatible with it
+type BingSearchEngine struct{}
+
+// NewRequest builds the search request
+func (BingSearchEngine) NewRequest(ctx context.Context, baseURL, apiKey, query string, count int) (*http.Request, error) {
+	params := url.Values{}
+	params.Add("q", query)
+	params.Add("count", strconv.Itoa(count))
+	params.Add("textFormat", "Raw")
+	req, err := http.NewRequestWithContext(ctx, "GET", baseURL+"?"+params.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Ocp-Apim-Subscription-Key", apiKey)
+	return req, nil
+}
+
+// ParseResults reads the web pages of the response
+func (BingSearchEngine) ParseResults(body []byte) ([]SearchResult, error) {
+	var response struct {
+		WebPages struct {
+			Value []struct {
+				Name          string `json:"name"`
+				URL           string `json:"url"`
+				Snippet       string `json:"snippet"`
+				DatePublished string `json:"datePublished"`
+			} `json:"value"`
+		} `json:"webPages"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, err
+	}
+
+	items := response.WebPages.Value
+	results := make([]SearchResult, 0, len(items))
+	for i, item := range items {
+		results = append(results, SearchResult{
+			Title:         item.Name,
+			URL:           item.URL,
+			Snippet:       item.Snippet,
+			PublishedDate: item.DatePublished,
+			Relevance:     len(items) - i,
+		})
+	}
+	return results, nil
+}
+
+var snippetTagPattern = regexp.MustCompile(`<[^>]*>`)
+
+// plainSnippet removes the highlighting markup some APIs put in titles and snippets
+func plainSnippet(text string) string {
+	return collapseWhitespace(html.UnescapeString(snippetTagPattern.ReplaceAllString(text, "")))
+}
@@ -0,0 +1,131 @@
+package content_creation
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchProviderRegistry_WebProviders(t *testing.T) {
+	tests := []struct {
+		provider string
+		path     string
+		header   string // header carrying the API key
+		response string
+	}{
+		{SearchProviderGeneric, "/", "", `{"items": [
+			{"title": "Team Size", "link": "https://example.com/a", "snippet": "Small teams"},
+			{"title": "Delivery", "link": "https://example.com/b", "snippet": "Ship faster"}]}`},
+		{SearchProviderSearXNG, "/search", "", `{"results": [
+			{"title": "Team Size", "url": "https://example.com/a", "content": "Small teams", "publishedDate": null},
+			{"title": "Delivery", "url": "https://example.com/b", "content": "Ship faster", "publishedDate": "2024-03-01"}]}`},
+		{SearchProviderBrave, "/", "X-Subscription-Token", `{"web": {"results": [
+			{"title": "Team <strong>Size</strong>", "url": "https://example.com/a", "description": "<strong>Small</strong> teams"},
+			{"title": "Delivery", "url": "https://example.com/b", "description": "Ship faster", "page_age": "2024-03-01"}]}}`},
+		{SearchProviderBing, "/", "Ocp-Apim-Subscription-Key", `{"webPages": {"value": [
+			{"name": "Team Size", "url": "https://example.com/a", "snippet": "Small teams"},
+			{"name": "Delivery", "url": "https://example.com/b", "snippet": "Ship faster", "datePublished": "2024-03-01"}]}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.provider, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, tt.path, r.URL.Path)
+				assert.Equal(t, "team size", r.URL.Query().Get("q"))
+				if tt.header != "" {
+					assert.Equal(t, "secret", r.Header.Get(tt.header))
+				}
+				w.Write([]byte(tt.response))
+			}))
+			defer server.Close()
+
+			service, err := NewSearchProviderRegistry().Build(SearchProviderConfig{
+				Provider: tt.provider,
+				BaseURL:  server.URL,
+				APIKey:   "secret",
+			})
+			require.NoError(t, err)
+
+			results, err := service.Search(context.Background(), "team size")
+			require.NoError(t, err)
+			require.Len(t, results, 2)
+			assert.Equal(t, SearchResult{Title: "Team Size", URL: "https://example.com/a", Snippet: "Small teams", Relevance: 2}, results[0])
+			if tt.provider != SearchProviderGeneric {
+				assert.Equal(t, "2024-03-01", results[1].PublishedDate)
+			}
+		})
+	}
+
+	_, err := NewSearchProviderRegistry().Build(SearchProviderConfig{Provider: "altavista"})
+	assert.ErrorContains(t, err, "unknown search provider")
+	_, err = NewSearchProviderRegistry().Build(SearchProviderConfig{Provider: SearchProviderBrave})
+	assert.ErrorContains(t, err, "API key is required")
+}
+
+func writeCorpus(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	files := map[string]string{
+		"small-teams.md": "---\ntitle: Team Size and Delivery Speed\nurl: https://example.com/small-teams\ndate: 2024-03-01\n---\n" +
+			"# Team Size and Delivery Speed\n\nCommunication paths grow quadratically with team size.\n\n" +
+			"Small teams ship faster because small teams hand off less work between people.",
+		"notes/handoffs.txt": "Handoffs between teams add queues and waiting time to delivery.",
+		"remote.html": "<html><head><title>Remote Work</title></head><body><article><p>Remote work changes how people " +
+			"communicate, with more writing and fewer meetings across time zones.</p></article></body></html>",
+		"logo.png": "not a document",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+	return dir
+}
+
+func TestLocalCorpusSearchService_RanksDocumentsWithBM25(t *testing.T) {
+	corpus, err := NewLocalCorpusSearchService(writeCorpus(t))
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	results, err := corpus.Search(ctx, "small teams delivery")
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, "Team Size and Delivery Speed", results[0].Title)
+	assert.Equal(t, "https://example.com/small-teams", results[0].URL)
+	assert.Equal(t, "2024-03-01", results[0].PublishedDate)
+	assert.Equal(t, 10, results[0].Relevance)
+	assert.Equal(t, "Small teams ship faster because small teams hand off less work between people.", results[0].Snippet)
+	assert.Equal(t, "handoffs", results[1].Title)
+	assert.Less(t, results[1].Relevance, 10)
+
+	remote, err := corpus.Search(ctx, "remote meetings")
+	require.NoError(t, err)
+	require.Len(t, remote, 1)
+	assert.Equal(t, "Remote Work", remote[0].Title)
+
+	content, err := corpus.FetchContent(ctx, results[0].URL)
+	require.NoError(t, err)
+	assert.Contains(t, content, "Published: 2024-03-01")
+	_, err = corpus.FetchContent(ctx, "https://example.com/missing")
+	assert.Error(t, err)
+}
+
+func TestLLMResearcher_GathersSourcesFromLocalCorpus(t *testing.T) {
+	corpus, err := NewSearchProviderRegistry().Build(SearchProviderConfig{Provider: SearchProviderLocal, CorpusDir: writeCorpus(t)})
+	require.NoError(t, err)
+	researcher := NewLLMResearcher(new(MockLLMClient), corpus)
+
+	sources, err := researcher.gatherSources(context.Background(), ResearchTopic{Keywords: []string{"team size"}, MaxSources: 1}, ResearchRequirements{})
+	require.NoError(t, err)
+	require.Len(t, sources, 1)
+	assert.Equal(t, "https://example.com/small-teams", sources[0].URL)
+	assert.Equal(t, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), sources[0].LastUpdated)
+	assert.Contains(t, sources[0].Content, "Communication paths grow quadratically")
+}
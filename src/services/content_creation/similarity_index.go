@@ -0,0 +1,326 @@
+package content_creation
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/Ceesaxp/autonomous-content-service/src/domain/entities"
+	"github.com/Ceesaxp/autonomous-content-service/src/domain/repositories"
+	"github.com/google/uuid"
+)
+
+const (
+	shingleWords    = 5  // words per shingle
+	minHashCount    = 64 // hash functions per MinHash signature
+	lshBandRows     = 2  // signature rows per LSH band, giving 32 bands
+	chunkShingles   = 20 // shingles per signed chunk; chunks overlap by half
+	minOverlapWords = 8  // shortest shared passage reported as a match
+)
+
+var shingleTokenPattern = regexp.MustCompile(`[\p{L}\p{N}]+(?:['’][\p{L}\p{N}]+)*`)
+
+// SimilarityIndex is a persistent near-duplicate index of content versions and research
+// sources. Texts are shingled into word 5-grams and signed per chunk with MinHash, so that
+// locality-sensitive hashing finds documents sharing even a single passage; candidates are
+// then confirmed by their exact shingle overlap.
+type SimilarityIndex struct {
+	repo          repositories.SimilarityIndexRepository
+	projectRepo   repositories.ProjectRepository
+	fingerprinter *ContentFingerprinter
+	seeds         [minHashCount]uint64
+}
+
+// NewSimilarityIndex creates a similarity index. The project repository resolves the
+// client of indexed and checked content.
+func NewSimilarityIndex(repo repositories.SimilarityIndexRepository, projectRepo repositories.ProjectRepository) *SimilarityIndex {
+	index := &SimilarityIndex{
+		repo:          repo,
+		projectRepo:   projectRepo,
+		fingerprinter: NewContentFingerprinter(),
+	}
+
+	// The seeds are fixed so that signatures stay comparable across processes and restarts
+	state := uint64(0x5eed5eed5eed5eed)
+	for i := range index.seeds {
+		state += 0x9e3779b97f4a7c15
+		index.seeds[i] = mix64(state)
+	}
+	return index
+}
+
+// SimilarityMatch is an indexed document sharing passages with a checked text
+type SimilarityMatch struct {
+	Document    *entities.SimilarityDocument `json:"document"`
+	Containment float64                      `json:"containment"` // share of the checked text's shingles found in the document
+	CrossClient bool                         `json:"crossClient"` // content of another client
+	Spans       []OverlapSpan                `json:"spans"`
+}
+
+// OverlapSpan is a passage shared word for word by a checked text and an indexed document
+type OverlapSpan struct {
+	Text        string `json:"text"`
+	Start       int    `json:"start"` // byte offsets in the checked text
+	End         int    `json:"end"`
+	SourceText  string `json:"sourceText"`
+	SourceStart int    `json:"sourceStart"` // byte offsets in the indexed document
+	SourceEnd   int    `json:"sourceEnd"`
+}
+
+// IndexContent adds the current text and the earlier versions of the content to the index.
+// Versions that are already indexed are skipped.
+func (i *SimilarityIndex) IndexContent(ctx context.Context, content *entities.Content) error {
+	clientID := i.clientOf(ctx, content)
+
+	texts := map[int]string{content.Version: content.Data}
+	for _, version := range content.Versions {
+		texts[version.VersionNumber] = version.Data
+	}
+
+	for versionNumber, text := range texts {
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+		exists, err := i.repo.Exists(ctx, entities.ContentSimilarityKey(content.ContentID, versionNumber))
+		if err != nil {
+			return err
+		}
+		if exists {
+			continue
+		}
+		if err := i.save(ctx, entities.NewContentSimilarityDocument(content, clientID, versionNumber, text)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// IndexSource adds a page fetched during research to the index
+func (i *SimilarityIndex) IndexSource(ctx context.Context, url, title, text string) error {
+	if strings.TrimSpace(text) == "" {
+		return nil
+	}
+	return i.save(ctx, entities.NewSourceSimilarityDocument(url, title, text))
+}
+
+func (i *SimilarityIndex) save(ctx context.Context, document *entities.SimilarityDocument) error {
+	shingles := i.fingerprinter.shingles(document.Text)
+	if len(shingles) == 0 {
+		return nil
+	}
+	if err := i.repo.Save(ctx, document, i.bands(shingles)); err != nil {
+		return fmt.Errorf("failed to index %s: %w", document.Key, err)
+	}
+	return nil
+}
+
+// FindMatches returns the indexed documents of the given kind that share passages with the
+// text, best match first. When the text belongs to content, its own versions are not
+// matches and only the latest matching version of other content is returned.
+func (i *SimilarityIndex) FindMatches(ctx context.Context, text string, kind entities.SimilarityDocumentKind, content *entities.Content) ([]SimilarityMatch, error) {
+	shingles := i.fingerprinter.shingles(text)
+	if len(shingles) == 0 {
+		return []SimilarityMatch{}, nil
+	}
+
+	candidates, err := i.repo.FindByBands(ctx, i.bands(shingles))
+	if err != nil {
+		return nil, err
+	}
+
+	var contentID, clientID uuid.UUID
+	if content != nil {
+		contentID = content.ContentID
+		clientID = i.clientOf(ctx, content)
+	}
+
+	matches := []SimilarityMatch{}
+	byContent := make(map[uuid.UUID]int) // content to its position in matches
+	for _, candidate := range candidates {
+		if candidate.Kind != kind || (contentID != uuid.Nil && candidate.ContentID == contentID) {
+			continue
+		}
+
+		spans, shared := overlapSpans(text, shingles, candidate.Text, i.fingerprinter.shingles(candidate.Text))
+		if len(spans) == 0 {
+			continue
+		}
+
+		match := SimilarityMatch{
+			Document:    candidate,
+			Containment: float64(shared) / float64(len(shingles)),
+			CrossClient: candidate.Kind == entities.SimilarityDocumentContent && clientID != uuid.Nil && candidate.ClientID != clientID,
+			Spans:       spans,
+		}
+
+		if candidate.ContentID != uuid.Nil {
+			if position, seen := byContent[candidate.ContentID]; seen {
+				if candidate.VersionNumber > matches[position].Document.VersionNumber {
+					matches[position] = match
+				}
+				continue
+			}
+			byContent[candidate.ContentID] = len(matches)
+		}
+		matches = append(matches, match)
+	}
+
+	sort.SliceStable(matches, func(a, b int) bool {
+		return matches[a].Containment > matches[b].Containment
+	})
+	return matches, nil
+}
+
+// clientOf returns the client of the content's project, or uuid.Nil when it cannot be loaded
+func (i *SimilarityIndex) clientOf(ctx context.Context, content *entities.Content) uuid.UUID {
+	project, err := i.projectRepo.FindByID(ctx, content.ProjectID)
+	if err != nil {
+		fmt.Printf("Warning: Failed to load project of content %s for the similarity index: %v\n", content.ContentID, err)
+		return uuid.Nil
+	}
+	return project.ClientID
+}
+
+// bands returns the LSH band hashes of every chunk of the shingles
+func (i *SimilarityIndex) bands(shingles []textShingle) []uint64 {
+	var bands []uint64
+	seen := make(map[uint64]bool)
+	for start := 0; ; start += chunkShingles / 2 {
+		end := start + chunkShingles
+		if end > len(shingles) {
+			end = len(shingles)
+		}
+
+		signature := i.signature(shingles[start:end])
+		for band := 0; band < minHashCount/lshBandRows; band++ {
+			hash := mix64(uint64(band) + 1)
+			for _, value := range signature[band*lshBandRows : (band+1)*lshBandRows] {
+				hash = mix64(hash ^ value)
+			}
+			if !seen[hash] {
+				seen[hash] = true
+				bands = append(bands, hash)
+			}
+		}
+
+		if end == len(shingles) {
+			return bands
+		}
+	}
+}
+
+// signature computes the MinHash signature of a set of shingles
+func (i *SimilarityIndex) signature(shingles []textShingle) [minHashCount]uint64 {
+	var signature [minHashCount]uint64
+	for j := range signature {
+		signature[j] = ^uint64(0)
+	}
+	for _, shingle := range shingles {
+		for j, seed := range i.seeds {
+			if value := mix64(shingle.hash ^ seed); value < signature[j] {
+				signature[j] = value
+			}
+		}
+	}
+	return signature
+}
+
+// textShingle is a run of shingleWords consecutive words of a text
+type textShingle struct {
+	hash  uint64
+	start int // byte offset of the first word
+	end   int // byte offset after the last word
+}
+
+// shingles splits content into overlapping word shingles, ignoring case and punctuation
+func (cf *ContentFingerprinter) shingles(content string) []textShingle {
+	words := shingleTokenPattern.FindAllStringIndex(content, -1)
+	if len(words) < shingleWords {
+		return nil
+	}
+
+	shingles := make([]textShingle, 0, len(words)-shingleWords+1)
+	for i := 0; i+shingleWords <= len(words); i++ {
+		hasher := fnv.New64a()
+		for _, word := range words[i : i+shingleWords] {
+			hasher.Write([]byte(strings.ToLower(content[word[0]:word[1]])))
+			hasher.Write([]byte{' '})
+		}
+		shingles = append(shingles, textShingle{
+			hash:  hasher.Sum64(),
+			start: words[i][0],
+			end:   words[i+shingleWords-1][1],
+		})
+	}
+	return shingles
+}
+
+// overlapSpans finds the passages of text that appear word for word in source. It returns
+// the passages of at least minOverlapWords words and the number of shared shingles.
+func overlapSpans(text string, shingles []textShingle, source string, sourceShingles []textShingle) ([]OverlapSpan, int) {
+	positions := make(map[uint64][]int, len(sourceShingles))
+	for i, shingle := range sourceShingles {
+		positions[shingle.hash] = append(positions[shingle.hash], i)
+	}
+
+	spans := []OverlapSpan{}
+	shared := 0
+	runStart, sourceStart, runLength := 0, 0, 0
+	closeRun := func() {
+		if runLength > 0 && runLength+shingleWords-1 >= minOverlapWords {
+			first, last := shingles[runStart], shingles[runStart+runLength-1]
+			sourceFirst, sourceLast := sourceShingles[sourceStart], sourceShingles[sourceStart+runLength-1]
+			spans = append(spans, OverlapSpan{
+				Text:        text[first.start:last.end],
+				Start:       first.start,
+				End:         last.end,
+				SourceText:  source[sourceFirst.start:sourceLast.end],
+				SourceStart: sourceFirst.start,
+				SourceEnd:   sourceLast.end,
+			})
+		}
+		runLength = 0
+	}
+
+	for i, shingle := range shingles {
+		found := positions[shingle.hash]
+		if len(found) == 0 {
+			closeRun()
+			continue
+		}
+		shared++
+
+		// Extend the current run when the source continues at the next shingle too
+		if runLength > 0 && containsInt(found, sourceStart+runLength) {
+			runLength++
+			continue
+		}
+		closeRun()
+		runStart, sourceStart, runLength = i, found[0], 1
+	}
+	closeRun()
+
+	return spans, shared
+}
+
+func containsInt(values []int, target int) bool {
+	for _, value := range values {
+		if value == target {
+			return true
+		}
+	}
+	return false
+}
+
+// mix64 is the SplitMix64 finalizer, used to derive independent hash functions
+func mix64(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
@@ -0,0 +1,73 @@
+package content_creation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Ceesaxp/autonomous-content-service/src/domain/entities"
+	"github.com/Ceesaxp/autonomous-content-service/src/infrastructure/memory"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	indexedSource = `Tourism boards reported a strong summer season across the coast. The harbour city welcomed twelve thousand visitors during the first week of the festival, and hotel occupancy stayed above ninety percent until September.`
+	indexedPost   = `Our roasting team spent three months tasting beans from small farms in the highlands before settling on a single origin blend with notes of dark cherry and cocoa. Every bag is roasted on Tuesday and shipped the same afternoon.`
+	checkedPost   = `Festival news from the waterfront: the harbour city welcomed twelve thousand visitors during the first week of the festival. ` +
+		`Local cafes kept up with demand. Our roasting team spent three months tasting beans from small farms in the highlands before settling on a single origin blend with notes of dark cherry and cocoa.`
+)
+
+func TestSimilarityIndex_FindsCopiedSourceAndCrossClientReuse(t *testing.T) {
+	ctx := context.Background()
+	repos := newTestRepositories()
+	index := NewSimilarityIndex(memory.NewSimilarityIndexRepository(), repos.project)
+
+	earlier := seededContent(t, repos, indexedPost)
+	require.NoError(t, index.IndexSource(ctx, "https://news.example.com/festival", "Festival season", indexedSource))
+	require.NoError(t, index.IndexContent(ctx, earlier))
+	require.NoError(t, index.IndexContent(ctx, earlier)) // already indexed versions are skipped
+
+	checked := seededContent(t, repos, checkedPost)
+
+	sources, err := index.FindMatches(ctx, checkedPost, entities.SimilarityDocumentSource, checked)
+	require.NoError(t, err)
+	require.Len(t, sources, 1)
+	assert.Equal(t, "https://news.example.com/festival", sources[0].Document.URL)
+	require.Len(t, sources[0].Spans, 1)
+	span := sources[0].Spans[0]
+	assert.Equal(t, "the harbour city welcomed twelve thousand visitors during the first week of the festival", span.Text)
+	assert.Equal(t, span.Text, checkedPost[span.Start:span.End])
+	assert.Equal(t, "The harbour city welcomed twelve thousand visitors during the first week of the festival", indexedSource[span.SourceStart:span.SourceEnd])
+
+	reused, err := index.FindMatches(ctx, checkedPost, entities.SimilarityDocumentContent, checked)
+	require.NoError(t, err)
+	require.Len(t, reused, 1)
+	assert.True(t, reused[0].CrossClient)
+	assert.Equal(t, earlier.ContentID, reused[0].Document.ContentID)
+	assert.Greater(t, reused[0].Containment, 0.4)
+
+	// Content does not match its own versions
+	own, err := index.FindMatches(ctx, indexedPost, entities.SimilarityDocumentContent, earlier)
+	require.NoError(t, err)
+	assert.Empty(t, own)
+
+	detector := NewPlagiarismDetector(&MockPlagiarismAPI{}, &MockQALLMClient{})
+	detector.UseSimilarityIndex(index)
+	result, err := detector.CheckPlagiarism(ctx, PlagiarismCheckRequest{Content: checkedPost, Subject: checked, CheckDatabase: true})
+	require.NoError(t, err)
+	require.NotEmpty(t, result.Matches)
+	assert.Equal(t, SourceInternal, result.Matches[0].Source.Type)
+	assert.Equal(t, "Reuses content written for another client", result.Matches[0].Context)
+	assert.Less(t, result.OriginalityScore, 100.0)
+}
+
+// seededContent creates content with the given text in a project of a new client
+func seededContent(t *testing.T, repos *testRepositories, text string) *entities.Content {
+	t.Helper()
+	project := repos.seedProject(t, uuid.New(), entities.ContentTypeBlogPost)
+	content, err := entities.NewContent(project.ProjectID, "Post", entities.ContentTypeBlogPost)
+	require.NoError(t, err)
+	content.Data = text
+	return content
+}
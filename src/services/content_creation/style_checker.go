@@ -267,7 +267,7 @@ Respond in JSON format:
   }
 }`, targetAudience, content)
 
-	response, err := sc.llmClient.Generate(ctx, prompt)
+	response, err := generateText(ctx, sc.llmClient, prompt)
 	if err != nil {
 		return nil, err
 	}
@@ -308,7 +308,7 @@ Respond in JSON format:
   }
 }`, content)
 
-	response, err := sc.llmClient.Generate(ctx, prompt)
+	response, err := generateText(ctx, sc.llmClient, prompt)
 	if err != nil {
 		return nil, err
 	}
@@ -408,7 +408,7 @@ Respond in JSON format:
   ]
 }`, brandVoice, content)
 
-	response, err := sc.llmClient.Generate(ctx, prompt)
+	response, err := generateText(ctx, sc.llmClient, prompt)
 	if err != nil {
 		return 0, nil, err
 	}
@@ -439,7 +439,7 @@ Respond in JSON format:
   "characteristics": ["<characteristic1>", "<characteristic2>"]
 }`, content)
 
-	response, err := sc.llmClient.Generate(ctx, prompt)
+	response, err := generateText(ctx, sc.llmClient, prompt)
 	if err != nil {
 		return nil, err
 	}
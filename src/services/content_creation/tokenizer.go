@@ -0,0 +1,410 @@
+package content_creation
+
+import (
+	"bufio"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Tokenizer counts the tokens an LLM sees in a text
+type Tokenizer interface {
+	CountTokens(text string) int
+}
+
+// TokenizerFunc adapts a function to the Tokenizer interface
+type TokenizerFunc func(text string) int
+
+// CountTokens calls f(text)
+func (f TokenizerFunc) CountTokens(text string) int {
+	return f(text)
+}
+
+// countTokens counts tokens with a tokenizer, falling back to the heuristic one
+func countTokens(tokenizer Tokenizer, text string) int {
+	if tokenizer == nil {
+		tokenizer = HeuristicTokenizer{}
+	}
+	return tokenizer.CountTokens(text)
+}
+
+// BPETokenizer is a byte-level byte pair encoding tokenizer over a tiktoken vocabulary
+type BPETokenizer struct {
+	encoding string
+	ranks    map[string]int
+	tokens   map[int]string
+	split    func(text string) []string
+}
+
+// NewBPETokenizer creates a tokenizer for an encoding from its merge ranks. The vocabulary
+// must hold every single byte, so that any text can be encoded.
+func NewBPETokenizer(encoding string, ranks map[string]int) (*BPETokenizer, error) {
+	for b := 0; b < 256; b++ {
+		if _, ok := ranks[string([]byte{byte(b)})]; !ok {
+			return nil, fmt.Errorf("vocabulary %s has no token for byte %d", encoding, b)
+		}
+	}
+
+	tokens := make(map[int]string, len(ranks))
+	for token, rank := range ranks {
+		tokens[rank] = token
+	}
+
+	split := splitCL100K
+	if encoding == EncodingO200K {
+		split = splitO200K
+	}
+	return &BPETokenizer{encoding: encoding, ranks: ranks, tokens: tokens, split: split}, nil
+}
+
+// Encoding returns the name of the tokenizer's encoding
+func (t *BPETokenizer) Encoding() string {
+	return t.encoding
+}
+
+// Encode converts text to token ranks
+func (t *BPETokenizer) Encode(text string) []int {
+	var encoded []int
+	for _, piece := range t.split(text) {
+		if rank, ok := t.ranks[piece]; ok {
+			encoded = append(encoded, rank)
+			continue
+		}
+		encoded = append(encoded, t.bytePairMerge([]byte(piece))...)
+	}
+	return encoded
+}
+
+// Decode converts token ranks back to text
+func (t *BPETokenizer) Decode(tokens []int) string {
+	var decoded strings.Builder
+	for _, rank := range tokens {
+		decoded.WriteString(t.tokens[rank])
+	}
+	return decoded.String()
+}
+
+// CountTokens returns the number of tokens in text
+func (t *BPETokenizer) CountTokens(text string) int {
+	return len(t.Encode(text))
+}
+
+// bytePairMerge repeatedly merges the adjacent parts of a piece whose union has the lowest
+// rank, until no union is in the vocabulary, and returns the ranks of the parts
+func (t *BPETokenizer) bytePairMerge(piece []byte) []int {
+	// bounds holds the start of each part, and the end of the piece
+	bounds := make([]int, len(piece)+1)
+	for i := range bounds {
+		bounds[i] = i
+	}
+
+	for len(bounds) > 2 {
+		minRank, minIndex := math.MaxInt, -1
+		for i := 0; i < len(bounds)-2; i++ {
+			if rank, ok := t.ranks[string(piece[bounds[i]:bounds[i+2]])]; ok && rank < minRank {
+				minRank, minIndex = rank, i
+			}
+		}
+		if minIndex < 0 {
+			break
+		}
+		bounds = append(bounds[:minIndex+1], bounds[minIndex+2:]...)
+	}
+
+	encoded := make([]int, len(bounds)-1)
+	for i := range encoded {
+		encoded[i] = t.ranks[string(piece[bounds[i]:bounds[i+1]])]
+	}
+	return encoded
+}
+
+// ParseTiktokenVocabulary reads merge ranks in the tiktoken format: one base64 encoded
+// token and its rank per line
+func ParseTiktokenVocabulary(r io.Reader) (map[string]int, error) {
+	ranks := make(map[string]int)
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		fields := strings.Fields(text)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("line %d: expected a token and a rank", line)
+		}
+		token, err := base64.StdEncoding.DecodeString(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid token: %w", line, err)
+		}
+		rank, err := strconv.Atoi(fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid rank: %w", line, err)
+		}
+		ranks[string(token)] = rank
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return ranks, nil
+}
+
+// HeuristicTokenizer estimates token counts without a vocabulary, from the pieces a BPE
+// tokenizer would merge within. It is used when a model's vocabulary cannot be loaded.
+type HeuristicTokenizer struct{}
+
+// CountTokens estimates the number of tokens in text
+func (HeuristicTokenizer) CountTokens(text string) int {
+	tokens := 0
+	for _, piece := range splitCL100K(text) {
+		tokens += estimatePieceTokens(piece)
+	}
+	return tokens
+}
+
+// estimatePieceTokens estimates the tokens of one piece: common words are single tokens,
+// long words split every few characters, punctuation runs every couple of characters and
+// non-Latin scripts close to one token per character
+func estimatePieceTokens(piece string) int {
+	ascii, letters, wide, other := 0, 0, 0, 0
+	for _, r := range piece {
+		switch {
+		case r <= unicode.MaxASCII:
+			ascii++
+			if unicode.IsLetter(r) || unicode.IsDigit(r) {
+				letters++
+			}
+		case r >= 0x2E80:
+			wide++
+		default:
+			other++
+		}
+	}
+
+	tokens := wide + (other+1)/2
+	switch {
+	case ascii == 0:
+	case letters == 0:
+		tokens += (ascii + 1) / 2
+	case ascii <= 6:
+		tokens++
+	default:
+		tokens += 1 + (ascii-2)/5
+	}
+	if tokens == 0 {
+		return 1
+	}
+	return tokens
+}
+
+// splitCL100K splits text into pieces like the cl100k_base pattern:
+// (?i:'s|'t|'re|'ve|'m|'ll|'d)|[^\r\n\p{L}\p{N}]?\p{L}+|\p{N}{1,3}| ?[^\s\p{L}\p{N}]+[\r\n]*|\s*[\r\n]+|\s+(?!\S)|\s+
+func splitCL100K(text string) []string {
+	return splitPieces(text, func(runes []rune, i int) int {
+		if end := matchContraction(runes, i); end > i {
+			return end
+		}
+		if end := matchCL100KWord(runes, i); end > i {
+			return end
+		}
+		if end := matchDigits(runes, i); end > i {
+			return end
+		}
+		if end := matchPunctuation(runes, i, isNewline); end > i {
+			return end
+		}
+		return matchWhitespace(runes, i)
+	})
+}
+
+// splitO200K splits text into pieces like the o200k_base pattern, which keeps
+// contractions with their word and splits words at case changes:
+// [^\r\n\p{L}\p{N}]?[\p{Lu}\p{Lt}\p{Lm}\p{Lo}\p{M}]*[\p{Ll}\p{Lm}\p{Lo}\p{M}]+(?i:'s|'t|'re|'ve|'m|'ll|'d)?|
+// [^\r\n\p{L}\p{N}]?[\p{Lu}\p{Lt}\p{Lm}\p{Lo}\p{M}]+[\p{Ll}\p{Lm}\p{Lo}\p{M}]*(?i:'s|'t|'re|'ve|'m|'ll|'d)?|
+// \p{N}{1,3}| ?[^\s\p{L}\p{N}]+[\r\n/]*|\s*[\r\n]+|\s+(?!\S)|\s+
+func splitO200K(text string) []string {
+	return splitPieces(text, func(runes []rune, i int) int {
+		if end := matchO200KWord(runes, i); end > i {
+			return end
+		}
+		if end := matchDigits(runes, i); end > i {
+			return end
+		}
+		if end := matchPunctuation(runes, i, func(r rune) bool { return isNewline(r) || r == '/' }); end > i {
+			return end
+		}
+		return matchWhitespace(runes, i)
+	})
+}
+
+// splitPieces splits text with a matcher returning the end of the piece starting at i
+func splitPieces(text string, match func(runes []rune, i int) int) []string {
+	runes := []rune(text)
+	var pieces []string
+	for i := 0; i < len(runes); {
+		end := match(runes, i)
+		if end <= i {
+			end = i + 1
+		}
+		pieces = append(pieces, string(runes[i:end]))
+		i = end
+	}
+	return pieces
+}
+
+var contractionSuffixes = []string{"s", "t", "re", "ve", "m", "ll", "d"}
+
+// matchContraction matches (?i:'s|'t|'re|'ve|'m|'ll|'d)
+func matchContraction(runes []rune, i int) int {
+	if i >= len(runes) || runes[i] != '\'' {
+		return i
+	}
+	for _, suffix := range contractionSuffixes {
+		end := i + 1 + len(suffix)
+		if end <= len(runes) && strings.EqualFold(string(runes[i+1:end]), suffix) {
+			return end
+		}
+	}
+	return i
+}
+
+// matchCL100KWord matches [^\r\n\p{L}\p{N}]?\p{L}+
+func matchCL100KWord(runes []rune, i int) int {
+	j := i
+	if !unicode.IsLetter(runes[j]) {
+		if !isWordPrefix(runes[j]) || j+1 >= len(runes) || !unicode.IsLetter(runes[j+1]) {
+			return i
+		}
+		j++
+	}
+	for j < len(runes) && unicode.IsLetter(runes[j]) {
+		j++
+	}
+	return j
+}
+
+// matchO200KWord matches the two word alternatives of o200k_base: a run of lower case
+// letters after optional capitals, or capitals followed by optional lower case letters
+func matchO200KWord(runes []rune, i int) int {
+	starts := []int{i}
+	if isWordPrefix(runes[i]) {
+		starts = []int{i + 1, i}
+	}
+
+	for _, start := range starts {
+		if end := matchCasedWord(runes, start); end > start {
+			return matchContraction(runes, end)
+		}
+	}
+	for _, start := range starts {
+		if end := matchCapitalizedWord(runes, start); end > start {
+			return matchContraction(runes, end)
+		}
+	}
+	return i
+}
+
+// matchCasedWord matches [\p{Lu}\p{Lt}\p{Lm}\p{Lo}\p{M}]*[\p{Ll}\p{Lm}\p{Lo}\p{M}]+
+func matchCasedWord(runes []rune, i int) int {
+	upper := i
+	for upper < len(runes) && isUpperClass(runes[upper]) {
+		upper++
+	}
+	// The capitals give back characters until a lower case run can follow
+	for start := upper; start >= i; start-- {
+		if start < len(runes) && isLowerClass(runes[start]) {
+			end := start
+			for end < len(runes) && isLowerClass(runes[end]) {
+				end++
+			}
+			return end
+		}
+	}
+	return i
+}
+
+// matchCapitalizedWord matches [\p{Lu}\p{Lt}\p{Lm}\p{Lo}\p{M}]+[\p{Ll}\p{Lm}\p{Lo}\p{M}]*
+func matchCapitalizedWord(runes []rune, i int) int {
+	end := i
+	for end < len(runes) && isUpperClass(runes[end]) {
+		end++
+	}
+	if end == i {
+		return i
+	}
+	for end < len(runes) && isLowerClass(runes[end]) {
+		end++
+	}
+	return end
+}
+
+// matchDigits matches \p{N}{1,3}
+func matchDigits(runes []rune, i int) int {
+	end := i
+	for end < len(runes) && end-i < 3 && unicode.IsNumber(runes[end]) {
+		end++
+	}
+	return end
+}
+
+// matchPunctuation matches  ?[^\s\p{L}\p{N}]+ followed by any characters in tail
+func matchPunctuation(runes []rune, i int, tail func(rune) bool) int {
+	start := i
+	if runes[start] == ' ' {
+		start++
+	}
+	end := start
+	for end < len(runes) && !unicode.IsSpace(runes[end]) && !unicode.IsLetter(runes[end]) && !unicode.IsNumber(runes[end]) {
+		end++
+	}
+	if end == start {
+		return i
+	}
+	for end < len(runes) && tail(runes[end]) {
+		end++
+	}
+	return end
+}
+
+// matchWhitespace matches \s*[\r\n]+|\s+(?!\S)|\s+
+func matchWhitespace(runes []rune, i int) int {
+	end := i
+	lastNewline := -1
+	for end < len(runes) && unicode.IsSpace(runes[end]) {
+		if isNewline(runes[end]) {
+			lastNewline = end
+		}
+		end++
+	}
+	switch {
+	case end == i:
+		return i
+	case lastNewline >= 0:
+		return lastNewline + 1
+	case end == len(runes) || end-1 == i:
+		return end
+	default:
+		// Leave the last space to prefix the word that follows
+		return end - 1
+	}
+}
+
+func isNewline(r rune) bool {
+	return r == '\r' || r == '\n'
+}
+
+// isWordPrefix reports whether r may precede a word in its piece: [^\r\n\p{L}\p{N}]
+func isWordPrefix(r rune) bool {
+	return !isNewline(r) && !unicode.IsLetter(r) && !unicode.IsNumber(r)
+}
+
+func isUpperClass(r rune) bool {
+	return unicode.In(r, unicode.Lu, unicode.Lt, unicode.Lm, unicode.Lo, unicode.M)
+}
+
+func isLowerClass(r rune) bool {
+	return unicode.In(r, unicode.Ll, unicode.Lm, unicode.Lo, unicode.M)
+}
@@ -0,0 +1,239 @@
+package content_creation
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Tokenizer encodings
+const (
+	EncodingCL100K = "cl100k_base"
+	EncodingO200K  = "o200k_base"
+)
+
+// DefaultTokenizerVocabularyURL is OpenAI's public copy of the vocabularies
+const DefaultTokenizerVocabularyURL = "https://openaipublic.blob.core.windows.net/encodings/"
+
+// maxVocabularyBytes bounds the size of a downloaded vocabulary
+const maxVocabularyBytes = 16 << 20
+
+// o200kModelPrefixes are the model families tokenized with o200k_base
+var o200kModelPrefixes = []string{"gpt-4o", "chatgpt-4o", "gpt-4.1", "gpt-4.5", "gpt-5", "o1", "o3", "o4"}
+
+// EncodingForModel returns the encoding that tokenizes a model's text. OpenAI's newer
+// models use o200k_base. Anthropic does not publish its tokenizer, so Claude and other
+// models are counted with cl100k_base, which comes closest.
+func EncodingForModel(model string) string {
+	model = strings.ToLower(model)
+	for _, prefix := range o200kModelPrefixes {
+		if strings.HasPrefix(model, prefix) {
+			return EncodingO200K
+		}
+	}
+	return EncodingCL100K
+}
+
+// Retry delays after a vocabulary fails to load; they double with each failure
+const (
+	vocabularyRetryDelay    = 30 * time.Second
+	maxVocabularyRetryDelay = 30 * time.Minute
+)
+
+// TokenizerRegistry provides the tokenizer of each model. Vocabularies are read from
+// <vocabDir>/<encoding>.tiktoken and, when missing and a download URL is set, downloaded
+// there. Each encoding loads once, without holding up callers of other encodings; a failed
+// load is retried with a backoff.
+type TokenizerRegistry struct {
+	vocabDir   string
+	vocabURL   string // empty disables downloads
+	httpClient *http.Client
+
+	mutex sync.Mutex
+	loads map[string]*tokenizerLoad // by encoding
+}
+
+// tokenizerLoad holds the tokenizer of an encoding, or when its last load failed
+type tokenizerLoad struct {
+	mutex     sync.Mutex // held while the vocabulary loads
+	tokenizer Tokenizer  // nil until the vocabulary has loaded
+	failures  int
+	retryAt   time.Time
+}
+
+// NewTokenizerRegistry creates a registry reading vocabularies from vocabDir. Missing
+// vocabularies are not downloaded unless a URL is set with UseVocabularyURL.
+func NewTokenizerRegistry(vocabDir string) *TokenizerRegistry {
+	return &TokenizerRegistry{
+		vocabDir:   vocabDir,
+		httpClient: &http.Client{Timeout: 60 * time.Second},
+		loads:      make(map[string]*tokenizerLoad),
+	}
+}
+
+// UseVocabularyURL sets the base URL missing vocabularies are downloaded from, such as
+// DefaultTokenizerVocabularyURL; an empty URL disables downloads
+func (r *TokenizerRegistry) UseVocabularyURL(baseURL string) {
+	if baseURL != "" && !strings.HasSuffix(baseURL, "/") {
+		baseURL += "/"
+	}
+	r.vocabURL = baseURL
+}
+
+// Register sets the tokenizer of an encoding
+func (r *TokenizerRegistry) Register(encoding string, tokenizer Tokenizer) {
+	load := r.load(encoding)
+	load.mutex.Lock()
+	defer load.mutex.Unlock()
+	load.tokenizer = tokenizer
+}
+
+// ForModel returns the tokenizer of a model. A nil registry, or an encoding whose
+// vocabulary cannot be loaded, gives the heuristic tokenizer.
+func (r *TokenizerRegistry) ForModel(model string) Tokenizer {
+	if r == nil {
+		return HeuristicTokenizer{}
+	}
+	return r.ForEncoding(EncodingForModel(model))
+}
+
+// ForEncoding returns the tokenizer of an encoding, loading its vocabulary on first use.
+// While the vocabulary cannot be loaded the heuristic tokenizer is returned, and loading
+// is tried again once the retry delay has passed.
+func (r *TokenizerRegistry) ForEncoding(encoding string) Tokenizer {
+	tokenizer, err := r.loadEncoding(encoding)
+	if err != nil {
+		return HeuristicTokenizer{}
+	}
+	return tokenizer
+}
+
+// LoadModels loads the vocabularies of the models, so a deployment can check at startup
+// that their token counts will not be estimated
+func (r *TokenizerRegistry) LoadModels(models ...string) error {
+	for _, model := range models {
+		if _, err := r.loadEncoding(EncodingForModel(model)); err != nil {
+			return fmt.Errorf("model %s: %w", model, err)
+		}
+	}
+	return nil
+}
+
+// load returns the load state of an encoding
+func (r *TokenizerRegistry) load(encoding string) *tokenizerLoad {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	load, ok := r.loads[encoding]
+	if !ok {
+		load = &tokenizerLoad{}
+		r.loads[encoding] = load
+	}
+	return load
+}
+
+// loadEncoding returns the tokenizer of an encoding, loading its vocabulary unless it is
+// loaded or a failed load is waiting for its retry. Concurrent callers for the encoding
+// wait for a single load.
+func (r *TokenizerRegistry) loadEncoding(encoding string) (Tokenizer, error) {
+	load := r.load(encoding)
+	load.mutex.Lock()
+	defer load.mutex.Unlock()
+
+	if load.tokenizer != nil {
+		return load.tokenizer, nil
+	}
+	if time.Now().Before(load.retryAt) {
+		return nil, fmt.Errorf("vocabulary %s failed to load; retrying at %s", encoding, load.retryAt.Format(time.RFC3339))
+	}
+
+	ranks, err := r.loadVocabulary(encoding)
+	var tokenizer *BPETokenizer
+	if err == nil {
+		tokenizer, err = NewBPETokenizer(encoding, ranks)
+	}
+	if err != nil {
+		load.failures++
+		delay := vocabularyRetryDelay << (load.failures - 1)
+		if delay > maxVocabularyRetryDelay || delay <= 0 {
+			delay = maxVocabularyRetryDelay
+		}
+		load.retryAt = time.Now().Add(delay)
+		fmt.Printf("Warning: token counts for %s are estimated until its vocabulary loads, next try in %v: %v\n", encoding, delay, err)
+		return nil, err
+	}
+
+	load.tokenizer = tokenizer
+	load.failures = 0
+	return tokenizer, nil
+}
+
+// loadVocabulary reads an encoding's vocabulary from the vocabulary directory,
+// downloading it there first when it is missing
+func (r *TokenizerRegistry) loadVocabulary(encoding string) (map[string]int, error) {
+	if r.vocabDir == "" {
+		return nil, fmt.Errorf("no vocabulary directory configured")
+	}
+	path := filepath.Join(r.vocabDir, encoding+".tiktoken")
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) && r.vocabURL != "" {
+		data, err = r.downloadVocabulary(encoding, path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read vocabulary: %w", err)
+	}
+	return ParseTiktokenVocabulary(bytes.NewReader(data))
+}
+
+// downloadVocabulary fetches an encoding's vocabulary and stores it at path
+func (r *TokenizerRegistry) downloadVocabulary(encoding, path string) ([]byte, error) {
+	response, err := r.httpClient.Get(r.vocabURL + encoding + ".tiktoken")
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("vocabulary download returned status %d", response.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(response.Body, maxVocabularyBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxVocabularyBytes {
+		return nil, fmt.Errorf("vocabulary is larger than %d bytes", maxVocabularyBytes)
+	}
+	if _, err := ParseTiktokenVocabulary(bytes.NewReader(data)); err != nil {
+		return nil, fmt.Errorf("downloaded vocabulary is invalid: %w", err)
+	}
+
+	// Written through a temporary file, so other processes never read a partial vocabulary
+	if err := os.MkdirAll(r.vocabDir, 0o755); err != nil {
+		fmt.Printf("Warning: failed to store vocabulary %s: %v\n", encoding, err)
+		return data, nil
+	}
+	temp, err := os.CreateTemp(r.vocabDir, encoding+".*.tmp")
+	if err == nil {
+		_, err = temp.Write(data)
+		if closeErr := temp.Close(); err == nil {
+			err = closeErr
+		}
+		if err == nil {
+			err = os.Rename(temp.Name(), path)
+		}
+		if err != nil {
+			os.Remove(temp.Name())
+		}
+	}
+	if err != nil {
+		fmt.Printf("Warning: failed to store vocabulary %s: %v\n", encoding, err)
+	}
+	return data, nil
+}
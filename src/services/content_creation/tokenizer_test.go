@@ -0,0 +1,151 @@
+package content_creation
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPretokenizers_SplitLikeTheEncodingPatterns(t *testing.T) {
+	assert.Equal(t,
+		[]string{"Hello", " world", "'s", " ", " end", "\n\n", " ", " x"},
+		splitCL100K("Hello world's  end\n\n  x"))
+	assert.Equal(t,
+		[]string{"if", " (", "x", ">=", "100", ")", " {\n", "\treturn", " ", "123", "45", ";\n", "}"},
+		splitCL100K("if (x>=100) {\n\treturn 12345;\n}"))
+
+	assert.Equal(t, []string{"Hello", "World", " don't"}, splitO200K("HelloWorld don't"))
+	assert.Equal(t, []string{"path", "/to", "/"}, splitO200K("path/to/"))
+}
+
+// testVocabulary holds every byte and the merges of "hello"
+func testVocabulary() map[string]int {
+	ranks := make(map[string]int)
+	for b := 0; b < 256; b++ {
+		ranks[string([]byte{byte(b)})] = b
+	}
+	ranks["ll"] = 256
+	ranks["he"] = 257
+	ranks["hell"] = 258
+	return ranks
+}
+
+func writeVocabulary(t *testing.T, path string, ranks map[string]int) []byte {
+	t.Helper()
+	var data strings.Builder
+	for token, rank := range ranks {
+		fmt.Fprintf(&data, "%s %d\n", base64.StdEncoding.EncodeToString([]byte(token)), rank)
+	}
+	if path != "" {
+		require.NoError(t, os.WriteFile(path, []byte(data.String()), 0o644))
+	}
+	return []byte(data.String())
+}
+
+func TestBPETokenizer_MergesByRank(t *testing.T) {
+	tokenizer, err := NewBPETokenizer(EncodingCL100K, testVocabulary())
+	require.NoError(t, err)
+
+	assert.Equal(t, []int{258, 'o'}, tokenizer.Encode("hello"))
+	assert.Equal(t, []int{258, 'o', ' ', 258, 'o'}, tokenizer.Encode("hello hello"))
+	assert.Equal(t, "hello hello", tokenizer.Decode(tokenizer.Encode("hello hello")))
+	assert.Equal(t, 5, tokenizer.CountTokens("héllo"), "é is two bytes and only ll merges")
+
+	_, err = NewBPETokenizer(EncodingCL100K, map[string]int{"a": 0})
+	assert.ErrorContains(t, err, "no token for byte")
+}
+
+func TestTokenizerRegistry_LoadsVocabulariesPerModel(t *testing.T) {
+	dir := t.TempDir()
+	writeVocabulary(t, filepath.Join(dir, EncodingCL100K+".tiktoken"), testVocabulary())
+
+	downloads := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		downloads++
+		if r.URL.Path != "/"+EncodingO200K+".tiktoken" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write(writeVocabulary(t, "", testVocabulary()))
+	}))
+	defer server.Close()
+
+	registry := NewTokenizerRegistry(dir)
+	registry.UseVocabularyURL(server.URL)
+
+	gpt4, ok := registry.ForModel("gpt-4").(*BPETokenizer)
+	require.True(t, ok)
+	assert.Equal(t, EncodingCL100K, gpt4.Encoding())
+	assert.Equal(t, gpt4, registry.ForModel("claude-3-5-sonnet"))
+
+	gpt4o, ok := registry.ForModel("gpt-4o-mini").(*BPETokenizer)
+	require.True(t, ok)
+	assert.Equal(t, EncodingO200K, gpt4o.Encoding())
+	assert.Equal(t, 2, gpt4o.CountTokens("hello"))
+	assert.FileExists(t, filepath.Join(dir, EncodingO200K+".tiktoken"))
+	assert.Equal(t, 1, downloads)
+
+	// Without a vocabulary, token counts are estimated
+	offline := NewTokenizerRegistry(t.TempDir())
+	offline.UseVocabularyURL("")
+	assert.Equal(t, HeuristicTokenizer{}, offline.ForModel("gpt-4"))
+	var unset *TokenizerRegistry
+	assert.Equal(t, HeuristicTokenizer{}, unset.ForModel("gpt-4"))
+}
+
+func TestTokenizerRegistry_RetriesFailedLoadsWithoutBlockingOtherEncodings(t *testing.T) {
+	dir := t.TempDir()
+	writeVocabulary(t, filepath.Join(dir, EncodingCL100K+".tiktoken"), testVocabulary())
+
+	var downloads atomic.Int64
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if downloads.Add(1) == 1 {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		<-release
+		w.Write(writeVocabulary(t, "", testVocabulary()))
+	}))
+	defer server.Close()
+
+	registry := NewTokenizerRegistry(dir)
+	registry.UseVocabularyURL(server.URL)
+
+	// A failed download is not retried until its retry delay has passed
+	assert.Equal(t, HeuristicTokenizer{}, registry.ForModel("gpt-4o"))
+	assert.ErrorContains(t, registry.LoadModels("gpt-4", "gpt-4o"), "model gpt-4o")
+	assert.Equal(t, int64(1), downloads.Load())
+
+	registry.load(EncodingO200K).retryAt = time.Time{}
+	loaded := make(chan Tokenizer)
+	go func() { loaded <- registry.ForModel("gpt-4o") }()
+	require.Eventually(t, func() bool { return downloads.Load() == 2 }, time.Second, time.Millisecond)
+
+	// Other encodings are served while the download is in flight
+	_, ok := registry.ForModel("gpt-4").(*BPETokenizer)
+	assert.True(t, ok)
+
+	close(release)
+	gpt4o, ok := (<-loaded).(*BPETokenizer)
+	require.True(t, ok)
+	assert.Equal(t, EncodingO200K, gpt4o.Encoding())
+	assert.NoError(t, registry.LoadModels("gpt-4", "gpt-4o"))
+}
+
+func TestHeuristicTokenizer_CountsPieces(t *testing.T) {
+	tokenizer := HeuristicTokenizer{}
+	assert.Equal(t, 4, tokenizer.CountTokens("The quick brown fox"))
+	assert.Equal(t, 4, tokenizer.CountTokens("你好世界"))
+	assert.Greater(t, tokenizer.CountTokens("if (x>=100) {\n\treturn 12345;\n}"), len("if (x>=100) {\n\treturn 12345;\n}")/4)
+}
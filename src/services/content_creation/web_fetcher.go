@@ -0,0 +1,559 @@
+package content_creation
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/html/charset"
+)
+
+const (
+	// maxRobotsBytes is how much of a robots.txt file is read, as RFC 9309 requires at least 500 KiB
+	maxRobotsBytes = 512 * 1024
+	// robotsTTL is how long a host's robots.txt rules are kept
+	robotsTTL = 24 * time.Hour
+	// robotsRetryTTL is how long an unreachable robots.txt disallows its host before it is
+	// fetched again, so a transient failure does not block the host for a day
+	robotsRetryTTL = 5 * time.Minute
+)
+
+// FetcherConfig configures how research pages are fetched
+type FetcherConfig struct {
+	UserAgent           string        // sent with every request
+	RobotsAgent         string        // product token matched against robots.txt user-agent lines
+	AllowedContentTypes []string      // media types the fetcher accepts
+	MaxBodyBytes        int64         // pages larger than this are refused
+	HostDelay           time.Duration // minimum time between requests to the same host
+	MaxCrawlDelay       time.Duration // upper bound on a robots.txt crawl-delay
+	MaxRedirects        int
+	Timeout             time.Duration
+	CacheDir            string        // on-disk response cache; empty disables caching
+	CacheTTL            time.Duration // how long cached responses are served
+}
+
+// DefaultFetcherConfig returns the fetcher configuration used when none is given
+func DefaultFetcherConfig() FetcherConfig {
+	return FetcherConfig{
+		UserAgent:           "Mozilla/5.0 (compatible; ContentCreationService/1.0)",
+		RobotsAgent:         "ContentCreationService",
+		AllowedContentTypes: []string{"text/html", "application/xhtml+xml", "text/plain"},
+		MaxBodyBytes:        5 * 1024 * 1024,
+		HostDelay:           time.Second,
+		MaxCrawlDelay:       10 * time.Second,
+		MaxRedirects:        5,
+		Timeout:             10 * time.Second,
+		CacheTTL:            24 * time.Hour,
+	}
+}
+
+// FetchRefusedError reports a page the fetcher would not retrieve or keep
+type FetchRefusedError struct {
+	URL    string
+	Reason string
+}
+
+func (e *FetchRefusedError) Error() string {
+	return fmt.Sprintf("refused to fetch %s: %s", e.URL, e.Reason)
+}
+
+// PageFetcher retrieves research pages politely: it honours robots.txt, spaces out requests
+// to the same host, refuses unexpected content types and oversized bodies, decodes the
+// page's charset to UTF-8, caches responses on disk and extracts the main article.
+type PageFetcher struct {
+	config FetcherConfig
+	client *http.Client
+
+	mu       sync.Mutex
+	robots   map[string]*robotsRules // by scheme and host
+	nextSlot map[string]time.Time    // earliest time of the next request to a host
+}
+
+// NewPageFetcher creates a page fetcher. Zero fields of the config take their defaults,
+// except that a zero HostDelay spaces no requests and an empty CacheDir disables the cache.
+func NewPageFetcher(config FetcherConfig) *PageFetcher {
+	defaults := DefaultFetcherConfig()
+	if config.UserAgent == "" {
+		config.UserAgent = defaults.UserAgent
+	}
+	if config.RobotsAgent == "" {
+		config.RobotsAgent = defaults.RobotsAgent
+	}
+	if len(config.AllowedContentTypes) == 0 {
+		config.AllowedContentTypes = defaults.AllowedContentTypes
+	}
+	if config.MaxBodyBytes <= 0 {
+		config.MaxBodyBytes = defaults.MaxBodyBytes
+	}
+	if config.MaxCrawlDelay <= 0 {
+		config.MaxCrawlDelay = defaults.MaxCrawlDelay
+	}
+	if config.MaxRedirects <= 0 {
+		config.MaxRedirects = defaults.MaxRedirects
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = defaults.Timeout
+	}
+	if config.CacheTTL <= 0 {
+		config.CacheTTL = defaults.CacheTTL
+	}
+
+	f := &PageFetcher{
+		config:   config,
+		robots:   make(map[string]*robotsRules),
+		nextSlot: make(map[string]time.Time),
+	}
+	f.client = &http.Client{
+		Timeout:       config.Timeout,
+		CheckRedirect: f.checkRedirect,
+	}
+	return f
+}
+
+// Fetch retrieves a page and extracts its main content
+func (f *PageFetcher) Fetch(ctx context.Context, rawURL string) (*FetchedPage, error) {
+	target, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid URL %q: %w", rawURL, err)
+	}
+	if target.Scheme != "http" && target.Scheme != "https" {
+		return nil, &FetchRefusedError{URL: rawURL, Reason: "only http and https URLs are fetched"}
+	}
+
+	response, ok := f.cached(rawURL)
+	if !ok {
+		response, err = f.download(ctx, target)
+		if err != nil {
+			return nil, err
+		}
+		f.store(rawURL, response)
+	}
+
+	return extractPage(response.FinalURL, response.ContentType, response.Body), nil
+}
+
+// fetchedResponse is a page body decoded to UTF-8, as kept in the response cache
+type fetchedResponse struct {
+	URL         string    `json:"url"`
+	FinalURL    string    `json:"finalUrl"` // after redirects
+	ContentType string    `json:"contentType"`
+	Body        string    `json:"body"`
+	FetchedAt   time.Time `json:"fetchedAt"`
+}
+
+func (f *PageFetcher) download(ctx context.Context, target *url.URL) (*fetchedResponse, error) {
+	if err := f.checkAllowed(ctx, target); err != nil {
+		return nil, err
+	}
+	if err := f.wait(ctx, target); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", target.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", f.config.UserAgent)
+	req.Header.Set("Accept", strings.Join(f.config.AllowedContentTypes, ", "))
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, &APIError{
+			StatusCode: resp.StatusCode,
+			Message:    "Failed to fetch content",
+			RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After")),
+		}
+	}
+	if resp.ContentLength > f.config.MaxBodyBytes {
+		return nil, &FetchRefusedError{URL: target.String(), Reason: fmt.Sprintf("body of %d bytes exceeds the %d byte limit", resp.ContentLength, f.config.MaxBodyBytes)}
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, f.config.MaxBodyBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(body)) > f.config.MaxBodyBytes {
+		return nil, &FetchRefusedError{URL: target.String(), Reason: fmt.Sprintf("body exceeds the %d byte limit", f.config.MaxBodyBytes)}
+	}
+
+	contentType := resp.Header.Get("Content-Type")
+	if contentType == "" {
+		contentType = http.DetectContentType(body)
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil || !f.allowedType(mediaType) {
+		return nil, &FetchRefusedError{URL: target.String(), Reason: fmt.Sprintf("content type %q is not allowed", contentType)}
+	}
+
+	// Decode using the charset of the header, a byte order mark or the page's meta tags
+	encoding, _, _ := charset.DetermineEncoding(body, contentType)
+	decoded, err := encoding.NewDecoder().Bytes(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", target, err)
+	}
+
+	return &fetchedResponse{
+		URL:         target.String(),
+		FinalURL:    resp.Request.URL.String(),
+		ContentType: mediaType,
+		Body:        string(decoded),
+		FetchedAt:   time.Now(),
+	}, nil
+}
+
+func (f *PageFetcher) allowedType(mediaType string) bool {
+	for _, allowed := range f.config.AllowedContentTypes {
+		if strings.EqualFold(mediaType, allowed) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkRedirect limits redirects and applies robots.txt and politeness to every hop
+func (f *PageFetcher) checkRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) >= f.config.MaxRedirects {
+		return fmt.Errorf("stopped after %d redirects", f.config.MaxRedirects)
+	}
+	if req.URL.Scheme != "http" && req.URL.Scheme != "https" {
+		return &FetchRefusedError{URL: req.URL.String(), Reason: "redirect leaves http and https"}
+	}
+	if err := f.checkAllowed(req.Context(), req.URL); err != nil {
+		return err
+	}
+	return f.wait(req.Context(), req.URL)
+}
+
+// wait blocks until the host of target may be sent another request
+func (f *PageFetcher) wait(ctx context.Context, target *url.URL) error {
+	delay := f.config.HostDelay
+	if rules := f.cachedRobots(target); rules != nil && rules.crawlDelay > delay {
+		delay = rules.crawlDelay
+		if delay > f.config.MaxCrawlDelay {
+			delay = f.config.MaxCrawlDelay
+		}
+	}
+
+	f.mu.Lock()
+	now := time.Now()
+	slot := now
+	if next, ok := f.nextSlot[target.Host]; ok && next.After(now) {
+		slot = next
+	}
+	f.nextSlot[target.Host] = slot.Add(delay)
+	f.mu.Unlock()
+
+	if wait := slot.Sub(now); wait > 0 {
+		timer := time.NewTimer(wait)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+	return nil
+}
+
+// checkAllowed returns an error when robots.txt disallows fetching target
+func (f *PageFetcher) checkAllowed(ctx context.Context, target *url.URL) error {
+	rules := f.cachedRobots(target)
+	if rules == nil {
+		var err error
+		if rules, err = f.loadRobots(ctx, target); err != nil {
+			return err
+		}
+	}
+	path := target.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	if target.RawQuery != "" {
+		path += "?" + target.RawQuery
+	}
+	if !rules.allows(path) {
+		return &FetchRefusedError{URL: target.String(), Reason: "disallowed by robots.txt"}
+	}
+	return nil
+}
+
+func (f *PageFetcher) cachedRobots(target *url.URL) *robotsRules {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	rules, ok := f.robots[target.Scheme+"://"+target.Host]
+	if !ok || time.Since(rules.fetchedAt) > rules.ttl() {
+		return nil
+	}
+	return rules
+}
+
+// loadRobots fetches and parses the robots.txt of target's host. Following RFC 9309, a
+// missing file allows everything and an unreachable one disallows everything, for as long
+// as it stays unreachable. Giving up because ctx is done is returned as an error and not
+// held against the host.
+func (f *PageFetcher) loadRobots(ctx context.Context, target *url.URL) (*robotsRules, error) {
+	origin := target.Scheme + "://" + target.Host
+	rules := &robotsRules{fetchedAt: time.Now()}
+
+	status, body, err := f.getRobots(ctx, origin+"/robots.txt")
+	if err != nil && ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	switch {
+	case err != nil || status >= 500:
+		rules.disallowAll = true
+		rules.unreachable = true
+	case status >= 200 && status < 300:
+		rules = parseRobots(body, f.config.RobotsAgent)
+	}
+
+	f.mu.Lock()
+	f.robots[origin] = rules
+	f.mu.Unlock()
+	return rules, nil
+}
+
+func (f *PageFetcher) getRobots(ctx context.Context, robotsURL string) (int, string, error) {
+	parsed, err := url.Parse(robotsURL)
+	if err != nil {
+		return 0, "", err
+	}
+	if err := f.wait(ctx, parsed); err != nil {
+		return 0, "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", robotsURL, nil)
+	if err != nil {
+		return 0, "", err
+	}
+	req.Header.Set("User-Agent", f.config.UserAgent)
+
+	// robots.txt redirects are followed without consulting robots.txt again
+	client := &http.Client{Timeout: f.config.Timeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, "", err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxRobotsBytes))
+	if err != nil {
+		return 0, "", err
+	}
+	return resp.StatusCode, string(body), nil
+}
+
+// cached returns the cached response for a URL when it is still fresh
+func (f *PageFetcher) cached(rawURL string) (*fetchedResponse, bool) {
+	if f.config.CacheDir == "" {
+		return nil, false
+	}
+	data, err := os.ReadFile(f.cachePath(rawURL))
+	if err != nil {
+		return nil, false
+	}
+	var response fetchedResponse
+	if err := json.Unmarshal(data, &response); err != nil || response.URL != rawURL {
+		return nil, false
+	}
+	if time.Since(response.FetchedAt) > f.config.CacheTTL {
+		return nil, false
+	}
+	return &response, true
+}
+
+// store writes a response to the cache. The file is renamed into place so that concurrent
+// fetchers never read a partial entry.
+func (f *PageFetcher) store(rawURL string, response *fetchedResponse) {
+	if f.config.CacheDir == "" {
+		return
+	}
+	if err := os.MkdirAll(f.config.CacheDir, 0o755); err != nil {
+		fmt.Printf("Warning: Failed to create fetch cache directory: %v\n", err)
+		return
+	}
+	data, err := json.Marshal(response)
+	if err != nil {
+		fmt.Printf("Warning: Failed to encode cached response for %s: %v\n", rawURL, err)
+		return
+	}
+
+	tmp, err := os.CreateTemp(f.config.CacheDir, "fetch-*.tmp")
+	if err != nil {
+		fmt.Printf("Warning: Failed to cache response for %s: %v\n", rawURL, err)
+		return
+	}
+	_, writeErr := tmp.Write(data)
+	closeErr := tmp.Close()
+	if writeErr == nil {
+		writeErr = closeErr
+	}
+	if writeErr == nil {
+		writeErr = os.Rename(tmp.Name(), f.cachePath(rawURL))
+	}
+	if writeErr != nil {
+		os.Remove(tmp.Name())
+		fmt.Printf("Warning: Failed to cache response for %s: %v\n", rawURL, writeErr)
+	}
+}
+
+func (f *PageFetcher) cachePath(rawURL string) string {
+	sum := sha256.Sum256([]byte(rawURL))
+	return filepath.Join(f.config.CacheDir, hex.EncodeToString(sum[:])+".json")
+}
+
+// robotsRules are the robots.txt rules that apply to the fetcher
+type robotsRules struct {
+	rules       []robotsRule
+	crawlDelay  time.Duration
+	disallowAll bool
+	unreachable bool // robots.txt could not be fetched, so it is tried again sooner
+	fetchedAt   time.Time
+}
+
+// ttl returns how long the rules are kept
+func (r *robotsRules) ttl() time.Duration {
+	if r.unreachable {
+		return robotsRetryTTL
+	}
+	return robotsTTL
+}
+
+type robotsRule struct {
+	allow   bool
+	length  int // length of the pattern; the longest matching rule wins
+	pattern *regexp.Regexp
+}
+
+// allows reports whether the rules allow a path, including its query
+func (r *robotsRules) allows(path string) bool {
+	if r.disallowAll {
+		return false
+	}
+	allowed, longest := true, -1
+	for _, rule := range r.rules {
+		if !rule.pattern.MatchString(path) {
+			continue
+		}
+		// On equal length the least restrictive rule wins
+		if rule.length > longest || (rule.length == longest && rule.allow) {
+			allowed, longest = rule.allow, rule.length
+		}
+	}
+	return allowed
+}
+
+// parseRobots parses a robots.txt file, keeping the groups for agent or, when no group
+// names it, the groups for every crawler
+func parseRobots(body, agent string) *robotsRules {
+	type group struct {
+		agents     []string
+		rules      []robotsRule
+		crawlDelay time.Duration
+	}
+
+	var groups []*group
+	var current *group
+	inAgents := false
+	for _, line := range strings.Split(body, "\n") {
+		if comment := strings.Index(line, "#"); comment >= 0 {
+			line = line[:comment]
+		}
+		key, value, found := strings.Cut(line, ":")
+		if !found {
+			continue
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+
+		switch key {
+		case "user-agent":
+			if !inAgents {
+				current = &group{}
+				groups = append(groups, current)
+			}
+			current.agents = append(current.agents, strings.ToLower(value))
+			inAgents = true
+		case "allow", "disallow":
+			inAgents = false
+			if current == nil || value == "" {
+				continue
+			}
+			current.rules = append(current.rules, robotsRule{
+				allow:   key == "allow",
+				length:  len(value),
+				pattern: robotsPattern(value),
+			})
+		case "crawl-delay":
+			inAgents = false
+			if current == nil {
+				continue
+			}
+			if seconds, err := strconv.ParseFloat(value, 64); err == nil && seconds > 0 {
+				current.crawlDelay = time.Duration(seconds * float64(time.Second))
+			}
+		}
+	}
+
+	// Merge every group naming the agent, falling back to the groups for all crawlers
+	agent = strings.ToLower(agent)
+	rules := &robotsRules{fetchedAt: time.Now()}
+	for _, wildcard := range []bool{false, true} {
+		matched := false
+		for _, g := range groups {
+			for _, name := range g.agents {
+				if (wildcard && name == "*") || (!wildcard && name == agent) {
+					matched = true
+					rules.rules = append(rules.rules, g.rules...)
+					if g.crawlDelay > rules.crawlDelay {
+						rules.crawlDelay = g.crawlDelay
+					}
+					break
+				}
+			}
+		}
+		if matched {
+			break
+		}
+	}
+	return rules
+}
+
+// robotsPattern compiles a robots.txt path pattern, where * matches any characters and a
+// trailing $ anchors the end of the path
+func robotsPattern(value string) *regexp.Regexp {
+	anchored := strings.HasSuffix(value, "$")
+	value = strings.TrimSuffix(value, "$")
+
+	var pattern bytes.Buffer
+	pattern.WriteString("^")
+	for i, part := range strings.Split(value, "*") {
+		if i > 0 {
+			pattern.WriteString(".*")
+		}
+		pattern.WriteString(regexp.QuoteMeta(part))
+	}
+	if anchored {
+		pattern.WriteString("$")
+	}
+	return regexp.MustCompile(pattern.String())
+}
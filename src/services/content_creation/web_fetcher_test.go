@@ -0,0 +1,178 @@
+package content_creation
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const articlePage = `<!DOCTYPE html>
+<html><head>
+<meta charset="iso-8859-1">
+<title>Small teams | Example Blog</title>
+<meta property="og:title" content="Why Small Teams Ship Faster">
+<meta property="article:published_time" content="2024-03-01T09:00:00Z">
+<script>var tracking = "do not keep";</script>
+</head><body>
+<header class="site-header"><a href="/">Home</a> <a href="/about">About</a></header>
+<nav><ul><li><a href="/blog">Blog</a></li><li><a href="/contact">Contact</a></li></ul></nav>
+<div id="cookie-banner">We use cookies. Accept cookies to continue browsing the site.</div>
+<article class="post-content">
+<h1>Why Small Teams Ship Faster</h1>
+<p>The number of communication paths in a team grows quadratically with its size, which is why a caf` + "\xe9" + ` table of five moves faster than a room of fifteen.</p>
+<h2>Fewer handoffs</h2>
+<p>Every handoff between people is a place where context is lost, decisions are repeated, and work waits in a queue for someone else.</p>
+<ul><li>Own the whole feature</li><li>Decide close to the work<ul><li>Keep meetings short</li></ul></li></ul>
+<p>Small teams keep ownership in one place, so the people who build a feature also ship it, support it and improve it.</p>
+</article>
+<aside class="sidebar"><p>Related posts you might enjoy reading next, with plenty of links and commentary.</p></aside>
+<footer>Copyright Example Blog, all rights reserved, every year.</footer>
+</body></html>`
+
+func newFetcherTestServer(t *testing.T, hits *int32) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if hits != nil && r.URL.Path != "/robots.txt" {
+			atomic.AddInt32(hits, 1)
+		}
+		switch r.URL.Path {
+		case "/robots.txt":
+			w.Write([]byte("User-agent: *\nDisallow: /private\nAllow: /private/press\n\nUser-agent: OtherBot\nDisallow: /\n"))
+		case "/article", "/private/press/release":
+			w.Header().Set("Content-Type", "text/html; charset=ISO-8859-1")
+			w.Write([]byte(articlePage))
+		case "/old-article":
+			http.Redirect(w, r, "/article", http.StatusMovedPermanently)
+		case "/image":
+			w.Header().Set("Content-Type", "image/png")
+			w.Write([]byte("\x89PNG"))
+		case "/huge":
+			w.Header().Set("Content-Type", "text/plain")
+			w.Write([]byte(strings.Repeat("a", 4096)))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestPageFetcher_ExtractsMainArticle(t *testing.T) {
+	server := newFetcherTestServer(t, nil)
+	fetcher := NewPageFetcher(FetcherConfig{})
+
+	page, err := fetcher.Fetch(context.Background(), server.URL+"/old-article")
+	require.NoError(t, err)
+
+	assert.Equal(t, server.URL+"/article", page.URL)
+	assert.Equal(t, "Why Small Teams Ship Faster", page.Title)
+	assert.Equal(t, time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC), page.PublishedAt.UTC())
+	assert.Contains(t, page.Text, "# Why Small Teams Ship Faster")
+	assert.Contains(t, page.Text, "a café table of five")
+	assert.Contains(t, page.Text, "## Fewer handoffs")
+	assert.Contains(t, page.Text, "- Own the whole feature\n- Decide close to the work\n  - Keep meetings short")
+	for _, boilerplate := range []string{"Home", "Contact", "cookies", "tracking", "Related posts", "Copyright"} {
+		assert.NotContains(t, page.Text, boilerplate)
+	}
+	assert.True(t, strings.HasPrefix(page.Document(), "Published: 2024-03-01\n\n# Why Small Teams Ship Faster"))
+}
+
+func TestPageFetcher_RefusesPages(t *testing.T) {
+	server := newFetcherTestServer(t, nil)
+	fetcher := NewPageFetcher(FetcherConfig{MaxBodyBytes: 2048})
+	ctx := context.Background()
+
+	for _, path := range []string{"/private/notes", "/image", "/huge"} {
+		_, err := fetcher.Fetch(ctx, server.URL+path)
+		var refused *FetchRefusedError
+		assert.ErrorAs(t, err, &refused, path)
+	}
+
+	// The longer allow rule wins over the shorter disallow rule
+	_, err := fetcher.Fetch(ctx, server.URL+"/private/press/release")
+	assert.NoError(t, err)
+
+	rules := parseRobots("User-agent: *\nDisallow: /private\n\nUser-agent: contentcreationservice\nDisallow: /drafts/*.html$\n", "ContentCreationService")
+	assert.True(t, rules.allows("/private"))
+	assert.False(t, rules.allows("/drafts/one.html"))
+	assert.True(t, rules.allows("/drafts/one.html?print=1"))
+}
+
+func TestPageFetcher_CachesResponsesOnDisk(t *testing.T) {
+	var hits int32
+	server := newFetcherTestServer(t, &hits)
+	config := FetcherConfig{CacheDir: t.TempDir()}
+
+	first := NewPageFetcher(config)
+	fetched, err := first.Fetch(context.Background(), server.URL+"/article")
+	require.NoError(t, err)
+
+	// A new fetcher, as in another process, serves the page from the cache
+	second := NewPageFetcher(config)
+	cached, err := second.Fetch(context.Background(), server.URL+"/article")
+	require.NoError(t, err)
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&hits))
+	assert.Equal(t, fetched, cached)
+}
+
+func TestPageFetcher_SpacesRequestsToAHost(t *testing.T) {
+	server := newFetcherTestServer(t, nil)
+	fetcher := NewPageFetcher(FetcherConfig{HostDelay: 50 * time.Millisecond})
+
+	start := time.Now()
+	for i := 0; i < 2; i++ {
+		_, err := fetcher.Fetch(context.Background(), server.URL+"/article")
+		require.NoError(t, err)
+	}
+
+	// robots.txt and two page requests, each waiting for the previous one's slot
+	assert.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond)
+}
+
+func TestPageFetcher_RetriesUnreachableRobotsSoon(t *testing.T) {
+	var unavailable atomic.Bool
+	unavailable.Store(true)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/robots.txt" && unavailable.Load() {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(articlePage))
+	}))
+	t.Cleanup(server.Close)
+	fetcher := NewPageFetcher(FetcherConfig{})
+
+	// A cancelled fetch is not held against the host
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := fetcher.Fetch(cancelled, server.URL+"/article")
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Empty(t, fetcher.robots)
+
+	// An unreachable robots.txt disallows the host until it is fetched again
+	ctx := context.Background()
+	_, err = fetcher.Fetch(ctx, server.URL+"/article")
+	var refused *FetchRefusedError
+	require.ErrorAs(t, err, &refused)
+	rules := fetcher.robots[server.URL]
+	require.NotNil(t, rules)
+	assert.Equal(t, robotsRetryTTL, rules.ttl())
+
+	unavailable.Store(false)
+	_, err = fetcher.Fetch(ctx, server.URL+"/article")
+	assert.ErrorAs(t, err, &refused)
+
+	rules.fetchedAt = time.Now().Add(-robotsRetryTTL - time.Second)
+	_, err = fetcher.Fetch(ctx, server.URL+"/article")
+	assert.NoError(t, err)
+	assert.Equal(t, robotsTTL, fetcher.robots[server.URL].ttl())
+}
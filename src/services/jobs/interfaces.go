@@ -0,0 +1,39 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/Ceesaxp/autonomous-content-service/src/domain/entities"
+	"github.com/Ceesaxp/autonomous-content-service/src/services/content_creation"
+	"github.com/google/uuid"
+)
+
+// ContentRunner runs stored content through the pipeline stages.
+// It is implemented by content_creation.ContentPipeline.
+type ContentRunner interface {
+	// FirstIncompleteStage returns the stage a run should start from
+	FirstIncompleteStage(ctx context.Context, contentID uuid.UUID) (content_creation.PipelineStage, error)
+
+	RunContent(ctx context.Context, contentID uuid.UUID, from content_creation.PipelineStage, observer content_creation.StageObserver) (*entities.Content, error)
+}
+
+// Config contains the worker pool settings
+type Config struct {
+	// Workers is the number of jobs processed concurrently
+	Workers int
+
+	// MaxAttempts is how many times a job is run before it is marked as failed
+	MaxAttempts int
+
+	// WorkerID identifies this process in job leases; defaults to the host name and a
+	// random suffix
+	WorkerID string
+
+	// Lease is how long a claimed job is held without a renewal before other processes
+	// requeue it. Running jobs renew it at a third of the interval.
+	Lease time.Duration
+
+	// RetryBackoff is the delay before a failed job is retried, multiplied by its attempts
+	RetryBackoff time.Duration
+}
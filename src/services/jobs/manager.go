@@ -0,0 +1,389 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Ceesaxp/autonomous-content-service/src/domain/entities"
+	"github.com/Ceesaxp/autonomous-content-service/src/domain/repositories"
+	"github.com/Ceesaxp/autonomous-content-service/src/services/content_creation"
+	"github.com/google/uuid"
+)
+
+// saveTimeout bounds job persistence so state is still written while shutting down
+const saveTimeout = 5 * time.Second
+
+const (
+	defaultLease        = 2 * time.Minute
+	defaultRetryBackoff = 30 * time.Second
+)
+
+// Manager runs content generation jobs on a pool of background workers.
+// Jobs are persisted, so queued and interrupted jobs are picked up again by Start
+// and resume from the first stage without a completed checkpoint.
+//
+// Several processes can share the job repository: a worker claims a job atomically
+// before running it and holds it on a lease it keeps renewing, so a job only runs in one
+// place. Jobs whose lease expired, because their process died, are requeued.
+type Manager struct {
+	repo   repositories.ContentJobRepository
+	runner ContentRunner
+	config Config
+
+	mu      sync.Mutex
+	pending []uuid.UUID
+	wake    chan struct{}
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewManager creates a job manager; call Start to begin processing
+func NewManager(repo repositories.ContentJobRepository, runner ContentRunner, config Config) *Manager {
+	if config.Workers <= 0 {
+		config.Workers = 1
+	}
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = 1
+	}
+	if config.WorkerID == "" {
+		host, _ := os.Hostname()
+		config.WorkerID = fmt.Sprintf("%s-%s", host, uuid.NewString()[:8])
+	}
+	if config.Lease <= 0 {
+		config.Lease = defaultLease
+	}
+	if config.RetryBackoff <= 0 {
+		config.RetryBackoff = defaultRetryBackoff
+	}
+
+	return &Manager{
+		repo:   repo,
+		runner: runner,
+		config: config,
+		wake:   make(chan struct{}, 1),
+	}
+}
+
+// Start re-queues jobs whose lease expired, queues the unfinished jobs from the
+// repository and starts the workers. Jobs still leased by another process are left to it.
+func (m *Manager) Start(ctx context.Context) error {
+	if _, err := m.repo.RequeueExpired(ctx, time.Now()); err != nil {
+		return fmt.Errorf("failed to requeue interrupted jobs: %w", err)
+	}
+	queued, err := m.repo.FindByStatus(ctx, entities.ContentJobStatusQueued)
+	if err != nil {
+		return fmt.Errorf("failed to load unfinished jobs: %w", err)
+	}
+
+	for _, job := range queued {
+		m.enqueue(job.JobID)
+	}
+	if len(queued) > 0 {
+		log.Printf("Resuming %d content jobs", len(queued))
+	}
+
+	workerCtx, cancel := context.WithCancel(context.Background())
+	m.cancel = cancel
+	for i := 0; i < m.config.Workers; i++ {
+		m.wg.Add(1)
+		go m.worker(workerCtx)
+	}
+	m.wg.Add(1)
+	go m.requeueExpired(workerCtx)
+
+	return nil
+}
+
+// requeueExpired periodically takes over the jobs of processes that stopped renewing
+// their leases
+func (m *Manager) requeueExpired(ctx context.Context) {
+	defer m.wg.Done()
+
+	ticker := time.NewTicker(m.config.Lease)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		expired, err := m.repo.RequeueExpired(ctx, time.Now())
+		if err != nil {
+			log.Printf("Failed to requeue expired content jobs: %v", err)
+			continue
+		}
+		for _, job := range expired {
+			log.Printf("Content job %s lost its lease on %s, requeuing", job.JobID, job.Worker)
+			m.enqueue(job.JobID)
+		}
+	}
+}
+
+// Stop cancels running jobs and waits for the workers to exit or ctx to expire.
+// Interrupted jobs stay queued and are resumed by the next Start.
+func (m *Manager) Stop(ctx context.Context) error {
+	if m.cancel == nil {
+		return nil
+	}
+	m.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		m.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// ErrJobActive is returned when content already has a queued or running job
+var ErrJobActive = repositories.ErrContentJobActive
+
+// Submit persists a new job for the content and queues it for the workers.
+// The job starts from the first stage the content has not completed.
+func (m *Manager) Submit(ctx context.Context, content *entities.Content) (*entities.ContentJob, error) {
+	job := entities.NewContentJob(content.ContentID, content.ProjectID)
+	if err := m.repo.Create(ctx, job); err != nil {
+		if errors.Is(err, repositories.ErrContentJobActive) {
+			return nil, ErrJobActive
+		}
+		return nil, fmt.Errorf("failed to persist job: %w", err)
+	}
+
+	m.enqueue(job.JobID)
+	return job, nil
+}
+
+// Get returns the current state of a job
+func (m *Manager) Get(ctx context.Context, jobID uuid.UUID) (*entities.ContentJob, error) {
+	return m.repo.FindByID(ctx, jobID)
+}
+
+// Latest returns the most recent job of the content, or nil when it has none
+func (m *Manager) Latest(ctx context.Context, contentID uuid.UUID) (*entities.ContentJob, error) {
+	jobs, err := m.repo.FindByContentID(ctx, contentID)
+	if err != nil || len(jobs) == 0 {
+		return nil, err
+	}
+	return jobs[len(jobs)-1], nil
+}
+
+func (m *Manager) enqueue(jobID uuid.UUID) {
+	m.mu.Lock()
+	m.pending = append(m.pending, jobID)
+	m.mu.Unlock()
+
+	m.signal()
+}
+
+// signal wakes one idle worker without blocking
+func (m *Manager) signal() {
+	select {
+	case m.wake <- struct{}{}:
+	default:
+	}
+}
+
+// next pops the oldest pending job
+func (m *Manager) next() (uuid.UUID, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if len(m.pending) == 0 {
+		return uuid.Nil, false
+	}
+	jobID := m.pending[0]
+	m.pending = m.pending[1:]
+
+	// Pass the wake-up on so other idle workers pick up the remaining jobs
+	if len(m.pending) > 0 {
+		m.signal()
+	}
+	return jobID, true
+}
+
+func (m *Manager) worker(ctx context.Context) {
+	defer m.wg.Done()
+
+	for {
+		jobID, ok := m.next()
+		if !ok {
+			select {
+			case <-ctx.Done():
+				return
+			case <-m.wake:
+				continue
+			}
+		}
+
+		if ctx.Err() != nil {
+			return
+		}
+		m.process(ctx, jobID)
+	}
+}
+
+// process claims a queued job and runs one attempt of it, resuming after its last
+// completed stage
+func (m *Manager) process(ctx context.Context, jobID uuid.UUID) {
+	job, err := m.repo.Claim(ctx, jobID, m.config.WorkerID, time.Now().Add(m.config.Lease))
+	if repositories.IsNotFound(err) {
+		// Another worker claimed the job, or it is no longer queued
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to claim content job %s: %v", jobID, err)
+		return
+	}
+
+	runCtx, cancelRun := context.WithCancel(ctx)
+	defer cancelRun()
+	var lost atomic.Bool
+	renewed := make(chan struct{})
+	go func() {
+		defer close(renewed)
+		if !m.renewLease(runCtx, job.JobID) {
+			lost.Store(true)
+			cancelRun()
+		}
+	}()
+	defer func() {
+		cancelRun()
+		<-renewed
+	}()
+
+	from, err := m.runner.FirstIncompleteStage(runCtx, job.ContentID)
+	if errors.Is(err, content_creation.ErrPipelineComplete) {
+		// Every stage finished before the job could be marked complete
+		job.Complete()
+		m.save(job)
+		return
+	}
+
+	observer := &jobObserver{manager: m, job: job}
+	if err == nil {
+		_, err = m.runner.RunContent(runCtx, job.ContentID, from, observer)
+	}
+
+	if lost.Load() {
+		// The job was requeued while it ran and belongs to whoever claimed it since
+		log.Printf("Content job %s lost its lease, abandoning the attempt", job.JobID)
+		return
+	}
+
+	if ctx.Err() != nil {
+		// Shutting down; leave the job queued for the next start
+		job.Requeue()
+		m.save(job)
+		return
+	}
+
+	if err == nil {
+		job.Complete()
+		m.save(job)
+		return
+	}
+
+	if !observer.failed {
+		job.RecordError(job.Stage, job.Attempts, err.Error())
+	}
+
+	if job.Attempts >= m.config.MaxAttempts {
+		log.Printf("Content job %s failed after %d attempts: %v", job.JobID, job.Attempts, err)
+		job.Fail()
+		m.save(job)
+		return
+	}
+
+	job.Requeue()
+	m.save(job)
+	m.retryLater(ctx, job.JobID, time.Duration(job.Attempts)*m.config.RetryBackoff)
+}
+
+// renewLease keeps renewing the lease of a job until ctx is done. It returns false when
+// the lease was lost to another process.
+func (m *Manager) renewLease(ctx context.Context, jobID uuid.UUID) bool {
+	ticker := time.NewTicker(m.config.Lease / 3)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return true
+		case <-ticker.C:
+		}
+
+		err := m.repo.RenewLease(ctx, jobID, m.config.WorkerID, time.Now().Add(m.config.Lease))
+		if repositories.IsNotFound(err) {
+			return false
+		}
+		if err != nil && ctx.Err() == nil {
+			log.Printf("Failed to renew the lease of content job %s: %v", jobID, err)
+		}
+	}
+}
+
+// retryLater queues a job again once the delay has passed. A job still waiting when the
+// manager stops stays queued for the next start.
+func (m *Manager) retryLater(ctx context.Context, jobID uuid.UUID, delay time.Duration) {
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+		case <-timer.C:
+			m.enqueue(jobID)
+		}
+	}()
+}
+
+// save persists job state, independently of the worker context
+func (m *Manager) save(job *entities.ContentJob) {
+	ctx, cancel := context.WithTimeout(context.Background(), saveTimeout)
+	defer cancel()
+
+	if err := m.repo.Update(ctx, job); err != nil {
+		log.Printf("Failed to save content job %s: %v", job.JobID, err)
+	}
+}
+
+// jobObserver records pipeline progress on the job as stages run
+type jobObserver struct {
+	manager *Manager
+	job     *entities.ContentJob
+
+	// failed is set when the latest stage attempt failed and its error is already recorded
+	failed bool
+}
+
+func (o *jobObserver) StageStarted(stage content_creation.PipelineStage, attempt int) {
+	o.failed = false
+	o.job.EnterStage(string(stage))
+	o.manager.save(o.job)
+}
+
+func (o *jobObserver) StageFailed(stage content_creation.PipelineStage, attempt int, err error) {
+	o.failed = true
+	o.job.RecordError(string(stage), attempt, err.Error())
+	o.manager.save(o.job)
+}
+
+func (o *jobObserver) StageCompleted(stage content_creation.PipelineStage) {
+	o.job.CompleteStage(string(stage))
+	o.manager.save(o.job)
+}
@@ -0,0 +1,346 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Ceesaxp/autonomous-content-service/src/domain/entities"
+	"github.com/Ceesaxp/autonomous-content-service/src/domain/repositories"
+	"github.com/Ceesaxp/autonomous-content-service/src/infrastructure/memory"
+	"github.com/Ceesaxp/autonomous-content-service/src/services/content_creation"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRunner records the runs it is asked for and delegates each one to run
+type fakeRunner struct {
+	from content_creation.PipelineStage
+	run  func(ctx context.Context, observer content_creation.StageObserver) error
+
+	mu      sync.Mutex
+	calls   []content_creation.PipelineStage
+	times   []time.Time
+	running int
+	peak    int
+}
+
+func (r *fakeRunner) FirstIncompleteStage(ctx context.Context, contentID uuid.UUID) (content_creation.PipelineStage, error) {
+	return r.from, nil
+}
+
+func (r *fakeRunner) RunContent(ctx context.Context, contentID uuid.UUID, from content_creation.PipelineStage, observer content_creation.StageObserver) (*entities.Content, error) {
+	r.mu.Lock()
+	r.calls = append(r.calls, from)
+	r.times = append(r.times, time.Now())
+	r.running++
+	if r.running > r.peak {
+		r.peak = r.running
+	}
+	r.mu.Unlock()
+
+	defer func() {
+		r.mu.Lock()
+		r.running--
+		r.mu.Unlock()
+	}()
+
+	if r.run == nil {
+		return nil, nil
+	}
+	return nil, r.run(ctx, observer)
+}
+
+func (r *fakeRunner) stats() (calls []content_creation.PipelineStage, running, peak int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]content_creation.PipelineStage(nil), r.calls...), r.running, r.peak
+}
+
+func waitForStatus(t *testing.T, repo repositories.ContentJobRepository, jobID uuid.UUID, status entities.ContentJobStatus) *entities.ContentJob {
+	t.Helper()
+	var job *entities.ContentJob
+	require.Eventually(t, func() bool {
+		var err error
+		job, err = repo.FindByID(context.Background(), jobID)
+		require.NoError(t, err)
+		return job.Status == status
+	}, 2*time.Second, 5*time.Millisecond, "job did not reach %s", status)
+	return job
+}
+
+func stopManager(t *testing.T, manager *Manager) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	require.NoError(t, manager.Stop(ctx))
+}
+
+func TestManager_StartResumesJobsInterruptedMidRun(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewContentJobRepository()
+
+	interrupted := entities.NewContentJob(uuid.New(), uuid.New())
+	interrupted.Start()
+	interrupted.EnterStage(string(content_creation.StageDrafting))
+	interrupted.CompleteStage(string(content_creation.StageOutlining))
+	require.NoError(t, repo.Create(ctx, interrupted))
+
+	runner := &fakeRunner{from: content_creation.StageDrafting}
+	manager := NewManager(repo, runner, Config{Workers: 1, MaxAttempts: 3})
+	require.NoError(t, manager.Start(ctx))
+	defer stopManager(t, manager)
+
+	job := waitForStatus(t, repo, interrupted.JobID, entities.ContentJobStatusCompleted)
+	assert.Equal(t, 2, job.Attempts)
+	assert.NotNil(t, job.CompletedAt)
+
+	calls, _, _ := runner.stats()
+	assert.Equal(t, []content_creation.PipelineStage{content_creation.StageDrafting}, calls)
+}
+
+func TestManager_FailsJobAfterMaxAttempts(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewContentJobRepository()
+	runner := &fakeRunner{
+		from: content_creation.StageResearch,
+		run: func(ctx context.Context, observer content_creation.StageObserver) error {
+			err := errors.New("model unavailable")
+			observer.StageStarted(content_creation.StageResearch, 1)
+			observer.StageCompleted(content_creation.StageResearch)
+			observer.StageStarted(content_creation.StageOutlining, 1)
+			observer.StageFailed(content_creation.StageOutlining, 1, err)
+			return err
+		},
+	}
+
+	manager := NewManager(repo, runner, Config{Workers: 1, MaxAttempts: 3, RetryBackoff: time.Millisecond})
+	require.NoError(t, manager.Start(ctx))
+	defer stopManager(t, manager)
+
+	submitted, err := manager.Submit(ctx, &entities.Content{ContentID: uuid.New(), ProjectID: uuid.New()})
+	require.NoError(t, err)
+
+	job := waitForStatus(t, repo, submitted.JobID, entities.ContentJobStatusFailed)
+	assert.Equal(t, 3, job.Attempts)
+	assert.Equal(t, string(content_creation.StageOutlining), job.Stage)
+	assert.Equal(t, string(content_creation.StageResearch), job.LastCompletedStage)
+	require.Len(t, job.Errors, 3)
+	for _, jobError := range job.Errors {
+		assert.Equal(t, string(content_creation.StageOutlining), jobError.Stage)
+		assert.Equal(t, "model unavailable", jobError.Message)
+	}
+	assert.Equal(t, "model unavailable", job.LastError())
+
+	calls, _, _ := runner.stats()
+	assert.Len(t, calls, 3)
+}
+
+func TestManager_RecordsErrorsNotReportedByAStage(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewContentJobRepository()
+	runner := &fakeRunner{
+		from: content_creation.StageResearch,
+		run: func(ctx context.Context, observer content_creation.StageObserver) error {
+			return errors.New("content not found")
+		},
+	}
+
+	manager := NewManager(repo, runner, Config{Workers: 1, MaxAttempts: 1})
+	require.NoError(t, manager.Start(ctx))
+	defer stopManager(t, manager)
+
+	submitted, err := manager.Submit(ctx, &entities.Content{ContentID: uuid.New(), ProjectID: uuid.New()})
+	require.NoError(t, err)
+
+	job := waitForStatus(t, repo, submitted.JobID, entities.ContentJobStatusFailed)
+	require.Len(t, job.Errors, 1)
+	assert.Equal(t, 1, job.Errors[0].Attempt)
+	assert.Equal(t, "content not found", job.Errors[0].Message)
+}
+
+func TestManager_LatestReturnsTheNewestJobOfTheContent(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewContentJobRepository()
+	runner := &fakeRunner{
+		from: content_creation.StageResearch,
+		run: func(ctx context.Context, observer content_creation.StageObserver) error {
+			return errors.New("research failed")
+		},
+	}
+
+	manager := NewManager(repo, runner, Config{Workers: 1, MaxAttempts: 1})
+	content := &entities.Content{ContentID: uuid.New(), ProjectID: uuid.New()}
+
+	latest, err := manager.Latest(ctx, content.ContentID)
+	require.NoError(t, err)
+	assert.Nil(t, latest)
+
+	require.NoError(t, manager.Start(ctx))
+	defer stopManager(t, manager)
+
+	first, err := manager.Submit(ctx, content)
+	require.NoError(t, err)
+	waitForStatus(t, repo, first.JobID, entities.ContentJobStatusFailed)
+
+	second, err := manager.Submit(ctx, content)
+	require.NoError(t, err)
+	waitForStatus(t, repo, second.JobID, entities.ContentJobStatusFailed)
+
+	latest, err = manager.Latest(ctx, content.ContentID)
+	require.NoError(t, err)
+	assert.Equal(t, second.JobID, latest.JobID)
+	assert.Equal(t, "research failed", latest.LastError())
+}
+
+func TestManager_SubmitRejectsContentWithActiveJob(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewContentJobRepository()
+	manager := NewManager(repo, &fakeRunner{}, Config{})
+
+	content := &entities.Content{ContentID: uuid.New(), ProjectID: uuid.New()}
+	_, err := manager.Submit(ctx, content)
+	require.NoError(t, err)
+
+	_, err = manager.Submit(ctx, content)
+	assert.ErrorIs(t, err, ErrJobActive)
+}
+
+func TestManager_StopDrainsWorkersAndLeavesJobsQueued(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewContentJobRepository()
+	runner := &fakeRunner{
+		from: content_creation.StageDrafting,
+		run: func(ctx context.Context, observer content_creation.StageObserver) error {
+			<-ctx.Done()
+			return ctx.Err()
+		},
+	}
+
+	manager := NewManager(repo, runner, Config{Workers: 2, MaxAttempts: 1})
+	require.NoError(t, manager.Start(ctx))
+
+	var submitted []*entities.ContentJob
+	for i := 0; i < 3; i++ {
+		job, err := manager.Submit(ctx, &entities.Content{ContentID: uuid.New(), ProjectID: uuid.New()})
+		require.NoError(t, err)
+		submitted = append(submitted, job)
+	}
+
+	// Only as many jobs as there are workers run at once
+	require.Eventually(t, func() bool {
+		_, running, _ := runner.stats()
+		return running == 2
+	}, 2*time.Second, 5*time.Millisecond)
+	time.Sleep(20 * time.Millisecond)
+	_, _, peak := runner.stats()
+	assert.Equal(t, 2, peak)
+
+	stopManager(t, manager)
+
+	calls, running, _ := runner.stats()
+	assert.Zero(t, running)
+	assert.Len(t, calls, 2)
+	for _, job := range submitted {
+		stored, err := repo.FindByID(ctx, job.JobID)
+		require.NoError(t, err)
+		assert.Equal(t, entities.ContentJobStatusQueued, stored.Status)
+		assert.Empty(t, stored.Errors)
+	}
+}
+
+func TestManager_WaitsBeforeRetryingAFailedAttempt(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewContentJobRepository()
+	runner := &fakeRunner{
+		from: content_creation.StageResearch,
+		run: func(ctx context.Context, observer content_creation.StageObserver) error {
+			return errors.New("model unavailable")
+		},
+	}
+
+	manager := NewManager(repo, runner, Config{Workers: 1, MaxAttempts: 2, RetryBackoff: 50 * time.Millisecond})
+	require.NoError(t, manager.Start(ctx))
+	defer stopManager(t, manager)
+
+	submitted, err := manager.Submit(ctx, &entities.Content{ContentID: uuid.New(), ProjectID: uuid.New()})
+	require.NoError(t, err)
+	waitForStatus(t, repo, submitted.JobID, entities.ContentJobStatusFailed)
+
+	runner.mu.Lock()
+	defer runner.mu.Unlock()
+	require.Len(t, runner.times, 2)
+	assert.GreaterOrEqual(t, runner.times[1].Sub(runner.times[0]), 50*time.Millisecond)
+}
+
+func TestManager_LeasedJobsRunInOneProcessOnly(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewContentJobRepository()
+	release := make(chan struct{})
+	first := &fakeRunner{
+		from: content_creation.StageDrafting,
+		run: func(ctx context.Context, observer content_creation.StageObserver) error {
+			select {
+			case <-release:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		},
+	}
+	second := &fakeRunner{from: content_creation.StageDrafting}
+
+	config := Config{Workers: 1, MaxAttempts: 1, Lease: 30 * time.Millisecond}
+	config.WorkerID = "first"
+	running := NewManager(repo, first, config)
+	require.NoError(t, running.Start(ctx))
+	defer stopManager(t, running)
+
+	submitted, err := running.Submit(ctx, &entities.Content{ContentID: uuid.New(), ProjectID: uuid.New()})
+	require.NoError(t, err)
+	job := waitForStatus(t, repo, submitted.JobID, entities.ContentJobStatusRunning)
+	assert.Equal(t, "first", job.Worker)
+
+	// A second process starting while the job runs leaves it alone, across several
+	// lease renewals, and cannot claim it
+	config.WorkerID = "second"
+	starting := NewManager(repo, second, config)
+	require.NoError(t, starting.Start(ctx))
+	defer stopManager(t, starting)
+	starting.enqueue(submitted.JobID)
+	time.Sleep(150 * time.Millisecond)
+
+	calls, _, _ := second.stats()
+	assert.Empty(t, calls)
+	job, err = repo.FindByID(ctx, submitted.JobID)
+	require.NoError(t, err)
+	assert.Equal(t, entities.ContentJobStatusRunning, job.Status)
+	assert.Equal(t, "first", job.Worker)
+
+	close(release)
+	waitForStatus(t, repo, submitted.JobID, entities.ContentJobStatusCompleted)
+	calls, _, _ = first.stats()
+	assert.Len(t, calls, 1)
+}
+
+func TestManager_StartRequeuesJobsWhoseLeaseExpired(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewContentJobRepository()
+
+	abandoned := entities.NewContentJob(uuid.New(), uuid.New())
+	require.NoError(t, repo.Create(ctx, abandoned))
+	_, err := repo.Claim(ctx, abandoned.JobID, "gone", time.Now().Add(-time.Second))
+	require.NoError(t, err)
+
+	runner := &fakeRunner{from: content_creation.StageResearch}
+	manager := NewManager(repo, runner, Config{Workers: 1, MaxAttempts: 3, WorkerID: "replacement"})
+	require.NoError(t, manager.Start(ctx))
+	defer stopManager(t, manager)
+
+	job := waitForStatus(t, repo, abandoned.JobID, entities.ContentJobStatusCompleted)
+	assert.Equal(t, 2, job.Attempts)
+	assert.Equal(t, "replacement", job.Worker)
+}
@@ -0,0 +1,88 @@
+package pricing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrNoLLMRate is returned when the rate table has no price for a model
+var ErrNoLLMRate = errors.New("no LLM rate for model")
+
+// LLMRate is the price of a model in USD per million tokens
+type LLMRate struct {
+	InputPerMillion  float64 `json:"inputPerMillion"`
+	OutputPerMillion float64 `json:"outputPerMillion"`
+}
+
+// LLMRateTable maps model names to their rates. A model without an exact entry
+// uses the longest entry it starts with, so "gpt-4o-2024-08-06" is priced as "gpt-4o".
+type LLMRateTable map[string]LLMRate
+
+// DefaultLLMRates returns the list prices of commonly used hosted models
+func DefaultLLMRates() LLMRateTable {
+	return LLMRateTable{
+		"gpt-4":             {InputPerMillion: 30, OutputPerMillion: 60},
+		"gpt-4-turbo":       {InputPerMillion: 10, OutputPerMillion: 30},
+		"gpt-4o":            {InputPerMillion: 2.5, OutputPerMillion: 10},
+		"gpt-4o-mini":       {InputPerMillion: 0.15, OutputPerMillion: 0.6},
+		"gpt-3.5-turbo":     {InputPerMillion: 0.5, OutputPerMillion: 1.5},
+		"claude-3-opus":     {InputPerMillion: 15, OutputPerMillion: 75},
+		"claude-3-5-sonnet": {InputPerMillion: 3, OutputPerMillion: 15},
+		"claude-3-5-haiku":  {InputPerMillion: 0.8, OutputPerMillion: 4},
+		"claude-3-haiku":    {InputPerMillion: 0.25, OutputPerMillion: 1.25},
+	}
+}
+
+// Lookup returns the rate for a model
+func (t LLMRateTable) Lookup(model string) (LLMRate, bool) {
+	if rate, ok := t[model]; ok {
+		return rate, true
+	}
+
+	var best string
+	for name := range t {
+		if strings.HasPrefix(model, name) && len(name) > len(best) {
+			best = name
+		}
+	}
+	if best == "" {
+		return LLMRate{}, false
+	}
+	return t[best], true
+}
+
+// LLMCostCalculator prices LLM usage from a rate table. It provides the
+// CalculateLLMCost part of a CostCalculationEngine.
+type LLMCostCalculator struct {
+	rates LLMRateTable
+}
+
+// NewLLMCostCalculator creates a calculator for the given rates
+func NewLLMCostCalculator(rates LLMRateTable) *LLMCostCalculator {
+	return &LLMCostCalculator{rates: rates}
+}
+
+// CalculateLLMCost prices the tokens of one or more requests to a model
+func (c *LLMCostCalculator) CalculateLLMCost(ctx context.Context, req *LLMCostRequest) (*LLMCostResponse, error) {
+	rate, ok := c.rates.Lookup(req.ModelName)
+	if !ok {
+		return nil, fmt.Errorf("%w %q", ErrNoLLMRate, req.ModelName)
+	}
+
+	response := &LLMCostResponse{
+		InputCost:  float64(req.InputTokens) * rate.InputPerMillion / 1e6,
+		OutputCost: float64(req.OutputTokens) * rate.OutputPerMillion / 1e6,
+	}
+	response.TotalCost = response.InputCost + response.OutputCost
+
+	if tokens := req.InputTokens + req.OutputTokens; tokens > 0 {
+		response.CostPerToken = response.TotalCost / float64(tokens)
+	}
+	if req.RequestCount > 0 {
+		response.CostPerRequest = response.TotalCost / float64(req.RequestCount)
+	}
+
+	return response, nil
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/Ceesaxp/autonomous-content-service/src/config"
+	"github.com/Ceesaxp/autonomous-content-service/src/domain/repositories"
+	"github.com/Ceesaxp/autonomous-content-service/src/infrastructure/database"
+	"github.com/Ceesaxp/autonomous-content-service/src/infrastructure/memory"
+)
+
+// storage holds the repositories used by the server
+type storage struct {
+	clientRepo         repositories.ClientRepository
+	projectRepo        repositories.ProjectRepository
+	contentRepo        repositories.ContentRepository
+	contentVersionRepo repositories.ContentVersionRepository
+	feedbackRepo       repositories.FeedbackRepository
+	eventRepo          repositories.EventRepository
+	contentJobRepo     repositories.ContentJobRepository
+	checkpointRepo     repositories.StageCheckpointRepository
+	llmUsageRepo       repositories.LLMUsageRepository
+	llmCacheRepo       repositories.LLMCacheRepository // nil when the LLM cache is disabled
+	qualityRepo        repositories.QualityAssessmentRepository
+	similarityRepo     repositories.SimilarityIndexRepository
+	contextRepo        repositories.ProjectContextRepository
+	promptTemplateRepo repositories.PromptTemplateRepository
+	priceQuoteRepo     repositories.PriceQuoteRepository // in memory with either backend
+
+	close func() error
+}
+
+// openStorage creates the repositories for the configured storage backend.
+// The postgres backend connects to the database and applies pending migrations.
+func openStorage(cfg *config.Config) (*storage, error) {
+	if cfg.StorageBackend == config.StorageBackendMemory {
+		log.Println("Using in-memory storage; data is lost when the server stops")
+		return &storage{
+			clientRepo:         memory.NewClientRepository(),
+			projectRepo:        memory.NewProjectRepository(),
+			contentRepo:        memory.NewContentRepository(),
+			contentVersionRepo: memory.NewContentVersionRepository(),
+			feedbackRepo:       memory.NewFeedbackRepository(),
+			eventRepo:          memory.NewEventRepository(),
+			contentJobRepo:     memory.NewContentJobRepository(),
+			checkpointRepo:     memory.NewStageCheckpointRepository(),
+			llmUsageRepo:       memory.NewLLMUsageRepository(),
+			llmCacheRepo:       newMemoryLLMCache(cfg),
+			qualityRepo:        memory.NewQualityAssessmentRepository(),
+			similarityRepo:     memory.NewSimilarityIndexRepository(),
+			contextRepo:        memory.NewProjectContextRepository(),
+			promptTemplateRepo: memory.NewPromptTemplateRepository(),
+			priceQuoteRepo:     memory.NewPriceQuoteRepository(),
+			close:              func() error { return nil },
+		}, nil
+	}
+
+	db, err := database.NewPostgresDB(cfg.GetDSN())
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+
+	if err := database.RunMigrations(db); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to run database migrations: %w", err)
+	}
+
+	llmCacheRepo := newMemoryLLMCache(cfg)
+	if cfg.LLMCacheBackend == config.LLMCacheBackendPostgres {
+		llmCacheRepo = database.NewLLMCacheRepository(db)
+	}
+
+	return &storage{
+		clientRepo:         database.NewClientRepository(db),
+		projectRepo:        database.NewProjectRepository(db),
+		contentRepo:        database.NewContentRepository(db),
+		contentVersionRepo: database.NewContentVersionRepository(db),
+		feedbackRepo:       database.NewFeedbackRepository(db),
+		eventRepo:          database.NewEventRepository(db),
+		contentJobRepo:     database.NewContentJobRepository(db),
+		checkpointRepo:     database.NewStageCheckpointRepository(db),
+		llmUsageRepo:       database.NewLLMUsageRepository(db),
+		llmCacheRepo:       llmCacheRepo,
+		qualityRepo:        database.NewQualityAssessmentRepository(db),
+		similarityRepo:     database.NewSimilarityIndexRepository(db),
+		contextRepo:        database.NewProjectContextRepository(db),
+		promptTemplateRepo: database.NewPromptTemplateRepository(db),
+		priceQuoteRepo:     memory.NewPriceQuoteRepository(),
+		close:              db.Close,
+	}, nil
+}
+
+// newMemoryLLMCache creates the in-memory LLM cache, or returns nil when the cache is
+// disabled or kept in PostgreSQL
+func newMemoryLLMCache(cfg *config.Config) repositories.LLMCacheRepository {
+	if cfg.LLMCacheBackend != config.LLMCacheBackendMemory {
+		return nil
+	}
+	return memory.NewLLMCacheRepository(cfg.LLMCacheMaxEntries)
+}